@@ -42,6 +42,7 @@ func main() {
 	}
 
 	pb.RegisterAuthServiceServer(grpcServer, srv)
+	pb.RegisterAdminServiceServer(grpcServer, srv)
 
 	grpc_health_v1.RegisterHealthServer(grpcServer, health.NewServer())
 
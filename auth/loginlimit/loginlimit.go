@@ -0,0 +1,191 @@
+// Package loginlimit slows down password guessing. Failed logins are counted
+// per email and per client IP in sliding windows kept in Redis. Too many
+// failures for one email lock it for a while, each lockout within a day
+// lasting twice as long as the one before. Too many failures from one IP
+// hold it off until its oldest failure leaves the window. Wrong second
+// factor codes are counted per mfa token as well, so that callers can give
+// up on the token.
+package loginlimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// lockoutMemory is how long earlier lockouts of an email make the next one
+// longer
+const lockoutMemory = 24 * time.Hour
+
+type Config struct {
+	// MaxEmailFailures within Window lock the email
+	MaxEmailFailures int
+	// MaxIPFailures within Window hold off the IP
+	MaxIPFailures int
+	Window        time.Duration
+	// LockoutDuration is how long the first lockout lasts
+	LockoutDuration time.Duration
+	// MaxLockoutDuration caps the doubling of repeated lockouts
+	MaxLockoutDuration time.Duration
+}
+
+type LimiterInterface interface {
+	// Check returns how long logins for email from ip have to wait, zero if
+	// they may go ahead
+	Check(ctx context.Context, email, ip string) (time.Duration, error)
+	// RecordFailure counts a failed login. It returns how long the email is
+	// locked for if this failure locked it, zero otherwise.
+	RecordFailure(ctx context.Context, email, ip string) (time.Duration, error)
+	// Reset forgets the failures of email after a successful login
+	Reset(ctx context.Context, email string) error
+	// Unlock lifts the lockout of email and forgets its history
+	Unlock(ctx context.Context, email string) error
+	// RecordTokenFailure counts a wrong code given with an mfa token and
+	// returns how many it has seen within the window
+	RecordTokenFailure(ctx context.Context, token string) (int64, error)
+}
+
+type Limiter struct {
+	redis  *redis.Client
+	config Config
+	now    func() time.Time
+}
+
+func NewLimiter(redisClient *redis.Client, config Config) *Limiter {
+	return &Limiter{redis: redisClient, config: config, now: time.Now}
+}
+
+func (l *Limiter) Check(ctx context.Context, email, ip string) (time.Duration, error) {
+	locked, err := l.redis.PTTL(ctx, lockedKey(email)).Result()
+	if err != nil {
+		return 0, err
+	}
+	if locked > 0 {
+		return locked, nil
+	}
+
+	if ip == "" {
+		return 0, nil
+	}
+	now := l.now()
+	start := strconv.FormatInt(now.Add(-l.config.Window).UnixMilli(), 10)
+	failures, err := l.redis.ZCount(ctx, ipFailuresKey(ip), "("+start, "+inf").Result()
+	if err != nil {
+		return 0, err
+	}
+	if failures < int64(l.config.MaxIPFailures) {
+		return 0, nil
+	}
+	// Wait until enough failures have left the window
+	oldest, err := l.redis.ZRangeByScoreWithScores(ctx, ipFailuresKey(ip), &redis.ZRangeBy{
+		Min:    "(" + start,
+		Max:    "+inf",
+		Offset: failures - int64(l.config.MaxIPFailures),
+		Count:  1,
+	}).Result()
+	if err != nil {
+		return 0, err
+	}
+	if len(oldest) == 0 {
+		return 0, nil
+	}
+	return max(time.UnixMilli(int64(oldest[0].Score)).Add(l.config.Window).Sub(now), time.Second), nil
+}
+
+func (l *Limiter) RecordFailure(ctx context.Context, email, ip string) (time.Duration, error) {
+	now := l.now()
+	failures, err := l.addFailure(ctx, emailFailuresKey(email), now)
+	if err != nil {
+		return 0, err
+	}
+	if ip != "" {
+		if _, err := l.addFailure(ctx, ipFailuresKey(ip), now); err != nil {
+			return 0, err
+		}
+	}
+	if failures < int64(l.config.MaxEmailFailures) {
+		return 0, nil
+	}
+
+	lockouts, err := l.redis.Incr(ctx, lockoutsKey(email)).Result()
+	if err != nil {
+		return 0, err
+	}
+	if err := l.redis.Expire(ctx, lockoutsKey(email), lockoutMemory).Err(); err != nil {
+		return 0, err
+	}
+	duration := l.config.LockoutDuration
+	for i := int64(1); i < lockouts && duration < l.config.MaxLockoutDuration; i++ {
+		duration *= 2
+	}
+	duration = min(duration, l.config.MaxLockoutDuration)
+
+	// The failures that led to the lockout are used up
+	if err := l.redis.Set(ctx, lockedKey(email), lockouts, duration).Err(); err != nil {
+		return 0, err
+	}
+	if err := l.redis.Del(ctx, emailFailuresKey(email)).Err(); err != nil {
+		return 0, err
+	}
+	return duration, nil
+}
+
+func (l *Limiter) Reset(ctx context.Context, email string) error {
+	return l.redis.Del(ctx, emailFailuresKey(email)).Err()
+}
+
+func (l *Limiter) Unlock(ctx context.Context, email string) error {
+	return l.redis.Del(ctx, lockedKey(email), emailFailuresKey(email), lockoutsKey(email)).Err()
+}
+
+func (l *Limiter) RecordTokenFailure(ctx context.Context, token string) (int64, error) {
+	return l.addFailure(ctx, tokenFailuresKey(token), l.now())
+}
+
+// addFailure adds a failure at now to the window of key and returns how many
+// failures it holds
+func (l *Limiter) addFailure(ctx context.Context, key string, now time.Time) (int64, error) {
+	var count *redis.IntCmd
+	_, err := l.redis.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.ZAdd(ctx, key, redis.Z{Score: float64(now.UnixMilli()), Member: now.UnixNano()})
+		pipe.ZRemRangeByScore(ctx, key, "-inf", strconv.FormatInt(now.Add(-l.config.Window).UnixMilli(), 10))
+		count = pipe.ZCard(ctx, key)
+		pipe.PExpire(ctx, key, l.config.Window)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count.Val(), nil
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+func emailFailuresKey(email string) string {
+	return "login_failures:email:" + normalizeEmail(email)
+}
+
+func ipFailuresKey(ip string) string {
+	return "login_failures:ip:" + ip
+}
+
+// tokenFailuresKey names tokens by their hash, they are long and secret
+func tokenFailuresKey(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return "login_failures:token:" + hex.EncodeToString(hash[:])
+}
+
+func lockedKey(email string) string {
+	return "login_locked:" + normalizeEmail(email)
+}
+
+func lockoutsKey(email string) string {
+	return "login_lockouts:" + normalizeEmail(email)
+}
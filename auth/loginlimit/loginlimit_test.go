@@ -0,0 +1,164 @@
+package loginlimit
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testConfig = Config{
+	MaxEmailFailures:   3,
+	MaxIPFailures:      10,
+	Window:             15 * time.Minute,
+	LockoutDuration:    15 * time.Minute,
+	MaxLockoutDuration: time.Hour,
+}
+
+func newTestLimiter(db *redis.Client, now time.Time) *Limiter {
+	limiter := NewLimiter(db, testConfig)
+	limiter.now = func() time.Time { return now }
+	return limiter
+}
+
+// expectAddFailure expects a failure at now to be added to key, which then
+// holds count failures
+func expectAddFailure(mock redismock.ClientMock, key string, now time.Time, count int64) {
+	mock.ExpectTxPipeline()
+	mock.ExpectZAdd(key, redis.Z{Score: float64(now.UnixMilli()), Member: now.UnixNano()}).SetVal(1)
+	mock.ExpectZRemRangeByScore(key, "-inf", strconv.FormatInt(now.Add(-testConfig.Window).UnixMilli(), 10)).SetVal(0)
+	mock.ExpectZCard(key).SetVal(count)
+	mock.ExpectPExpire(key, testConfig.Window).SetVal(true)
+	mock.ExpectTxPipelineExec()
+}
+
+func TestCheck_Locked(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	defer db.Close()
+	limiter := newTestLimiter(db, time.Now())
+
+	// Emails are the same whatever their case
+	mock.ExpectPTTL("login_locked:harry@hogwarts.edu").SetVal(10 * time.Minute)
+
+	wait, err := limiter.Check(context.Background(), " Harry@Hogwarts.edu", "203.0.113.7")
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Minute, wait)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCheck_Allowed(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	defer db.Close()
+	now := time.Now()
+	limiter := newTestLimiter(db, now)
+
+	start := strconv.FormatInt(now.Add(-testConfig.Window).UnixMilli(), 10)
+	mock.ExpectPTTL("login_locked:harry@hogwarts.edu").SetVal(-2)
+	mock.ExpectZCount("login_failures:ip:203.0.113.7", "("+start, "+inf").SetVal(9)
+
+	wait, err := limiter.Check(context.Background(), "harry@hogwarts.edu", "203.0.113.7")
+	require.NoError(t, err)
+	assert.Zero(t, wait)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCheck_IPOverLimit(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	defer db.Close()
+	now := time.Now()
+	limiter := newTestLimiter(db, now)
+
+	start := strconv.FormatInt(now.Add(-testConfig.Window).UnixMilli(), 10)
+	mock.ExpectPTTL("login_locked:harry@hogwarts.edu").SetVal(-2)
+	mock.ExpectZCount("login_failures:ip:203.0.113.7", "("+start, "+inf").SetVal(12)
+	// Three failures have to leave the window, the third oldest did 5 minutes ago
+	third := now.Add(-5 * time.Minute)
+	mock.ExpectZRangeByScoreWithScores("login_failures:ip:203.0.113.7", &redis.ZRangeBy{
+		Min:    "(" + start,
+		Max:    "+inf",
+		Offset: 2,
+		Count:  1,
+	}).SetVal([]redis.Z{{Score: float64(third.UnixMilli())}})
+
+	wait, err := limiter.Check(context.Background(), "harry@hogwarts.edu", "203.0.113.7")
+	require.NoError(t, err)
+	assert.InDelta(t, 10*time.Minute, wait, float64(time.Millisecond))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecordFailure_BelowLimit(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	defer db.Close()
+	now := time.Now()
+	limiter := newTestLimiter(db, now)
+
+	expectAddFailure(mock, "login_failures:email:harry@hogwarts.edu", now, 2)
+	expectAddFailure(mock, "login_failures:ip:203.0.113.7", now, 2)
+
+	lockout, err := limiter.RecordFailure(context.Background(), "harry@hogwarts.edu", "203.0.113.7")
+	require.NoError(t, err)
+	assert.Zero(t, lockout)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecordFailure_Locks(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		lockouts int64
+		want     time.Duration
+	}{
+		{"first", 1, 15 * time.Minute},
+		{"second", 2, 30 * time.Minute},
+		{"capped", 5, time.Hour},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			db, mock := redismock.NewClientMock()
+			defer db.Close()
+			now := time.Now()
+			limiter := newTestLimiter(db, now)
+
+			expectAddFailure(mock, "login_failures:email:harry@hogwarts.edu", now, 3)
+			mock.ExpectIncr("login_lockouts:harry@hogwarts.edu").SetVal(tc.lockouts)
+			mock.ExpectExpire("login_lockouts:harry@hogwarts.edu", lockoutMemory).SetVal(true)
+			mock.ExpectSet("login_locked:harry@hogwarts.edu", tc.lockouts, tc.want).SetVal("OK")
+			mock.ExpectDel("login_failures:email:harry@hogwarts.edu").SetVal(1)
+
+			lockout, err := limiter.RecordFailure(context.Background(), "harry@hogwarts.edu", "")
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, lockout)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestUnlock(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	defer db.Close()
+	limiter := newTestLimiter(db, time.Now())
+
+	mock.ExpectDel("login_locked:harry@hogwarts.edu", "login_failures:email:harry@hogwarts.edu", "login_lockouts:harry@hogwarts.edu").SetVal(3)
+
+	require.NoError(t, limiter.Unlock(context.Background(), "harry@hogwarts.edu"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecordTokenFailure(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	defer db.Close()
+	now := time.Now()
+	limiter := newTestLimiter(db, now)
+
+	key := tokenFailuresKey("mfa-token")
+	assert.NotContains(t, key, "mfa-token")
+	expectAddFailure(mock, key, now, 4)
+
+	failures, err := limiter.RecordTokenFailure(context.Background(), "mfa-token")
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), failures)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,114 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/sejamuchhal/taskhub/auth/loginlimit (interfaces: LimiterInterface)
+//
+// Generated by this command:
+//
+//	mockgen --build_flags=--mod=mod --destination=./loginlimit/mock_loginlimit/loginlimit.go github.com/sejamuchhal/taskhub/auth/loginlimit LimiterInterface
+//
+
+// Package mock_loginlimit is a generated GoMock package.
+package mock_loginlimit
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockLimiterInterface is a mock of LimiterInterface interface.
+type MockLimiterInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockLimiterInterfaceMockRecorder
+}
+
+// MockLimiterInterfaceMockRecorder is the mock recorder for MockLimiterInterface.
+type MockLimiterInterfaceMockRecorder struct {
+	mock *MockLimiterInterface
+}
+
+// NewMockLimiterInterface creates a new mock instance.
+func NewMockLimiterInterface(ctrl *gomock.Controller) *MockLimiterInterface {
+	mock := &MockLimiterInterface{ctrl: ctrl}
+	mock.recorder = &MockLimiterInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLimiterInterface) EXPECT() *MockLimiterInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Check mocks base method.
+func (m *MockLimiterInterface) Check(arg0 context.Context, arg1, arg2 string) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Check", arg0, arg1, arg2)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Check indicates an expected call of Check.
+func (mr *MockLimiterInterfaceMockRecorder) Check(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Check", reflect.TypeOf((*MockLimiterInterface)(nil).Check), arg0, arg1, arg2)
+}
+
+// RecordFailure mocks base method.
+func (m *MockLimiterInterface) RecordFailure(arg0 context.Context, arg1, arg2 string) (time.Duration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordFailure", arg0, arg1, arg2)
+	ret0, _ := ret[0].(time.Duration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordFailure indicates an expected call of RecordFailure.
+func (mr *MockLimiterInterfaceMockRecorder) RecordFailure(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordFailure", reflect.TypeOf((*MockLimiterInterface)(nil).RecordFailure), arg0, arg1, arg2)
+}
+
+// RecordTokenFailure mocks base method.
+func (m *MockLimiterInterface) RecordTokenFailure(arg0 context.Context, arg1 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordTokenFailure", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RecordTokenFailure indicates an expected call of RecordTokenFailure.
+func (mr *MockLimiterInterfaceMockRecorder) RecordTokenFailure(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordTokenFailure", reflect.TypeOf((*MockLimiterInterface)(nil).RecordTokenFailure), arg0, arg1)
+}
+
+// Reset mocks base method.
+func (m *MockLimiterInterface) Reset(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockLimiterInterfaceMockRecorder) Reset(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockLimiterInterface)(nil).Reset), arg0, arg1)
+}
+
+// Unlock mocks base method.
+func (m *MockLimiterInterface) Unlock(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unlock", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unlock indicates an expected call of Unlock.
+func (mr *MockLimiterInterfaceMockRecorder) Unlock(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unlock", reflect.TypeOf((*MockLimiterInterface)(nil).Unlock), arg0, arg1)
+}
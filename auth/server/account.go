@@ -0,0 +1,207 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	pb "github.com/sejamuchhal/taskhub/auth/pb"
+	"github.com/sejamuchhal/taskhub/auth/storage"
+	"github.com/sejamuchhal/taskhub/auth/util"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// ChangePassword replaces the password of the caller after checking the
+// current one. Every other session is blocked and the access tokens issued
+// so far are revoked, the caller gets a new one.
+func (s *Server) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*pb.ChangePasswordResponse, error) {
+	logger := s.Logger.WithField("method", "ChangePassword")
+	logger.Debug("Incoming request")
+
+	user, err := s.accessTokenUser(logger, req.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	logger = logger.WithField("user_id", user.ID)
+
+	if req.NewPassword == "" {
+		return nil, status.Error(codes.InvalidArgument, "New password is required")
+	}
+	ip := clientIP(ctx)
+	if err := s.checkLoginAttempts(ctx, logger, user.Email, ip); err != nil {
+		return nil, err
+	}
+	if err := util.CheckPasswordHash(req.CurrentPassword, user.Password); err != nil {
+		logger.WithError(err).Warn("Invalid current password")
+		return nil, s.loginFailed(ctx, logger, user, user.Email, ip,
+			status.Error(codes.InvalidArgument, "Current password is incorrect"))
+	}
+	if err := s.LoginLimiter.Reset(ctx, user.Email); err != nil {
+		logger.WithError(err).Error("Error resetting failed logins")
+	}
+
+	keepSessionID, err := s.refreshTokenSessionID(logger, user, req.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := util.HashPassword(req.NewPassword)
+	if err != nil {
+		logger.WithError(err).Error("Error hashing password")
+		return nil, status.Errorf(codes.Internal, "Error hashing password: %v", err)
+	}
+	if err := s.Storage.UpdateUserPassword(user.ID, hashedPassword); err != nil {
+		logger.WithError(err).Error("Error updating password")
+		return nil, status.Errorf(codes.Internal, "Error updating password: %v", err)
+	}
+
+	if err := s.Storage.BlockSessionsByEmail(user.Email, keepSessionID); err != nil {
+		logger.WithError(err).Error("Error blocking sessions")
+		return nil, status.Errorf(codes.Internal, "Error blocking sessions: %v", err)
+	}
+	if err := s.TokenHandler.RevokeUserTokens(user.ID, s.Config.AccessTokenDuration); err != nil {
+		logger.WithError(err).Error("Error revoking access tokens")
+		return nil, status.Errorf(codes.Internal, "Error revoking access tokens: %v", err)
+	}
+	// Reset links sent for the old password are of no use any more
+	if err := s.Storage.DeleteUserTokens(user.ID, storage.UserTokenResetPassword); err != nil {
+		logger.WithError(err).Warn("Error deleting password reset tokens")
+	}
+
+	accessToken, accessClaims, err := s.TokenHandler.CreateToken(user, s.Config.AccessTokenDuration, "access")
+	if err != nil {
+		logger.WithError(err).Error("Error creating access token")
+		return nil, status.Errorf(codes.Internal, "Error creating access token: %v", err)
+	}
+
+	logger.Debug("Password changed")
+	return &pb.ChangePasswordResponse{
+		Message:              "Password changed",
+		AccessToken:          accessToken,
+		AccessTokenExpiresAt: timestamppb.New(accessClaims.RegisteredClaims.ExpiresAt.Time),
+	}, nil
+}
+
+// UpdateAccount changes the name and email of the caller. A new email only
+// replaces the current one once the user follows the link sent to it, asking
+// for the current email again cancels the change.
+func (s *Server) UpdateAccount(ctx context.Context, req *pb.UpdateAccountRequest) (*pb.UpdateAccountResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"method": "UpdateAccount",
+		"name":   req.Name,
+		"email":  req.Email,
+	})
+	logger.Debug("Incoming request")
+
+	user, err := s.accessTokenUser(logger, req.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	logger = logger.WithField("user_id", user.ID)
+
+	name := strings.TrimSpace(req.Name)
+	email := strings.TrimSpace(req.Email)
+	if name == "" && email == "" {
+		return nil, status.Error(codes.InvalidArgument, "Name or email is required")
+	}
+
+	message := "Account updated"
+	if name != "" && name != user.Name {
+		if err := s.Storage.UpdateUserName(user.ID, name); err != nil {
+			logger.WithError(err).Error("Error updating name")
+			return nil, status.Errorf(codes.Internal, "Error updating name: %v", err)
+		}
+		user.Name = name
+	}
+
+	switch {
+	case email == "" || email == user.PendingEmail:
+		// Nothing to change
+	case email == user.Email:
+		if user.PendingEmail == "" {
+			break
+		}
+		if err := s.Storage.SetUserPendingEmail(user.ID, ""); err != nil {
+			logger.WithError(err).Error("Error cancelling email change")
+			return nil, status.Errorf(codes.Internal, "Error cancelling email change: %v", err)
+		}
+		if err := s.Storage.DeleteUserTokens(user.ID, storage.UserTokenChangeEmail); err != nil {
+			logger.WithError(err).Warn("Error deleting email change tokens")
+		}
+		user.PendingEmail = ""
+	default:
+		_, err := s.Storage.GetUserByEmail(email)
+		if err == nil {
+			logger.Warn("Email already in use")
+			return nil, status.Error(codes.AlreadyExists, "Email is already in use")
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.WithError(err).Error("Error fetching user from the database")
+			return nil, status.Errorf(codes.Internal, "Error fetching user from the database: %v", err)
+		}
+
+		if err := s.Storage.SetUserPendingEmail(user.ID, email); err != nil {
+			logger.WithError(err).Error("Error setting pending email")
+			return nil, status.Errorf(codes.Internal, "Error setting pending email: %v", err)
+		}
+		user.PendingEmail = email
+
+		// The link goes to the new address, proving the user can read it
+		recipient := *user
+		recipient.Email = email
+		err = s.emailUserToken(&recipient, storage.UserTokenChangeEmail, s.Config.VerificationTokenDuration,
+			s.Config.VerifyEmailURL, UserEventEmailChangeRequested)
+		if err != nil {
+			logger.WithError(err).Error("Error sending email change confirmation")
+			return nil, status.Errorf(codes.Internal, "Error sending email change confirmation: %v", err)
+		}
+		message = "Account updated, follow the link sent to the new email to start using it"
+	}
+
+	logger.Debug("Account updated")
+	return &pb.UpdateAccountResponse{
+		Message:      message,
+		User:         &pb.UserDetail{Name: user.Name, Email: user.Email},
+		PendingEmail: user.PendingEmail,
+	}, nil
+}
+
+// accessTokenUser returns the user an access token was issued to
+func (s *Server) accessTokenUser(logger *logrus.Entry, accessToken string) (*storage.User, error) {
+	claims, err := s.TokenHandler.VerifyToken(accessToken, "access")
+	if err != nil {
+		logger.WithError(err).Warn("Invalid access token")
+		return nil, status.Errorf(codes.Unauthenticated, "Invalid access token")
+	}
+
+	user, err := s.Storage.GetUserByID(claims.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.WithError(err).Warn("User of access token not found")
+			return nil, status.Errorf(codes.Unauthenticated, "Invalid access token")
+		}
+		logger.WithError(err).Error("Error fetching user from the database")
+		return nil, status.Errorf(codes.Internal, "Error fetching user from the database: %v", err)
+	}
+	if err := checkActive(logger, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// adminUser returns the user of accessToken, who has to be an admin
+func (s *Server) adminUser(logger *logrus.Entry, accessToken string) (*storage.User, error) {
+	user, err := s.accessTokenUser(logger, accessToken)
+	if err != nil {
+		return nil, err
+	}
+	if user.Role != "admin" {
+		logger.WithField("user_id", user.ID).Warn("Caller is not an admin")
+		return nil, status.Error(codes.PermissionDenied, "Permission denied")
+	}
+	return user, nil
+}
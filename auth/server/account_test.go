@@ -0,0 +1,188 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	pb "github.com/sejamuchhal/taskhub/auth/pb"
+	event_pb "github.com/sejamuchhal/taskhub/auth/pb/event"
+	"github.com/sejamuchhal/taskhub/auth/server"
+	"github.com/sejamuchhal/taskhub/auth/storage"
+	"github.com/sejamuchhal/taskhub/auth/util"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// accessToken returns an access token of user that the token handler accepts
+func (suite *ServerTestSuite) accessToken(user *storage.User) string {
+	token, _, err := suite.Server.TokenHandler.CreateToken(user, time.Hour, "access")
+	suite.Require().NoError(err)
+
+	mock := *suite.MockRedis
+	mock.ExpectGet(token).RedisNil()
+	mock.ExpectGet("revoked_before:" + user.ID).RedisNil()
+	return token
+}
+
+func (suite *ServerTestSuite) TestChangePassword_Success() {
+	hashed, err := util.HashPassword("old-password")
+	suite.Require().NoError(err)
+	user := &storage.User{ID: "1", Email: "harry@hogwarts.edu", Password: hashed, Active: true}
+
+	accessToken := suite.accessToken(user)
+	refreshToken, refreshClaims, err := suite.Server.TokenHandler.CreateToken(user, 24*time.Hour, "refresh")
+	suite.Require().NoError(err)
+	mock := *suite.MockRedis
+	mock.ExpectGet(refreshToken).RedisNil()
+	mock.Regexp().ExpectSet("revoked_before:1", `^\d+$`, time.Hour).SetVal("OK")
+
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(user, nil)
+	suite.expectLoginAllowed("harry@hogwarts.edu", "")
+	suite.MockLimiter.EXPECT().Reset(gomock.Any(), "harry@hogwarts.edu").Return(nil)
+	suite.MockStorage.EXPECT().UpdateUserPassword("1", gomock.Any()).DoAndReturn(func(userID, password string) error {
+		suite.NoError(util.CheckPasswordHash("new-password", password))
+		return nil
+	})
+	// The caller stays logged in
+	suite.MockStorage.EXPECT().BlockSessionsByEmail("harry@hogwarts.edu", refreshClaims.RegisteredClaims.ID).Return(nil)
+	suite.MockStorage.EXPECT().DeleteUserTokens("1", storage.UserTokenResetPassword).Return(nil)
+
+	resp, err := suite.Server.ChangePassword(context.Background(), &pb.ChangePasswordRequest{
+		AccessToken:     accessToken,
+		RefreshToken:    refreshToken,
+		CurrentPassword: "old-password",
+		NewPassword:     "new-password",
+	})
+	suite.Require().NoError(err)
+	suite.NotEmpty(resp.AccessToken)
+	suite.NotEqual(accessToken, resp.AccessToken)
+	suite.NoError(mock.ExpectationsWereMet())
+}
+
+func (suite *ServerTestSuite) TestChangePassword_WrongCurrentPassword() {
+	hashed, err := util.HashPassword("old-password")
+	suite.Require().NoError(err)
+	user := &storage.User{ID: "1", Email: "harry@hogwarts.edu", Password: hashed, Active: true}
+
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(user, nil)
+	// Guesses count like failed logins
+	suite.expectLoginAllowed("harry@hogwarts.edu", "203.0.113.7")
+	suite.MockLimiter.EXPECT().RecordFailure(gomock.Any(), "harry@hogwarts.edu", "203.0.113.7").Return(time.Duration(0), nil)
+
+	resp, err := suite.Server.ChangePassword(clientContext("203.0.113.7"), &pb.ChangePasswordRequest{
+		AccessToken:     suite.accessToken(user),
+		CurrentPassword: "guess",
+		NewPassword:     "new-password",
+	})
+	suite.Nil(resp)
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (suite *ServerTestSuite) TestChangePassword_Locked() {
+	user := &storage.User{ID: "1", Email: "harry@hogwarts.edu", Password: testPasswordHash(), Active: true}
+
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(user, nil)
+	suite.MockLimiter.EXPECT().Check(gomock.Any(), "harry@hogwarts.edu", "").Return(10*time.Minute, nil)
+
+	resp, err := suite.Server.ChangePassword(context.Background(), &pb.ChangePasswordRequest{
+		AccessToken:     suite.accessToken(user),
+		CurrentPassword: "password",
+		NewPassword:     "new-password",
+	})
+	suite.Nil(resp)
+	suite.Equal(codes.ResourceExhausted, status.Code(err))
+}
+
+func (suite *ServerTestSuite) TestChangePassword_InvalidAccessToken() {
+	mock := *suite.MockRedis
+	mock.ExpectGet("invalid-token").RedisNil()
+
+	resp, err := suite.Server.ChangePassword(context.Background(), &pb.ChangePasswordRequest{
+		AccessToken:     "invalid-token",
+		CurrentPassword: "old-password",
+		NewPassword:     "new-password",
+	})
+	suite.Nil(resp)
+	suite.Equal(codes.Unauthenticated, status.Code(err))
+}
+
+func (suite *ServerTestSuite) TestUpdateAccount_Name() {
+	user := &storage.User{ID: "1", Name: "Harry", Email: "harry@hogwarts.edu", Active: true}
+
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(user, nil)
+	suite.MockStorage.EXPECT().UpdateUserName("1", "Harry Potter").Return(nil)
+
+	resp, err := suite.Server.UpdateAccount(context.Background(), &pb.UpdateAccountRequest{
+		AccessToken: suite.accessToken(user),
+		Name:        " Harry Potter ",
+	})
+	suite.Require().NoError(err)
+	suite.Equal("Harry Potter", resp.User.Name)
+	suite.Equal("harry@hogwarts.edu", resp.User.Email)
+	suite.Empty(resp.PendingEmail)
+}
+
+func (suite *ServerTestSuite) TestUpdateAccount_Email() {
+	user := &storage.User{ID: "1", Name: "Harry Potter", Email: "harry@hogwarts.edu", Active: true}
+
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(user, nil)
+	suite.MockStorage.EXPECT().GetUserByEmail("potter@ministry.gov").Return(nil, gorm.ErrRecordNotFound)
+	suite.MockStorage.EXPECT().SetUserPendingEmail("1", "potter@ministry.gov").Return(nil)
+	suite.MockStorage.EXPECT().DeleteUserTokens("1", storage.UserTokenChangeEmail).Return(nil)
+	suite.MockStorage.EXPECT().CreateUserToken(gomock.Any()).DoAndReturn(func(token *storage.UserToken) error {
+		suite.Equal(storage.UserTokenChangeEmail, token.Purpose)
+		return nil
+	})
+	suite.MockRabbitMQ.EXPECT().Publish(gomock.Any()).DoAndReturn(func(body []byte) error {
+		var userEvent event_pb.UserEvent
+		suite.Require().NoError(json.Unmarshal(body, &userEvent))
+		suite.Equal(server.UserEventEmailChangeRequested, userEvent.Type)
+		// The new address has to be confirmed
+		suite.Equal("potter@ministry.gov", userEvent.Email)
+		return nil
+	})
+
+	resp, err := suite.Server.UpdateAccount(context.Background(), &pb.UpdateAccountRequest{
+		AccessToken: suite.accessToken(user),
+		Email:       "potter@ministry.gov",
+	})
+	suite.Require().NoError(err)
+	suite.Equal("harry@hogwarts.edu", resp.User.Email)
+	suite.Equal("potter@ministry.gov", resp.PendingEmail)
+}
+
+func (suite *ServerTestSuite) TestUpdateAccount_EmailTaken() {
+	user := &storage.User{ID: "1", Email: "harry@hogwarts.edu", Active: true}
+
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(user, nil)
+	suite.MockStorage.EXPECT().GetUserByEmail("ron@hogwarts.edu").Return(&storage.User{ID: "2"}, nil)
+
+	resp, err := suite.Server.UpdateAccount(context.Background(), &pb.UpdateAccountRequest{
+		AccessToken: suite.accessToken(user),
+		Email:       "ron@hogwarts.edu",
+	})
+	suite.Nil(resp)
+	suite.Equal(codes.AlreadyExists, status.Code(err))
+}
+
+func (suite *ServerTestSuite) TestVerifyEmail_ConfirmsEmailChange() {
+	suite.MockStorage.EXPECT().ConsumeUserToken(storage.UserTokenVerifyEmail, util.HashOneTimeToken("token")).
+		Return(&storage.UserToken{}, gorm.ErrRecordNotFound)
+	suite.MockStorage.EXPECT().ConsumeUserToken(storage.UserTokenChangeEmail, util.HashOneTimeToken("token")).
+		Return(&storage.UserToken{UserID: "1", Purpose: storage.UserTokenChangeEmail, ExpiresAt: time.Now().Add(time.Hour)}, nil)
+	suite.MockStorage.EXPECT().GetUserByID("1").
+		Return(&storage.User{ID: "1", Email: "harry@hogwarts.edu", PendingEmail: "potter@ministry.gov", Active: true}, nil)
+	suite.MockStorage.EXPECT().ConfirmUserPendingEmail("1", gomock.Any()).Return(nil)
+	// Sessions belong to the old email
+	suite.MockStorage.EXPECT().BlockSessionsByEmail("harry@hogwarts.edu", "").Return(nil)
+	mock := *suite.MockRedis
+	mock.Regexp().ExpectSet("revoked_before:1", `^\d+$`, time.Hour).SetVal("OK")
+
+	resp, err := suite.Server.VerifyEmail(context.Background(), &pb.VerifyEmailRequest{Token: "token"})
+	suite.Require().NoError(err)
+	suite.Equal("Email changed, please log in again", resp.Message)
+	suite.NoError(mock.ExpectationsWereMet())
+}
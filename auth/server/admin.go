@@ -0,0 +1,279 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	pb "github.com/sejamuchhal/taskhub/auth/pb"
+	"github.com/sejamuchhal/taskhub/auth/storage"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// roles are the roles an admin can give users
+var roles = []string{"user", "admin"}
+
+const maxListUsersLimit = 100
+
+// ListUsers returns a page of the users matching the query, the newest first
+func (s *Server) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	logger := s.Logger.WithField("method", "ListUsers")
+	logger.Debug("Incoming request")
+
+	if _, err := s.adminUser(logger, req.AccessToken); err != nil {
+		return nil, err
+	}
+
+	filter := storage.UserFilter{Query: req.Query, Role: req.Role}
+	switch req.Status {
+	case "", "all":
+	case "active", "inactive":
+		active := req.Status == "active"
+		filter.Active = &active
+	default:
+		return nil, status.Error(codes.InvalidArgument, "Status must be all, active or inactive")
+	}
+	if req.Limit < 0 || req.Offset < 0 {
+		return nil, status.Error(codes.InvalidArgument, "Limit and offset cannot be negative")
+	}
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = 10
+	}
+	limit = min(limit, maxListUsersLimit)
+
+	users, count, err := s.Storage.ListUsers(filter, limit, int(req.Offset))
+	if err != nil {
+		logger.WithError(err).Error("Error listing users")
+		return nil, status.Errorf(codes.Internal, "Error listing users: %v", err)
+	}
+
+	res := &pb.ListUsersResponse{
+		Users:      make([]*pb.AccountDetail, 0, len(users)),
+		TotalCount: count,
+	}
+	for i := range users {
+		res.Users = append(res.Users, accountDetail(&users[i]))
+	}
+	return res, nil
+}
+
+func (s *Server) GetUser(ctx context.Context, req *pb.GetUserRequest) (*pb.GetUserResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"method":         "GetUser",
+		"target_user_id": req.UserId,
+	})
+	logger.Debug("Incoming request")
+
+	if _, err := s.adminUser(logger, req.AccessToken); err != nil {
+		return nil, err
+	}
+
+	user, err := s.targetUser(logger, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetUserResponse{User: accountDetail(user)}, nil
+}
+
+// SetUserRole gives a user another role. It takes effect on their next
+// request, tokens carry no authority of their own.
+func (s *Server) SetUserRole(ctx context.Context, req *pb.SetUserRoleRequest) (*pb.SetUserRoleResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"method":         "SetUserRole",
+		"target_user_id": req.UserId,
+		"role":           req.Role,
+	})
+	logger.Debug("Incoming request")
+
+	admin, err := s.adminUser(logger, req.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	logger = logger.WithField("user_id", admin.ID)
+
+	if !slices.Contains(roles, req.Role) {
+		return nil, status.Error(codes.InvalidArgument, "Role must be user or admin")
+	}
+	// Admins could otherwise lock everyone out by demoting the last one
+	if req.UserId == admin.ID {
+		return nil, status.Error(codes.FailedPrecondition, "Admins cannot change their own role")
+	}
+
+	user, err := s.targetUser(logger, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Storage.SetUserRole(user.ID, req.Role); err != nil {
+		logger.WithError(err).Error("Error setting role")
+		return nil, status.Errorf(codes.Internal, "Error setting role: %v", err)
+	}
+	user.Role = req.Role
+
+	logger.Debug("Role set")
+	return &pb.SetUserRoleResponse{User: accountDetail(user)}, nil
+}
+
+// DeactivateUser keeps a user from logging in and logs them out everywhere
+func (s *Server) DeactivateUser(ctx context.Context, req *pb.DeactivateUserRequest) (*pb.DeactivateUserResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"method":         "DeactivateUser",
+		"target_user_id": req.UserId,
+	})
+	logger.Debug("Incoming request")
+
+	admin, err := s.adminUser(logger, req.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	logger = logger.WithField("user_id", admin.ID)
+
+	if req.UserId == admin.ID {
+		return nil, status.Error(codes.FailedPrecondition, "Admins cannot deactivate themselves")
+	}
+
+	user, err := s.targetUser(logger, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Storage.SetUserActive(user.ID, false); err != nil {
+		logger.WithError(err).Error("Error deactivating user")
+		return nil, status.Errorf(codes.Internal, "Error deactivating user: %v", err)
+	}
+	user.Active = false
+	if err := s.logoutUser(logger, user); err != nil {
+		return nil, err
+	}
+
+	logger.Debug("User deactivated")
+	return &pb.DeactivateUserResponse{User: accountDetail(user)}, nil
+}
+
+// ReactivateUser lets a deactivated user log in again
+func (s *Server) ReactivateUser(ctx context.Context, req *pb.ReactivateUserRequest) (*pb.ReactivateUserResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"method":         "ReactivateUser",
+		"target_user_id": req.UserId,
+	})
+	logger.Debug("Incoming request")
+
+	admin, err := s.adminUser(logger, req.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	logger = logger.WithField("user_id", admin.ID)
+
+	user, err := s.targetUser(logger, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.Storage.SetUserActive(user.ID, true); err != nil {
+		logger.WithError(err).Error("Error reactivating user")
+		return nil, status.Errorf(codes.Internal, "Error reactivating user: %v", err)
+	}
+	user.Active = true
+
+	logger.Debug("User reactivated")
+	return &pb.ReactivateUserResponse{User: accountDetail(user)}, nil
+}
+
+// LogoutUser ends every session of a user and revokes their access tokens
+func (s *Server) LogoutUser(ctx context.Context, req *pb.LogoutUserRequest) (*pb.LogoutUserResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"method":         "LogoutUser",
+		"target_user_id": req.UserId,
+	})
+	logger.Debug("Incoming request")
+
+	admin, err := s.adminUser(logger, req.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	logger = logger.WithField("user_id", admin.ID)
+
+	user, err := s.targetUser(logger, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.logoutUser(logger, user); err != nil {
+		return nil, err
+	}
+
+	logger.Debug("User logged out")
+	return &pb.LogoutUserResponse{Message: "User logged out"}, nil
+}
+
+// UnlockAccount lets an admin lift the lockout of an email before it runs
+// out
+func (s *Server) UnlockAccount(ctx context.Context, req *pb.UnlockAccountRequest) (*pb.UnlockAccountResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"method": "UnlockAccount",
+		"email":  req.Email,
+	})
+	logger.Debug("Incoming request")
+
+	admin, err := s.adminUser(logger, req.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	logger = logger.WithField("user_id", admin.ID)
+
+	if req.Email == "" {
+		return nil, status.Error(codes.InvalidArgument, "Email is required")
+	}
+
+	if err := s.LoginLimiter.Unlock(ctx, req.Email); err != nil {
+		logger.WithError(err).Error("Error unlocking account")
+		return nil, status.Errorf(codes.Internal, "Error unlocking account: %v", err)
+	}
+
+	logger.Debug("Account unlocked")
+	return &pb.UnlockAccountResponse{Message: "Account unlocked"}, nil
+}
+
+// targetUser returns the user an admin call is about
+func (s *Server) targetUser(logger *logrus.Entry, userID string) (*storage.User, error) {
+	if userID == "" {
+		return nil, status.Error(codes.InvalidArgument, "User ID is required")
+	}
+	user, err := s.Storage.GetUserByID(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.Warn("User not found")
+		return nil, status.Error(codes.NotFound, "User not found")
+	}
+	if err != nil {
+		logger.WithError(err).Error("Error fetching user from the database")
+		return nil, status.Errorf(codes.Internal, "Error fetching user from the database: %v", err)
+	}
+	return user, nil
+}
+
+// logoutUser blocks every session of user and revokes their access tokens
+func (s *Server) logoutUser(logger *logrus.Entry, user *storage.User) error {
+	if err := s.Storage.BlockSessionsByEmail(user.Email, ""); err != nil {
+		logger.WithError(err).Error("Error blocking sessions")
+		return status.Errorf(codes.Internal, "Error blocking sessions: %v", err)
+	}
+	if err := s.TokenHandler.RevokeUserTokens(user.ID, s.Config.AccessTokenDuration); err != nil {
+		logger.WithError(err).Error("Error revoking access tokens")
+		return status.Errorf(codes.Internal, "Error revoking access tokens: %v", err)
+	}
+	return nil
+}
+
+func accountDetail(user *storage.User) *pb.AccountDetail {
+	return &pb.AccountDetail{
+		Id:            user.ID,
+		Name:          user.Name,
+		Email:         user.Email,
+		Role:          user.Role,
+		Active:        user.Active,
+		EmailVerified: user.EmailVerifiedAt != nil,
+		MfaEnabled:    user.MFAEnabledAt != nil,
+		CreatedAt:     timestamppb.New(user.CreatedAt),
+	}
+}
@@ -0,0 +1,201 @@
+package server_test
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/sejamuchhal/taskhub/auth/pb"
+	"github.com/sejamuchhal/taskhub/auth/storage"
+	"github.com/sejamuchhal/taskhub/auth/util"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// adminToken returns an access token of an admin, whose ID is "admin"
+func (suite *ServerTestSuite) adminToken() string {
+	admin := &storage.User{ID: "admin", Email: "dumbledore@hogwarts.edu", Role: "admin", Active: true}
+	token := suite.accessToken(admin)
+	suite.MockStorage.EXPECT().GetUserByID("admin").Return(admin, nil)
+	return token
+}
+
+func (suite *ServerTestSuite) TestListUsers_Success() {
+	inactive := false
+	suite.MockStorage.EXPECT().ListUsers(storage.UserFilter{Query: "harry", Active: &inactive}, 10, 20).Return([]storage.User{
+		{ID: "1", Name: "Harry Potter", Email: "harry@hogwarts.edu", Role: "user"},
+	}, int64(21), nil)
+
+	resp, err := suite.Server.ListUsers(context.Background(), &pb.ListUsersRequest{
+		AccessToken: suite.adminToken(),
+		Query:       "harry",
+		Status:      "inactive",
+		Offset:      20,
+	})
+	suite.Require().NoError(err)
+	suite.Equal(int64(21), resp.TotalCount)
+	suite.Require().Len(resp.Users, 1)
+	suite.Equal("harry@hogwarts.edu", resp.Users[0].Email)
+	suite.False(resp.Users[0].Active)
+}
+
+func (suite *ServerTestSuite) TestListUsers_InvalidStatus() {
+	resp, err := suite.Server.ListUsers(context.Background(), &pb.ListUsersRequest{
+		AccessToken: suite.adminToken(),
+		Status:      "banned",
+	})
+	suite.Nil(resp)
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (suite *ServerTestSuite) TestListUsers_NotAdmin() {
+	user := &storage.User{ID: "1", Email: "draco@hogwarts.edu", Role: "user", Active: true}
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(user, nil)
+
+	resp, err := suite.Server.ListUsers(context.Background(), &pb.ListUsersRequest{AccessToken: suite.accessToken(user)})
+	suite.Nil(resp)
+	suite.Equal(codes.PermissionDenied, status.Code(err))
+}
+
+func (suite *ServerTestSuite) TestGetUser_NotFound() {
+	accessToken := suite.adminToken()
+	suite.MockStorage.EXPECT().GetUserByID("2").Return(&storage.User{}, gorm.ErrRecordNotFound)
+
+	resp, err := suite.Server.GetUser(context.Background(), &pb.GetUserRequest{AccessToken: accessToken, UserId: "2"})
+	suite.Nil(resp)
+	suite.Equal(codes.NotFound, status.Code(err))
+}
+
+func (suite *ServerTestSuite) TestSetUserRole_Success() {
+	accessToken := suite.adminToken()
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(&storage.User{ID: "1", Email: "harry@hogwarts.edu", Role: "user", Active: true}, nil)
+	suite.MockStorage.EXPECT().SetUserRole("1", "admin").Return(nil)
+
+	resp, err := suite.Server.SetUserRole(context.Background(), &pb.SetUserRoleRequest{
+		AccessToken: accessToken,
+		UserId:      "1",
+		Role:        "admin",
+	})
+	suite.Require().NoError(err)
+	suite.Equal("admin", resp.User.Role)
+}
+
+func (suite *ServerTestSuite) TestSetUserRole_InvalidRole() {
+	resp, err := suite.Server.SetUserRole(context.Background(), &pb.SetUserRoleRequest{
+		AccessToken: suite.adminToken(),
+		UserId:      "1",
+		Role:        "headmaster",
+	})
+	suite.Nil(resp)
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (suite *ServerTestSuite) TestSetUserRole_Self() {
+	resp, err := suite.Server.SetUserRole(context.Background(), &pb.SetUserRoleRequest{
+		AccessToken: suite.adminToken(),
+		UserId:      "admin",
+		Role:        "user",
+	})
+	suite.Nil(resp)
+	suite.Equal(codes.FailedPrecondition, status.Code(err))
+}
+
+func (suite *ServerTestSuite) TestDeactivateUser_Success() {
+	accessToken := suite.adminToken()
+	mock := *suite.MockRedis
+	mock.Regexp().ExpectSet("revoked_before:1", `^\d+$`, time.Hour).SetVal("OK")
+
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(&storage.User{ID: "1", Email: "harry@hogwarts.edu", Role: "user", Active: true}, nil)
+	suite.MockStorage.EXPECT().SetUserActive("1", false).Return(nil)
+	suite.MockStorage.EXPECT().BlockSessionsByEmail("harry@hogwarts.edu", "").Return(nil)
+
+	resp, err := suite.Server.DeactivateUser(context.Background(), &pb.DeactivateUserRequest{AccessToken: accessToken, UserId: "1"})
+	suite.Require().NoError(err)
+	suite.False(resp.User.Active)
+	suite.NoError(mock.ExpectationsWereMet())
+}
+
+func (suite *ServerTestSuite) TestDeactivateUser_Self() {
+	resp, err := suite.Server.DeactivateUser(context.Background(), &pb.DeactivateUserRequest{AccessToken: suite.adminToken(), UserId: "admin"})
+	suite.Nil(resp)
+	suite.Equal(codes.FailedPrecondition, status.Code(err))
+}
+
+func (suite *ServerTestSuite) TestReactivateUser_Success() {
+	accessToken := suite.adminToken()
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(&storage.User{ID: "1", Email: "harry@hogwarts.edu", Role: "user"}, nil)
+	suite.MockStorage.EXPECT().SetUserActive("1", true).Return(nil)
+
+	resp, err := suite.Server.ReactivateUser(context.Background(), &pb.ReactivateUserRequest{AccessToken: accessToken, UserId: "1"})
+	suite.Require().NoError(err)
+	suite.True(resp.User.Active)
+}
+
+func (suite *ServerTestSuite) TestLogoutUser_Success() {
+	accessToken := suite.adminToken()
+	mock := *suite.MockRedis
+	mock.Regexp().ExpectSet("revoked_before:1", `^\d+$`, time.Hour).SetVal("OK")
+
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(&storage.User{ID: "1", Email: "harry@hogwarts.edu", Role: "user", Active: true}, nil)
+	suite.MockStorage.EXPECT().BlockSessionsByEmail("harry@hogwarts.edu", "").Return(nil)
+
+	resp, err := suite.Server.LogoutUser(context.Background(), &pb.LogoutUserRequest{AccessToken: accessToken, UserId: "1"})
+	suite.Require().NoError(err)
+	suite.Equal("User logged out", resp.Message)
+	suite.NoError(mock.ExpectationsWereMet())
+}
+
+func (suite *ServerTestSuite) TestLogin_Deactivated() {
+	hashed, err := util.HashPassword("password")
+	suite.Require().NoError(err)
+	user := &storage.User{ID: "1", Email: "harry@hogwarts.edu", Password: hashed, Active: false}
+
+	suite.expectLoginAllowed("harry@hogwarts.edu", "")
+	suite.MockStorage.EXPECT().GetUserByEmail("harry@hogwarts.edu").Return(user, nil)
+	suite.MockLimiter.EXPECT().Reset(gomock.Any(), "harry@hogwarts.edu").Return(nil)
+
+	resp, err := suite.Server.Login(context.Background(), &pb.LoginRequest{Email: "harry@hogwarts.edu", Password: "password"})
+	suite.Nil(resp)
+	suite.Equal(codes.PermissionDenied, status.Code(err))
+}
+
+func (suite *ServerTestSuite) TestValidate_Deactivated() {
+	user := &storage.User{ID: "1", Email: "harry@hogwarts.edu", Role: "user"}
+	token, _, err := suite.Server.TokenHandler.CreateToken(user, time.Hour, "access")
+	suite.Require().NoError(err)
+	mock := *suite.MockRedis
+	mock.ExpectGet(token).RedisNil()
+	mock.ExpectGet("revoked_before:1").RedisNil()
+
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(user, nil)
+
+	resp, err := suite.Server.Validate(context.Background(), &pb.ValidateRequest{Token: token})
+	suite.Nil(resp)
+	suite.Equal(codes.Unauthenticated, status.Code(err))
+}
+
+func (suite *ServerTestSuite) TestUnlockAccount_Success() {
+	accessToken := suite.adminToken()
+	suite.MockLimiter.EXPECT().Unlock(gomock.Any(), "harry@hogwarts.edu").Return(nil)
+
+	resp, err := suite.Server.UnlockAccount(context.Background(), &pb.UnlockAccountRequest{
+		AccessToken: accessToken,
+		Email:       "harry@hogwarts.edu",
+	})
+	suite.Require().NoError(err)
+	suite.Equal("Account unlocked", resp.Message)
+}
+
+func (suite *ServerTestSuite) TestUnlockAccount_NotAdmin() {
+	user := &storage.User{ID: "1", Email: "draco@hogwarts.edu", Role: "user", Active: true}
+
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(user, nil)
+
+	resp, err := suite.Server.UnlockAccount(context.Background(), &pb.UnlockAccountRequest{
+		AccessToken: suite.accessToken(user),
+		Email:       "harry@hogwarts.edu",
+	})
+	suite.Nil(resp)
+	suite.Equal(codes.PermissionDenied, status.Code(err))
+}
@@ -42,13 +42,15 @@ func (s *Server) Signup(ctx context.Context, req *pb.SignupRequest) (*pb.SignupR
 		return nil, status.Errorf(codes.Internal, "Error hashing password: %v", err)
 	}
 
-	err = s.Storage.CreateUser(&storage.User{
+	user = &storage.User{
 		Name:     req.Name,
 		Email:    req.Email,
 		Password: hashedPassword,
-	})
+		Active:   true,
+	}
+	err = s.Storage.CreateUser(user)
 	if err != nil {
-		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+		if pqErr, ok := err.(*pq.Error); (ok && pqErr.Code == "23505") || errors.Is(err, gorm.ErrDuplicatedKey) {
 			logger.WithError(err).Error("User already exists")
 			return nil, status.Errorf(codes.AlreadyExists, "User already exists")
 		}
@@ -56,6 +58,11 @@ func (s *Server) Signup(ctx context.Context, req *pb.SignupRequest) (*pb.SignupR
 		return nil, status.Errorf(codes.Internal, "Error creating user in the database: %v", err)
 	}
 
+	// The user can ask for another link if this one does not arrive
+	if err := s.sendVerification(user); err != nil {
+		logger.WithError(err).Error("Error sending verification email")
+	}
+
 	logger.Debug("User signup successful")
 	return &pb.SignupResponse{Message: "User signup successful"}, nil
 }
@@ -70,11 +77,17 @@ func (s *Server) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResp
 	})
 	logger.Debug("Incoming login request")
 
+	ip := clientIP(ctx)
+	if err := s.checkLoginAttempts(ctx, logger, req.Email, ip); err != nil {
+		return nil, err
+	}
+
 	user, err := s.Storage.GetUserByEmail(req.Email)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			logger.WithError(err).Warn("User not found")
-			return nil, status.Errorf(codes.NotFound, "Invalid email or password")
+			return nil, s.loginFailed(ctx, logger, nil, req.Email, ip,
+				status.Errorf(codes.NotFound, "Invalid email or password"))
 		}
 		logger.WithError(err).Error("Error fetching user from the database")
 		return nil, status.Errorf(codes.Internal, "Error fetching user from the database: %v", err)
@@ -82,42 +95,25 @@ func (s *Server) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResp
 
 	if err := util.CheckPasswordHash(req.Password, user.Password); err != nil {
 		logger.WithError(err).Warn("Invalid password")
-		return nil, status.Errorf(codes.Unauthenticated, "Invalid email or password")
+		return nil, s.loginFailed(ctx, logger, user, req.Email, ip,
+			status.Errorf(codes.Unauthenticated, "Invalid email or password"))
 	}
-
-	accessToken, accessClaims, err := s.TokenHandler.CreateToken(user, s.Config.AccessTokenDuration, "access")
-	if err != nil {
-		logger.WithError(err).Error("Error creating access token")
-		return nil, status.Errorf(codes.Internal, "Error creating access token: %v", err)
+	if err := s.LoginLimiter.Reset(ctx, req.Email); err != nil {
+		logger.WithError(err).Error("Error resetting failed logins")
 	}
 
-	refreshToken, refreshClaims, err := s.TokenHandler.CreateToken(user, s.Config.RefreshTokenDuration, "refresh")
-	if err != nil {
-		logger.WithError(err).Error("Error creating refresh token")
-		return nil, status.Errorf(codes.Internal, "Error creating refresh token: %v", err)
+	if s.Config.RequireVerifiedEmail && user.EmailVerifiedAt == nil {
+		logger.Warn("Email not verified")
+		return nil, status.Errorf(codes.FailedPrecondition, "Email address is not verified")
 	}
 
-	err = s.Storage.CreateSession(&storage.Session{
-		ID:           refreshClaims.RegisteredClaims.ID,
-		Email:        user.Email,
-		RefreshToken: refreshToken,
-		ExpiresAt:    refreshClaims.RegisteredClaims.ExpiresAt.Time,
-	})
-	if err != nil {
-		logger.WithError(err).Error("Error creating session")
-		return nil, status.Errorf(codes.Internal, "Error creating session: %v", err)
+	if user.MFAEnabledAt != nil || s.mfaRequired(user) {
+		return s.mfaChallenge(logger, user)
 	}
 
-	res := &pb.LoginResponse{
-		SessionId:             refreshClaims.RegisteredClaims.ID,
-		AccessToken:           accessToken,
-		RefreshToken:          refreshToken,
-		AccessTokenExpiresAt:  timestamppb.New(accessClaims.RegisteredClaims.ExpiresAt.Time),
-		RefreshTokenExpiresAt: timestamppb.New(refreshClaims.RegisteredClaims.ExpiresAt.Time),
-		User: &pb.UserDetail{
-			Name:  user.Name,
-			Email: user.Email,
-		},
+	res, err := s.startSession(ctx, logger, user, req.Client)
+	if err != nil {
+		return nil, err
 	}
 
 	logger.Debug("User login successful")
@@ -136,13 +132,28 @@ func (s *Server) Validate(ctx context.Context, req *pb.ValidateRequest) (*pb.Val
 	}
 	logger.WithField("claims", claims).Debug("Fetched claims")
 
+	// The user as stored, so that deactivation and role changes apply to
+	// tokens handed out before
+	user, err := s.Storage.GetUserByID(claims.UserID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && !user.Active) {
+		logger.WithError(err).Warn("User of token not found or deactivated")
+		return nil, status.Error(codes.Unauthenticated, "Invalid token: user not found or deactivated")
+	}
+	if err != nil {
+		logger.WithError(err).Error("Error fetching user from the database")
+		return nil, status.Errorf(codes.Internal, "Error fetching user from the database: %v", err)
+	}
+
 	return &pb.ValidateResponse{
-		UserId: claims.UserID,
-		Email:  claims.Email,
-		Role:   claims.Role,
+		UserId: user.ID,
+		Email:  user.Email,
+		Role:   user.Role,
 	}, nil
 }
 
+// RenewAccessToken hands out a new access token and rotates the refresh token.
+// A refresh token that was already replaced means it leaked, so the session
+// it belongs to is ended.
 func (s *Server) RenewAccessToken(ctx context.Context, req *pb.RenewAccessTokenRequest) (*pb.RenewAccessTokenResponse, error) {
 	logger := s.Logger.WithField("method", "RenewAccessToken")
 	logger.Debug("Incoming request")
@@ -152,7 +163,13 @@ func (s *Server) RenewAccessToken(ctx context.Context, req *pb.RenewAccessTokenR
 		logger.WithError(err).Error("Error veryfying token")
 		return nil, status.Errorf(codes.Unauthenticated, "Error veryfying token: %v", err)
 	}
-	session, err := s.Storage.GetSessionByID(refreshClaims.RegisteredClaims.ID)
+	logger = logger.WithField("session_id", refreshClaims.SessionID())
+
+	session, err := s.Storage.GetSessionByID(refreshClaims.SessionID())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.Warn("Session revoked")
+		return nil, status.Error(codes.Unauthenticated, "Session revoked")
+	}
 	if err != nil {
 		logger.WithError(err).Error("Error fethcing session")
 		return nil, status.Errorf(codes.Internal, "Error fethcing session: %v", err)
@@ -163,29 +180,50 @@ func (s *Server) RenewAccessToken(ctx context.Context, req *pb.RenewAccessTokenR
 		logger.WithError(err).Error("Session blocked")
 		return nil, status.Error(codes.Unauthenticated, "Session blocked")
 	}
-	if session.Email != refreshClaims.Email || session.RefreshToken != req.RefreshToken {
+	if session.Email != refreshClaims.Email {
 		logger.WithError(err).Error("Invalid session")
 		return nil, status.Error(codes.Unauthenticated, "Invalid session")
 	}
+	if session.RefreshToken != req.RefreshToken {
+		return nil, s.revokeReusedSession(logger, session, refreshClaims)
+	}
 
 	if time.Now().After(session.ExpiresAt) {
 		logger.WithError(err).Error("Expired session")
 		return nil, status.Error(codes.Unauthenticated, "Expired session")
 	}
 
-	accessToken, accessClaims, err := s.TokenHandler.CreateToken(&storage.User{
+	user := &storage.User{
 		ID:    refreshClaims.UserID,
 		Email: refreshClaims.Email,
 		Role:  refreshClaims.Role,
-	}, s.Config.AccessTokenDuration, "access")
-
+	}
+	accessToken, accessClaims, err := s.TokenHandler.CreateToken(user, s.Config.AccessTokenDuration, "access")
 	if err != nil {
 		logger.WithError(err).Error("Error creating token")
 		return nil, status.Errorf(codes.Internal, "Error creating token: %v", err)
 	}
+	refreshToken, newRefreshClaims, err := s.TokenHandler.CreateRefreshToken(user, s.Config.RefreshTokenDuration, session.ID)
+	if err != nil {
+		logger.WithError(err).Error("Error creating refresh token")
+		return nil, status.Errorf(codes.Internal, "Error creating refresh token: %v", err)
+	}
+
+	err = s.Storage.RotateSessionToken(session.ID, req.RefreshToken, refreshToken, newRefreshClaims.RegisteredClaims.ExpiresAt.Time, time.Now())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// Another renewal with the same token got there first
+		return nil, s.revokeReusedSession(logger, session, refreshClaims)
+	}
+	if err != nil {
+		logger.WithError(err).Error("Error rotating refresh token")
+		return nil, status.Errorf(codes.Internal, "Error rotating refresh token: %v", err)
+	}
+
 	res := &pb.RenewAccessTokenResponse{
-		AccessToken:          accessToken,
-		AccessTokenExpiresAt: timestamppb.New(accessClaims.RegisteredClaims.ExpiresAt.Time),
+		AccessToken:           accessToken,
+		AccessTokenExpiresAt:  timestamppb.New(accessClaims.RegisteredClaims.ExpiresAt.Time),
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: timestamppb.New(newRefreshClaims.RegisteredClaims.ExpiresAt.Time),
 	}
 	return res, nil
 
@@ -216,13 +254,13 @@ func (s *Server) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutR
 		return nil, status.Errorf(codes.InvalidArgument, "Invalid refresh token")
 	}
 
-	session, err := s.Storage.GetSessionByID(refreshClaims.RegisteredClaims.ID)
-	if err != nil {
+	session, err := s.Storage.GetSessionByID(refreshClaims.SessionID())
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		logger.WithError(err).Error("Error fetching session")
 		return nil, status.Errorf(codes.Internal, "Error fetching session: %v", err)
 	}
 
-	if session.IsBlocked {
+	if err != nil || session.IsBlocked {
 		logger.Warning("Session already revoked")
 		return &pb.LogoutResponse{}, nil
 	}
@@ -236,3 +274,60 @@ func (s *Server) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutR
 	logger.Debug("User logout successful")
 	return &pb.LogoutResponse{}, nil
 }
+
+// startSession logs user in with a new session started from client, which
+// may be nil
+func (s *Server) startSession(ctx context.Context, logger *logrus.Entry, user *storage.User, client *pb.ClientInfo) (*pb.LoginResponse, error) {
+	if err := checkActive(logger, user); err != nil {
+		return nil, err
+	}
+
+	accessToken, accessClaims, err := s.TokenHandler.CreateToken(user, s.Config.AccessTokenDuration, "access")
+	if err != nil {
+		logger.WithError(err).Error("Error creating access token")
+		return nil, status.Errorf(codes.Internal, "Error creating access token: %v", err)
+	}
+
+	refreshToken, refreshClaims, err := s.TokenHandler.CreateRefreshToken(user, s.Config.RefreshTokenDuration, "")
+	if err != nil {
+		logger.WithError(err).Error("Error creating refresh token")
+		return nil, status.Errorf(codes.Internal, "Error creating refresh token: %v", err)
+	}
+
+	err = s.Storage.CreateSession(&storage.Session{
+		ID:           refreshClaims.SessionID(),
+		Email:        user.Email,
+		RefreshToken: refreshToken,
+		ExpiresAt:    refreshClaims.RegisteredClaims.ExpiresAt.Time,
+		UserAgent:    truncate(client.GetUserAgent(), 255),
+		IPAddress:    truncate(clientIP(ctx), 45),
+		LastUsedAt:   time.Now(),
+	})
+	if err != nil {
+		logger.WithError(err).Error("Error creating session")
+		return nil, status.Errorf(codes.Internal, "Error creating session: %v", err)
+	}
+
+	res := &pb.LoginResponse{
+		SessionId:             refreshClaims.SessionID(),
+		AccessToken:           accessToken,
+		RefreshToken:          refreshToken,
+		AccessTokenExpiresAt:  timestamppb.New(accessClaims.RegisteredClaims.ExpiresAt.Time),
+		RefreshTokenExpiresAt: timestamppb.New(refreshClaims.RegisteredClaims.ExpiresAt.Time),
+		User: &pb.UserDetail{
+			Name:  user.Name,
+			Email: user.Email,
+		},
+	}
+	return res, nil
+}
+
+// checkActive keeps deactivated users from logging in and from using the
+// tokens they still hold
+func checkActive(logger *logrus.Entry, user *storage.User) error {
+	if !user.Active {
+		logger.WithField("user_id", user.ID).Warn("User is deactivated")
+		return status.Error(codes.PermissionDenied, "Account is deactivated")
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package server_test
 
 import (
+	"bytes"
 	"context"
 	"log"
 	"testing"
@@ -8,6 +9,8 @@ import (
 
 	"github.com/go-redis/redismock/v9"
 	"github.com/sejamuchhal/taskhub/auth/common"
+	mock_events "github.com/sejamuchhal/taskhub/auth/events/mock_rabbitmq"
+	"github.com/sejamuchhal/taskhub/auth/loginlimit/mock_loginlimit"
 	pb "github.com/sejamuchhal/taskhub/auth/pb"
 	"github.com/sejamuchhal/taskhub/auth/server"
 	"github.com/sejamuchhal/taskhub/auth/storage"
@@ -24,29 +27,46 @@ import (
 
 type ServerTestSuite struct {
 	suite.Suite
-	Server      *server.Server
-	MockCtrl    *gomock.Controller
-	MockStorage *mock_storage.MockStorageInterface
-	MockRedis   *redismock.ClientMock
+	Server       *server.Server
+	MockCtrl     *gomock.Controller
+	MockStorage  *mock_storage.MockStorageInterface
+	MockRedis    *redismock.ClientMock
+	MockRabbitMQ *mock_events.MockRabbitMQBrokerInterface
+	MockLimiter  *mock_loginlimit.MockLimiterInterface
 }
 
 func (suite *ServerTestSuite) SetupSuite() {
 	logger := logrus.NewEntry(logrus.New())
 	config := &common.Config{
-		JWTSecret:            "test-secret",
-		AccessTokenDuration:  time.Hour,
-		RefreshTokenDuration: 24 * time.Hour,
+		AccessTokenDuration:        time.Hour,
+		RefreshTokenDuration:       24 * time.Hour,
+		VerifyEmailURL:             "https://taskhub.example.com/verify",
+		VerificationTokenDuration:  24 * time.Hour,
+		ResetPasswordURL:           "https://taskhub.example.com/reset",
+		PasswordResetTokenDuration: 30 * time.Minute,
+		MFAIssuer:                  "TaskHub",
+		MFAChallengeDuration:       5 * time.Minute,
+		MFARequiredRoles:           []string{"admin"},
 	}
+	secretBox, err := util.NewSecretBox(bytes.Repeat([]byte{1}, util.SecretBoxKeySize))
+	suite.Require().NoError(err)
+	keys, err := util.GenerateKeySet(config.RefreshTokenDuration)
+	suite.Require().NoError(err)
 	db, mock := redismock.NewClientMock()
 
 	suite.MockCtrl = gomock.NewController(suite.T())
 	suite.MockStorage = mock_storage.NewMockStorageInterface(suite.MockCtrl)
 	suite.MockRedis = &mock
+	suite.MockRabbitMQ = mock_events.NewMockRabbitMQBrokerInterface(suite.MockCtrl)
+	suite.MockLimiter = mock_loginlimit.NewMockLimiterInterface(suite.MockCtrl)
 	suite.Server = &server.Server{
 		Storage:      suite.MockStorage,
-		TokenHandler: util.NewTokenHandler(config.JWTSecret, db),
+		Publisher:    suite.MockRabbitMQ,
+		TokenHandler: util.NewTokenHandler(keys, "", db),
 		Logger:       logger,
 		Config:       config,
+		SecretBox:    secretBox,
+		LoginLimiter: suite.MockLimiter,
 	}
 }
 
@@ -68,6 +88,9 @@ func TestServerTestSuite(t *testing.T) {
 func (suite *ServerTestSuite) TestSignup_Success() {
 	suite.MockStorage.EXPECT().GetUserByEmail("harry@hogwarts.edu").Return(nil, gorm.ErrRecordNotFound)
 	suite.MockStorage.EXPECT().CreateUser(gomock.Any()).Return(nil)
+	suite.MockStorage.EXPECT().DeleteUserTokens(gomock.Any(), storage.UserTokenVerifyEmail).Return(nil)
+	suite.MockStorage.EXPECT().CreateUserToken(gomock.Any()).Return(nil)
+	suite.MockRabbitMQ.EXPECT().Publish(gomock.Any()).Return(nil)
 
 	req := &pb.SignupRequest{
 		Name:     "Harry Potter",
@@ -109,9 +132,12 @@ func (suite *ServerTestSuite) TestLogin_Success() {
 		Name:     "Harry Potter",
 		Email:    "harry@hogwarts.edu",
 		Password: hashedPassword,
+		Active:   true,
 	}
 
+	suite.expectLoginAllowed("harry@hogwarts.edu", "")
 	suite.MockStorage.EXPECT().GetUserByEmail("harry@hogwarts.edu").Return(user, nil)
+	suite.MockLimiter.EXPECT().Reset(gomock.Any(), "harry@hogwarts.edu").Return(nil)
 	suite.MockStorage.EXPECT().CreateSession(gomock.Any()).Return(nil)
 
 	req := &pb.LoginRequest{
@@ -134,7 +160,9 @@ func (suite *ServerTestSuite) TestLogin_InvalidPassword() {
 		Password: hashedPassword,
 	}
 
+	suite.expectLoginAllowed("harry@hogwarts.edu", "")
 	suite.MockStorage.EXPECT().GetUserByEmail("harry@hogwarts.edu").Return(user, nil)
+	suite.MockLimiter.EXPECT().RecordFailure(gomock.Any(), "harry@hogwarts.edu", "").Return(time.Duration(0), nil)
 
 	req := &pb.LoginRequest{
 		Email:    "harry@hogwarts.edu",
@@ -149,14 +177,17 @@ func (suite *ServerTestSuite) TestLogin_InvalidPassword() {
 
 func (suite *ServerTestSuite) TestValidate_Success() {
 	user := &storage.User{
-		ID:    "1",
-		Name:  "Harry Potter",
-		Email: "harry@hogwarts.edu",
+		ID:     "1",
+		Name:   "Harry Potter",
+		Email:  "harry@hogwarts.edu",
+		Role:   "user",
+		Active: true,
 	}
 	tokenString, claims, err := suite.Server.TokenHandler.CreateToken(user, time.Hour, "access")
 	assert.NoError(suite.T(), err)
 	mock := *suite.MockRedis
 	mock.ExpectGet(tokenString).SetVal("")
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(user, nil)
 	req := &pb.ValidateRequest{
 		Token: tokenString,
 	}
@@ -201,6 +232,7 @@ func (suite *ServerTestSuite) TestRenewAccessToken_Success() {
 	}
 
 	session := &storage.Session{
+		ID:           refreshClaims.RegisteredClaims.ID,
 		IsBlocked:    false,
 		Email:        "harry@hogwarts.edu",
 		RefreshToken: refreshToken,
@@ -208,11 +240,21 @@ func (suite *ServerTestSuite) TestRenewAccessToken_Success() {
 	}
 
 	suite.MockStorage.EXPECT().GetSessionByID(refreshClaims.RegisteredClaims.ID).Return(session, nil)
+	// The refresh token is rotated within the session
+	var rotated string
+	suite.MockStorage.EXPECT().RotateSessionToken(refreshClaims.RegisteredClaims.ID, refreshToken, gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(id, oldToken, newToken string, expiresAt, usedAt time.Time) error {
+			rotated = newToken
+			return nil
+		})
 
 	resp, err := suite.Server.RenewAccessToken(context.Background(), req)
 
-	assert.NotNil(suite.T(), resp.AccessToken)
 	assert.NoError(suite.T(), err)
+	assert.NotEmpty(suite.T(), resp.AccessToken)
+	assert.Equal(suite.T(), rotated, resp.RefreshToken)
+	assert.NotEqual(suite.T(), refreshToken, resp.RefreshToken)
+	assert.NotNil(suite.T(), resp.RefreshTokenExpiresAt)
 }
 
 func (suite *ServerTestSuite) TestRenewAccessToken_SessionBlocked() {
@@ -268,7 +310,7 @@ func (suite *ServerTestSuite) TestLogout_Success() {
 	mock.ExpectGet(refreshToken).SetVal("")
 
 	session := &storage.Session{
-		ID: refreshClaims.RegisteredClaims.ID,
+		ID:           refreshClaims.RegisteredClaims.ID,
 		IsBlocked:    false,
 		Email:        "harry@hogwarts.edu",
 		RefreshToken: refreshToken,
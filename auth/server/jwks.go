@@ -0,0 +1,29 @@
+package server
+
+import (
+	"context"
+
+	pb "github.com/sejamuchhal/taskhub/auth/pb"
+)
+
+// GetJWKS returns the public keys tokens are signed with, so that other
+// services can verify tokens without asking
+func (s *Server) GetJWKS(ctx context.Context, req *pb.GetJWKSRequest) (*pb.GetJWKSResponse, error) {
+	logger := s.Logger.WithField("method", "GetJWKS")
+	logger.Debug("Incoming request")
+
+	var res pb.GetJWKSResponse
+	for _, key := range s.TokenHandler.PublicKeys() {
+		res.Keys = append(res.Keys, &pb.JWK{
+			Kty: key.Kty,
+			Kid: key.Kid,
+			Use: key.Use,
+			Alg: key.Alg,
+			N:   key.N,
+			E:   key.E,
+			Crv: key.Crv,
+			X:   key.X,
+		})
+	}
+	return &res, nil
+}
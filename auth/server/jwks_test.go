@@ -0,0 +1,26 @@
+package server_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	pb "github.com/sejamuchhal/taskhub/auth/pb"
+	"github.com/sejamuchhal/taskhub/auth/storage"
+)
+
+func (suite *ServerTestSuite) TestGetJWKS() {
+	resp, err := suite.Server.GetJWKS(context.Background(), &pb.GetJWKSRequest{})
+	suite.Require().NoError(err)
+	suite.Require().Len(resp.Keys, 1)
+	suite.Equal("OKP", resp.Keys[0].Kty)
+	suite.Equal("EdDSA", resp.Keys[0].Alg)
+	suite.NotEmpty(resp.Keys[0].X)
+
+	// Tokens carry the ID of the published key
+	token, _, err := suite.Server.TokenHandler.CreateToken(&storage.User{ID: "1"}, time.Hour, "access")
+	suite.Require().NoError(err)
+	parsed, _, err := jwt.NewParser().ParseUnverified(token, &jwt.MapClaims{})
+	suite.Require().NoError(err)
+	suite.Equal(resp.Keys[0].Kid, parsed.Header["kid"])
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	event_pb "github.com/sejamuchhal/taskhub/auth/pb/event"
+	"github.com/sejamuchhal/taskhub/auth/storage"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// clientIPKey is the metadata the gateway passes the IP address of the client
+// in. Request messages are filled in from what the client sends, so they
+// cannot be trusted with it.
+const clientIPKey = "client_ip"
+
+// clientIP returns the IP address of the client the gateway got ctx from, or
+// "" if there is none
+func clientIP(ctx context.Context) string {
+	if values := metadata.ValueFromIncomingContext(ctx, clientIPKey); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// checkLoginAttempts fails with ResourceExhausted while logins for email or
+// from ip are held off
+func (s *Server) checkLoginAttempts(ctx context.Context, logger *logrus.Entry, email, ip string) error {
+	wait, err := s.LoginLimiter.Check(ctx, email, ip)
+	if err != nil {
+		logger.WithError(err).Error("Error checking failed logins")
+		return status.Errorf(codes.Internal, "Error checking failed logins: %v", err)
+	}
+	if wait > 0 {
+		logger.WithField("retry_after", wait).Warn("Too many failed logins")
+		return tooManyLoginAttempts(wait)
+	}
+	return nil
+}
+
+// loginFailed counts a failed login for email from ip and returns err, or
+// ResourceExhausted if the failure locked the email. user is nil for emails
+// without an account, there is no one to tell about their lockout.
+func (s *Server) loginFailed(ctx context.Context, logger *logrus.Entry, user *storage.User, email, ip string, err error) error {
+	lockout, recordErr := s.LoginLimiter.RecordFailure(ctx, email, ip)
+	if recordErr != nil {
+		logger.WithError(recordErr).Error("Error recording failed login")
+		return err
+	}
+	if lockout == 0 {
+		return err
+	}
+
+	logger.WithField("lockout", lockout).Warn("Account locked after too many failed logins")
+	if user != nil {
+		err := s.publishUserEvent(&event_pb.UserEvent{
+			Type:      UserEventAccountLocked,
+			Email:     user.Email,
+			Name:      user.Name,
+			IpAddress: ip,
+		})
+		if err != nil {
+			logger.WithError(err).Error("Error publishing account locked event")
+		}
+	}
+	return tooManyLoginAttempts(lockout)
+}
+
+// tooManyLoginAttempts tells the client to wait before logging in again
+func tooManyLoginAttempts(wait time.Duration) error {
+	st := status.New(codes.ResourceExhausted, "Too many failed login attempts, try again later")
+	withRetry, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(wait)})
+	if err != nil {
+		return st.Err()
+	}
+	return withRetry.Err()
+}
@@ -0,0 +1,100 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	pb "github.com/sejamuchhal/taskhub/auth/pb"
+	event_pb "github.com/sejamuchhal/taskhub/auth/pb/event"
+	"github.com/sejamuchhal/taskhub/auth/server"
+	"github.com/sejamuchhal/taskhub/auth/storage"
+	"github.com/sejamuchhal/taskhub/auth/util"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// clientContext returns the context of a request the gateway got from ip
+func clientContext(ip string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("client_ip", ip))
+}
+
+// expectLoginAllowed lets a login for email from ip go ahead
+func (suite *ServerTestSuite) expectLoginAllowed(email, ip string) {
+	suite.MockLimiter.EXPECT().Check(gomock.Any(), email, ip).Return(time.Duration(0), nil)
+}
+
+// retryDelay returns the RetryInfo delay of err
+func (suite *ServerTestSuite) retryDelay(err error) time.Duration {
+	for _, detail := range status.Convert(err).Details() {
+		if retryInfo, ok := detail.(*errdetails.RetryInfo); ok {
+			return retryInfo.RetryDelay.AsDuration()
+		}
+	}
+	suite.Fail("no RetryInfo in error", err)
+	return 0
+}
+
+func (suite *ServerTestSuite) TestLogin_Locked() {
+	suite.MockLimiter.EXPECT().Check(gomock.Any(), "harry@hogwarts.edu", "203.0.113.7").Return(10*time.Minute, nil)
+
+	// The password is not even looked at
+	resp, err := suite.Server.Login(clientContext("203.0.113.7"), &pb.LoginRequest{
+		Email:    "harry@hogwarts.edu",
+		Password: "password",
+	})
+	suite.Nil(resp)
+	suite.Equal(codes.ResourceExhausted, status.Code(err))
+	suite.Equal(10*time.Minute, suite.retryDelay(err))
+}
+
+func (suite *ServerTestSuite) TestLogin_CheckFails() {
+	suite.MockLimiter.EXPECT().Check(gomock.Any(), "harry@hogwarts.edu", "").Return(time.Duration(0), errors.New("redis down"))
+
+	resp, err := suite.Server.Login(context.Background(), &pb.LoginRequest{Email: "harry@hogwarts.edu", Password: "password"})
+	suite.Nil(resp)
+	suite.Equal(codes.Internal, status.Code(err))
+}
+
+func (suite *ServerTestSuite) TestLogin_FailureLocksAccount() {
+	hashed, err := util.HashPassword("password")
+	suite.Require().NoError(err)
+	user := &storage.User{ID: "1", Name: "Harry Potter", Email: "harry@hogwarts.edu", Password: hashed}
+
+	suite.expectLoginAllowed("harry@hogwarts.edu", "203.0.113.7")
+	suite.MockStorage.EXPECT().GetUserByEmail("harry@hogwarts.edu").Return(user, nil)
+	suite.MockLimiter.EXPECT().RecordFailure(gomock.Any(), "harry@hogwarts.edu", "203.0.113.7").Return(15*time.Minute, nil)
+	suite.MockRabbitMQ.EXPECT().Publish(gomock.Any()).DoAndReturn(func(body []byte) error {
+		var userEvent event_pb.UserEvent
+		suite.Require().NoError(json.Unmarshal(body, &userEvent))
+		suite.Equal(server.UserEventAccountLocked, userEvent.Type)
+		suite.Equal("harry@hogwarts.edu", userEvent.Email)
+		suite.Equal("Harry Potter", userEvent.Name)
+		suite.Equal("203.0.113.7", userEvent.IpAddress)
+		return nil
+	})
+
+	resp, err := suite.Server.Login(clientContext("203.0.113.7"), &pb.LoginRequest{
+		Email:    "harry@hogwarts.edu",
+		Password: "wrong-password",
+	})
+	suite.Nil(resp)
+	suite.Equal(codes.ResourceExhausted, status.Code(err))
+	suite.Equal(15*time.Minute, suite.retryDelay(err))
+}
+
+func (suite *ServerTestSuite) TestLogin_UnknownEmailCounted() {
+	suite.expectLoginAllowed("nobody@hogwarts.edu", "")
+	suite.MockStorage.EXPECT().GetUserByEmail("nobody@hogwarts.edu").Return(nil, gorm.ErrRecordNotFound)
+	// Locked like a real account would be, but there is no one to tell
+	suite.MockLimiter.EXPECT().RecordFailure(gomock.Any(), "nobody@hogwarts.edu", "").Return(15*time.Minute, nil)
+
+	resp, err := suite.Server.Login(context.Background(), &pb.LoginRequest{Email: "nobody@hogwarts.edu", Password: "password"})
+	suite.Nil(resp)
+	suite.Equal(codes.ResourceExhausted, status.Code(err))
+}
@@ -0,0 +1,336 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	pb "github.com/sejamuchhal/taskhub/auth/pb"
+	"github.com/sejamuchhal/taskhub/auth/storage"
+	"github.com/sejamuchhal/taskhub/auth/util"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// recoveryCodeCount is how many recovery codes users get when they enable
+// two-factor authentication
+const recoveryCodeCount = 10
+
+// maxMFATokenFailures wrong codes given with one mfa token use it up, the
+// user has to log in again for another try
+const maxMFATokenFailures = 3
+
+// VerifyMFA finishes a login that needs a second factor, with a code from
+// the user's TOTP app or one of their recovery codes
+func (s *Server) VerifyMFA(ctx context.Context, req *pb.VerifyMFARequest) (*pb.LoginResponse, error) {
+	logger := s.Logger.WithField("method", "VerifyMFA")
+	logger.Debug("Incoming request")
+
+	user, err := s.mfaTokenUser(logger, req.MfaToken)
+	if err != nil {
+		return nil, err
+	}
+	logger = logger.WithField("user_id", user.ID)
+
+	if user.MFAEnabledAt == nil {
+		logger.Warn("Two-factor authentication not enabled")
+		return nil, status.Error(codes.FailedPrecondition, "Two-factor authentication has to be set up first")
+	}
+	ip := clientIP(ctx)
+	if err := s.checkLoginAttempts(ctx, logger, user.Email, ip); err != nil {
+		return nil, err
+	}
+	if err := s.checkMFACode(logger, user, req.Code, req.RecoveryCode); err != nil {
+		if status.Code(err) != codes.InvalidArgument {
+			return nil, err
+		}
+		return nil, s.mfaFailed(ctx, logger, user, req.MfaToken, ip, err)
+	}
+	if err := s.useMFAToken(logger, req.MfaToken); err != nil {
+		return nil, err
+	}
+	if err := s.LoginLimiter.Reset(ctx, user.Email); err != nil {
+		logger.WithError(err).Error("Error resetting failed logins")
+	}
+
+	res, err := s.startSession(ctx, logger, user, req.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Debug("User login successful")
+	return res, nil
+}
+
+// EnrollMFA starts two-factor authentication for the caller with a new TOTP
+// secret. It only counts once ConfirmMFA sees a code made with it.
+func (s *Server) EnrollMFA(ctx context.Context, req *pb.EnrollMFARequest) (*pb.EnrollMFAResponse, error) {
+	logger := s.Logger.WithField("method", "EnrollMFA")
+	logger.Debug("Incoming request")
+
+	user, err := s.mfaCaller(logger, req.AccessToken, req.MfaToken)
+	if err != nil {
+		return nil, err
+	}
+	logger = logger.WithField("user_id", user.ID)
+
+	if user.MFAEnabledAt != nil {
+		return nil, status.Error(codes.FailedPrecondition, "Two-factor authentication is already enabled")
+	}
+
+	secret, uri, err := util.NewTOTPKey(s.Config.MFAIssuer, user.Email)
+	if err != nil {
+		logger.WithError(err).Error("Error generating TOTP secret")
+		return nil, status.Errorf(codes.Internal, "Error generating TOTP secret: %v", err)
+	}
+	sealed, err := s.SecretBox.Seal(secret)
+	if err != nil {
+		logger.WithError(err).Error("Error encrypting TOTP secret")
+		return nil, status.Errorf(codes.Internal, "Error encrypting TOTP secret: %v", err)
+	}
+	if err := s.Storage.SetUserMFASecret(user.ID, sealed); err != nil {
+		logger.WithError(err).Error("Error saving TOTP secret")
+		return nil, status.Errorf(codes.Internal, "Error saving TOTP secret: %v", err)
+	}
+
+	logger.Debug("Two-factor authentication enrollment started")
+	return &pb.EnrollMFAResponse{Secret: secret, Uri: uri}, nil
+}
+
+// ConfirmMFA enables two-factor authentication once the caller shows a code
+// made with the secret from EnrollMFA, and hands out recovery codes. Callers
+// that enrolled during a login are logged in too.
+func (s *Server) ConfirmMFA(ctx context.Context, req *pb.ConfirmMFARequest) (*pb.ConfirmMFAResponse, error) {
+	logger := s.Logger.WithField("method", "ConfirmMFA")
+	logger.Debug("Incoming request")
+
+	user, err := s.mfaCaller(logger, req.AccessToken, req.MfaToken)
+	if err != nil {
+		return nil, err
+	}
+	logger = logger.WithField("user_id", user.ID)
+
+	if user.MFAEnabledAt != nil {
+		return nil, status.Error(codes.FailedPrecondition, "Two-factor authentication is already enabled")
+	}
+	if user.MFASecret == "" {
+		return nil, status.Error(codes.FailedPrecondition, "Two-factor authentication enrollment has not been started")
+	}
+	if err := s.checkTOTPCode(logger, user, req.Code); err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, hashes, err := util.NewRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		logger.WithError(err).Error("Error generating recovery codes")
+		return nil, status.Errorf(codes.Internal, "Error generating recovery codes: %v", err)
+	}
+	if err := s.Storage.EnableUserMFA(user.ID, time.Now(), hashes); err != nil {
+		logger.WithError(err).Error("Error enabling two-factor authentication")
+		return nil, status.Errorf(codes.Internal, "Error enabling two-factor authentication: %v", err)
+	}
+	logger.Debug("Two-factor authentication enabled")
+
+	res := &pb.ConfirmMFAResponse{RecoveryCodes: recoveryCodes}
+	if req.AccessToken == "" {
+		if err := s.useMFAToken(logger, req.MfaToken); err != nil {
+			return nil, err
+		}
+		res.Login, err = s.startSession(ctx, logger, user, req.Client)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// DisableMFA turns off two-factor authentication for the caller, who has to
+// show their password and a code. Roles that require it cannot turn it off.
+func (s *Server) DisableMFA(ctx context.Context, req *pb.DisableMFARequest) (*pb.DisableMFAResponse, error) {
+	logger := s.Logger.WithField("method", "DisableMFA")
+	logger.Debug("Incoming request")
+
+	user, err := s.accessTokenUser(logger, req.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	logger = logger.WithField("user_id", user.ID)
+
+	if user.MFAEnabledAt == nil {
+		return nil, status.Error(codes.FailedPrecondition, "Two-factor authentication is not enabled")
+	}
+	if s.mfaRequired(user) {
+		return nil, status.Error(codes.FailedPrecondition, "Two-factor authentication is required for your role")
+	}
+	// Both the password and the code count towards the failed logins of
+	// the user, or a stolen access token would allow guessing them
+	ip := clientIP(ctx)
+	if err := s.checkLoginAttempts(ctx, logger, user.Email, ip); err != nil {
+		return nil, err
+	}
+	if err := util.CheckPasswordHash(req.Password, user.Password); err != nil {
+		logger.WithError(err).Warn("Invalid password")
+		return nil, s.loginFailed(ctx, logger, user, user.Email, ip,
+			status.Error(codes.InvalidArgument, "Password is incorrect"))
+	}
+	if err := s.checkMFACode(logger, user, req.Code, req.RecoveryCode); err != nil {
+		if status.Code(err) != codes.InvalidArgument {
+			return nil, err
+		}
+		return nil, s.loginFailed(ctx, logger, user, user.Email, ip, err)
+	}
+	if err := s.LoginLimiter.Reset(ctx, user.Email); err != nil {
+		logger.WithError(err).Error("Error resetting failed logins")
+	}
+
+	if err := s.Storage.DisableUserMFA(user.ID); err != nil {
+		logger.WithError(err).Error("Error disabling two-factor authentication")
+		return nil, status.Errorf(codes.Internal, "Error disabling two-factor authentication: %v", err)
+	}
+
+	logger.Debug("Two-factor authentication disabled")
+	return &pb.DisableMFAResponse{Message: "Two-factor authentication disabled"}, nil
+}
+
+// mfaRequired reports whether the role of user has to use two-factor
+// authentication
+func (s *Server) mfaRequired(user *storage.User) bool {
+	return slices.Contains(s.Config.MFARequiredRoles, user.Role)
+}
+
+// mfaChallenge answers a login that needs a second factor with a short lived
+// mfa token instead of a session
+func (s *Server) mfaChallenge(logger *logrus.Entry, user *storage.User) (*pb.LoginResponse, error) {
+	if err := checkActive(logger, user); err != nil {
+		return nil, err
+	}
+
+	token, claims, err := s.TokenHandler.CreateToken(user, s.Config.MFAChallengeDuration, "mfa")
+	if err != nil {
+		logger.WithError(err).Error("Error creating mfa token")
+		return nil, status.Errorf(codes.Internal, "Error creating mfa token: %v", err)
+	}
+
+	logger.Debug("Second factor required")
+	return &pb.LoginResponse{
+		MfaRequired:           true,
+		MfaToken:              token,
+		MfaTokenExpiresAt:     timestamppb.New(claims.RegisteredClaims.ExpiresAt.Time),
+		MfaEnrollmentRequired: user.MFAEnabledAt == nil,
+	}, nil
+}
+
+// mfaTokenUser returns the user an mfa token was issued to
+func (s *Server) mfaTokenUser(logger *logrus.Entry, mfaToken string) (*storage.User, error) {
+	claims, err := s.TokenHandler.VerifyToken(mfaToken, "mfa")
+	if err != nil {
+		logger.WithError(err).Warn("Invalid mfa token")
+		return nil, status.Error(codes.Unauthenticated, "Invalid or expired mfa token")
+	}
+
+	user, err := s.Storage.GetUserByID(claims.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.WithError(err).Warn("User of mfa token not found")
+			return nil, status.Error(codes.Unauthenticated, "Invalid or expired mfa token")
+		}
+		logger.WithError(err).Error("Error fetching user from the database")
+		return nil, status.Errorf(codes.Internal, "Error fetching user from the database: %v", err)
+	}
+	return user, nil
+}
+
+// mfaCaller returns the user enrolling in two-factor authentication. An mfa
+// token only works while the user has not enabled it, so that knowing the
+// password is not enough to replace someone's second factor.
+func (s *Server) mfaCaller(logger *logrus.Entry, accessToken, mfaToken string) (*storage.User, error) {
+	if accessToken != "" {
+		return s.accessTokenUser(logger, accessToken)
+	}
+
+	user, err := s.mfaTokenUser(logger, mfaToken)
+	if err != nil {
+		return nil, err
+	}
+	if user.MFAEnabledAt != nil {
+		logger.WithField("user_id", user.ID).Warn("Enrollment with an mfa token after enabling")
+		return nil, status.Error(codes.FailedPrecondition, "Two-factor authentication is already enabled")
+	}
+	return user, nil
+}
+
+// useMFAToken makes sure an mfa token only logs in once
+func (s *Server) useMFAToken(logger *logrus.Entry, mfaToken string) error {
+	if err := s.TokenHandler.BlacklistToken(mfaToken, s.Config.MFAChallengeDuration); err != nil {
+		logger.WithError(err).Error("Error blacklisting mfa token")
+		return status.Errorf(codes.Internal, "Error blacklisting mfa token: %v", err)
+	}
+	return nil
+}
+
+// mfaFailed counts a wrong code given with mfaToken, both against the token
+// and as a failed login of user, and returns err or what loginFailed makes
+// of it. The token is used up after maxMFATokenFailures wrong codes.
+func (s *Server) mfaFailed(ctx context.Context, logger *logrus.Entry, user *storage.User, mfaToken, ip string, err error) error {
+	failures, recordErr := s.LoginLimiter.RecordTokenFailure(ctx, mfaToken)
+	if recordErr != nil {
+		logger.WithError(recordErr).Error("Error recording wrong code")
+	} else if failures >= maxMFATokenFailures {
+		logger.Warn("Too many wrong codes, mfa token used up")
+		if err := s.useMFAToken(logger, mfaToken); err != nil {
+			return err
+		}
+	}
+	return s.loginFailed(ctx, logger, user, user.Email, ip, err)
+}
+
+// checkMFACode checks a code from the TOTP app of user, or if it is given,
+// one of their recovery codes. Both work once.
+func (s *Server) checkMFACode(logger *logrus.Entry, user *storage.User, code, recoveryCode string) error {
+	if recoveryCode == "" {
+		return s.checkTOTPCode(logger, user, code)
+	}
+
+	err := s.Storage.ConsumeMFARecoveryCode(user.ID, util.HashRecoveryCode(recoveryCode))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("Invalid recovery code")
+			return status.Error(codes.InvalidArgument, "Invalid code")
+		}
+		logger.WithError(err).Error("Error checking recovery code")
+		return status.Errorf(codes.Internal, "Error checking recovery code: %v", err)
+	}
+	logger.Info("Recovery code used")
+	return nil
+}
+
+func (s *Server) checkTOTPCode(logger *logrus.Entry, user *storage.User, code string) error {
+	if code == "" {
+		return status.Error(codes.InvalidArgument, "Code is required")
+	}
+
+	secret, err := s.SecretBox.Open(user.MFASecret)
+	if err != nil {
+		logger.WithError(err).Error("Error decrypting TOTP secret")
+		return status.Errorf(codes.Internal, "Error decrypting TOTP secret: %v", err)
+	}
+	step, ok := util.ValidateTOTP(secret, code, time.Now())
+	if !ok {
+		logger.Warn("Invalid TOTP code")
+		return status.Error(codes.InvalidArgument, "Invalid code")
+	}
+
+	if err := s.Storage.UseUserMFAStep(user.ID, step); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("TOTP code used before")
+			return status.Error(codes.InvalidArgument, "Invalid code")
+		}
+		logger.WithError(err).Error("Error recording TOTP code")
+		return status.Errorf(codes.Internal, "Error recording TOTP code: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,324 @@
+package server_test
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+	pb "github.com/sejamuchhal/taskhub/auth/pb"
+	"github.com/sejamuchhal/taskhub/auth/storage"
+	"github.com/sejamuchhal/taskhub/auth/util"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+const testTOTPSecret = "JBSWY3DPEHPK3PXP"
+
+// testPasswordHash is the hash of "password", hashing is slow
+var testPasswordHash = sync.OnceValue(func() string {
+	hashed, err := util.HashPassword("password")
+	if err != nil {
+		panic(err)
+	}
+	return hashed
+})
+
+// mfaUser returns a user with password "password" and, if enabled, TOTP on
+func (suite *ServerTestSuite) mfaUser(role string, enabled bool) *storage.User {
+	sealed, err := suite.Server.SecretBox.Seal(testTOTPSecret)
+	suite.Require().NoError(err)
+
+	user := &storage.User{ID: "1", Name: "Harry Potter", Email: "harry@hogwarts.edu", Password: testPasswordHash(), Role: role, MFASecret: sealed, Active: true}
+	if enabled {
+		enabledAt := time.Now().Add(-time.Hour)
+		user.MFAEnabledAt = &enabledAt
+	}
+	return user
+}
+
+// mfaToken returns an mfa token of user that the token handler accepts
+func (suite *ServerTestSuite) mfaToken(user *storage.User) string {
+	token, _, err := suite.Server.TokenHandler.CreateToken(user, 5*time.Minute, "mfa")
+	suite.Require().NoError(err)
+
+	mock := *suite.MockRedis
+	mock.ExpectGet(token).RedisNil()
+	return token
+}
+
+func (suite *ServerTestSuite) TestLogin_MFAEnabled() {
+	user := suite.mfaUser("user", true)
+	suite.expectLoginAllowed("harry@hogwarts.edu", "")
+	suite.MockStorage.EXPECT().GetUserByEmail("harry@hogwarts.edu").Return(user, nil)
+	suite.MockLimiter.EXPECT().Reset(gomock.Any(), "harry@hogwarts.edu").Return(nil)
+
+	resp, err := suite.Server.Login(context.Background(), &pb.LoginRequest{Email: "harry@hogwarts.edu", Password: "password"})
+	suite.Require().NoError(err)
+	suite.True(resp.MfaRequired)
+	suite.False(resp.MfaEnrollmentRequired)
+	suite.NotEmpty(resp.MfaToken)
+	// No session until the second factor
+	suite.Empty(resp.AccessToken)
+	suite.Empty(resp.RefreshToken)
+}
+
+func (suite *ServerTestSuite) TestLogin_AdminWithoutMFA() {
+	user := suite.mfaUser("admin", false)
+	suite.expectLoginAllowed("harry@hogwarts.edu", "")
+	suite.MockStorage.EXPECT().GetUserByEmail("harry@hogwarts.edu").Return(user, nil)
+	suite.MockLimiter.EXPECT().Reset(gomock.Any(), "harry@hogwarts.edu").Return(nil)
+
+	resp, err := suite.Server.Login(context.Background(), &pb.LoginRequest{Email: "harry@hogwarts.edu", Password: "password"})
+	suite.Require().NoError(err)
+	suite.True(resp.MfaRequired)
+	suite.True(resp.MfaEnrollmentRequired)
+	suite.Empty(resp.AccessToken)
+}
+
+func (suite *ServerTestSuite) TestVerifyMFA_Success() {
+	user := suite.mfaUser("user", true)
+	mfaToken := suite.mfaToken(user)
+	code, err := totp.GenerateCode(testTOTPSecret, time.Now())
+	suite.Require().NoError(err)
+
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(user, nil)
+	suite.expectLoginAllowed("harry@hogwarts.edu", "")
+	suite.MockStorage.EXPECT().UseUserMFAStep("1", gomock.Any()).Return(nil)
+	mock := *suite.MockRedis
+	mock.ExpectSet(mfaToken, "blacklisted", 5*time.Minute).SetVal("OK")
+	suite.MockLimiter.EXPECT().Reset(gomock.Any(), "harry@hogwarts.edu").Return(nil)
+	suite.MockStorage.EXPECT().CreateSession(gomock.Any()).Return(nil)
+
+	resp, err := suite.Server.VerifyMFA(context.Background(), &pb.VerifyMFARequest{MfaToken: mfaToken, Code: code})
+	suite.Require().NoError(err)
+	suite.NotEmpty(resp.AccessToken)
+	suite.NotEmpty(resp.RefreshToken)
+	suite.NoError(mock.ExpectationsWereMet())
+}
+
+func (suite *ServerTestSuite) TestVerifyMFA_RecoveryCode() {
+	user := suite.mfaUser("user", true)
+	mfaToken := suite.mfaToken(user)
+
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(user, nil)
+	suite.expectLoginAllowed("harry@hogwarts.edu", "")
+	suite.MockStorage.EXPECT().ConsumeMFARecoveryCode("1", util.HashRecoveryCode("abcde-fghij")).Return(nil)
+	mock := *suite.MockRedis
+	mock.ExpectSet(mfaToken, "blacklisted", 5*time.Minute).SetVal("OK")
+	suite.MockLimiter.EXPECT().Reset(gomock.Any(), "harry@hogwarts.edu").Return(nil)
+	suite.MockStorage.EXPECT().CreateSession(gomock.Any()).Return(nil)
+
+	resp, err := suite.Server.VerifyMFA(context.Background(), &pb.VerifyMFARequest{MfaToken: mfaToken, RecoveryCode: "ABCDE-FGHIJ"})
+	suite.Require().NoError(err)
+	suite.NotEmpty(resp.AccessToken)
+}
+
+func (suite *ServerTestSuite) TestVerifyMFA_InvalidCodes() {
+	tests := map[string]struct {
+		req    *pb.VerifyMFARequest
+		expect func()
+	}{
+		"wrong code": {req: &pb.VerifyMFARequest{Code: "000000x"}},
+		"reused code": {
+			req: &pb.VerifyMFARequest{},
+			expect: func() {
+				suite.MockStorage.EXPECT().UseUserMFAStep("1", gomock.Any()).Return(gorm.ErrRecordNotFound)
+			},
+		},
+		"used recovery code": {
+			req: &pb.VerifyMFARequest{RecoveryCode: "abcde-fghij"},
+			expect: func() {
+				suite.MockStorage.EXPECT().ConsumeMFARecoveryCode("1", gomock.Any()).Return(gorm.ErrRecordNotFound)
+			},
+		},
+	}
+	for name, tt := range tests {
+		suite.Run(name, func() {
+			user := suite.mfaUser("user", true)
+			tt.req.MfaToken = suite.mfaToken(user)
+			if tt.req.Code == "" && tt.req.RecoveryCode == "" {
+				code, err := totp.GenerateCode(testTOTPSecret, time.Now())
+				suite.Require().NoError(err)
+				tt.req.Code = code
+			}
+			suite.MockStorage.EXPECT().GetUserByID("1").Return(user, nil)
+			suite.expectLoginAllowed("harry@hogwarts.edu", "")
+			if tt.expect != nil {
+				tt.expect()
+			}
+			suite.MockLimiter.EXPECT().RecordTokenFailure(gomock.Any(), tt.req.MfaToken).Return(int64(1), nil)
+			suite.MockLimiter.EXPECT().RecordFailure(gomock.Any(), "harry@hogwarts.edu", "").Return(time.Duration(0), nil)
+
+			resp, err := suite.Server.VerifyMFA(context.Background(), tt.req)
+			suite.Nil(resp)
+			suite.Equal(codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
+
+func (suite *ServerTestSuite) TestVerifyMFA_TooManyWrongCodes() {
+	user := suite.mfaUser("user", true)
+	mfaToken := suite.mfaToken(user)
+
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(user, nil)
+	suite.expectLoginAllowed("harry@hogwarts.edu", "203.0.113.7")
+	suite.MockLimiter.EXPECT().RecordTokenFailure(gomock.Any(), mfaToken).Return(int64(3), nil)
+	mock := *suite.MockRedis
+	mock.ExpectSet(mfaToken, "blacklisted", 5*time.Minute).SetVal("OK")
+	suite.MockLimiter.EXPECT().RecordFailure(gomock.Any(), "harry@hogwarts.edu", "203.0.113.7").Return(time.Duration(0), nil)
+
+	resp, err := suite.Server.VerifyMFA(clientContext("203.0.113.7"), &pb.VerifyMFARequest{
+		MfaToken: mfaToken,
+		Code:     "000000x",
+	})
+	suite.Nil(resp)
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+	suite.NoError(mock.ExpectationsWereMet())
+
+	// The token no longer works, not even with the right code
+	mock.ExpectGet(mfaToken).SetVal("blacklisted")
+	code, err := totp.GenerateCode(testTOTPSecret, time.Now())
+	suite.Require().NoError(err)
+	resp, err = suite.Server.VerifyMFA(context.Background(), &pb.VerifyMFARequest{MfaToken: mfaToken, Code: code})
+	suite.Nil(resp)
+	suite.Equal(codes.Unauthenticated, status.Code(err))
+}
+
+func (suite *ServerTestSuite) TestVerifyMFA_WrongCodeLocksAccount() {
+	user := suite.mfaUser("user", true)
+	mfaToken := suite.mfaToken(user)
+
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(user, nil)
+	suite.expectLoginAllowed("harry@hogwarts.edu", "")
+	suite.MockLimiter.EXPECT().RecordTokenFailure(gomock.Any(), mfaToken).Return(int64(1), nil)
+	suite.MockLimiter.EXPECT().RecordFailure(gomock.Any(), "harry@hogwarts.edu", "").Return(15*time.Minute, nil)
+	suite.MockRabbitMQ.EXPECT().Publish(gomock.Any()).Return(nil)
+
+	resp, err := suite.Server.VerifyMFA(context.Background(), &pb.VerifyMFARequest{MfaToken: mfaToken, Code: "000000x"})
+	suite.Nil(resp)
+	suite.Equal(codes.ResourceExhausted, status.Code(err))
+	suite.Equal(15*time.Minute, suite.retryDelay(err))
+}
+
+func (suite *ServerTestSuite) TestVerifyMFA_Locked() {
+	user := suite.mfaUser("user", true)
+	mfaToken := suite.mfaToken(user)
+
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(user, nil)
+	suite.MockLimiter.EXPECT().Check(gomock.Any(), "harry@hogwarts.edu", "").Return(10*time.Minute, nil)
+
+	// The code is not even looked at
+	resp, err := suite.Server.VerifyMFA(context.Background(), &pb.VerifyMFARequest{MfaToken: mfaToken, Code: "000000"})
+	suite.Nil(resp)
+	suite.Equal(codes.ResourceExhausted, status.Code(err))
+}
+
+func (suite *ServerTestSuite) TestEnrollMFA_DuringLogin() {
+	user := suite.mfaUser("admin", false)
+	user.MFASecret = ""
+
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(user, nil)
+	var sealed string
+	suite.MockStorage.EXPECT().SetUserMFASecret("1", gomock.Any()).DoAndReturn(func(userID, secret string) error {
+		sealed = secret
+		return nil
+	})
+
+	resp, err := suite.Server.EnrollMFA(context.Background(), &pb.EnrollMFARequest{MfaToken: suite.mfaToken(user)})
+	suite.Require().NoError(err)
+	suite.Contains(resp.Uri, "otpauth://totp/TaskHub:harry@hogwarts.edu")
+
+	// The secret is stored encrypted
+	suite.NotContains(sealed, resp.Secret)
+	opened, err := suite.Server.SecretBox.Open(sealed)
+	suite.Require().NoError(err)
+	suite.Equal(resp.Secret, opened)
+}
+
+func (suite *ServerTestSuite) TestEnrollMFA_AlreadyEnabled() {
+	user := suite.mfaUser("user", true)
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(user, nil)
+
+	// Knowing the password is not enough to replace the second factor
+	resp, err := suite.Server.EnrollMFA(context.Background(), &pb.EnrollMFARequest{MfaToken: suite.mfaToken(user)})
+	suite.Nil(resp)
+	suite.Equal(codes.FailedPrecondition, status.Code(err))
+}
+
+func (suite *ServerTestSuite) TestConfirmMFA_DuringLogin() {
+	user := suite.mfaUser("admin", false)
+	mfaToken := suite.mfaToken(user)
+	code, err := totp.GenerateCode(testTOTPSecret, time.Now())
+	suite.Require().NoError(err)
+
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(user, nil)
+	suite.MockStorage.EXPECT().UseUserMFAStep("1", gomock.Any()).Return(nil)
+	var hashes []string
+	suite.MockStorage.EXPECT().EnableUserMFA("1", gomock.Any(), gomock.Any()).DoAndReturn(func(userID string, enabledAt time.Time, codeHashes []string) error {
+		hashes = codeHashes
+		return nil
+	})
+	mock := *suite.MockRedis
+	mock.ExpectSet(mfaToken, "blacklisted", 5*time.Minute).SetVal("OK")
+	suite.MockStorage.EXPECT().CreateSession(gomock.Any()).Return(nil)
+
+	resp, err := suite.Server.ConfirmMFA(context.Background(), &pb.ConfirmMFARequest{MfaToken: mfaToken, Code: code})
+	suite.Require().NoError(err)
+	suite.Len(resp.RecoveryCodes, 10)
+	suite.Require().Len(hashes, 10)
+	// Only hashes of the recovery codes are stored
+	suite.Equal(util.HashRecoveryCode(resp.RecoveryCodes[0]), hashes[0])
+	suite.Require().NotNil(resp.Login)
+	suite.NotEmpty(resp.Login.AccessToken)
+}
+
+func (suite *ServerTestSuite) TestDisableMFA_RequiredRole() {
+	user := suite.mfaUser("admin", true)
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(user, nil)
+
+	resp, err := suite.Server.DisableMFA(context.Background(), &pb.DisableMFARequest{
+		AccessToken: suite.accessToken(user),
+		Password:    "password",
+		Code:        "123456",
+	})
+	suite.Nil(resp)
+	suite.Equal(codes.FailedPrecondition, status.Code(err))
+}
+
+func (suite *ServerTestSuite) TestDisableMFA_Success() {
+	user := suite.mfaUser("user", true)
+	code, err := totp.GenerateCode(testTOTPSecret, time.Now())
+	suite.Require().NoError(err)
+
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(user, nil)
+	suite.expectLoginAllowed("harry@hogwarts.edu", "")
+	suite.MockStorage.EXPECT().UseUserMFAStep("1", gomock.Any()).Return(nil)
+	suite.MockLimiter.EXPECT().Reset(gomock.Any(), "harry@hogwarts.edu").Return(nil)
+	suite.MockStorage.EXPECT().DisableUserMFA("1").Return(nil)
+
+	_, err = suite.Server.DisableMFA(context.Background(), &pb.DisableMFARequest{
+		AccessToken: suite.accessToken(user),
+		Password:    "password",
+		Code:        code,
+	})
+	suite.NoError(err)
+}
+
+func (suite *ServerTestSuite) TestDisableMFA_WrongCodeCounted() {
+	user := suite.mfaUser("user", true)
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(user, nil)
+	suite.expectLoginAllowed("harry@hogwarts.edu", "203.0.113.7")
+	suite.MockLimiter.EXPECT().RecordFailure(gomock.Any(), "harry@hogwarts.edu", "203.0.113.7").Return(15*time.Minute, nil)
+	suite.MockRabbitMQ.EXPECT().Publish(gomock.Any()).Return(nil)
+
+	resp, err := suite.Server.DisableMFA(clientContext("203.0.113.7"), &pb.DisableMFARequest{
+		AccessToken: suite.accessToken(user),
+		Password:    "password",
+		Code:        "000000x",
+	})
+	suite.Nil(resp)
+	suite.Equal(codes.ResourceExhausted, status.Code(err))
+}
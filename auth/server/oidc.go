@@ -0,0 +1,167 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	pb "github.com/sejamuchhal/taskhub/auth/pb"
+	"github.com/sejamuchhal/taskhub/auth/storage"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// OIDCLogin logs in the user linked to an identity at an OpenID Connect
+// provider. The gateway verifies the ID token and passes on its claims. An
+// unknown identity is linked to the user with the same email, or to a new
+// user without a password, as long as the provider verified the email.
+func (s *Server) OIDCLogin(ctx context.Context, req *pb.OIDCLoginRequest) (*pb.LoginResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"method":   "OIDCLogin",
+		"provider": req.Provider,
+		"email":    req.Email,
+	})
+	logger.Debug("Incoming request")
+
+	if req.Provider == "" || req.Subject == "" {
+		return nil, status.Error(codes.InvalidArgument, "Provider and subject are required")
+	}
+
+	var user *storage.User
+	identity, err := s.Storage.GetIdentity(req.Provider, req.Subject)
+	switch {
+	case err == nil:
+		user, err = s.Storage.GetUserByID(identity.UserID)
+		if err != nil {
+			logger.WithError(err).Error("Error fetching user of identity")
+			return nil, status.Errorf(codes.Internal, "Error fetching user of identity: %v", err)
+		}
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		user, err = s.linkIdentity(logger, req)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		logger.WithError(err).Error("Error fetching identity")
+		return nil, status.Errorf(codes.Internal, "Error fetching identity: %v", err)
+	}
+	logger = logger.WithField("user_id", user.ID)
+
+	// A locked account stays locked whichever way one logs in
+	if err := s.checkLoginAttempts(ctx, logger, user.Email, clientIP(ctx)); err != nil {
+		return nil, err
+	}
+	if err := s.LoginLimiter.Reset(ctx, user.Email); err != nil {
+		logger.WithError(err).Error("Error resetting failed logins")
+	}
+
+	if user.MFAEnabledAt != nil || s.mfaRequired(user) {
+		return s.mfaChallenge(logger, user)
+	}
+
+	res, err := s.startSession(ctx, logger, user, req.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Debug("User login successful")
+	return res, nil
+}
+
+// linkIdentity links the identity of req to the user with its email, creating
+// the user if there is none
+func (s *Server) linkIdentity(logger *logrus.Entry, req *pb.OIDCLoginRequest) (*storage.User, error) {
+	if req.Email == "" || !req.EmailVerified {
+		logger.Warn("Email not verified by provider")
+		return nil, status.Error(codes.PermissionDenied, "The provider has not verified your email address")
+	}
+
+	identity := &storage.Identity{
+		Provider: req.Provider,
+		Subject:  req.Subject,
+		Email:    req.Email,
+	}
+
+	user, err := s.Storage.GetUserByEmail(req.Email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		name := strings.TrimSpace(req.Name)
+		if name == "" {
+			name, _, _ = strings.Cut(req.Email, "@")
+		}
+		verifiedAt := time.Now()
+		user = &storage.User{
+			Name:            name,
+			Email:           req.Email,
+			EmailVerifiedAt: &verifiedAt,
+			Active:          true,
+		}
+		if err := s.Storage.CreateUserWithIdentity(user, identity); err != nil {
+			if errors.Is(err, gorm.ErrDuplicatedKey) {
+				logger.WithError(err).Warn("User or identity created concurrently")
+				return nil, status.Error(codes.Aborted, "Sign in is already in progress, please try again")
+			}
+			logger.WithError(err).Error("Error creating user in the database")
+			return nil, status.Errorf(codes.Internal, "Error creating user in the database: %v", err)
+		}
+		logger.WithField("user_id", user.ID).Info("User created from identity")
+		return user, nil
+	}
+	if err != nil {
+		logger.WithError(err).Error("Error fetching user from the database")
+		return nil, status.Errorf(codes.Internal, "Error fetching user from the database: %v", err)
+	}
+	logger = logger.WithField("user_id", user.ID)
+
+	// Anyone could have signed up with an address they do not own, waiting
+	// for its owner to link it. The owner has now proven it, so whoever set
+	// the password is locked out.
+	if user.EmailVerifiedAt == nil {
+		if err := s.takeOverUnverifiedUser(logger, user); err != nil {
+			return nil, err
+		}
+	}
+
+	identity.UserID = user.ID
+	if err := s.Storage.CreateIdentity(identity); err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			logger.WithError(err).Warn("Identity linked concurrently")
+			return nil, status.Error(codes.Aborted, "Sign in is already in progress, please try again")
+		}
+		logger.WithError(err).Error("Error linking identity")
+		return nil, status.Errorf(codes.Internal, "Error linking identity: %v", err)
+	}
+
+	logger.Info("Identity linked to user")
+	return user, nil
+}
+
+// takeOverUnverifiedUser clears the password of a user whose email was never
+// verified, logs them out everywhere and marks the email verified
+func (s *Server) takeOverUnverifiedUser(logger *logrus.Entry, user *storage.User) error {
+	logger.Warn("Linking identity to user with unverified email, dropping password")
+
+	if err := s.Storage.UpdateUserPassword(user.ID, ""); err != nil {
+		logger.WithError(err).Error("Error clearing password")
+		return status.Errorf(codes.Internal, "Error clearing password: %v", err)
+	}
+	if err := s.Storage.BlockSessionsByEmail(user.Email, ""); err != nil {
+		logger.WithError(err).Error("Error blocking sessions")
+		return status.Errorf(codes.Internal, "Error blocking sessions: %v", err)
+	}
+	if err := s.TokenHandler.RevokeUserTokens(user.ID, s.Config.AccessTokenDuration); err != nil {
+		logger.WithError(err).Error("Error revoking access tokens")
+		return status.Errorf(codes.Internal, "Error revoking access tokens: %v", err)
+	}
+
+	verifiedAt := time.Now()
+	if err := s.Storage.SetUserEmailVerified(user.ID, verifiedAt); err != nil {
+		logger.WithError(err).Error("Error marking email verified")
+		return status.Errorf(codes.Internal, "Error marking email verified: %v", err)
+	}
+	user.Password = ""
+	user.EmailVerifiedAt = &verifiedAt
+	return nil
+}
@@ -0,0 +1,142 @@
+package server_test
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/sejamuchhal/taskhub/auth/pb"
+	"github.com/sejamuchhal/taskhub/auth/storage"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func oidcLoginRequest() *pb.OIDCLoginRequest {
+	return &pb.OIDCLoginRequest{
+		Provider:      "google",
+		Subject:       "108",
+		Email:         "harry@hogwarts.edu",
+		EmailVerified: true,
+		Name:          "Harry Potter",
+	}
+}
+
+// expectOIDCLoginAllowed lets the OIDC login of harry@hogwarts.edu go ahead
+func (suite *ServerTestSuite) expectOIDCLoginAllowed() {
+	suite.expectLoginAllowed("harry@hogwarts.edu", "")
+	suite.MockLimiter.EXPECT().Reset(gomock.Any(), "harry@hogwarts.edu").Return(nil)
+}
+
+func (suite *ServerTestSuite) TestOIDCLogin_LinkedIdentity() {
+	verifiedAt := time.Now()
+	user := &storage.User{ID: "1", Name: "Harry Potter", Email: "harry@hogwarts.edu", EmailVerifiedAt: &verifiedAt, Active: true}
+	suite.MockStorage.EXPECT().GetIdentity("google", "108").Return(&storage.Identity{UserID: "1", Provider: "google", Subject: "108"}, nil)
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(user, nil)
+	suite.expectOIDCLoginAllowed()
+	suite.MockStorage.EXPECT().CreateSession(gomock.Any()).Return(nil)
+
+	resp, err := suite.Server.OIDCLogin(context.Background(), oidcLoginRequest())
+	suite.Require().NoError(err)
+	suite.NotEmpty(resp.AccessToken)
+	suite.NotEmpty(resp.RefreshToken)
+	suite.Equal("harry@hogwarts.edu", resp.User.Email)
+}
+
+func (suite *ServerTestSuite) TestOIDCLogin_NewUser() {
+	suite.MockStorage.EXPECT().GetIdentity("google", "108").Return(&storage.Identity{}, gorm.ErrRecordNotFound)
+	suite.MockStorage.EXPECT().GetUserByEmail("harry@hogwarts.edu").Return(&storage.User{}, gorm.ErrRecordNotFound)
+	suite.MockStorage.EXPECT().CreateUserWithIdentity(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(user *storage.User, identity *storage.Identity) error {
+			suite.Equal("Harry Potter", user.Name)
+			suite.Empty(user.Password)
+			suite.NotNil(user.EmailVerifiedAt)
+			suite.Equal("google", identity.Provider)
+			suite.Equal("108", identity.Subject)
+			user.ID = "1"
+			identity.UserID = user.ID
+			return nil
+		})
+	suite.expectOIDCLoginAllowed()
+	suite.MockStorage.EXPECT().CreateSession(gomock.Any()).Return(nil)
+
+	resp, err := suite.Server.OIDCLogin(context.Background(), oidcLoginRequest())
+	suite.Require().NoError(err)
+	suite.NotEmpty(resp.AccessToken)
+}
+
+func (suite *ServerTestSuite) TestOIDCLogin_LinksVerifiedUser() {
+	verifiedAt := time.Now()
+	user := &storage.User{ID: "1", Name: "Harry Potter", Email: "harry@hogwarts.edu", Password: "hash", EmailVerifiedAt: &verifiedAt, Active: true}
+	suite.MockStorage.EXPECT().GetIdentity("google", "108").Return(&storage.Identity{}, gorm.ErrRecordNotFound)
+	suite.MockStorage.EXPECT().GetUserByEmail("harry@hogwarts.edu").Return(user, nil)
+	suite.MockStorage.EXPECT().CreateIdentity(&storage.Identity{UserID: "1", Provider: "google", Subject: "108", Email: "harry@hogwarts.edu"}).Return(nil)
+	suite.expectOIDCLoginAllowed()
+	suite.MockStorage.EXPECT().CreateSession(gomock.Any()).Return(nil)
+
+	resp, err := suite.Server.OIDCLogin(context.Background(), oidcLoginRequest())
+	suite.Require().NoError(err)
+	suite.NotEmpty(resp.AccessToken)
+}
+
+func (suite *ServerTestSuite) TestOIDCLogin_LinksUnverifiedUser() {
+	user := &storage.User{ID: "1", Name: "Harry Potter", Email: "harry@hogwarts.edu", Password: "hash", Active: true}
+	suite.MockStorage.EXPECT().GetIdentity("google", "108").Return(&storage.Identity{}, gorm.ErrRecordNotFound)
+	suite.MockStorage.EXPECT().GetUserByEmail("harry@hogwarts.edu").Return(user, nil)
+	// Whoever signed up with the address is locked out
+	suite.MockStorage.EXPECT().UpdateUserPassword("1", "").Return(nil)
+	suite.MockStorage.EXPECT().BlockSessionsByEmail("harry@hogwarts.edu", "").Return(nil)
+	mock := *suite.MockRedis
+	mock.Regexp().ExpectSet("revoked_before:1", `^\d+$`, time.Hour).SetVal("OK")
+	suite.MockStorage.EXPECT().SetUserEmailVerified("1", gomock.Any()).Return(nil)
+	suite.MockStorage.EXPECT().CreateIdentity(gomock.Any()).Return(nil)
+	suite.expectOIDCLoginAllowed()
+	suite.MockStorage.EXPECT().CreateSession(gomock.Any()).Return(nil)
+
+	resp, err := suite.Server.OIDCLogin(context.Background(), oidcLoginRequest())
+	suite.Require().NoError(err)
+	suite.NotEmpty(resp.AccessToken)
+	suite.NoError(mock.ExpectationsWereMet())
+}
+
+func (suite *ServerTestSuite) TestOIDCLogin_UnverifiedEmail() {
+	req := oidcLoginRequest()
+	req.EmailVerified = false
+	suite.MockStorage.EXPECT().GetIdentity("google", "108").Return(&storage.Identity{}, gorm.ErrRecordNotFound)
+
+	_, err := suite.Server.OIDCLogin(context.Background(), req)
+	suite.Equal(codes.PermissionDenied, status.Code(err))
+}
+
+func (suite *ServerTestSuite) TestOIDCLogin_MFAEnabled() {
+	user := suite.mfaUser("user", true)
+	suite.MockStorage.EXPECT().GetIdentity("google", "108").Return(&storage.Identity{UserID: "1"}, nil)
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(user, nil)
+	suite.expectOIDCLoginAllowed()
+
+	resp, err := suite.Server.OIDCLogin(context.Background(), oidcLoginRequest())
+	suite.Require().NoError(err)
+	suite.True(resp.MfaRequired)
+	suite.NotEmpty(resp.MfaToken)
+	suite.Empty(resp.AccessToken)
+}
+
+func (suite *ServerTestSuite) TestOIDCLogin_Locked() {
+	verifiedAt := time.Now()
+	user := &storage.User{ID: "1", Name: "Harry Potter", Email: "harry@hogwarts.edu", EmailVerifiedAt: &verifiedAt, Active: true}
+	suite.MockStorage.EXPECT().GetIdentity("google", "108").Return(&storage.Identity{UserID: "1", Provider: "google", Subject: "108"}, nil)
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(user, nil)
+	suite.MockLimiter.EXPECT().Check(gomock.Any(), "harry@hogwarts.edu", "203.0.113.7").Return(10*time.Minute, nil)
+
+	resp, err := suite.Server.OIDCLogin(clientContext("203.0.113.7"), oidcLoginRequest())
+	suite.Nil(resp)
+	suite.Equal(codes.ResourceExhausted, status.Code(err))
+}
+
+func (suite *ServerTestSuite) TestOIDCLogin_MissingSubject() {
+	req := oidcLoginRequest()
+	req.Subject = ""
+
+	_, err := suite.Server.OIDCLogin(context.Background(), req)
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,99 @@
+package server
+
+import (
+	"context"
+	"errors"
+
+	pb "github.com/sejamuchhal/taskhub/auth/pb"
+	"github.com/sejamuchhal/taskhub/auth/storage"
+	"github.com/sejamuchhal/taskhub/auth/util"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// RequestPasswordReset emails a password reset link to the user with the
+// email, older links stop working. The response is the same whether or not
+// the email has an account, and failures are only logged, so that it cannot
+// be used to find out who has an account.
+func (s *Server) RequestPasswordReset(ctx context.Context, req *pb.RequestPasswordResetRequest) (*pb.RequestPasswordResetResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"method": "RequestPasswordReset",
+		"req":    req,
+	})
+	logger.Debug("Incoming request")
+
+	res := &pb.RequestPasswordResetResponse{Message: "If the email belongs to an account, a password reset link is on its way"}
+
+	user, err := s.Storage.GetUserByEmail(req.Email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Debug("User not found")
+		} else {
+			logger.WithError(err).Error("Error fetching user from the database")
+		}
+		return res, nil
+	}
+
+	err = s.emailUserToken(user, storage.UserTokenResetPassword, s.Config.PasswordResetTokenDuration,
+		s.Config.ResetPasswordURL, UserEventPasswordResetRequested)
+	if err != nil {
+		logger.WithError(err).Error("Error sending password reset email")
+	}
+	return res, nil
+}
+
+// ResetPassword sets a new password for the user a reset token was sent to,
+// blocks all of the user's sessions and revokes their access tokens. Tokens
+// work once.
+func (s *Server) ResetPassword(ctx context.Context, req *pb.ResetPasswordRequest) (*pb.ResetPasswordResponse, error) {
+	logger := s.Logger.WithField("method", "ResetPassword")
+	logger.Debug("Incoming request")
+
+	if req.Password == "" {
+		return nil, status.Error(codes.InvalidArgument, "Password is required")
+	}
+
+	token, err := s.consumeUserToken(logger, req.Token, storage.UserTokenResetPassword)
+	if err != nil {
+		return nil, err
+	}
+	logger = logger.WithField("user_id", token.UserID)
+
+	user, err := s.Storage.GetUserByID(token.UserID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.WithError(err).Warn("User of reset token not found")
+			return nil, status.Error(codes.InvalidArgument, "Invalid or expired token")
+		}
+		logger.WithError(err).Error("Error fetching user from the database")
+		return nil, status.Errorf(codes.Internal, "Error fetching user from the database: %v", err)
+	}
+
+	hashedPassword, err := util.HashPassword(req.Password)
+	if err != nil {
+		logger.WithError(err).Error("Error hashing password")
+		return nil, status.Errorf(codes.Internal, "Error hashing password: %v", err)
+	}
+	if err := s.Storage.UpdateUserPassword(user.ID, hashedPassword); err != nil {
+		logger.WithError(err).Error("Error updating password")
+		return nil, status.Errorf(codes.Internal, "Error updating password: %v", err)
+	}
+
+	// Whoever had the old password may still be signed in
+	if err := s.Storage.BlockSessionsByEmail(user.Email, ""); err != nil {
+		logger.WithError(err).Error("Error blocking sessions")
+		return nil, status.Errorf(codes.Internal, "Error blocking sessions: %v", err)
+	}
+	if err := s.TokenHandler.RevokeUserTokens(user.ID, s.Config.AccessTokenDuration); err != nil {
+		logger.WithError(err).Error("Error revoking access tokens")
+		return nil, status.Errorf(codes.Internal, "Error revoking access tokens: %v", err)
+	}
+	if err := s.Storage.DeleteUserTokens(user.ID, storage.UserTokenResetPassword); err != nil {
+		logger.WithError(err).Warn("Error deleting other password reset tokens")
+	}
+
+	logger.Debug("Password reset")
+	return &pb.ResetPasswordResponse{Message: "Password reset, please log in again"}, nil
+}
@@ -0,0 +1,98 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"time"
+
+	pb "github.com/sejamuchhal/taskhub/auth/pb"
+	event_pb "github.com/sejamuchhal/taskhub/auth/pb/event"
+	"github.com/sejamuchhal/taskhub/auth/server"
+	"github.com/sejamuchhal/taskhub/auth/storage"
+	"github.com/sejamuchhal/taskhub/auth/util"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func (suite *ServerTestSuite) TestRequestPasswordReset_Success() {
+	var tokenHash string
+	suite.MockStorage.EXPECT().GetUserByEmail("harry@hogwarts.edu").
+		Return(&storage.User{ID: "1", Name: "Harry Potter", Email: "harry@hogwarts.edu"}, nil)
+	suite.MockStorage.EXPECT().DeleteUserTokens("1", storage.UserTokenResetPassword).Return(nil)
+	suite.MockStorage.EXPECT().CreateUserToken(gomock.Any()).DoAndReturn(func(token *storage.UserToken) error {
+		suite.Equal(storage.UserTokenResetPassword, token.Purpose)
+		suite.WithinDuration(time.Now().Add(30*time.Minute), token.ExpiresAt, time.Minute)
+		tokenHash = token.TokenHash
+		return nil
+	})
+	suite.MockRabbitMQ.EXPECT().Publish(gomock.Any()).DoAndReturn(func(body []byte) error {
+		var userEvent event_pb.UserEvent
+		suite.Require().NoError(json.Unmarshal(body, &userEvent))
+		suite.Equal(server.UserEventPasswordResetRequested, userEvent.Type)
+		suite.Equal("harry@hogwarts.edu", userEvent.Email)
+
+		link, err := url.Parse(userEvent.Link)
+		suite.Require().NoError(err)
+		suite.Equal("/reset", link.Path)
+		suite.Equal(tokenHash, util.HashOneTimeToken(link.Query().Get("token")))
+		return nil
+	})
+
+	_, err := suite.Server.RequestPasswordReset(context.Background(), &pb.RequestPasswordResetRequest{Email: "harry@hogwarts.edu"})
+	suite.NoError(err)
+}
+
+func (suite *ServerTestSuite) TestRequestPasswordReset_DoesNotRevealAccounts() {
+	suite.MockStorage.EXPECT().GetUserByEmail("nobody@hogwarts.edu").Return(nil, gorm.ErrRecordNotFound)
+	unknown, err := suite.Server.RequestPasswordReset(context.Background(), &pb.RequestPasswordResetRequest{Email: "nobody@hogwarts.edu"})
+	suite.Require().NoError(err)
+
+	suite.MockStorage.EXPECT().GetUserByEmail("harry@hogwarts.edu").Return(&storage.User{ID: "1", Email: "harry@hogwarts.edu"}, nil)
+	suite.MockStorage.EXPECT().DeleteUserTokens("1", storage.UserTokenResetPassword).Return(nil)
+	suite.MockStorage.EXPECT().CreateUserToken(gomock.Any()).Return(nil)
+	suite.MockRabbitMQ.EXPECT().Publish(gomock.Any()).Return(errors.New("broker down"))
+	failed, err := suite.Server.RequestPasswordReset(context.Background(), &pb.RequestPasswordResetRequest{Email: "harry@hogwarts.edu"})
+	suite.Require().NoError(err)
+
+	suite.Equal(unknown.Message, failed.Message)
+}
+
+func (suite *ServerTestSuite) TestResetPassword_Success() {
+	suite.MockStorage.EXPECT().ConsumeUserToken(storage.UserTokenResetPassword, util.HashOneTimeToken("token")).
+		Return(&storage.UserToken{UserID: "1", ExpiresAt: time.Now().Add(time.Minute)}, nil)
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(&storage.User{ID: "1", Email: "harry@hogwarts.edu"}, nil)
+	suite.MockStorage.EXPECT().UpdateUserPassword("1", gomock.Any()).DoAndReturn(func(userID, password string) error {
+		suite.NoError(util.CheckPasswordHash("new-password", password))
+		return nil
+	})
+	suite.MockStorage.EXPECT().BlockSessionsByEmail("harry@hogwarts.edu", "").Return(nil)
+	mock := *suite.MockRedis
+	mock.Regexp().ExpectSet("revoked_before:1", `^\d+$`, time.Hour).SetVal("OK")
+	suite.MockStorage.EXPECT().DeleteUserTokens("1", storage.UserTokenResetPassword).Return(nil)
+
+	_, err := suite.Server.ResetPassword(context.Background(), &pb.ResetPasswordRequest{Token: "token", Password: "new-password"})
+	suite.NoError(err)
+	suite.NoError(mock.ExpectationsWereMet())
+}
+
+func (suite *ServerTestSuite) TestResetPassword_Expired() {
+	suite.MockStorage.EXPECT().ConsumeUserToken(storage.UserTokenResetPassword, util.HashOneTimeToken("token")).
+		Return(&storage.UserToken{UserID: "1", ExpiresAt: time.Now().Add(-time.Minute)}, nil)
+
+	resp, err := suite.Server.ResetPassword(context.Background(), &pb.ResetPasswordRequest{Token: "token", Password: "new-password"})
+	suite.Nil(resp)
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (suite *ServerTestSuite) TestResetPassword_UsedToken() {
+	suite.MockStorage.EXPECT().ConsumeUserToken(storage.UserTokenResetPassword, util.HashOneTimeToken("token")).
+		Return(nil, gorm.ErrRecordNotFound)
+
+	resp, err := suite.Server.ResetPassword(context.Background(), &pb.ResetPasswordRequest{Token: "token", Password: "new-password"})
+	suite.Nil(resp)
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+}
@@ -3,8 +3,11 @@ package server
 import (
 	"errors"
 
+	amqp "github.com/rabbitmq/amqp091-go"
 	"github.com/redis/go-redis/v9"
 	"github.com/sejamuchhal/taskhub/auth/common"
+	"github.com/sejamuchhal/taskhub/auth/events"
+	"github.com/sejamuchhal/taskhub/auth/loginlimit"
 	auth "github.com/sejamuchhal/taskhub/auth/pb"
 	"github.com/sejamuchhal/taskhub/auth/storage"
 	"github.com/sejamuchhal/taskhub/auth/util"
@@ -14,10 +17,16 @@ import (
 // Server implements the TaskServiceServer interface
 type Server struct {
 	auth.UnsafeAuthServiceServer
+	auth.UnsafeAdminServiceServer
 	TokenHandler util.TokenHandler
 	Storage      storage.StorageInterface
+	Publisher    events.RabbitMQBrokerInterface
 	Logger       *logrus.Entry
 	Config       *common.Config
+	// SecretBox encrypts TOTP secrets
+	SecretBox *util.SecretBox
+	// LoginLimiter counts failed logins
+	LoginLimiter loginlimit.LimiterInterface
 }
 
 func NewServer(cfg *common.Config) (*Server, error) {
@@ -33,11 +42,63 @@ func NewServer(cfg *common.Config) (*Server, error) {
 		return nil, errors.New("unable to connect to redis")
 	}
 
+	conn, err := amqp.Dial(cfg.RabbitMQURL)
+	if err != nil {
+		logger.WithError(err).Error("Failed to connect to rabbitmq")
+		return nil, err
+	}
+
+	rmq := &events.RabbitMQBroker{
+		Connection: conn,
+		Logger:     logger,
+		QueueName:  cfg.UserQueue,
+	}
+
+	store, err := storage.New(cfg.DatabaseURL)
+	if err != nil {
+		logger.WithError(err).Error("Failed to open storage")
+		return nil, err
+	}
+
+	secretBox, err := util.NewSecretBox(cfg.MFAEncryptionKey)
+	if err != nil {
+		logger.WithError(err).Error("Failed to set up MFA encryption")
+		return nil, err
+	}
+
+	keys, err := loadKeySet(cfg)
+	if err != nil {
+		logger.WithError(err).Error("Failed to load signing keys")
+		return nil, err
+	}
+
+	limiter := loginlimit.NewLimiter(rdb, loginlimit.Config{
+		MaxEmailFailures:   cfg.LoginMaxFailures,
+		MaxIPFailures:      cfg.LoginMaxIPFailures,
+		Window:             cfg.LoginFailureWindow,
+		LockoutDuration:    cfg.LoginLockoutDuration,
+		MaxLockoutDuration: cfg.LoginMaxLockoutDuration,
+	})
+
 	server := &Server{
-		Storage:      storage.New(),
-		TokenHandler: util.NewTokenHandler(cfg.JWTSecret, rdb),
+		Storage:      store,
+		Publisher:    rmq,
+		SecretBox:    secretBox,
+		TokenHandler: util.NewTokenHandler(keys, cfg.JWTSecret, rdb),
 		Logger:       logger,
 		Config:       cfg,
+		LoginLimiter: limiter,
 	}
 	return server, nil
 }
+
+// loadKeySet loads the signing keys of cfg. Replaced keys keep verifying for
+// as long as the longest lived token.
+func loadKeySet(cfg *common.Config) (*util.KeySet, error) {
+	retention := max(cfg.AccessTokenDuration, cfg.RefreshTokenDuration, cfg.MFAChallengeDuration)
+	if cfg.JWTKeysDir == "" {
+		cfg.Logger.Warn("JWT_KEYS_DIR is not set, generating a signing key. Tokens stop working on restart.")
+		return util.GenerateKeySet(retention)
+	}
+	return util.LoadKeySet(cfg.JWTKeysDir, retention)
+}
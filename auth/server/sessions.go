@@ -0,0 +1,193 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pb "github.com/sejamuchhal/taskhub/auth/pb"
+	event_pb "github.com/sejamuchhal/taskhub/auth/pb/event"
+	"github.com/sejamuchhal/taskhub/auth/storage"
+	"github.com/sejamuchhal/taskhub/auth/util"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// ListSessions returns the active sessions of the caller
+func (s *Server) ListSessions(ctx context.Context, req *pb.ListSessionsRequest) (*pb.ListSessionsResponse, error) {
+	logger := s.Logger.WithField("method", "ListSessions")
+	logger.Debug("Incoming request")
+
+	user, err := s.accessTokenUser(logger, req.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	logger = logger.WithField("user_id", user.ID)
+
+	currentSessionID, err := s.refreshTokenSessionID(logger, user, req.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.Storage.ListSessionsByEmail(user.Email, time.Now())
+	if err != nil {
+		logger.WithError(err).Error("Error listing sessions")
+		return nil, status.Errorf(codes.Internal, "Error listing sessions: %v", err)
+	}
+
+	res := &pb.ListSessionsResponse{Sessions: make([]*pb.SessionDetail, 0, len(sessions))}
+	for _, session := range sessions {
+		res.Sessions = append(res.Sessions, &pb.SessionDetail{
+			Id:         session.ID,
+			CreatedAt:  timestamppb.New(session.CreatedAt),
+			LastUsedAt: timestamppb.New(session.LastUsedAt),
+			ExpiresAt:  timestamppb.New(session.ExpiresAt),
+			UserAgent:  session.UserAgent,
+			IpAddress:  session.IPAddress,
+			Current:    session.ID == currentSessionID,
+		})
+	}
+	return res, nil
+}
+
+// RevokeSession ends a session of the caller, its refresh token stops working
+// right away. Access tokens already issued for it run until they expire.
+func (s *Server) RevokeSession(ctx context.Context, req *pb.RevokeSessionRequest) (*pb.RevokeSessionResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"method":     "RevokeSession",
+		"session_id": req.SessionId,
+	})
+	logger.Debug("Incoming request")
+
+	user, err := s.accessTokenUser(logger, req.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	logger = logger.WithField("user_id", user.ID)
+
+	if req.SessionId == "" {
+		return nil, status.Error(codes.InvalidArgument, "Session ID is required")
+	}
+
+	session, err := s.Storage.GetSessionByID(req.SessionId)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		logger.WithError(err).Error("Error fetching session")
+		return nil, status.Errorf(codes.Internal, "Error fetching session: %v", err)
+	}
+	// Sessions of other users are as good as missing
+	if err != nil || session.Email != user.Email || session.IsBlocked {
+		logger.Warn("Session not found")
+		return nil, status.Error(codes.NotFound, "Session not found")
+	}
+
+	if err := s.Storage.DeleteSessionByID(session.ID); err != nil {
+		logger.WithError(err).Error("Error deleting session")
+		return nil, status.Errorf(codes.Internal, "Error deleting session: %v", err)
+	}
+
+	logger.Debug("Session revoked")
+	return &pb.RevokeSessionResponse{Message: "Session revoked"}, nil
+}
+
+// RevokeAllSessions blocks every session of the caller but the one of
+// refresh_token, and revokes the access tokens issued so far. The caller gets
+// a new access token.
+func (s *Server) RevokeAllSessions(ctx context.Context, req *pb.RevokeAllSessionsRequest) (*pb.RevokeAllSessionsResponse, error) {
+	logger := s.Logger.WithField("method", "RevokeAllSessions")
+	logger.Debug("Incoming request")
+
+	user, err := s.accessTokenUser(logger, req.AccessToken)
+	if err != nil {
+		return nil, err
+	}
+	logger = logger.WithField("user_id", user.ID)
+
+	keepSessionID, err := s.refreshTokenSessionID(logger, user, req.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.Storage.BlockSessionsByEmail(user.Email, keepSessionID); err != nil {
+		logger.WithError(err).Error("Error blocking sessions")
+		return nil, status.Errorf(codes.Internal, "Error blocking sessions: %v", err)
+	}
+	if err := s.TokenHandler.RevokeUserTokens(user.ID, s.Config.AccessTokenDuration); err != nil {
+		logger.WithError(err).Error("Error revoking access tokens")
+		return nil, status.Errorf(codes.Internal, "Error revoking access tokens: %v", err)
+	}
+
+	accessToken, accessClaims, err := s.TokenHandler.CreateToken(user, s.Config.AccessTokenDuration, "access")
+	if err != nil {
+		logger.WithError(err).Error("Error creating access token")
+		return nil, status.Errorf(codes.Internal, "Error creating access token: %v", err)
+	}
+
+	logger.Debug("Sessions revoked")
+	return &pb.RevokeAllSessionsResponse{
+		Message:              "Sessions revoked",
+		AccessToken:          accessToken,
+		AccessTokenExpiresAt: timestamppb.New(accessClaims.RegisteredClaims.ExpiresAt.Time),
+	}, nil
+}
+
+// refreshTokenSessionID returns the session of refreshToken, which has to
+// belong to user. An empty refreshToken has no session.
+func (s *Server) refreshTokenSessionID(logger *logrus.Entry, user *storage.User, refreshToken string) (string, error) {
+	if refreshToken == "" {
+		return "", nil
+	}
+	refreshClaims, err := s.TokenHandler.VerifyToken(refreshToken, "refresh")
+	if err != nil || refreshClaims.UserID != user.ID {
+		logger.WithError(err).Warn("Invalid refresh token")
+		return "", status.Error(codes.InvalidArgument, "Invalid refresh token")
+	}
+	return refreshClaims.SessionID(), nil
+}
+
+// revokeReusedSession ends session, whose replaced refresh token was sent
+// again. Either the token or the one that replaced it is in the wrong hands,
+// and there is no telling which, so the session is blocked, the access
+// tokens of the user are revoked and the user is told.
+func (s *Server) revokeReusedSession(logger *logrus.Entry, session *storage.Session, refreshClaims *util.UserClaims) error {
+	logger = logger.WithField("user_id", refreshClaims.UserID)
+	logger.Warn("Replaced refresh token reused, revoking session")
+
+	if err := s.Storage.BlockSessionByID(session.ID); err != nil {
+		logger.WithError(err).Error("Error blocking session")
+		return status.Errorf(codes.Internal, "Error blocking session: %v", err)
+	}
+	if err := s.TokenHandler.RevokeUserTokens(refreshClaims.UserID, s.Config.AccessTokenDuration); err != nil {
+		logger.WithError(err).Error("Error revoking access tokens")
+		return status.Errorf(codes.Internal, "Error revoking access tokens: %v", err)
+	}
+
+	var name string
+	if user, err := s.Storage.GetUserByID(refreshClaims.UserID); err == nil {
+		name = user.Name
+	}
+	err := s.publishUserEvent(&event_pb.UserEvent{
+		Type:      UserEventSessionTokenReused,
+		Email:     session.Email,
+		Name:      name,
+		UserAgent: session.UserAgent,
+		IpAddress: session.IPAddress,
+	})
+	if err != nil {
+		logger.WithError(err).Error("Error publishing session token reuse event")
+	}
+
+	return status.Error(codes.Unauthenticated, "Session revoked")
+}
+
+// truncate cuts s to at most n characters, to fit client supplied values
+// into their columns
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
@@ -0,0 +1,242 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	pb "github.com/sejamuchhal/taskhub/auth/pb"
+	event_pb "github.com/sejamuchhal/taskhub/auth/pb/event"
+	"github.com/sejamuchhal/taskhub/auth/server"
+	"github.com/sejamuchhal/taskhub/auth/storage"
+	"github.com/sejamuchhal/taskhub/auth/util"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func (suite *ServerTestSuite) TestLogin_RecordsClient() {
+	hashed, err := util.HashPassword("password")
+	suite.Require().NoError(err)
+	user := &storage.User{ID: "1", Email: "harry@hogwarts.edu", Password: hashed, Active: true}
+
+	suite.expectLoginAllowed("harry@hogwarts.edu", "203.0.113.7")
+	suite.MockStorage.EXPECT().GetUserByEmail("harry@hogwarts.edu").Return(user, nil)
+	suite.MockLimiter.EXPECT().Reset(gomock.Any(), "harry@hogwarts.edu").Return(nil)
+	suite.MockStorage.EXPECT().CreateSession(gomock.Any()).DoAndReturn(func(session *storage.Session) error {
+		suite.Equal("Mozilla/5.0", session.UserAgent)
+		suite.Equal("203.0.113.7", session.IPAddress)
+		suite.False(session.LastUsedAt.IsZero())
+		return nil
+	})
+
+	_, err = suite.Server.Login(clientContext("203.0.113.7"), &pb.LoginRequest{
+		Email:    "harry@hogwarts.edu",
+		Password: "password",
+		Client:   &pb.ClientInfo{UserAgent: "Mozilla/5.0"},
+	})
+	suite.Require().NoError(err)
+}
+
+func (suite *ServerTestSuite) TestListSessions_Success() {
+	user := &storage.User{ID: "1", Email: "harry@hogwarts.edu", Active: true}
+	accessToken := suite.accessToken(user)
+	refreshToken, refreshClaims, err := suite.Server.TokenHandler.CreateToken(user, 24*time.Hour, "refresh")
+	suite.Require().NoError(err)
+	mock := *suite.MockRedis
+	mock.ExpectGet(refreshToken).RedisNil()
+
+	lastUsedAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(user, nil)
+	suite.MockStorage.EXPECT().ListSessionsByEmail("harry@hogwarts.edu", gomock.Any()).Return([]storage.Session{
+		{ID: refreshClaims.RegisteredClaims.ID, UserAgent: "Mozilla/5.0", IPAddress: "203.0.113.7", LastUsedAt: lastUsedAt},
+		{ID: "other", UserAgent: "curl/8.0", IPAddress: "198.51.100.1"},
+	}, nil)
+
+	resp, err := suite.Server.ListSessions(context.Background(), &pb.ListSessionsRequest{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	})
+	suite.Require().NoError(err)
+	suite.Require().Len(resp.Sessions, 2)
+	suite.Equal("Mozilla/5.0", resp.Sessions[0].UserAgent)
+	suite.Equal("203.0.113.7", resp.Sessions[0].IpAddress)
+	suite.True(resp.Sessions[0].LastUsedAt.AsTime().Equal(lastUsedAt))
+	suite.True(resp.Sessions[0].Current)
+	suite.False(resp.Sessions[1].Current)
+	suite.NoError(mock.ExpectationsWereMet())
+}
+
+func (suite *ServerTestSuite) TestListSessions_Deactivated() {
+	// Access tokens handed out before the deactivation stop working
+	user := &storage.User{ID: "1", Email: "harry@hogwarts.edu", Active: false}
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(user, nil)
+
+	resp, err := suite.Server.ListSessions(context.Background(), &pb.ListSessionsRequest{AccessToken: suite.accessToken(user)})
+	suite.Nil(resp)
+	suite.Equal(codes.PermissionDenied, status.Code(err))
+}
+
+func (suite *ServerTestSuite) TestListSessions_ForeignRefreshToken() {
+	user := &storage.User{ID: "1", Email: "harry@hogwarts.edu", Active: true}
+	accessToken := suite.accessToken(user)
+	refreshToken, _, err := suite.Server.TokenHandler.CreateToken(&storage.User{ID: "2"}, 24*time.Hour, "refresh")
+	suite.Require().NoError(err)
+	mock := *suite.MockRedis
+	mock.ExpectGet(refreshToken).RedisNil()
+
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(user, nil)
+
+	resp, err := suite.Server.ListSessions(context.Background(), &pb.ListSessionsRequest{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	})
+	suite.Nil(resp)
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (suite *ServerTestSuite) TestRevokeSession_Success() {
+	user := &storage.User{ID: "1", Email: "harry@hogwarts.edu", Active: true}
+
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(user, nil)
+	suite.MockStorage.EXPECT().GetSessionByID("session-id").Return(&storage.Session{ID: "session-id", Email: "harry@hogwarts.edu"}, nil)
+	suite.MockStorage.EXPECT().DeleteSessionByID("session-id").Return(nil)
+
+	resp, err := suite.Server.RevokeSession(context.Background(), &pb.RevokeSessionRequest{
+		AccessToken: suite.accessToken(user),
+		SessionId:   "session-id",
+	})
+	suite.Require().NoError(err)
+	suite.Equal("Session revoked", resp.Message)
+}
+
+func (suite *ServerTestSuite) TestRevokeSession_NotOwned() {
+	user := &storage.User{ID: "1", Email: "harry@hogwarts.edu", Active: true}
+
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(user, nil)
+	suite.MockStorage.EXPECT().GetSessionByID("session-id").Return(&storage.Session{ID: "session-id", Email: "draco@hogwarts.edu"}, nil)
+
+	resp, err := suite.Server.RevokeSession(context.Background(), &pb.RevokeSessionRequest{
+		AccessToken: suite.accessToken(user),
+		SessionId:   "session-id",
+	})
+	suite.Nil(resp)
+	suite.Equal(codes.NotFound, status.Code(err))
+}
+
+func (suite *ServerTestSuite) TestRevokeSession_NotFound() {
+	user := &storage.User{ID: "1", Email: "harry@hogwarts.edu", Active: true}
+
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(user, nil)
+	suite.MockStorage.EXPECT().GetSessionByID("session-id").Return(&storage.Session{}, gorm.ErrRecordNotFound)
+
+	resp, err := suite.Server.RevokeSession(context.Background(), &pb.RevokeSessionRequest{
+		AccessToken: suite.accessToken(user),
+		SessionId:   "session-id",
+	})
+	suite.Nil(resp)
+	suite.Equal(codes.NotFound, status.Code(err))
+}
+
+func (suite *ServerTestSuite) TestRenewAccessToken_RevokedSession() {
+	user := &storage.User{ID: "1", Email: "harry@hogwarts.edu", Active: true}
+	refreshToken, refreshClaims, err := suite.Server.TokenHandler.CreateToken(user, 24*time.Hour, "refresh")
+	suite.Require().NoError(err)
+	mock := *suite.MockRedis
+	mock.ExpectGet(refreshToken).RedisNil()
+
+	suite.MockStorage.EXPECT().GetSessionByID(refreshClaims.RegisteredClaims.ID).Return(&storage.Session{}, gorm.ErrRecordNotFound)
+
+	resp, err := suite.Server.RenewAccessToken(context.Background(), &pb.RenewAccessTokenRequest{RefreshToken: refreshToken})
+	suite.Nil(resp)
+	suite.Equal(codes.Unauthenticated, status.Code(err))
+}
+
+func (suite *ServerTestSuite) TestRevokeAllSessions_Success() {
+	user := &storage.User{ID: "1", Email: "harry@hogwarts.edu", Active: true}
+	accessToken := suite.accessToken(user)
+	refreshToken, refreshClaims, err := suite.Server.TokenHandler.CreateToken(user, 24*time.Hour, "refresh")
+	suite.Require().NoError(err)
+	mock := *suite.MockRedis
+	mock.ExpectGet(refreshToken).RedisNil()
+	mock.Regexp().ExpectSet("revoked_before:1", `^\d+$`, time.Hour).SetVal("OK")
+
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(user, nil)
+	// The caller stays logged in
+	suite.MockStorage.EXPECT().BlockSessionsByEmail("harry@hogwarts.edu", refreshClaims.RegisteredClaims.ID).Return(nil)
+
+	resp, err := suite.Server.RevokeAllSessions(context.Background(), &pb.RevokeAllSessionsRequest{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	})
+	suite.Require().NoError(err)
+	suite.NotEmpty(resp.AccessToken)
+	suite.NotEqual(accessToken, resp.AccessToken)
+	suite.NoError(mock.ExpectationsWereMet())
+}
+
+func (suite *ServerTestSuite) TestRenewAccessToken_ReusedToken() {
+	user := &storage.User{ID: "1", Name: "Harry Potter", Email: "harry@hogwarts.edu", Active: true}
+	first, firstClaims, err := suite.Server.TokenHandler.CreateRefreshToken(user, 24*time.Hour, "")
+	suite.Require().NoError(err)
+	current, _, err := suite.Server.TokenHandler.CreateRefreshToken(user, 24*time.Hour, firstClaims.SessionID())
+	suite.Require().NoError(err)
+	mock := *suite.MockRedis
+	mock.ExpectGet(first).RedisNil()
+	mock.Regexp().ExpectSet("revoked_before:1", `^\d+$`, time.Hour).SetVal("OK")
+
+	// The session moved on to another token already
+	suite.MockStorage.EXPECT().GetSessionByID(firstClaims.SessionID()).Return(&storage.Session{
+		ID:           firstClaims.SessionID(),
+		Email:        "harry@hogwarts.edu",
+		RefreshToken: current,
+		ExpiresAt:    time.Now().Add(24 * time.Hour),
+		UserAgent:    "Mozilla/5.0",
+		IPAddress:    "203.0.113.7",
+	}, nil)
+	suite.MockStorage.EXPECT().BlockSessionByID(firstClaims.SessionID()).Return(nil)
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(user, nil)
+	suite.MockRabbitMQ.EXPECT().Publish(gomock.Any()).DoAndReturn(func(body []byte) error {
+		var userEvent event_pb.UserEvent
+		suite.Require().NoError(json.Unmarshal(body, &userEvent))
+		suite.Equal(server.UserEventSessionTokenReused, userEvent.Type)
+		suite.Equal("harry@hogwarts.edu", userEvent.Email)
+		suite.Equal("Harry Potter", userEvent.Name)
+		suite.Equal("Mozilla/5.0", userEvent.UserAgent)
+		suite.Equal("203.0.113.7", userEvent.IpAddress)
+		return nil
+	})
+
+	resp, err := suite.Server.RenewAccessToken(context.Background(), &pb.RenewAccessTokenRequest{RefreshToken: first})
+	suite.Nil(resp)
+	suite.Equal(codes.Unauthenticated, status.Code(err))
+	suite.NoError(mock.ExpectationsWereMet())
+}
+
+func (suite *ServerTestSuite) TestRenewAccessToken_ConcurrentRenewal() {
+	user := &storage.User{ID: "1", Email: "harry@hogwarts.edu", Active: true}
+	refreshToken, refreshClaims, err := suite.Server.TokenHandler.CreateRefreshToken(user, 24*time.Hour, "")
+	suite.Require().NoError(err)
+	mock := *suite.MockRedis
+	mock.ExpectGet(refreshToken).RedisNil()
+	mock.Regexp().ExpectSet("revoked_before:1", `^\d+$`, time.Hour).SetVal("OK")
+
+	session := &storage.Session{
+		ID:           refreshClaims.SessionID(),
+		Email:        "harry@hogwarts.edu",
+		RefreshToken: refreshToken,
+		ExpiresAt:    time.Now().Add(24 * time.Hour),
+	}
+	suite.MockStorage.EXPECT().GetSessionByID(session.ID).Return(session, nil)
+	// Another renewal rotated the token in the meantime
+	suite.MockStorage.EXPECT().RotateSessionToken(session.ID, refreshToken, gomock.Any(), gomock.Any(), gomock.Any()).Return(gorm.ErrRecordNotFound)
+	suite.MockStorage.EXPECT().BlockSessionByID(session.ID).Return(nil)
+	suite.MockStorage.EXPECT().GetUserByID("1").Return(user, nil)
+	suite.MockRabbitMQ.EXPECT().Publish(gomock.Any()).Return(nil)
+
+	resp, err := suite.Server.RenewAccessToken(context.Background(), &pb.RenewAccessTokenRequest{RefreshToken: refreshToken})
+	suite.Nil(resp)
+	suite.Equal(codes.Unauthenticated, status.Code(err))
+	suite.NoError(mock.ExpectationsWereMet())
+}
@@ -0,0 +1,115 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"time"
+
+	event_pb "github.com/sejamuchhal/taskhub/auth/pb/event"
+	"github.com/sejamuchhal/taskhub/auth/storage"
+	"github.com/sejamuchhal/taskhub/auth/util"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// Types of user events, each one is an email the notification service sends
+const (
+	UserEventVerificationRequested  = "user.verification_requested"
+	UserEventPasswordResetRequested = "user.password_reset_requested"
+	UserEventEmailChangeRequested   = "user.email_change_requested"
+	// UserEventSessionTokenReused warns the user that a session was ended
+	// because its refresh token showed up again after it had been replaced
+	UserEventSessionTokenReused = "user.session_token_reused"
+	// UserEventAccountLocked tells the user that too many failed logins
+	// locked their account
+	UserEventAccountLocked = "user.account_locked"
+)
+
+// emailUserToken replaces the tokens of user for purpose with a new one that
+// works for ttl, and asks the notification service to email the user a link
+// to linkBase with the token
+func (s *Server) emailUserToken(user *storage.User, purpose string, ttl time.Duration, linkBase, eventType string) error {
+	token, hash, err := util.NewOneTimeToken()
+	if err != nil {
+		return err
+	}
+
+	if err := s.Storage.DeleteUserTokens(user.ID, purpose); err != nil {
+		return err
+	}
+	err = s.Storage.CreateUserToken(&storage.UserToken{
+		UserID:    user.ID,
+		Purpose:   purpose,
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(ttl),
+	})
+	if err != nil {
+		return err
+	}
+
+	link, err := tokenLink(linkBase, token)
+	if err != nil {
+		return err
+	}
+	return s.publishUserEvent(&event_pb.UserEvent{
+		Type:  eventType,
+		Email: user.Email,
+		Name:  user.Name,
+		Link:  link,
+	})
+}
+
+// consumeUserToken returns the unexpired token for one of purposes, and uses
+// it up. Unknown, used and expired tokens are all an InvalidArgument error.
+func (s *Server) consumeUserToken(logger *logrus.Entry, token string, purposes ...string) (*storage.UserToken, error) {
+	if token == "" {
+		return nil, status.Error(codes.InvalidArgument, "Token is required")
+	}
+
+	hash := util.HashOneTimeToken(token)
+	var userToken *storage.UserToken
+	err := gorm.ErrRecordNotFound
+	for _, purpose := range purposes {
+		userToken, err = s.Storage.ConsumeUserToken(purpose, hash)
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			break
+		}
+	}
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.WithError(err).Warn("Unknown token")
+			return nil, status.Error(codes.InvalidArgument, "Invalid or expired token")
+		}
+		logger.WithError(err).Error("Error fetching token")
+		return nil, status.Errorf(codes.Internal, "Error fetching token: %v", err)
+	}
+
+	if time.Now().After(userToken.ExpiresAt) {
+		logger.WithField("user_id", userToken.UserID).Warn("Expired token")
+		return nil, status.Error(codes.InvalidArgument, "Invalid or expired token")
+	}
+	return userToken, nil
+}
+
+func (s *Server) publishUserEvent(userEvent *event_pb.UserEvent) error {
+	body, err := json.Marshal(userEvent)
+	if err != nil {
+		return err
+	}
+	return s.Publisher.Publish(body)
+}
+
+// tokenLink adds token to the query of base
+func tokenLink(base, token string) (string, error) {
+	link, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return link.String(), nil
+}
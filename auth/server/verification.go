@@ -0,0 +1,118 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pb "github.com/sejamuchhal/taskhub/auth/pb"
+	"github.com/sejamuchhal/taskhub/auth/storage"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// VerifyEmail marks the email of the user a verification token was sent to
+// as verified. Links confirming a new email land here too, the user is then
+// logged out everywhere since sessions are tied to the old email. Tokens
+// work once.
+func (s *Server) VerifyEmail(ctx context.Context, req *pb.VerifyEmailRequest) (*pb.VerifyEmailResponse, error) {
+	logger := s.Logger.WithField("method", "VerifyEmail")
+	logger.Debug("Incoming request")
+
+	token, err := s.consumeUserToken(logger, req.Token, storage.UserTokenVerifyEmail, storage.UserTokenChangeEmail)
+	if err != nil {
+		return nil, err
+	}
+	logger = logger.WithField("user_id", token.UserID)
+
+	if token.Purpose == storage.UserTokenChangeEmail {
+		return s.confirmEmailChange(logger, token.UserID)
+	}
+
+	if err := s.Storage.SetUserEmailVerified(token.UserID, time.Now()); err != nil {
+		logger.WithError(err).Error("Error verifying email")
+		return nil, status.Errorf(codes.Internal, "Error verifying email: %v", err)
+	}
+
+	logger.Debug("Email verified")
+	return &pb.VerifyEmailResponse{Message: "Email verified"}, nil
+}
+
+func (s *Server) confirmEmailChange(logger *logrus.Entry, userID string) (*pb.VerifyEmailResponse, error) {
+	user, err := s.Storage.GetUserByID(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.WithError(err).Warn("User of email change token not found")
+			return nil, status.Error(codes.InvalidArgument, "Invalid or expired token")
+		}
+		logger.WithError(err).Error("Error fetching user from the database")
+		return nil, status.Errorf(codes.Internal, "Error fetching user from the database: %v", err)
+	}
+
+	err = s.Storage.ConfirmUserPendingEmail(user.ID, time.Now())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.WithError(err).Warn("Email change was cancelled")
+			return nil, status.Error(codes.InvalidArgument, "Invalid or expired token")
+		}
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			logger.WithError(err).Warn("Email taken while the change was pending")
+			return nil, status.Error(codes.AlreadyExists, "Email is already in use")
+		}
+		logger.WithError(err).Error("Error changing email")
+		return nil, status.Errorf(codes.Internal, "Error changing email: %v", err)
+	}
+
+	if err := s.Storage.BlockSessionsByEmail(user.Email, ""); err != nil {
+		logger.WithError(err).Error("Error blocking sessions")
+		return nil, status.Errorf(codes.Internal, "Error blocking sessions: %v", err)
+	}
+	if err := s.TokenHandler.RevokeUserTokens(user.ID, s.Config.AccessTokenDuration); err != nil {
+		logger.WithError(err).Error("Error revoking access tokens")
+		return nil, status.Errorf(codes.Internal, "Error revoking access tokens: %v", err)
+	}
+
+	logger.WithField("email", user.PendingEmail).Debug("Email changed")
+	return &pb.VerifyEmailResponse{Message: "Email changed, please log in again"}, nil
+}
+
+// ResendVerification sends a new verification link to an unverified user,
+// older links stop working. Unknown and verified emails get the same
+// response so that it cannot be used to find out who has an account.
+func (s *Server) ResendVerification(ctx context.Context, req *pb.ResendVerificationRequest) (*pb.ResendVerificationResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"method": "ResendVerification",
+		"req":    req,
+	})
+	logger.Debug("Incoming request")
+
+	res := &pb.ResendVerificationResponse{Message: "If the email belongs to an unverified account, a new verification link is on its way"}
+
+	user, err := s.Storage.GetUserByEmail(req.Email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Debug("User not found")
+			return res, nil
+		}
+		logger.WithError(err).Error("Error fetching user from the database")
+		return nil, status.Errorf(codes.Internal, "Error fetching user from the database: %v", err)
+	}
+	if user.EmailVerifiedAt != nil {
+		logger.Debug("Email already verified")
+		return res, nil
+	}
+
+	if err := s.sendVerification(user); err != nil {
+		logger.WithError(err).Error("Error sending verification email")
+		return nil, status.Errorf(codes.Internal, "Error sending verification email: %v", err)
+	}
+	return res, nil
+}
+
+// sendVerification emails user a new verification link
+func (s *Server) sendVerification(user *storage.User) error {
+	return s.emailUserToken(user, storage.UserTokenVerifyEmail, s.Config.VerificationTokenDuration,
+		s.Config.VerifyEmailURL, UserEventVerificationRequested)
+}
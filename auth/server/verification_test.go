@@ -0,0 +1,132 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"time"
+
+	pb "github.com/sejamuchhal/taskhub/auth/pb"
+	event_pb "github.com/sejamuchhal/taskhub/auth/pb/event"
+	"github.com/sejamuchhal/taskhub/auth/server"
+	"github.com/sejamuchhal/taskhub/auth/storage"
+	"github.com/sejamuchhal/taskhub/auth/util"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func (suite *ServerTestSuite) TestSignup_SendsVerification() {
+	var tokenHash string
+	suite.MockStorage.EXPECT().GetUserByEmail("ron@hogwarts.edu").Return(nil, gorm.ErrRecordNotFound)
+	suite.MockStorage.EXPECT().CreateUser(gomock.Any()).DoAndReturn(func(user *storage.User) error {
+		user.ID = "2"
+		return nil
+	})
+	suite.MockStorage.EXPECT().DeleteUserTokens("2", storage.UserTokenVerifyEmail).Return(nil)
+	suite.MockStorage.EXPECT().CreateUserToken(gomock.Any()).DoAndReturn(func(token *storage.UserToken) error {
+		suite.Equal("2", token.UserID)
+		suite.WithinDuration(time.Now().Add(24*time.Hour), token.ExpiresAt, time.Minute)
+		tokenHash = token.TokenHash
+		return nil
+	})
+	suite.MockRabbitMQ.EXPECT().Publish(gomock.Any()).DoAndReturn(func(body []byte) error {
+		var userEvent event_pb.UserEvent
+		suite.Require().NoError(json.Unmarshal(body, &userEvent))
+		suite.Equal(server.UserEventVerificationRequested, userEvent.Type)
+		suite.Equal("ron@hogwarts.edu", userEvent.Email)
+		suite.Equal("Ron Weasley", userEvent.Name)
+
+		// Only the hash of the emailed token is stored
+		link, err := url.Parse(userEvent.Link)
+		suite.Require().NoError(err)
+		suite.Equal("taskhub.example.com", link.Host)
+		suite.Equal(tokenHash, util.HashOneTimeToken(link.Query().Get("token")))
+		return nil
+	})
+
+	_, err := suite.Server.Signup(context.Background(), &pb.SignupRequest{
+		Name:     "Ron Weasley",
+		Email:    "ron@hogwarts.edu",
+		Password: "password",
+	})
+	suite.NoError(err)
+}
+
+func (suite *ServerTestSuite) TestVerifyEmail_Success() {
+	suite.MockStorage.EXPECT().ConsumeUserToken(storage.UserTokenVerifyEmail, util.HashOneTimeToken("token")).
+		Return(&storage.UserToken{UserID: "1", ExpiresAt: time.Now().Add(time.Hour)}, nil)
+	suite.MockStorage.EXPECT().SetUserEmailVerified("1", gomock.Any()).Return(nil)
+
+	resp, err := suite.Server.VerifyEmail(context.Background(), &pb.VerifyEmailRequest{Token: "token"})
+	suite.NoError(err)
+	suite.Equal("Email verified", resp.Message)
+}
+
+func (suite *ServerTestSuite) TestVerifyEmail_Expired() {
+	suite.MockStorage.EXPECT().ConsumeUserToken(storage.UserTokenVerifyEmail, util.HashOneTimeToken("token")).
+		Return(&storage.UserToken{UserID: "1", ExpiresAt: time.Now().Add(-time.Hour)}, nil)
+
+	resp, err := suite.Server.VerifyEmail(context.Background(), &pb.VerifyEmailRequest{Token: "token"})
+	suite.Nil(resp)
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (suite *ServerTestSuite) TestVerifyEmail_UnknownToken() {
+	suite.MockStorage.EXPECT().ConsumeUserToken(storage.UserTokenVerifyEmail, util.HashOneTimeToken("token")).
+		Return(&storage.UserToken{}, gorm.ErrRecordNotFound)
+	suite.MockStorage.EXPECT().ConsumeUserToken(storage.UserTokenChangeEmail, util.HashOneTimeToken("token")).
+		Return(&storage.UserToken{}, gorm.ErrRecordNotFound)
+
+	resp, err := suite.Server.VerifyEmail(context.Background(), &pb.VerifyEmailRequest{Token: "token"})
+	suite.Nil(resp)
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (suite *ServerTestSuite) TestResendVerification() {
+	suite.MockStorage.EXPECT().GetUserByEmail("harry@hogwarts.edu").
+		Return(&storage.User{ID: "1", Name: "Harry Potter", Email: "harry@hogwarts.edu"}, nil)
+	suite.MockStorage.EXPECT().DeleteUserTokens("1", storage.UserTokenVerifyEmail).Return(nil)
+	suite.MockStorage.EXPECT().CreateUserToken(gomock.Any()).Return(nil)
+	suite.MockRabbitMQ.EXPECT().Publish(gomock.Any()).Return(nil)
+
+	resp, err := suite.Server.ResendVerification(context.Background(), &pb.ResendVerificationRequest{Email: "harry@hogwarts.edu"})
+	suite.NoError(err)
+	suite.NotEmpty(resp.Message)
+}
+
+func (suite *ServerTestSuite) TestResendVerification_NothingToSend() {
+	verifiedAt := time.Now()
+	suite.MockStorage.EXPECT().GetUserByEmail("harry@hogwarts.edu").
+		Return(&storage.User{ID: "1", Email: "harry@hogwarts.edu", EmailVerifiedAt: &verifiedAt}, nil)
+	suite.MockStorage.EXPECT().GetUserByEmail("nobody@hogwarts.edu").Return(&storage.User{}, gorm.ErrRecordNotFound)
+
+	verified, err := suite.Server.ResendVerification(context.Background(), &pb.ResendVerificationRequest{Email: "harry@hogwarts.edu"})
+	suite.NoError(err)
+	unknown, err := suite.Server.ResendVerification(context.Background(), &pb.ResendVerificationRequest{Email: "nobody@hogwarts.edu"})
+	suite.NoError(err)
+	// Both look like a link was sent
+	suite.Equal(verified.Message, unknown.Message)
+}
+
+func (suite *ServerTestSuite) TestLogin_EmailNotVerified() {
+	suite.Server.Config.RequireVerifiedEmail = true
+	defer func() { suite.Server.Config.RequireVerifiedEmail = false }()
+
+	hashedPassword, _ := util.HashPassword("password")
+	suite.expectLoginAllowed("harry@hogwarts.edu", "")
+	suite.MockStorage.EXPECT().GetUserByEmail("harry@hogwarts.edu").Return(&storage.User{
+		ID:       "1",
+		Email:    "harry@hogwarts.edu",
+		Password: hashedPassword,
+	}, nil)
+	suite.MockLimiter.EXPECT().Reset(gomock.Any(), "harry@hogwarts.edu").Return(nil)
+
+	resp, err := suite.Server.Login(context.Background(), &pb.LoginRequest{
+		Email:    "harry@hogwarts.edu",
+		Password: "password",
+	})
+	suite.Nil(resp)
+	suite.Equal(codes.FailedPrecondition, status.Code(err))
+}
@@ -0,0 +1,462 @@
+package storage
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MemoryStorage keeps users, sessions and tokens in maps. It is meant for local
+// development and tests, nothing survives a restart. Lookups of missing rows
+// return gorm.ErrRecordNotFound like the database backed Storage.
+type MemoryStorage struct {
+	mu       sync.Mutex
+	users    map[string]*User
+	sessions map[string]*Session
+	tokens   map[string]*UserToken
+	// codes holds the hashes of the MFA recovery codes of each user
+	codes      map[string]map[string]bool
+	identities map[string]*Identity
+}
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		users:      map[string]*User{},
+		sessions:   map[string]*Session{},
+		tokens:     map[string]*UserToken{},
+		codes:      map[string]map[string]bool{},
+		identities: map[string]*Identity{},
+	}
+}
+
+// CreateUser returns gorm.ErrDuplicatedKey if the email is taken
+func (m *MemoryStorage) CreateUser(user *User) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.createUser(user)
+}
+
+func (m *MemoryStorage) createUser(user *User) error {
+	for _, other := range m.users {
+		if other.Email == user.Email {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	user.BeforeSave(nil)
+	now := time.Now()
+	user.CreatedAt, user.UpdatedAt = now, now
+	if user.Role == "" {
+		user.Role = "user"
+	}
+	user.Active = true
+
+	copied := *user
+	m.users[user.ID] = &copied
+	return nil
+}
+
+func (m *MemoryStorage) GetUserByEmail(email string) (*User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, user := range m.users {
+		if user.Email == email {
+			copied := *user
+			return &copied, nil
+		}
+	}
+	return &User{}, gorm.ErrRecordNotFound
+}
+
+func (m *MemoryStorage) GetUserByID(id string) (*User, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, ok := m.users[id]
+	if !ok {
+		return &User{}, gorm.ErrRecordNotFound
+	}
+	copied := *user
+	return &copied, nil
+}
+
+func (m *MemoryStorage) ListUsers(filter UserFilter, limit, offset int) ([]User, int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	query := strings.ToLower(filter.Query)
+	var users []User
+	for _, user := range m.users {
+		if query != "" && !strings.Contains(strings.ToLower(user.Name), query) && !strings.Contains(strings.ToLower(user.Email), query) {
+			continue
+		}
+		if filter.Role != "" && user.Role != filter.Role {
+			continue
+		}
+		if filter.Active != nil && user.Active != *filter.Active {
+			continue
+		}
+		users = append(users, *user)
+	}
+	sort.Slice(users, func(i, j int) bool {
+		if !users[i].CreatedAt.Equal(users[j].CreatedAt) {
+			return users[i].CreatedAt.After(users[j].CreatedAt)
+		}
+		return users[i].ID < users[j].ID
+	})
+
+	count := int64(len(users))
+	users = users[min(offset, len(users)):]
+	if limit >= 0 {
+		users = users[:min(limit, len(users))]
+	}
+	return users, count, nil
+}
+
+func (m *MemoryStorage) SetUserRole(userID, role string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, ok := m.users[userID]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	user.Role = role
+	user.UpdatedAt = time.Now()
+	return nil
+}
+
+func (m *MemoryStorage) SetUserActive(userID string, active bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, ok := m.users[userID]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	user.Active = active
+	user.UpdatedAt = time.Now()
+	return nil
+}
+
+func (m *MemoryStorage) UpdateUserPassword(userID, password string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if user, ok := m.users[userID]; ok {
+		user.Password = password
+		user.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+func (m *MemoryStorage) UpdateUserName(userID, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if user, ok := m.users[userID]; ok {
+		user.Name = name
+		user.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+func (m *MemoryStorage) SetUserPendingEmail(userID, email string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if user, ok := m.users[userID]; ok {
+		user.PendingEmail = email
+		user.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+func (m *MemoryStorage) ConfirmUserPendingEmail(userID string, verifiedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, ok := m.users[userID]
+	if !ok || user.PendingEmail == "" {
+		return gorm.ErrRecordNotFound
+	}
+	for _, other := range m.users {
+		if other.ID != userID && other.Email == user.PendingEmail {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	user.Email, user.PendingEmail = user.PendingEmail, ""
+	user.EmailVerifiedAt = &verifiedAt
+	user.UpdatedAt = time.Now()
+	return nil
+}
+
+func (m *MemoryStorage) CreateSession(session *Session) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	session.BeforeSave(nil)
+	if _, ok := m.sessions[session.ID]; ok {
+		return gorm.ErrDuplicatedKey
+	}
+	now := time.Now()
+	session.CreatedAt, session.UpdatedAt = now, now
+
+	copied := *session
+	m.sessions[session.ID] = &copied
+	return nil
+}
+
+func (m *MemoryStorage) GetSessionByID(id string) (*Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	session, ok := m.sessions[id]
+	if !ok {
+		return &Session{}, gorm.ErrRecordNotFound
+	}
+	copied := *session
+	return &copied, nil
+}
+
+func (m *MemoryStorage) BlockSessionByID(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if session, ok := m.sessions[id]; ok {
+		session.IsBlocked = true
+		session.BlockedAt = time.Now()
+		session.UpdatedAt = session.BlockedAt
+	}
+	return nil
+}
+
+func (m *MemoryStorage) BlockSessionsByEmail(email, keepSessionID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	for _, session := range m.sessions {
+		if session.Email == email && !session.IsBlocked && session.ID != keepSessionID {
+			session.IsBlocked = true
+			session.BlockedAt = now
+			session.UpdatedAt = now
+		}
+	}
+	return nil
+}
+
+func (m *MemoryStorage) DeleteSessionByID(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.sessions, id)
+	return nil
+}
+
+func (m *MemoryStorage) ListSessionsByEmail(email string, now time.Time) ([]Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var sessions []Session
+	for _, session := range m.sessions {
+		if session.Email == email && !session.IsBlocked && session.ExpiresAt.After(now) {
+			sessions = append(sessions, *session)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastUsedAt.After(sessions[j].LastUsedAt)
+	})
+	return sessions, nil
+}
+
+func (m *MemoryStorage) RotateSessionToken(id, oldToken, newToken string, expiresAt, usedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	session, ok := m.sessions[id]
+	if !ok || session.RefreshToken != oldToken || session.IsBlocked {
+		return gorm.ErrRecordNotFound
+	}
+	session.RefreshToken = newToken
+	session.ExpiresAt = expiresAt
+	session.LastUsedAt = usedAt
+	session.UpdatedAt = time.Now()
+	return nil
+}
+
+func (m *MemoryStorage) SetUserEmailVerified(userID string, verifiedAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if user, ok := m.users[userID]; ok {
+		user.EmailVerifiedAt = &verifiedAt
+		user.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+// CreateUserToken returns gorm.ErrDuplicatedKey if the hash is taken
+func (m *MemoryStorage) CreateUserToken(token *UserToken) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, other := range m.tokens {
+		if other.TokenHash == token.TokenHash {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	token.BeforeSave(nil)
+	token.CreatedAt = time.Now()
+
+	copied := *token
+	m.tokens[token.ID] = &copied
+	return nil
+}
+
+func (m *MemoryStorage) ConsumeUserToken(purpose, tokenHash string) (*UserToken, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, token := range m.tokens {
+		if token.Purpose == purpose && token.TokenHash == tokenHash {
+			delete(m.tokens, id)
+			return token, nil
+		}
+	}
+	return &UserToken{}, gorm.ErrRecordNotFound
+}
+
+func (m *MemoryStorage) DeleteUserTokens(userID, purpose string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for id, token := range m.tokens {
+		if token.UserID == userID && token.Purpose == purpose {
+			delete(m.tokens, id)
+		}
+	}
+	return nil
+}
+
+func (m *MemoryStorage) SetUserMFASecret(userID, secret string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if user, ok := m.users[userID]; ok {
+		user.MFASecret = secret
+		user.MFAEnabledAt = nil
+		user.MFALastStep = 0
+		user.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+func (m *MemoryStorage) EnableUserMFA(userID string, enabledAt time.Time, recoveryCodeHashes []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if user, ok := m.users[userID]; ok {
+		user.MFAEnabledAt = &enabledAt
+		user.UpdatedAt = time.Now()
+	}
+	codes := map[string]bool{}
+	for _, hash := range recoveryCodeHashes {
+		codes[hash] = true
+	}
+	m.codes[userID] = codes
+	return nil
+}
+
+func (m *MemoryStorage) DisableUserMFA(userID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if user, ok := m.users[userID]; ok {
+		user.MFASecret = ""
+		user.MFAEnabledAt = nil
+		user.MFALastStep = 0
+		user.UpdatedAt = time.Now()
+	}
+	delete(m.codes, userID)
+	return nil
+}
+
+func (m *MemoryStorage) UseUserMFAStep(userID string, step int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, ok := m.users[userID]
+	if !ok || user.MFALastStep >= step {
+		return gorm.ErrRecordNotFound
+	}
+	user.MFALastStep = step
+	return nil
+}
+
+func (m *MemoryStorage) ConsumeMFARecoveryCode(userID, codeHash string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.codes[userID][codeHash] {
+		return gorm.ErrRecordNotFound
+	}
+	delete(m.codes[userID], codeHash)
+	return nil
+}
+
+func (m *MemoryStorage) GetIdentity(provider, subject string) (*Identity, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, identity := range m.identities {
+		if identity.Provider == provider && identity.Subject == subject {
+			copied := *identity
+			return &copied, nil
+		}
+	}
+	return &Identity{}, gorm.ErrRecordNotFound
+}
+
+// CreateIdentity returns gorm.ErrDuplicatedKey if the provider account is
+// linked already
+func (m *MemoryStorage) CreateIdentity(identity *Identity) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.createIdentity(identity)
+}
+
+func (m *MemoryStorage) createIdentity(identity *Identity) error {
+	for _, other := range m.identities {
+		if other.Provider == identity.Provider && other.Subject == identity.Subject {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	identity.BeforeSave(nil)
+	identity.CreatedAt = time.Now()
+
+	copied := *identity
+	m.identities[identity.ID] = &copied
+	return nil
+}
+
+func (m *MemoryStorage) CreateUserWithIdentity(user *User, identity *Identity) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, other := range m.identities {
+		if other.Provider == identity.Provider && other.Subject == identity.Subject {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	if err := m.createUser(user); err != nil {
+		return err
+	}
+	identity.UserID = user.ID
+	return m.createIdentity(identity)
+}
@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"fmt"
 	"log"
 	"time"
 
@@ -9,15 +10,17 @@ import (
 	"gorm.io/gorm"
 )
 
-func MigrateDB(db *gorm.DB) *gorm.DB {
+// MigrateDB brings the schema up to date. The migrations run on Postgres as
+// well as SQLite.
+func MigrateDB(db *gorm.DB) error {
 	logger := common.Logger
 	logger = logger.WithField("method", "MigrateDB")
 	dbObj, err := db.DB()
 	if err != nil {
-		logger.WithError(err).Error("Failed to get db")
+		return fmt.Errorf("failed to get db: %w", err)
 	}
 	if err := dbObj.Ping(); err != nil {
-		logger.WithError(err).Fatalln("Could not ping DB")
+		return fmt.Errorf("could not ping db: %w", err)
 	}
 	logger.Info("running migrations")
 	options := &gormigrate.Options{
@@ -27,11 +30,11 @@ func MigrateDB(db *gorm.DB) *gorm.DB {
 
 	migration := gormigrate.New(db, options, migrations)
 	if err := migration.Migrate(); err != nil {
-		logger.WithError(err).Fatalln("failed to migrate database")
+		return fmt.Errorf("failed to migrate database: %w", err)
 	}
 
 	logger.Info("migrations completed")
-	return db
+	return nil
 }
 
 var migrations = []*gormigrate.Migration{
@@ -119,4 +122,138 @@ var migrations = []*gormigrate.Migration{
 			return tx.Migrator().DropTable("sessions")
 		},
 	},
+	{
+		ID: "202610191000",
+		Migrate: func(tx *gorm.DB) error {
+			type User struct {
+				EmailVerifiedAt *time.Time
+			}
+			type UserToken struct {
+				ID        string    `gorm:"size:255;primary_key"`
+				UserID    string    `gorm:"size:255;not null;index:idx_user_token_user_id"`
+				Purpose   string    `gorm:"size:50;not null"`
+				TokenHash string    `gorm:"size:64;not null;uniqueIndex:idx_user_token_hash"`
+				ExpiresAt time.Time `gorm:"not null"`
+				CreatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+			}
+			if err := tx.Migrator().AddColumn(&User{}, "EmailVerifiedAt"); err != nil {
+				return err
+			}
+			// Users who signed up before verification existed keep logging in
+			if err := tx.Exec("UPDATE users SET email_verified_at = created_at").Error; err != nil {
+				return err
+			}
+			return tx.Migrator().CreateTable(&UserToken{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			type User struct {
+				EmailVerifiedAt *time.Time
+			}
+			if err := tx.Migrator().DropTable("user_tokens"); err != nil {
+				return err
+			}
+			return tx.Migrator().DropColumn(&User{}, "EmailVerifiedAt")
+		},
+	},
+	{
+		ID: "202610191100",
+		Migrate: func(tx *gorm.DB) error {
+			type User struct {
+				PendingEmail string `gorm:"size:100"`
+			}
+			return tx.Migrator().AddColumn(&User{}, "PendingEmail")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			type User struct {
+				PendingEmail string `gorm:"size:100"`
+			}
+			return tx.Migrator().DropColumn(&User{}, "PendingEmail")
+		},
+	},
+	{
+		ID: "202610191200",
+		Migrate: func(tx *gorm.DB) error {
+			type User struct {
+				MFASecret    string `gorm:"size:255"`
+				MFAEnabledAt *time.Time
+				MFALastStep  int64 `gorm:"not null;default:0"`
+			}
+			type MFARecoveryCode struct {
+				ID        string    `gorm:"size:255;primary_key"`
+				UserID    string    `gorm:"size:255;not null;index:idx_mfa_recovery_code_user_id"`
+				CodeHash  string    `gorm:"size:64;not null"`
+				CreatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+			}
+			for _, field := range []string{"MFASecret", "MFAEnabledAt", "MFALastStep"} {
+				if err := tx.Migrator().AddColumn(&User{}, field); err != nil {
+					return err
+				}
+			}
+			return tx.Migrator().CreateTable(&MFARecoveryCode{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			type User struct {
+				MFASecret    string `gorm:"size:255"`
+				MFAEnabledAt *time.Time
+				MFALastStep  int64 `gorm:"not null;default:0"`
+			}
+			if err := tx.Migrator().DropTable("mfa_recovery_codes"); err != nil {
+				return err
+			}
+			for _, field := range []string{"MFASecret", "MFAEnabledAt", "MFALastStep"} {
+				if err := tx.Migrator().DropColumn(&User{}, field); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
+	{
+		ID: "202610191300",
+		Migrate: func(tx *gorm.DB) error {
+			type Identity struct {
+				ID        string    `gorm:"size:255;primary_key"`
+				UserID    string    `gorm:"size:255;not null;index:idx_identity_user_id"`
+				Provider  string    `gorm:"size:100;not null;uniqueIndex:idx_identity_provider_subject"`
+				Subject   string    `gorm:"size:255;not null;uniqueIndex:idx_identity_provider_subject"`
+				Email     string    `gorm:"size:100"`
+				CreatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+			}
+			return tx.Migrator().CreateTable(&Identity{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable("identities")
+		},
+	},
+	{
+		ID: "202610191400",
+		Migrate: func(tx *gorm.DB) error {
+			type Session struct {
+				UserAgent  string `gorm:"size:255"`
+				IPAddress  string `gorm:"size:45"`
+				LastUsedAt time.Time
+			}
+			for _, field := range []string{"UserAgent", "IPAddress", "LastUsedAt"} {
+				if err := tx.Migrator().AddColumn(&Session{}, field); err != nil {
+					return err
+				}
+			}
+			// Sessions started before were last used when they started at the
+			// latest
+			return tx.Exec("UPDATE sessions SET last_used_at = created_at").Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			type Session struct {
+				UserAgent  string `gorm:"size:255"`
+				IPAddress  string `gorm:"size:45"`
+				LastUsedAt time.Time
+			}
+			for _, field := range []string{"UserAgent", "IPAddress", "LastUsedAt"} {
+				if err := tx.Migrator().DropColumn(&Session{}, field); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	},
 }
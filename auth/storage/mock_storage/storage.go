@@ -11,6 +11,7 @@ package mock_storage
 
 import (
 	reflect "reflect"
+	time "time"
 
 	storage "github.com/sejamuchhal/taskhub/auth/storage"
 	gomock "go.uber.org/mock/gomock"
@@ -53,6 +54,77 @@ func (mr *MockStorageInterfaceMockRecorder) BlockSessionByID(arg0 any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockSessionByID", reflect.TypeOf((*MockStorageInterface)(nil).BlockSessionByID), arg0)
 }
 
+// BlockSessionsByEmail mocks base method.
+func (m *MockStorageInterface) BlockSessionsByEmail(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlockSessionsByEmail", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BlockSessionsByEmail indicates an expected call of BlockSessionsByEmail.
+func (mr *MockStorageInterfaceMockRecorder) BlockSessionsByEmail(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlockSessionsByEmail", reflect.TypeOf((*MockStorageInterface)(nil).BlockSessionsByEmail), arg0, arg1)
+}
+
+// ConfirmUserPendingEmail mocks base method.
+func (m *MockStorageInterface) ConfirmUserPendingEmail(arg0 string, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmUserPendingEmail", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmUserPendingEmail indicates an expected call of ConfirmUserPendingEmail.
+func (mr *MockStorageInterfaceMockRecorder) ConfirmUserPendingEmail(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmUserPendingEmail", reflect.TypeOf((*MockStorageInterface)(nil).ConfirmUserPendingEmail), arg0, arg1)
+}
+
+// ConsumeMFARecoveryCode mocks base method.
+func (m *MockStorageInterface) ConsumeMFARecoveryCode(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeMFARecoveryCode", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConsumeMFARecoveryCode indicates an expected call of ConsumeMFARecoveryCode.
+func (mr *MockStorageInterfaceMockRecorder) ConsumeMFARecoveryCode(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeMFARecoveryCode", reflect.TypeOf((*MockStorageInterface)(nil).ConsumeMFARecoveryCode), arg0, arg1)
+}
+
+// ConsumeUserToken mocks base method.
+func (m *MockStorageInterface) ConsumeUserToken(arg0, arg1 string) (*storage.UserToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConsumeUserToken", arg0, arg1)
+	ret0, _ := ret[0].(*storage.UserToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConsumeUserToken indicates an expected call of ConsumeUserToken.
+func (mr *MockStorageInterfaceMockRecorder) ConsumeUserToken(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeUserToken", reflect.TypeOf((*MockStorageInterface)(nil).ConsumeUserToken), arg0, arg1)
+}
+
+// CreateIdentity mocks base method.
+func (m *MockStorageInterface) CreateIdentity(arg0 *storage.Identity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIdentity", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateIdentity indicates an expected call of CreateIdentity.
+func (mr *MockStorageInterfaceMockRecorder) CreateIdentity(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIdentity", reflect.TypeOf((*MockStorageInterface)(nil).CreateIdentity), arg0)
+}
+
 // CreateSession mocks base method.
 func (m *MockStorageInterface) CreateSession(arg0 *storage.Session) error {
 	m.ctrl.T.Helper()
@@ -81,6 +153,34 @@ func (mr *MockStorageInterfaceMockRecorder) CreateUser(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockStorageInterface)(nil).CreateUser), arg0)
 }
 
+// CreateUserToken mocks base method.
+func (m *MockStorageInterface) CreateUserToken(arg0 *storage.UserToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserToken", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateUserToken indicates an expected call of CreateUserToken.
+func (mr *MockStorageInterfaceMockRecorder) CreateUserToken(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserToken", reflect.TypeOf((*MockStorageInterface)(nil).CreateUserToken), arg0)
+}
+
+// CreateUserWithIdentity mocks base method.
+func (m *MockStorageInterface) CreateUserWithIdentity(arg0 *storage.User, arg1 *storage.Identity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserWithIdentity", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateUserWithIdentity indicates an expected call of CreateUserWithIdentity.
+func (mr *MockStorageInterfaceMockRecorder) CreateUserWithIdentity(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserWithIdentity", reflect.TypeOf((*MockStorageInterface)(nil).CreateUserWithIdentity), arg0, arg1)
+}
+
 // DeleteSessionByID mocks base method.
 func (m *MockStorageInterface) DeleteSessionByID(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -95,6 +195,63 @@ func (mr *MockStorageInterfaceMockRecorder) DeleteSessionByID(arg0 any) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSessionByID", reflect.TypeOf((*MockStorageInterface)(nil).DeleteSessionByID), arg0)
 }
 
+// DeleteUserTokens mocks base method.
+func (m *MockStorageInterface) DeleteUserTokens(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserTokens", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserTokens indicates an expected call of DeleteUserTokens.
+func (mr *MockStorageInterfaceMockRecorder) DeleteUserTokens(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserTokens", reflect.TypeOf((*MockStorageInterface)(nil).DeleteUserTokens), arg0, arg1)
+}
+
+// DisableUserMFA mocks base method.
+func (m *MockStorageInterface) DisableUserMFA(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableUserMFA", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableUserMFA indicates an expected call of DisableUserMFA.
+func (mr *MockStorageInterfaceMockRecorder) DisableUserMFA(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableUserMFA", reflect.TypeOf((*MockStorageInterface)(nil).DisableUserMFA), arg0)
+}
+
+// EnableUserMFA mocks base method.
+func (m *MockStorageInterface) EnableUserMFA(arg0 string, arg1 time.Time, arg2 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnableUserMFA", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// EnableUserMFA indicates an expected call of EnableUserMFA.
+func (mr *MockStorageInterfaceMockRecorder) EnableUserMFA(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnableUserMFA", reflect.TypeOf((*MockStorageInterface)(nil).EnableUserMFA), arg0, arg1, arg2)
+}
+
+// GetIdentity mocks base method.
+func (m *MockStorageInterface) GetIdentity(arg0, arg1 string) (*storage.Identity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdentity", arg0, arg1)
+	ret0, _ := ret[0].(*storage.Identity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdentity indicates an expected call of GetIdentity.
+func (mr *MockStorageInterfaceMockRecorder) GetIdentity(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdentity", reflect.TypeOf((*MockStorageInterface)(nil).GetIdentity), arg0, arg1)
+}
+
 // GetSessionByID mocks base method.
 func (m *MockStorageInterface) GetSessionByID(arg0 string) (*storage.Session, error) {
 	m.ctrl.T.Helper()
@@ -124,3 +281,175 @@ func (mr *MockStorageInterfaceMockRecorder) GetUserByEmail(arg0 any) *gomock.Cal
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockStorageInterface)(nil).GetUserByEmail), arg0)
 }
+
+// GetUserByID mocks base method.
+func (m *MockStorageInterface) GetUserByID(arg0 string) (*storage.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", arg0)
+	ret0, _ := ret[0].(*storage.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockStorageInterfaceMockRecorder) GetUserByID(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockStorageInterface)(nil).GetUserByID), arg0)
+}
+
+// ListSessionsByEmail mocks base method.
+func (m *MockStorageInterface) ListSessionsByEmail(arg0 string, arg1 time.Time) ([]storage.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessionsByEmail", arg0, arg1)
+	ret0, _ := ret[0].([]storage.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessionsByEmail indicates an expected call of ListSessionsByEmail.
+func (mr *MockStorageInterfaceMockRecorder) ListSessionsByEmail(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessionsByEmail", reflect.TypeOf((*MockStorageInterface)(nil).ListSessionsByEmail), arg0, arg1)
+}
+
+// ListUsers mocks base method.
+func (m *MockStorageInterface) ListUsers(arg0 storage.UserFilter, arg1, arg2 int) ([]storage.User, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]storage.User)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockStorageInterfaceMockRecorder) ListUsers(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockStorageInterface)(nil).ListUsers), arg0, arg1, arg2)
+}
+
+// RotateSessionToken mocks base method.
+func (m *MockStorageInterface) RotateSessionToken(arg0, arg1, arg2 string, arg3, arg4 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateSessionToken", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RotateSessionToken indicates an expected call of RotateSessionToken.
+func (mr *MockStorageInterfaceMockRecorder) RotateSessionToken(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSessionToken", reflect.TypeOf((*MockStorageInterface)(nil).RotateSessionToken), arg0, arg1, arg2, arg3, arg4)
+}
+
+// SetUserActive mocks base method.
+func (m *MockStorageInterface) SetUserActive(arg0 string, arg1 bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserActive", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserActive indicates an expected call of SetUserActive.
+func (mr *MockStorageInterfaceMockRecorder) SetUserActive(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserActive", reflect.TypeOf((*MockStorageInterface)(nil).SetUserActive), arg0, arg1)
+}
+
+// SetUserEmailVerified mocks base method.
+func (m *MockStorageInterface) SetUserEmailVerified(arg0 string, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserEmailVerified", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserEmailVerified indicates an expected call of SetUserEmailVerified.
+func (mr *MockStorageInterfaceMockRecorder) SetUserEmailVerified(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserEmailVerified", reflect.TypeOf((*MockStorageInterface)(nil).SetUserEmailVerified), arg0, arg1)
+}
+
+// SetUserMFASecret mocks base method.
+func (m *MockStorageInterface) SetUserMFASecret(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserMFASecret", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserMFASecret indicates an expected call of SetUserMFASecret.
+func (mr *MockStorageInterfaceMockRecorder) SetUserMFASecret(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserMFASecret", reflect.TypeOf((*MockStorageInterface)(nil).SetUserMFASecret), arg0, arg1)
+}
+
+// SetUserPendingEmail mocks base method.
+func (m *MockStorageInterface) SetUserPendingEmail(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserPendingEmail", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserPendingEmail indicates an expected call of SetUserPendingEmail.
+func (mr *MockStorageInterfaceMockRecorder) SetUserPendingEmail(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserPendingEmail", reflect.TypeOf((*MockStorageInterface)(nil).SetUserPendingEmail), arg0, arg1)
+}
+
+// SetUserRole mocks base method.
+func (m *MockStorageInterface) SetUserRole(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserRole", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetUserRole indicates an expected call of SetUserRole.
+func (mr *MockStorageInterfaceMockRecorder) SetUserRole(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserRole", reflect.TypeOf((*MockStorageInterface)(nil).SetUserRole), arg0, arg1)
+}
+
+// UpdateUserName mocks base method.
+func (m *MockStorageInterface) UpdateUserName(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserName", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserName indicates an expected call of UpdateUserName.
+func (mr *MockStorageInterfaceMockRecorder) UpdateUserName(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserName", reflect.TypeOf((*MockStorageInterface)(nil).UpdateUserName), arg0, arg1)
+}
+
+// UpdateUserPassword mocks base method.
+func (m *MockStorageInterface) UpdateUserPassword(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserPassword", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserPassword indicates an expected call of UpdateUserPassword.
+func (mr *MockStorageInterfaceMockRecorder) UpdateUserPassword(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserPassword", reflect.TypeOf((*MockStorageInterface)(nil).UpdateUserPassword), arg0, arg1)
+}
+
+// UseUserMFAStep mocks base method.
+func (m *MockStorageInterface) UseUserMFAStep(arg0 string, arg1 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseUserMFAStep", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseUserMFAStep indicates an expected call of UseUserMFAStep.
+func (mr *MockStorageInterfaceMockRecorder) UseUserMFAStep(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseUserMFAStep", reflect.TypeOf((*MockStorageInterface)(nil).UseUserMFAStep), arg0, arg1)
+}
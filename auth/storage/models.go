@@ -17,6 +17,17 @@ type User struct {
 	Tasks     []Task    `gorm:"foreignKey:UserID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL" json:"tasks"`
 	Active    bool      `gorm:"default:true"`
 	Role      string    `gorm:"size:100;default:'user'"`
+	// EmailVerifiedAt is nil until the user follows their verification link
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	// PendingEmail replaces Email once the user confirms it
+	PendingEmail string `gorm:"size:100" json:"pending_email"`
+	// MFASecret is the encrypted TOTP secret, it only counts once
+	// MFAEnabledAt is set
+	MFASecret    string     `gorm:"size:255" json:"-"`
+	MFAEnabledAt *time.Time `json:"mfa_enabled_at"`
+	// MFALastStep is the TOTP time step of the last code used, codes cannot
+	// be used twice
+	MFALastStep int64 `gorm:"not null;default:0" json:"-"`
 }
 
 func (user *User) BeforeSave(tx *gorm.DB) (err error) {
@@ -35,6 +46,15 @@ type Task struct {
 	UserID      string    `gorm:"type:string;index:idx_task_user_id;not null"`
 }
 
+// UserFilter selects the users ListUsers returns
+type UserFilter struct {
+	// Query matches part of the name or email, ignoring case
+	Query string
+	Role  string
+	// Active, if set, selects active or deactivated users only
+	Active *bool
+}
+
 type Session struct {
 	ID           string    `gorm:"type:uuid;primary_key;"`
 	Email        string    `gorm:"size:100;" json:"email"`
@@ -42,8 +62,14 @@ type Session struct {
 	ExpiresAt    time.Time `gorm:"not null" json:"expires_at"`
 	IsBlocked    bool      `gorm:"default:false" json:"is_blocked"`
 	BlockedAt    time.Time `json:"blocked_at"`
-	CreatedAt    time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
-	UpdatedAt    time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+	// The client the session was started from
+	UserAgent string `gorm:"size:255" json:"user_agent"`
+	IPAddress string `gorm:"size:45" json:"ip_address"`
+	// LastUsedAt is when the refresh token was last used, or the session
+	// started
+	LastUsedAt time.Time `json:"last_used_at"`
+	CreatedAt  time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt  time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
 }
 
 func (session *Session) BeforeSave(tx *gorm.DB) (err error) {
@@ -52,3 +78,61 @@ func (session *Session) BeforeSave(tx *gorm.DB) (err error) {
 	}
 	return nil
 }
+
+// Purposes of user tokens
+const (
+	UserTokenVerifyEmail   = "verify_email"
+	UserTokenResetPassword = "reset_password"
+	UserTokenChangeEmail   = "change_email"
+)
+
+// UserToken is a one-time token emailed to a user. Only its hash is stored.
+type UserToken struct {
+	ID        string    `gorm:"size:255;primary_key"`
+	UserID    string    `gorm:"size:255;not null;index:idx_user_token_user_id"`
+	Purpose   string    `gorm:"size:50;not null"`
+	TokenHash string    `gorm:"size:64;not null;uniqueIndex:idx_user_token_hash"`
+	ExpiresAt time.Time `gorm:"not null"`
+	CreatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (token *UserToken) BeforeSave(tx *gorm.DB) (err error) {
+	if token.ID == "" {
+		token.ID = uuid.NewString()
+	}
+	return nil
+}
+
+// MFARecoveryCode lets a user log in once without their TOTP app. Only its
+// hash is stored.
+type MFARecoveryCode struct {
+	ID        string    `gorm:"size:255;primary_key"`
+	UserID    string    `gorm:"size:255;not null;index:idx_mfa_recovery_code_user_id"`
+	CodeHash  string    `gorm:"size:64;not null"`
+	CreatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (code *MFARecoveryCode) BeforeSave(tx *gorm.DB) (err error) {
+	if code.ID == "" {
+		code.ID = uuid.NewString()
+	}
+	return nil
+}
+
+// Identity links a user to their account at an OpenID Connect provider, a user
+// can have one per provider
+type Identity struct {
+	ID        string    `gorm:"size:255;primary_key"`
+	UserID    string    `gorm:"size:255;not null;index:idx_identity_user_id"`
+	Provider  string    `gorm:"size:100;not null;uniqueIndex:idx_identity_provider_subject"`
+	Subject   string    `gorm:"size:255;not null;uniqueIndex:idx_identity_provider_subject"`
+	Email     string    `gorm:"size:100"`
+	CreatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (identity *Identity) BeforeSave(tx *gorm.DB) (err error) {
+	if identity.ID == "" {
+		identity.ID = uuid.NewString()
+	}
+	return nil
+}
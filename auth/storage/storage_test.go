@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"errors"
+	"testing"
+
+	"gorm.io/gorm"
+)
+
+func TestNew_DuplicateEmail(t *testing.T) {
+	for _, databaseURL := range []string{"memory://", "sqlite://:memory:"} {
+		t.Run(databaseURL, func(t *testing.T) {
+			store, err := New(databaseURL)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			if err := store.CreateUser(&User{Name: "Jane", Email: "jane@example.com", Password: "hash"}); err != nil {
+				t.Fatal(err)
+			}
+			err = store.CreateUser(&User{Name: "Jane", Email: "jane@example.com", Password: "hash"})
+			if !errors.Is(err, gorm.ErrDuplicatedKey) {
+				t.Errorf("CreateUser() error = %v, want %v", err, gorm.ErrDuplicatedKey)
+			}
+
+			user, err := store.GetUserByEmail("jane@example.com")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !user.Active || user.Role != "user" {
+				t.Errorf("new user is active %v with role %q, want active user", user.Active, user.Role)
+			}
+		})
+	}
+}
+
+func TestNew_UnsupportedScheme(t *testing.T) {
+	if _, err := New("mysql://localhost/auth"); err == nil {
+		t.Error("New() error = nil, want unsupported scheme")
+	}
+}
@@ -0,0 +1,484 @@
+// Package storagetest is a conformance suite for implementations of
+// storage.StorageInterface. Every backend must pass it, so that handlers
+// behave the same whichever one is configured.
+//
+// Each test works on fresh emails and session IDs, so the suite can also run
+// against a shared database that already holds rows.
+package storagetest
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sejamuchhal/taskhub/auth/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// Factory returns the implementation under test. It is called once per test.
+type Factory func(t *testing.T) storage.StorageInterface
+
+// Run runs the conformance suite against the implementation returned by
+// newStorage
+func Run(t *testing.T, newStorage Factory) {
+	tests := map[string]func(t *testing.T, s storage.StorageInterface){
+		"Users":              testUsers,
+		"ListUsers":          testListUsers,
+		"UserAdmin":          testUserAdmin,
+		"Sessions":           testSessions,
+		"ListSessions":       testListSessions,
+		"RotateSessionToken": testRotateSessionToken,
+		"Tokens":             testUserTokens,
+		"MFA":                testMFA,
+		"Identities":         testIdentities,
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test(t, newStorage(t))
+		})
+	}
+}
+
+// RunBackends runs the suite against the memory and SQLite backends of
+// storage.New, and against Postgres when TEST_DATABASE_URL is set. Leave it
+// unset unless the database can take test users.
+func RunBackends(t *testing.T) {
+	backends := map[string]string{
+		"memory": "memory://",
+		"sqlite": "sqlite://:memory:",
+	}
+	if databaseURL := os.Getenv("TEST_DATABASE_URL"); databaseURL != "" {
+		backends["postgres"] = databaseURL
+	}
+
+	for name, databaseURL := range backends {
+		t.Run(name, func(t *testing.T) {
+			Run(t, func(t *testing.T) storage.StorageInterface {
+				store, err := storage.New(databaseURL)
+				require.NoError(t, err, "New(%q)", databaseURL)
+				return store
+			})
+		})
+	}
+}
+
+func newEmail() string {
+	return uuid.NewString() + "@example.com"
+}
+
+func testUsers(t *testing.T, s storage.StorageInterface) {
+	email := newEmail()
+	user := &storage.User{Name: "Jane", Email: email, Password: "hash"}
+	require.NoError(t, s.CreateUser(user))
+	require.NotEmpty(t, user.ID)
+
+	got, err := s.GetUserByEmail(email)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, got.ID)
+	assert.Equal(t, "Jane", got.Name)
+	assert.Equal(t, "hash", got.Password)
+	assert.True(t, got.Active)
+	assert.Equal(t, "user", got.Role)
+	assert.False(t, got.CreatedAt.IsZero())
+	assert.Nil(t, got.EmailVerifiedAt)
+
+	verifiedAt := time.Date(2024, 7, 30, 10, 0, 0, 0, time.UTC)
+	require.NoError(t, s.SetUserEmailVerified(user.ID, verifiedAt))
+	got, err = s.GetUserByEmail(email)
+	require.NoError(t, err)
+	require.NotNil(t, got.EmailVerifiedAt)
+	assert.True(t, got.EmailVerifiedAt.Equal(verifiedAt))
+	// The user keeps their ID
+	assert.Equal(t, user.ID, got.ID)
+
+	require.NoError(t, s.UpdateUserPassword(user.ID, "new-hash"))
+	got, err = s.GetUserByID(user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, email, got.Email)
+	assert.Equal(t, "new-hash", got.Password)
+	assert.Equal(t, user.ID, got.ID)
+
+	require.NoError(t, s.UpdateUserName(user.ID, "Jane Doe"))
+	got, err = s.GetUserByID(user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Jane Doe", got.Name)
+
+	// A new email only counts once it is confirmed
+	newAddress := newEmail()
+	require.NoError(t, s.SetUserPendingEmail(user.ID, newAddress))
+	got, err = s.GetUserByID(user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, email, got.Email)
+	assert.Equal(t, newAddress, got.PendingEmail)
+
+	confirmedAt := time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)
+	require.NoError(t, s.ConfirmUserPendingEmail(user.ID, confirmedAt))
+	got, err = s.GetUserByID(user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, newAddress, got.Email)
+	assert.Empty(t, got.PendingEmail)
+	assert.True(t, got.EmailVerifiedAt.Equal(confirmedAt))
+	assert.Equal(t, user.ID, got.ID)
+
+	err = s.ConfirmUserPendingEmail(user.ID, confirmedAt)
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+
+	// Another user may take the address while the change is pending
+	taken := &storage.User{Name: "Taken", Email: newEmail(), Password: "hash"}
+	require.NoError(t, s.CreateUser(taken))
+	require.NoError(t, s.SetUserPendingEmail(user.ID, taken.Email))
+	err = s.ConfirmUserPendingEmail(user.ID, confirmedAt)
+	assert.True(t, errors.Is(err, gorm.ErrDuplicatedKey), "got %v", err)
+	require.NoError(t, s.SetUserPendingEmail(user.ID, ""))
+	email = newAddress
+
+	_, err = s.GetUserByID(uuid.NewString())
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+
+	// Emails are unique
+	err = s.CreateUser(&storage.User{Name: "Other Jane", Email: email, Password: "hash"})
+	assert.True(t, errors.Is(err, gorm.ErrDuplicatedKey), "got %v", err)
+
+	_, err = s.GetUserByEmail(newEmail())
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+}
+
+func testListUsers(t *testing.T, s storage.StorageInterface) {
+	// Other tests may share the database, the marker keeps them out
+	marker := uuid.NewString()[:8]
+	var ids []string
+	for _, name := range []string{"Harry " + marker, "Hermione " + marker, "Ron_" + marker} {
+		user := &storage.User{Name: name, Email: newEmail(), Password: "hash"}
+		require.NoError(t, s.CreateUser(user))
+		ids = append(ids, user.ID)
+	}
+	require.NoError(t, s.SetUserRole(ids[1], "admin"))
+	require.NoError(t, s.SetUserActive(ids[2], false))
+
+	first, count, err := s.ListUsers(storage.UserFilter{Query: strings.ToUpper(marker)}, 2, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	require.Len(t, first, 2)
+	rest, count, err := s.ListUsers(storage.UserFilter{Query: marker}, 2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	require.Len(t, rest, 1)
+	var listed []string
+	for _, user := range append(first, rest...) {
+		listed = append(listed, user.ID)
+	}
+	assert.ElementsMatch(t, ids, listed)
+
+	admins, _, err := s.ListUsers(storage.UserFilter{Query: marker, Role: "admin"}, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, admins, 1)
+	assert.Equal(t, ids[1], admins[0].ID)
+
+	active := false
+	inactive, _, err := s.ListUsers(storage.UserFilter{Query: marker, Active: &active}, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, inactive, 1)
+	assert.Equal(t, ids[2], inactive[0].ID)
+
+	// LIKE wildcards are taken literally
+	underscored, _, err := s.ListUsers(storage.UserFilter{Query: "_" + marker}, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, underscored, 1)
+	assert.Equal(t, ids[2], underscored[0].ID)
+}
+
+func testUserAdmin(t *testing.T, s storage.StorageInterface) {
+	user := &storage.User{Name: "Jane", Email: newEmail(), Password: "hash"}
+	require.NoError(t, s.CreateUser(user))
+
+	require.NoError(t, s.SetUserRole(user.ID, "admin"))
+	require.NoError(t, s.SetUserActive(user.ID, false))
+	got, err := s.GetUserByID(user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "admin", got.Role)
+	assert.False(t, got.Active)
+
+	require.NoError(t, s.SetUserActive(user.ID, true))
+	got, err = s.GetUserByID(user.ID)
+	require.NoError(t, err)
+	assert.True(t, got.Active)
+
+	assert.ErrorIs(t, s.SetUserRole(uuid.NewString(), "admin"), gorm.ErrRecordNotFound)
+	assert.ErrorIs(t, s.SetUserActive(uuid.NewString(), false), gorm.ErrRecordNotFound)
+}
+
+func testSessions(t *testing.T, s storage.StorageInterface) {
+	expiresAt := time.Date(2024, 7, 31, 10, 0, 0, 0, time.UTC)
+	session := &storage.Session{
+		ID:           uuid.NewString(),
+		Email:        newEmail(),
+		RefreshToken: "refresh-token",
+		ExpiresAt:    expiresAt,
+	}
+	require.NoError(t, s.CreateSession(session))
+	other := &storage.Session{ID: uuid.NewString(), Email: session.Email, RefreshToken: "other", ExpiresAt: expiresAt}
+	require.NoError(t, s.CreateSession(other))
+
+	got, err := s.GetSessionByID(session.ID)
+	require.NoError(t, err)
+	assert.Equal(t, session.Email, got.Email)
+	assert.Equal(t, "refresh-token", got.RefreshToken)
+	assert.True(t, got.ExpiresAt.Equal(expiresAt))
+	assert.False(t, got.IsBlocked)
+
+	// Session IDs are unique
+	err = s.CreateSession(&storage.Session{ID: session.ID, Email: session.Email, RefreshToken: "copy", ExpiresAt: expiresAt})
+	assert.True(t, errors.Is(err, gorm.ErrDuplicatedKey), "got %v", err)
+
+	require.NoError(t, s.BlockSessionByID(session.ID))
+	got, err = s.GetSessionByID(session.ID)
+	require.NoError(t, err)
+	assert.True(t, got.IsBlocked)
+	assert.False(t, got.BlockedAt.IsZero())
+
+	require.NoError(t, s.DeleteSessionByID(session.ID))
+	_, err = s.GetSessionByID(session.ID)
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+
+	// Only the given session is touched
+	got, err = s.GetSessionByID(other.ID)
+	require.NoError(t, err)
+	assert.False(t, got.IsBlocked)
+
+	// All sessions of one user can be blocked at once
+	third := &storage.Session{ID: uuid.NewString(), Email: session.Email, RefreshToken: "third", ExpiresAt: expiresAt}
+	require.NoError(t, s.CreateSession(third))
+	stranger := &storage.Session{ID: uuid.NewString(), Email: newEmail(), RefreshToken: "stranger", ExpiresAt: expiresAt}
+	require.NoError(t, s.CreateSession(stranger))
+
+	fourth := &storage.Session{ID: uuid.NewString(), Email: session.Email, RefreshToken: "fourth", ExpiresAt: expiresAt}
+	require.NoError(t, s.CreateSession(fourth))
+
+	// Except the one the user is signed in with
+	require.NoError(t, s.BlockSessionsByEmail(session.Email, fourth.ID))
+	got, err = s.GetSessionByID(fourth.ID)
+	require.NoError(t, err)
+	assert.False(t, got.IsBlocked)
+
+	require.NoError(t, s.BlockSessionsByEmail(session.Email, ""))
+	for _, id := range []string{other.ID, third.ID, fourth.ID} {
+		got, err = s.GetSessionByID(id)
+		require.NoError(t, err)
+		assert.True(t, got.IsBlocked)
+		assert.False(t, got.BlockedAt.IsZero())
+	}
+	got, err = s.GetSessionByID(stranger.ID)
+	require.NoError(t, err)
+	assert.False(t, got.IsBlocked)
+}
+
+func testRotateSessionToken(t *testing.T, s storage.StorageInterface) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	session := &storage.Session{
+		ID:           uuid.NewString(),
+		Email:        newEmail(),
+		RefreshToken: "first",
+		ExpiresAt:    now.Add(time.Hour),
+		LastUsedAt:   now.Add(-time.Hour),
+	}
+	require.NoError(t, s.CreateSession(session))
+
+	require.NoError(t, s.RotateSessionToken(session.ID, "first", "second", now.Add(2*time.Hour), now))
+	got, err := s.GetSessionByID(session.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "second", got.RefreshToken)
+	assert.True(t, got.ExpiresAt.Equal(now.Add(2*time.Hour)))
+	assert.True(t, got.LastUsedAt.Equal(now))
+
+	// The replaced token cannot rotate again
+	err = s.RotateSessionToken(session.ID, "first", "third", now.Add(2*time.Hour), now)
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+	got, err = s.GetSessionByID(session.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "second", got.RefreshToken)
+
+	// Neither can the tokens of blocked or missing sessions
+	require.NoError(t, s.BlockSessionByID(session.ID))
+	err = s.RotateSessionToken(session.ID, "second", "third", now.Add(2*time.Hour), now)
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+	err = s.RotateSessionToken(uuid.NewString(), "second", "third", now.Add(2*time.Hour), now)
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+}
+
+func testListSessions(t *testing.T, s storage.StorageInterface) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	email := newEmail()
+	newSession := func(lastUsedAt, expiresAt time.Time) *storage.Session {
+		session := &storage.Session{
+			ID:           uuid.NewString(),
+			Email:        email,
+			RefreshToken: "refresh-token",
+			ExpiresAt:    expiresAt,
+			UserAgent:    "Mozilla/5.0",
+			IPAddress:    "203.0.113.7",
+			LastUsedAt:   lastUsedAt,
+		}
+		require.NoError(t, s.CreateSession(session))
+		return session
+	}
+	older := newSession(now.Add(-2*time.Hour), now.Add(time.Hour))
+	newer := newSession(now.Add(-time.Hour), now.Add(time.Hour))
+	newSession(now.Add(-3*time.Hour), now.Add(-time.Minute))
+	blocked := newSession(now.Add(-time.Hour), now.Add(time.Hour))
+	require.NoError(t, s.BlockSessionByID(blocked.ID))
+	require.NoError(t, s.CreateSession(&storage.Session{ID: uuid.NewString(), Email: newEmail(), RefreshToken: "stranger", ExpiresAt: now.Add(time.Hour)}))
+
+	// Blocked and expired sessions are left out
+	sessions, err := s.ListSessionsByEmail(email, now)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	assert.Equal(t, newer.ID, sessions[0].ID)
+	assert.Equal(t, older.ID, sessions[1].ID)
+	assert.Equal(t, "Mozilla/5.0", sessions[0].UserAgent)
+	assert.Equal(t, "203.0.113.7", sessions[0].IPAddress)
+	assert.False(t, sessions[0].CreatedAt.IsZero())
+
+	// Using a session moves it to the top
+	require.NoError(t, s.RotateSessionToken(older.ID, "refresh-token", "rotated", now.Add(2*time.Hour), now))
+	sessions, err = s.ListSessionsByEmail(email, now)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	assert.Equal(t, older.ID, sessions[0].ID)
+	assert.True(t, sessions[0].LastUsedAt.Equal(now))
+
+	sessions, err = s.ListSessionsByEmail(newEmail(), now)
+	require.NoError(t, err)
+	assert.Empty(t, sessions)
+}
+
+func testUserTokens(t *testing.T, s storage.StorageInterface) {
+	userID := uuid.NewString()
+	expiresAt := time.Date(2024, 7, 31, 10, 0, 0, 0, time.UTC)
+	token := &storage.UserToken{
+		UserID:    userID,
+		Purpose:   storage.UserTokenVerifyEmail,
+		TokenHash: uuid.NewString(),
+		ExpiresAt: expiresAt,
+	}
+	require.NoError(t, s.CreateUserToken(token))
+	require.NotEmpty(t, token.ID)
+
+	// Hashes are unique
+	err := s.CreateUserToken(&storage.UserToken{UserID: userID, Purpose: storage.UserTokenVerifyEmail, TokenHash: token.TokenHash, ExpiresAt: expiresAt})
+	assert.True(t, errors.Is(err, gorm.ErrDuplicatedKey), "got %v", err)
+
+	// Tokens only work for their purpose
+	_, err = s.ConsumeUserToken("other", token.TokenHash)
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+
+	got, err := s.ConsumeUserToken(storage.UserTokenVerifyEmail, token.TokenHash)
+	require.NoError(t, err)
+	assert.Equal(t, userID, got.UserID)
+	assert.True(t, got.ExpiresAt.Equal(expiresAt))
+
+	// Tokens can only be used once
+	_, err = s.ConsumeUserToken(storage.UserTokenVerifyEmail, token.TokenHash)
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+
+	other := &storage.UserToken{UserID: userID, Purpose: storage.UserTokenVerifyEmail, TokenHash: uuid.NewString(), ExpiresAt: expiresAt}
+	require.NoError(t, s.CreateUserToken(other))
+	kept := &storage.UserToken{UserID: uuid.NewString(), Purpose: storage.UserTokenVerifyEmail, TokenHash: uuid.NewString(), ExpiresAt: expiresAt}
+	require.NoError(t, s.CreateUserToken(kept))
+
+	require.NoError(t, s.DeleteUserTokens(userID, storage.UserTokenVerifyEmail))
+	_, err = s.ConsumeUserToken(storage.UserTokenVerifyEmail, other.TokenHash)
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+	// Other users' tokens are kept
+	_, err = s.ConsumeUserToken(storage.UserTokenVerifyEmail, kept.TokenHash)
+	assert.NoError(t, err)
+}
+
+func testMFA(t *testing.T, s storage.StorageInterface) {
+	user := &storage.User{Name: "Jane", Email: newEmail(), Password: "hash"}
+	require.NoError(t, s.CreateUser(user))
+
+	// Enrolment is pending until it is enabled
+	require.NoError(t, s.SetUserMFASecret(user.ID, "sealed-secret"))
+	got, err := s.GetUserByID(user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "sealed-secret", got.MFASecret)
+	assert.Nil(t, got.MFAEnabledAt)
+
+	enabledAt := time.Date(2024, 7, 30, 10, 0, 0, 0, time.UTC)
+	require.NoError(t, s.EnableUserMFA(user.ID, enabledAt, []string{"hash-1", "hash-2"}))
+	got, err = s.GetUserByID(user.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.MFAEnabledAt)
+	assert.True(t, got.MFAEnabledAt.Equal(enabledAt))
+	assert.Equal(t, user.ID, got.ID)
+
+	// Codes of a time step work once, and not after a later step
+	require.NoError(t, s.UseUserMFAStep(user.ID, 100))
+	err = s.UseUserMFAStep(user.ID, 100)
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+	err = s.UseUserMFAStep(user.ID, 99)
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+	require.NoError(t, s.UseUserMFAStep(user.ID, 101))
+
+	// Recovery codes work once and only for their user
+	other := &storage.User{Name: "Other", Email: newEmail(), Password: "hash"}
+	require.NoError(t, s.CreateUser(other))
+	err = s.ConsumeMFARecoveryCode(other.ID, "hash-1")
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+	require.NoError(t, s.ConsumeMFARecoveryCode(user.ID, "hash-1"))
+	err = s.ConsumeMFARecoveryCode(user.ID, "hash-1")
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+
+	// Enabling again replaces the codes
+	require.NoError(t, s.EnableUserMFA(user.ID, enabledAt, []string{"hash-3"}))
+	err = s.ConsumeMFARecoveryCode(user.ID, "hash-2")
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+
+	require.NoError(t, s.DisableUserMFA(user.ID))
+	got, err = s.GetUserByID(user.ID)
+	require.NoError(t, err)
+	assert.Empty(t, got.MFASecret)
+	assert.Nil(t, got.MFAEnabledAt)
+	assert.Zero(t, got.MFALastStep)
+	err = s.ConsumeMFARecoveryCode(user.ID, "hash-3")
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+}
+
+func testIdentities(t *testing.T, s storage.StorageInterface) {
+	subject := uuid.NewString()
+	user := &storage.User{Name: "Jane", Email: newEmail()}
+	identity := &storage.Identity{Provider: "google", Subject: subject, Email: user.Email}
+	require.NoError(t, s.CreateUserWithIdentity(user, identity))
+	assert.NotEmpty(t, user.ID)
+	assert.Equal(t, user.ID, identity.UserID)
+
+	got, err := s.GetIdentity("google", subject)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, got.UserID)
+	assert.Equal(t, user.Email, got.Email)
+
+	// Subjects are only unique per provider
+	_, err = s.GetIdentity("corp", subject)
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+	require.NoError(t, s.CreateIdentity(&storage.Identity{UserID: user.ID, Provider: "corp", Subject: subject}))
+	got, err = s.GetIdentity("corp", subject)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, got.UserID)
+
+	other := &storage.User{Name: "Other", Email: newEmail()}
+	require.NoError(t, s.CreateUser(other))
+	err = s.CreateIdentity(&storage.Identity{UserID: other.ID, Provider: "google", Subject: subject})
+	assert.True(t, errors.Is(err, gorm.ErrDuplicatedKey), "got %v", err)
+
+	// Nothing is created when the identity is linked already
+	email := newEmail()
+	err = s.CreateUserWithIdentity(&storage.User{Name: "Dup", Email: email}, &storage.Identity{Provider: "google", Subject: subject})
+	assert.True(t, errors.Is(err, gorm.ErrDuplicatedKey), "got %v", err)
+	_, err = s.GetUserByEmail(email)
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+}
@@ -13,9 +13,21 @@ type UserClaims struct {
 	Email     string `json:"email"`
 	Role      string `json:"role"`
 	TokenType string `json:"token_type"`
+	// SID names the session of a refresh token. Every token the session is
+	// renewed with carries it.
+	SID string `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// SessionID returns the session of a refresh token. Tokens issued before
+// rotation have no sid, their ID is the one of the session.
+func (claims *UserClaims) SessionID() string {
+	if claims.SID != "" {
+		return claims.SID
+	}
+	return claims.ID
+}
+
 func (handler *TokenHandler) NewUserClaims(user *storage.User, duration time.Duration, tokenType string) (*UserClaims, error) {
 
 	return &UserClaims{
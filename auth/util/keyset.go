@@ -0,0 +1,237 @@
+package util
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// keyActivationLayouts are the ways key files can be named after the time
+// they start signing
+var keyActivationLayouts = []string{"20060102T150405Z", "20060102"}
+
+// SigningKey is a private key tokens are signed with. Its ID goes into the
+// kid header of the tokens.
+type SigningKey struct {
+	ID string
+	// ActiveFrom is when the key starts signing, it takes over from the key
+	// active before
+	ActiveFrom time.Time
+
+	private crypto.Signer
+	method  jwt.SigningMethod
+}
+
+// ParseSigningKey reads a PEM encoded RSA or Ed25519 private key. RSA keys
+// sign with RS256 and need at least 2048 bits, Ed25519 keys sign with EdDSA.
+func ParseSigningKey(id string, activeFrom time.Time, data []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	var private interface{}
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		private, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		private, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key := &SigningKey{ID: id, ActiveFrom: activeFrom}
+	switch private := private.(type) {
+	case *rsa.PrivateKey:
+		if private.N.BitLen() < 2048 {
+			return nil, fmt.Errorf("RSA key has %d bits, at least 2048 are needed", private.N.BitLen())
+		}
+		key.private, key.method = private, jwt.SigningMethodRS256
+	case ed25519.PrivateKey:
+		key.private, key.method = private, jwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("unsupported key type %T, use RSA or Ed25519", private)
+	}
+	return key, nil
+}
+
+// JWK is the public half of a signing key as a JSON Web Key
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	// RSA modulus and exponent
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Ed25519 curve and public key
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWK returns the public key of key
+func (key *SigningKey) JWK() JWK {
+	jwk := JWK{Kid: key.ID, Use: "sig", Alg: key.method.Alg()}
+	switch public := key.private.Public().(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(public)
+	}
+	return jwk
+}
+
+// KeySet holds the keys tokens are signed with over time. The key that
+// became active last signs, the ones before keep verifying for retention
+// after they were replaced, so that the tokens they signed can expire.
+// Keys that are not active yet are published, so that whoever verifies
+// tokens knows them before the first token signed with them shows up.
+type KeySet struct {
+	// keys are ordered by ActiveFrom
+	keys      []*SigningKey
+	retention time.Duration
+}
+
+// NewKeySet returns a keyset of keys, which need distinct IDs. retention
+// should be the lifetime of the longest lived token.
+func NewKeySet(keys []*SigningKey, retention time.Duration) (*KeySet, error) {
+	if len(keys) == 0 {
+		return nil, errors.New("no signing keys")
+	}
+
+	sorted := append([]*SigningKey(nil), keys...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].ActiveFrom.Before(sorted[j].ActiveFrom)
+	})
+	seen := map[string]bool{}
+	for _, key := range sorted {
+		if seen[key.ID] {
+			return nil, fmt.Errorf("duplicate key id %q", key.ID)
+		}
+		seen[key.ID] = true
+	}
+	return &KeySet{keys: sorted, retention: retention}, nil
+}
+
+// LoadKeySet reads every .pem file in dir. Each one is named after the UTC
+// time it starts signing, as 20261101T000000Z.pem or 20261101.pem, and the
+// name is its key ID.
+func LoadKeySet(dir string, retention time.Duration) (*KeySet, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+
+	var keys []*SigningKey
+	for _, path := range paths {
+		id := strings.TrimSuffix(filepath.Base(path), ".pem")
+		activeFrom, err := parseKeyActivation(id)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", path, err)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		key, err := ParseSigningKey(id, activeFrom, data)
+		if err != nil {
+			return nil, fmt.Errorf("key %s: %w", path, err)
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no .pem keys in %s", dir)
+	}
+	return NewKeySet(keys, retention)
+}
+
+// GenerateKeySet returns a keyset of one new Ed25519 key. Tokens signed with
+// it stop working when the process exits, it is meant for development.
+func GenerateKeySet(retention time.Duration) (*KeySet, error) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+
+	key := &SigningKey{
+		ID:         "generated-" + hex.EncodeToString(id),
+		ActiveFrom: time.Now(),
+		private:    private,
+		method:     jwt.SigningMethodEdDSA,
+	}
+	return NewKeySet([]*SigningKey{key}, retention)
+}
+
+func parseKeyActivation(id string) (time.Time, error) {
+	for _, layout := range keyActivationLayouts {
+		if activeFrom, err := time.Parse(layout, id); err == nil {
+			return activeFrom, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("name %q is not an activation time like 20261101T000000Z", id)
+}
+
+// SigningKey returns the key that signs tokens at now, or nil if no key is
+// active yet
+func (ks *KeySet) SigningKey(now time.Time) *SigningKey {
+	var active *SigningKey
+	for _, key := range ks.keys {
+		if key.ActiveFrom.After(now) {
+			break
+		}
+		active = key
+	}
+	return active
+}
+
+// VerificationKey returns the key with id if it may still verify tokens at
+// now
+func (ks *KeySet) VerificationKey(id string, now time.Time) (*SigningKey, bool) {
+	for _, key := range ks.PublicKeys(now) {
+		if key.ID == id {
+			return key, true
+		}
+	}
+	return nil, false
+}
+
+// PublicKeys returns the keys that sign or verify tokens at now, or will
+// sign them later
+func (ks *KeySet) PublicKeys(now time.Time) []*SigningKey {
+	var keys []*SigningKey
+	for i, key := range ks.keys {
+		// Replaced keys are dropped once the tokens they signed expired
+		if i+1 < len(ks.keys) && ks.keys[i+1].ActiveFrom.Add(ks.retention).Before(now) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,236 @@
+package util_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redismock/v9"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sejamuchhal/taskhub/auth/storage"
+	"github.com/sejamuchhal/taskhub/auth/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ed25519PEM(t *testing.T) []byte {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func rsaPEM(t *testing.T, bits int) []byte {
+	private, err := rsa.GenerateKey(rand.Reader, bits)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(private)})
+}
+
+func signingKey(t *testing.T, id string, activeFrom time.Time) *util.SigningKey {
+	key, err := util.ParseSigningKey(id, activeFrom, ed25519PEM(t))
+	require.NoError(t, err)
+	return key
+}
+
+func TestLoadKeySet(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "20261001.pem"), rsaPEM(t, 2048), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "20261101T120000Z.pem"), ed25519PEM(t), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README"), []byte("not a key"), 0o600))
+
+	keys, err := util.LoadKeySet(dir, 24*time.Hour)
+	require.NoError(t, err)
+
+	october := time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)
+	key := keys.SigningKey(october)
+	require.NotNil(t, key)
+	assert.Equal(t, "20261001", key.ID)
+	assert.Equal(t, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), key.ActiveFrom)
+	assert.Equal(t, "RS256", key.JWK().Alg)
+
+	november := time.Date(2026, 11, 1, 12, 0, 0, 0, time.UTC)
+	key = keys.SigningKey(november)
+	require.NotNil(t, key)
+	assert.Equal(t, "20261101T120000Z", key.ID)
+	assert.Equal(t, "EdDSA", key.JWK().Alg)
+
+	assert.Nil(t, keys.SigningKey(time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestLoadKeySet_Errors(t *testing.T) {
+	tests := map[string]struct {
+		name string
+		data []byte
+	}{
+		"not a time":    {"current.pem", ed25519PEM(t)},
+		"not a key":     {"20261001.pem", []byte("garbage")},
+		"short RSA key": {"20261001.pem", rsaPEM(t, 1024)},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, test.name), test.data, 0o600))
+			_, err := util.LoadKeySet(dir, time.Hour)
+			assert.Error(t, err)
+		})
+	}
+
+	_, err := util.LoadKeySet(t.TempDir(), time.Hour)
+	assert.Error(t, err, "empty directory")
+}
+
+func TestKeySet_Rotation(t *testing.T) {
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	old := signingKey(t, "old", start)
+	current := signingKey(t, "current", start.Add(30*24*time.Hour))
+	next := signingKey(t, "next", start.Add(60*24*time.Hour))
+	keys, err := util.NewKeySet([]*util.SigningKey{next, old, current}, 24*time.Hour)
+	require.NoError(t, err)
+
+	ids := func(now time.Time) []string {
+		var ids []string
+		for _, key := range keys.PublicKeys(now) {
+			ids = append(ids, key.ID)
+		}
+		return ids
+	}
+
+	// The next key is published before it signs
+	assert.Equal(t, "old", keys.SigningKey(start.Add(time.Hour)).ID)
+	assert.Equal(t, []string{"old", "current", "next"}, ids(start.Add(time.Hour)))
+
+	// The old key verifies until its tokens have expired
+	rotated := current.ActiveFrom
+	assert.Equal(t, "current", keys.SigningKey(rotated).ID)
+	_, ok := keys.VerificationKey("old", rotated.Add(23*time.Hour))
+	assert.True(t, ok)
+	_, ok = keys.VerificationKey("old", rotated.Add(25*time.Hour))
+	assert.False(t, ok)
+	assert.Equal(t, []string{"current", "next"}, ids(rotated.Add(25*time.Hour)))
+
+	_, err = util.NewKeySet([]*util.SigningKey{old, signingKey(t, "old", start)}, time.Hour)
+	assert.Error(t, err, "duplicate id")
+}
+
+func TestSigningKey_JWK(t *testing.T) {
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	require.NoError(t, err)
+	key, err := util.ParseSigningKey("rsa", time.Now(), pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	require.NoError(t, err)
+
+	jwk := key.JWK()
+	assert.Equal(t, "RSA", jwk.Kty)
+	assert.Equal(t, "rsa", jwk.Kid)
+	assert.Equal(t, "sig", jwk.Use)
+	assert.Equal(t, "AQAB", jwk.E)
+	assert.Equal(t, base64.RawURLEncoding.EncodeToString(private.N.Bytes()), jwk.N)
+
+	jwk = signingKey(t, "ed", time.Now()).JWK()
+	assert.Equal(t, "OKP", jwk.Kty)
+	assert.Equal(t, "Ed25519", jwk.Crv)
+	assert.Len(t, jwk.X, 43)
+}
+
+func TestVerifyToken_AfterRotation(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	defer db.Close()
+
+	now := time.Now()
+	old := signingKey(t, "old", now.Add(-48*time.Hour))
+	oldKeys, err := util.NewKeySet([]*util.SigningKey{old}, 24*time.Hour)
+	require.NoError(t, err)
+	user := &storage.User{ID: "test-user-id", Email: "user@mail.com"}
+	oldHandler := util.NewTokenHandler(oldKeys, "", db)
+	tokenString, _, err := oldHandler.CreateToken(user, 24*time.Hour, "refresh")
+	require.NoError(t, err)
+
+	// Signed with the old key, which was replaced an hour ago
+	keys, err := util.NewKeySet([]*util.SigningKey{old, signingKey(t, "new", now.Add(-time.Hour))}, 24*time.Hour)
+	require.NoError(t, err)
+	handler := util.NewTokenHandler(keys, "", db)
+	mock.ExpectGet(tokenString).RedisNil()
+	_, err = handler.VerifyToken(tokenString, "refresh")
+	require.NoError(t, err)
+
+	// Replaced over a day ago
+	keys, err = util.NewKeySet([]*util.SigningKey{old, signingKey(t, "new", now.Add(-25*time.Hour))}, 24*time.Hour)
+	require.NoError(t, err)
+	handler = util.NewTokenHandler(keys, "", db)
+	mock.ExpectGet(tokenString).RedisNil()
+	_, err = handler.VerifyToken(tokenString, "refresh")
+	assert.ErrorContains(t, err, "unknown key id")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestVerifyToken_LegacySecret(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	defer db.Close()
+
+	claims := &util.UserClaims{
+		UserID:    "test-user-id",
+		TokenType: "refresh",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+	tokenString, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("taskhub"))
+	require.NoError(t, err)
+
+	keys, err := util.GenerateKeySet(time.Hour)
+	require.NoError(t, err)
+
+	handler := util.NewTokenHandler(keys, "taskhub", db)
+	mock.ExpectGet(tokenString).RedisNil()
+	_, err = handler.VerifyToken(tokenString, "refresh")
+	require.NoError(t, err)
+
+	// Without the secret, such tokens are rejected
+	handler = util.NewTokenHandler(keys, "", db)
+	mock.ExpectGet(tokenString).RedisNil()
+	_, err = handler.VerifyToken(tokenString, "refresh")
+	assert.ErrorContains(t, err, "no key id")
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestVerifyToken_AlgorithmMismatch(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	defer db.Close()
+
+	key := signingKey(t, "ed", time.Now().Add(-time.Hour))
+	keys, err := util.NewKeySet([]*util.SigningKey{key}, time.Hour)
+	require.NoError(t, err)
+	handler := util.NewTokenHandler(keys, "", db)
+
+	// An HS256 token claiming the key ID, keyed with the public key
+	claims := &util.UserClaims{
+		UserID:    "test-user-id",
+		TokenType: "refresh",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = "ed"
+	x, err := base64.RawURLEncoding.DecodeString(key.JWK().X)
+	require.NoError(t, err)
+	tokenString, err := token.SignedString(x)
+	require.NoError(t, err)
+
+	mock.ExpectGet(tokenString).RedisNil()
+	_, err = handler.VerifyToken(tokenString, "refresh")
+	assert.Error(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
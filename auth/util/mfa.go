@@ -0,0 +1,85 @@
+package util
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base32"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+)
+
+// TOTP codes are RFC 6238 defaults, 6 digits for 30 second steps. A code of
+// the step before or after the current one is accepted too, for clocks that
+// are a little off.
+const (
+	totpPeriod = 30
+	totpSkew   = 1
+)
+
+// NewTOTPKey returns a new TOTP secret for account and its otpauth:// URI
+func NewTOTPKey(issuer, account string) (secret, uri string, err error) {
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      issuer,
+		AccountName: account,
+		Period:      totpPeriod,
+		Digits:      otp.DigitsSix,
+		Algorithm:   otp.AlgorithmSHA1,
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate totp key: %v", err)
+	}
+	return key.Secret(), key.URL(), nil
+}
+
+// ValidateTOTP checks code against secret at now. It returns the time step
+// the code belongs to, so that callers can refuse a code that was used
+// before.
+func ValidateTOTP(secret, code string, now time.Time) (int64, bool) {
+	code = strings.TrimSpace(code)
+	for offset := -totpSkew; offset <= totpSkew; offset++ {
+		at := now.Add(time.Duration(offset*totpPeriod) * time.Second)
+		want, err := totp.GenerateCodeCustom(secret, at, totp.ValidateOpts{
+			Period:    totpPeriod,
+			Digits:    otp.DigitsSix,
+			Algorithm: otp.AlgorithmSHA1,
+		})
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(want), []byte(code)) == 1 {
+			return at.Unix() / totpPeriod, true
+		}
+	}
+	return 0, false
+}
+
+// recoveryCodeEncoding spells recovery codes without padding and in lower case
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// NewRecoveryCodes returns n random recovery codes to show to a user, such
+// as "k3j9d-q2mxa", and the hashes to store for them
+func NewRecoveryCodes(n int) (codes, hashes []string, err error) {
+	for range n {
+		buf := make([]byte, 7)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate recovery code: %v", err)
+		}
+		code := strings.ToLower(recoveryCodeEncoding.EncodeToString(buf))[:10]
+		code = code[:5] + "-" + code[5:]
+		codes = append(codes, code)
+		hashes = append(hashes, HashRecoveryCode(code))
+	}
+	return codes, hashes, nil
+}
+
+// HashRecoveryCode returns the hash stored for code. Case, spaces and dashes
+// do not matter.
+func HashRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.NewReplacer("-", "", " ", "").Replace(code)
+	return HashOneTimeToken(code)
+}
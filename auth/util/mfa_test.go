@@ -0,0 +1,84 @@
+package util_test
+
+import (
+	"bytes"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+	"github.com/sejamuchhal/taskhub/auth/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateTOTP(t *testing.T) {
+	secret, uri, err := util.NewTOTPKey("TaskHub", "harry@hogwarts.edu")
+	require.NoError(t, err)
+
+	parsed, err := url.Parse(uri)
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", parsed.Scheme)
+	assert.Equal(t, "totp", parsed.Host)
+	assert.Equal(t, secret, parsed.Query().Get("secret"))
+	assert.Equal(t, "TaskHub", parsed.Query().Get("issuer"))
+
+	now := time.Unix(1722333600, 0)
+	code, err := totp.GenerateCode(secret, now)
+	require.NoError(t, err)
+
+	step, ok := util.ValidateTOTP(secret, code, now)
+	assert.True(t, ok)
+	assert.Equal(t, now.Unix()/30, step)
+
+	// Codes of the neighbouring steps are accepted, older ones are not
+	step, ok = util.ValidateTOTP(secret, code, now.Add(30*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, now.Unix()/30, step)
+	_, ok = util.ValidateTOTP(secret, code, now.Add(2*time.Minute))
+	assert.False(t, ok)
+
+	_, ok = util.ValidateTOTP(secret, "000000x", now)
+	assert.False(t, ok)
+}
+
+func TestNewRecoveryCodes(t *testing.T) {
+	codes, hashes, err := util.NewRecoveryCodes(10)
+	require.NoError(t, err)
+	require.Len(t, codes, 10)
+	require.Len(t, hashes, 10)
+
+	seen := map[string]bool{}
+	for i, code := range codes {
+		assert.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, code)
+		assert.Equal(t, hashes[i], util.HashRecoveryCode(code))
+		assert.False(t, seen[code])
+		seen[code] = true
+	}
+
+	// Users may type codes in upper case and without the dash
+	assert.Equal(t, util.HashRecoveryCode(codes[0]), util.HashRecoveryCode(" "+strings.ToUpper(codes[0][:5]+codes[0][6:])+" "))
+}
+
+func TestSecretBox(t *testing.T) {
+	box, err := util.NewSecretBox(bytes.Repeat([]byte{1}, util.SecretBoxKeySize))
+	require.NoError(t, err)
+
+	sealed, err := box.Seal("JBSWY3DPEHPK3PXP")
+	require.NoError(t, err)
+	assert.NotContains(t, sealed, "JBSWY3DPEHPK3PXP")
+
+	opened, err := box.Open(sealed)
+	require.NoError(t, err)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", opened)
+
+	other, err := util.NewSecretBox(bytes.Repeat([]byte{2}, util.SecretBoxKeySize))
+	require.NoError(t, err)
+	_, err = other.Open(sealed)
+	assert.Error(t, err)
+
+	// Passphrases are not keys
+	_, err = util.NewSecretBox([]byte("taskhub-mfa"))
+	assert.Error(t, err)
+}
@@ -3,6 +3,7 @@ package util
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -11,14 +12,19 @@ import (
 )
 
 type TokenHandler struct {
-	secret      string
-	redisClient *redis.Client
+	keys *KeySet
+	// legacySecret verifies HS256 tokens signed before keys were introduced
+	legacySecret string
+	redisClient  *redis.Client
 }
 
-func NewTokenHandler(secret string, redisClient *redis.Client) TokenHandler {
+// NewTokenHandler signs tokens with keys. Tokens without a key ID are only
+// accepted if legacySecret is set, as HS256 tokens signed with it.
+func NewTokenHandler(keys *KeySet, legacySecret string, redisClient *redis.Client) TokenHandler {
 	return TokenHandler{
-		secret:      secret,
-		redisClient: redisClient,
+		keys:         keys,
+		legacySecret: legacySecret,
+		redisClient:  redisClient,
 	}
 }
 
@@ -27,9 +33,31 @@ func (handler *TokenHandler) CreateToken(user *storage.User, duration time.Durat
 	if err != nil {
 		return "", nil, err
 	}
+	return handler.sign(claims)
+}
+
+// CreateRefreshToken returns a refresh token of session sessionID. An empty
+// sessionID starts a new session named after the token's ID.
+func (handler *TokenHandler) CreateRefreshToken(user *storage.User, duration time.Duration, sessionID string) (string, *UserClaims, error) {
+	claims, err := handler.NewUserClaims(user, duration, "refresh")
+	if err != nil {
+		return "", nil, err
+	}
+	claims.SID = sessionID
+	if claims.SID == "" {
+		claims.SID = claims.ID
+	}
+	return handler.sign(claims)
+}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(handler.secret))
+func (handler *TokenHandler) sign(claims *UserClaims) (string, *UserClaims, error) {
+	key := handler.keys.SigningKey(time.Now())
+	if key == nil {
+		return "", nil, errors.New("no signing key is active yet")
+	}
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.ID
+	tokenString, err := token.SignedString(key.private)
 	if err != nil {
 		return "", nil, err
 	}
@@ -44,9 +72,7 @@ func (handler *TokenHandler) VerifyToken(tokenString string, expectedType string
 		return nil, errors.New("token is blacklisted")
 	}
 
-	token, err := jwt.ParseWithClaims(tokenString, &UserClaims{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(handler.secret), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &UserClaims{}, handler.verificationKey)
 	if err != nil {
 		return nil, err
 	}
@@ -60,9 +86,49 @@ func (handler *TokenHandler) VerifyToken(tokenString string, expectedType string
 		return nil, errors.New("invalid token type")
 	}
 
+	// Refresh tokens are revoked through their sessions
+	if claims.TokenType == "access" {
+		revokedBefore, err := handler.redisClient.Get(context.Background(), revokedTokensKey(claims.UserID)).Int64()
+		if err == nil && claims.IssuedAt != nil && claims.IssuedAt.Unix() < revokedBefore {
+			return nil, errors.New("token is revoked")
+		}
+	}
+
 	return claims, nil
 }
 
+// PublicKeys returns the keys tokens may be signed with, to publish as a
+// JWKS
+func (handler *TokenHandler) PublicKeys() []JWK {
+	keys := handler.keys.PublicKeys(time.Now())
+	jwks := make([]JWK, len(keys))
+	for i, key := range keys {
+		jwks[i] = key.JWK()
+	}
+	return jwks
+}
+
+// verificationKey picks the key of token by its kid header, making sure the
+// token uses the algorithm of that key
+func (handler *TokenHandler) verificationKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		if handler.legacySecret == "" || token.Method != jwt.SigningMethodHS256 {
+			return nil, errors.New("token has no key id")
+		}
+		return []byte(handler.legacySecret), nil
+	}
+
+	key, ok := handler.keys.VerificationKey(kid, time.Now())
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("key %q does not sign with %s", kid, token.Method.Alg())
+	}
+	return key.private.Public(), nil
+}
+
 // Add token to blacklist in Redis
 func (handler *TokenHandler) BlacklistToken(tokenString string, expiry time.Duration) error {
 	ctx := context.Background()
@@ -71,4 +137,16 @@ func (handler *TokenHandler) BlacklistToken(tokenString string, expiry time.Dura
 		return err
 	}
 	return nil
-}
\ No newline at end of file
+}
+
+// RevokeUserTokens revokes the access tokens of a user issued before now.
+// Tokens carry their issue time in whole seconds, so tokens issued earlier in
+// the current second stay valid while those issued right after do too. The
+// mark is kept for expiry, the longest an access token lives.
+func (handler *TokenHandler) RevokeUserTokens(userID string, expiry time.Duration) error {
+	return handler.redisClient.Set(context.Background(), revokedTokensKey(userID), time.Now().Unix(), expiry).Err()
+}
+
+func revokedTokensKey(userID string) string {
+	return "revoked_before:" + userID
+}
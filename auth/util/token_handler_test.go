@@ -1,25 +1,33 @@
 package util_test
 
 import (
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/go-redis/redismock/v9"
+	"github.com/redis/go-redis/v9"
 	"github.com/sejamuchhal/taskhub/auth/storage"
 	"github.com/sejamuchhal/taskhub/auth/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+func newTokenHandler(t *testing.T, db *redis.Client) util.TokenHandler {
+	keys, err := util.GenerateKeySet(24 * time.Hour)
+	require.NoError(t, err)
+	return util.NewTokenHandler(keys, "", db)
+}
+
 func TestCreateToken(t *testing.T) {
 	db, mock := redismock.NewClientMock()
 	defer db.Close()
 
-	handler := util.NewTokenHandler("secret", db)
+	handler := newTokenHandler(t, db)
 
 	user := &storage.User{
-		ID: "test-user-id",
-		Name: "test-user",
+		ID:    "test-user-id",
+		Name:  "test-user",
 		Email: "user@mail.com",
 	}
 	tokenString, claims, err := handler.CreateToken(user, time.Hour, "access")
@@ -36,14 +44,43 @@ func TestCreateToken(t *testing.T) {
 	assert.Equal(t, claims.TokenType, verifiedClaims.TokenType)
 }
 
+func TestCreateRefreshToken(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	defer db.Close()
+	handler := newTokenHandler(t, db)
+	user := &storage.User{ID: "test-user-id", Email: "user@mail.com"}
+
+	// The first token names the session
+	first, claims, err := handler.CreateRefreshToken(user, time.Hour, "")
+	require.NoError(t, err)
+	assert.Equal(t, claims.ID, claims.SessionID())
+
+	// Tokens it is renewed with keep the session but get their own ID
+	renewed, renewedClaims, err := handler.CreateRefreshToken(user, time.Hour, claims.SessionID())
+	require.NoError(t, err)
+	assert.NotEqual(t, first, renewed)
+	assert.NotEqual(t, claims.ID, renewedClaims.ID)
+
+	mock.ExpectGet(renewed).RedisNil()
+	verifiedClaims, err := handler.VerifyToken(renewed, "refresh")
+	require.NoError(t, err)
+	assert.Equal(t, claims.ID, verifiedClaims.SessionID())
+	assert.Equal(t, "refresh", verifiedClaims.TokenType)
+
+	// Tokens from before rotation have no sid
+	legacy := &util.UserClaims{}
+	legacy.ID = "session-id"
+	assert.Equal(t, "session-id", legacy.SessionID())
+}
+
 func TestVerifyBlacklistedToken(t *testing.T) {
 	db, mock := redismock.NewClientMock()
 	defer db.Close()
-	handler := util.NewTokenHandler("secret", db)
+	handler := newTokenHandler(t, db)
 
 	user := &storage.User{
-		ID: "test-user-id",
-		Name: "test-user",
+		ID:    "test-user-id",
+		Name:  "test-user",
 		Email: "user@mail.com",
 	}
 
@@ -63,14 +100,13 @@ func TestBlacklistToken(t *testing.T) {
 	db, mock := redismock.NewClientMock()
 	defer db.Close()
 
-	handler := util.NewTokenHandler("secret", db)
+	handler := newTokenHandler(t, db)
 	user := &storage.User{
-		ID: "test-user-id",
-		Name: "test-user",
+		ID:    "test-user-id",
+		Name:  "test-user",
 		Email: "user@mail.com",
 	}
 
-	
 	tokenString, _, err := handler.CreateToken(user, 24*time.Hour, "refresh")
 	require.NoError(t, err)
 	require.NotNil(t, tokenString)
@@ -81,4 +117,36 @@ func TestBlacklistToken(t *testing.T) {
 
 	assert.NoError(t, mock.ExpectationsWereMet())
 
-}
\ No newline at end of file
+}
+
+func TestRevokeUserTokens(t *testing.T) {
+	db, mock := redismock.NewClientMock()
+	defer db.Close()
+
+	handler := newTokenHandler(t, db)
+	user := &storage.User{
+		ID:    "test-user-id",
+		Email: "user@mail.com",
+	}
+
+	tokenString, claims, err := handler.CreateToken(user, time.Hour, "access")
+	require.NoError(t, err)
+
+	mock.Regexp().ExpectSet("revoked_before:test-user-id", `^\d+$`, time.Hour).SetVal("OK")
+	require.NoError(t, handler.RevokeUserTokens(user.ID, time.Hour))
+
+	// Tokens issued before the revocation stop working
+	mock.ExpectGet(tokenString).RedisNil()
+	mock.ExpectGet("revoked_before:test-user-id").SetVal(strconv.FormatInt(claims.IssuedAt.Unix()+1, 10))
+	_, err = handler.VerifyToken(tokenString, "access")
+	require.Error(t, err)
+	assert.Equal(t, "token is revoked", err.Error())
+
+	// Tokens issued since keep working
+	mock.ExpectGet(tokenString).RedisNil()
+	mock.ExpectGet("revoked_before:test-user-id").SetVal(strconv.FormatInt(claims.IssuedAt.Unix(), 10))
+	_, err = handler.VerifyToken(tokenString, "access")
+	require.NoError(t, err)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,26 @@
+package util
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+// NewOneTimeToken returns a random token to send to a user and the hash to
+// store for it
+func NewOneTimeToken() (token, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("failed to generate token: %v", err)
+	}
+	token = base64.RawURLEncoding.EncodeToString(buf)
+	return token, HashOneTimeToken(token), nil
+}
+
+// HashOneTimeToken returns the hash stored for token
+func HashOneTimeToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
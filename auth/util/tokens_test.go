@@ -0,0 +1,22 @@
+package util_test
+
+import (
+	"testing"
+
+	"github.com/sejamuchhal/taskhub/auth/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOneTimeToken(t *testing.T) {
+	token, hash, err := util.NewOneTimeToken()
+	require.NoError(t, err)
+	assert.Len(t, token, 43)
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, util.HashOneTimeToken(token))
+
+	other, otherHash, err := util.NewOneTimeToken()
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other)
+	assert.NotEqual(t, hash, otherHash)
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"log"
+	_ "time/tzdata"
 
 	"github.com/sejamuchhal/taskhub/gateway/common"
 	srv "github.com/sejamuchhal/taskhub/gateway/server"
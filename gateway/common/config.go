@@ -2,9 +2,12 @@ package common
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"strconv"
+	"strings"
 
+	"github.com/sejamuchhal/taskhub/gateway/oidc"
 	"github.com/sirupsen/logrus"
 )
 
@@ -13,6 +16,11 @@ type Config struct {
 	HTTPPort       int64
 	AuthServiceUrl string
 	TaskServiceUrl string
+	// OIDCProviders users can sign in with
+	OIDCProviders []oidc.Config
+	// TrustedProxies may set X-Forwarded-For, the client IP is taken from
+	// the connection otherwise
+	TrustedProxies []string
 }
 
 func LoadConfig() (*Config, error) {
@@ -34,9 +42,49 @@ func LoadConfig() (*Config, error) {
 		TaskServiceUrl: taskServiceUrl,
 	}
 
+	for _, proxy := range strings.Split(getEnv("TRUSTED_PROXIES", ""), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			config.TrustedProxies = append(config.TrustedProxies, proxy)
+		}
+	}
+
+	config.OIDCProviders, err = loadOIDCProviders()
+	if err != nil {
+		return nil, err
+	}
+
 	return config, nil
 }
 
+// loadOIDCProviders reads the providers named in OIDC_PROVIDERS, each one
+// from its own OIDC_<NAME>_* variables
+func loadOIDCProviders() ([]oidc.Config, error) {
+	redirectBase := strings.TrimSuffix(getEnv("OIDC_REDIRECT_BASE_URL", "http://localhost:3000/auth/oidc"), "/")
+
+	var providers []oidc.Config
+	for _, name := range strings.Split(getEnv("OIDC_PROVIDERS", ""), ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+
+		provider := oidc.Config{
+			Name:         name,
+			Issuer:       getEnv(prefix+"ISSUER", ""),
+			ClientID:     getEnv(prefix+"CLIENT_ID", ""),
+			ClientSecret: getEnv(prefix+"CLIENT_SECRET", ""),
+			RedirectURL:  redirectBase + "/" + url.PathEscape(name) + "/callback",
+			Scopes:       strings.Fields(getEnv(prefix+"SCOPES", "")),
+		}
+		if provider.Issuer == "" || provider.ClientID == "" {
+			return nil, fmt.Errorf("missing %sISSUER or %sCLIENT_ID", prefix, prefix)
+		}
+		providers = append(providers, provider)
+	}
+	return providers, nil
+}
+
 func getEnv(key, defaultValue string) string {
 	if value, exists := os.LookupEnv(key); exists {
 		return value
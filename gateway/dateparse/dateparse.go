@@ -0,0 +1,263 @@
+// Package dateparse resolves the due dates people type into instants.
+//
+// Besides RFC 3339 it understands plain dates ("2024-07-30", "july 30"),
+// which mean the whole day, relative phrases ("today", "tomorrow",
+// "next friday", "in 3 days", "in 2 hours") and a time of day
+// ("5pm", "17:30", "at 9am", "noon") on its own or after a date.
+// Everything that is not an absolute timestamp is resolved in the caller's
+// location.
+package dateparse
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LegacyLayout is the only format the API used to accept
+const LegacyLayout = "January 2, 2006 3:04 PM MST"
+
+// ErrUnrecognized is returned for input none of the formats match
+var ErrUnrecognized = errors.New("unrecognized date")
+
+// Result is a resolved due date. All-day dates resolve to the last second of
+// that day, so that they are not overdue before the day is over.
+type Result struct {
+	Time   time.Time
+	AllDay bool
+}
+
+var absoluteLayouts = []string{
+	time.RFC3339,
+	LegacyLayout,
+}
+
+var localLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+}
+
+var (
+	timeOfDay   = regexp.MustCompile(`(?:^|\s)(?:at\s+)?(\d{1,2})(?::(\d{2}))?\s*(am|pm)$|(?:^|\s)(?:at\s+)?(\d{1,2}):(\d{2})$|(?:^|\s)(?:at\s+)?(noon|midnight)$`)
+	inDuration  = regexp.MustCompile(`^in\s+(\d+|an?)\s+(minute|hour|day|week|month)s?$`)
+	isoDate     = regexp.MustCompile(`^(\d{4})-(\d{2})-(\d{2})$`)
+	monthDay    = regexp.MustCompile(`^([a-z]+)\s+(\d{1,2})(?:st|nd|rd|th)?(?:\s+(\d{4}))?$`)
+	dayMonth    = regexp.MustCompile(`^(\d{1,2})(?:st|nd|rd|th)?\s+([a-z]+)(?:\s+(\d{4}))?$`)
+	whitespaces = regexp.MustCompile(`\s+`)
+)
+
+// Parse resolves input relative to now in loc
+func Parse(input string, now time.Time, loc *time.Location) (Result, error) {
+	input = strings.TrimSpace(input)
+	for _, layout := range absoluteLayouts {
+		if t, err := time.Parse(layout, input); err == nil {
+			return Result{Time: t}, nil
+		}
+	}
+	for _, layout := range localLayouts {
+		if t, err := time.ParseInLocation(layout, input, loc); err == nil {
+			return Result{Time: t}, nil
+		}
+	}
+
+	now = now.In(loc)
+	text := whitespaces.ReplaceAllString(strings.ToLower(strings.ReplaceAll(input, ",", " ")), " ")
+	text = strings.TrimSpace(text)
+
+	if m := inDuration.FindStringSubmatch(text); m != nil {
+		n := 1
+		if m[1] != "a" && m[1] != "an" {
+			n, _ = strconv.Atoi(m[1])
+		}
+		switch m[2] {
+		case "minute":
+			return Result{Time: now.Add(time.Duration(n) * time.Minute)}, nil
+		case "hour":
+			return Result{Time: now.Add(time.Duration(n) * time.Hour)}, nil
+		case "day":
+			return allDay(now.AddDate(0, 0, n)), nil
+		case "week":
+			return allDay(now.AddDate(0, 0, 7*n)), nil
+		default:
+			return allDay(now.AddDate(0, n, 0)), nil
+		}
+	}
+
+	hour, minute, hasTime, text, err := splitTimeOfDay(text)
+	if err != nil {
+		return Result{}, err
+	}
+
+	if text == "" {
+		if !hasTime {
+			return Result{}, ErrUnrecognized
+		}
+		// A bare time means its next occurrence
+		t := at(now, hour, minute)
+		if !t.After(now) {
+			t = at(now.AddDate(0, 0, 1), hour, minute)
+		}
+		return Result{Time: t}, nil
+	}
+
+	day, ok := parseDay(text, now)
+	if !ok {
+		return Result{}, ErrUnrecognized
+	}
+	if !hasTime {
+		return allDay(day), nil
+	}
+	return Result{Time: at(day, hour, minute)}, nil
+}
+
+// splitTimeOfDay cuts a trailing time of day off text
+func splitTimeOfDay(text string) (hour, minute int, ok bool, rest string, err error) {
+	m := timeOfDay.FindStringSubmatchIndex(text)
+	if m == nil {
+		return 0, 0, false, text, nil
+	}
+	group := func(i int) string {
+		if m[2*i] < 0 {
+			return ""
+		}
+		return text[m[2*i]:m[2*i+1]]
+	}
+	rest = strings.TrimSpace(text[:m[0]])
+
+	switch {
+	case group(6) == "noon":
+		return 12, 0, true, rest, nil
+	case group(6) == "midnight":
+		return 0, 0, true, rest, nil
+	case group(4) != "":
+		hour, _ = strconv.Atoi(group(4))
+		minute, _ = strconv.Atoi(group(5))
+		if hour > 23 || minute > 59 {
+			return 0, 0, false, "", ErrUnrecognized
+		}
+		return hour, minute, true, rest, nil
+	}
+
+	hour, _ = strconv.Atoi(group(1))
+	if group(2) != "" {
+		minute, _ = strconv.Atoi(group(2))
+	}
+	if hour < 1 || hour > 12 || minute > 59 {
+		return 0, 0, false, "", ErrUnrecognized
+	}
+	if hour == 12 {
+		hour = 0
+	}
+	if group(3) == "pm" {
+		hour += 12
+	}
+	return hour, minute, true, rest, nil
+}
+
+// parseDay resolves the date part of the input to a day in now's location
+func parseDay(text string, now time.Time) (time.Time, bool) {
+	switch text {
+	case "today":
+		return now, true
+	case "tomorrow":
+		return now.AddDate(0, 0, 1), true
+	case "next week":
+		return now.AddDate(0, 0, 7), true
+	case "next month":
+		return now.AddDate(0, 1, 0), true
+	}
+
+	// "friday" and "next friday" are both the coming friday, never today
+	if weekday, ok := weekdays[strings.TrimPrefix(text, "next ")]; ok {
+		days := (int(weekday) - int(now.Weekday()) + 7) % 7
+		if days == 0 {
+			days = 7
+		}
+		return now.AddDate(0, 0, days), true
+	}
+
+	if m := isoDate.FindStringSubmatch(text); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		month, _ := strconv.Atoi(m[2])
+		day, _ := strconv.Atoi(m[3])
+		return date(now, year, time.Month(month), day)
+	}
+
+	var monthName, dayText, yearText string
+	if m := monthDay.FindStringSubmatch(text); m != nil {
+		monthName, dayText, yearText = m[1], m[2], m[3]
+	} else if m := dayMonth.FindStringSubmatch(text); m != nil {
+		dayText, monthName, yearText = m[1], m[2], m[3]
+	} else {
+		return time.Time{}, false
+	}
+	month, ok := months[monthName]
+	if !ok {
+		return time.Time{}, false
+	}
+	day, _ := strconv.Atoi(dayText)
+	if yearText != "" {
+		year, _ := strconv.Atoi(yearText)
+		return date(now, year, month, day)
+	}
+	// Without a year the date is the next one that is not in the past
+	t, ok := date(now, now.Year(), month, day)
+	if ok && t.Before(at(now, 0, 0)) {
+		t, ok = date(now, now.Year()+1, month, day)
+	}
+	return t, ok
+}
+
+// date builds a day in now's location, rejecting overflowing values such as
+// February 30
+func date(now time.Time, year int, month time.Month, day int) (time.Time, bool) {
+	t := time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+	if t.Year() != year || t.Month() != month || t.Day() != day {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+func at(day time.Time, hour, minute int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, day.Location())
+}
+
+func allDay(day time.Time) Result {
+	end := time.Date(day.Year(), day.Month(), day.Day(), 23, 59, 59, 0, day.Location())
+	return Result{Time: end, AllDay: true}
+}
+
+// Weekday looks up a day of the week by its name or abbreviation, in lower case
+func Weekday(name string) (time.Weekday, bool) {
+	weekday, ok := weekdays[name]
+	return weekday, ok
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "thurs": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+var months = map[string]time.Month{
+	"january": time.January, "jan": time.January,
+	"february": time.February, "feb": time.February,
+	"march": time.March, "mar": time.March,
+	"april": time.April, "apr": time.April,
+	"may":  time.May,
+	"june": time.June, "jun": time.June,
+	"july": time.July, "jul": time.July,
+	"august": time.August, "aug": time.August,
+	"september": time.September, "sep": time.September, "sept": time.September,
+	"october": time.October, "oct": time.October,
+	"november": time.November, "nov": time.November,
+	"december": time.December, "dec": time.December,
+}
@@ -0,0 +1,83 @@
+package dateparse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Tuesday
+	now := time.Date(2024, 7, 30, 14, 0, 0, 0, kolkata)
+
+	tests := []struct {
+		input      string
+		want       time.Time
+		wantAllDay bool
+	}{
+		{input: "2024-08-02T10:00:00Z", want: time.Date(2024, 8, 2, 10, 0, 0, 0, time.UTC)},
+		{input: "2024-08-02T10:00:00+02:00", want: time.Date(2024, 8, 2, 8, 0, 0, 0, time.UTC)},
+		{input: "July 24, 2024 3:04 PM UTC", want: time.Date(2024, 7, 24, 15, 4, 0, 0, time.UTC)},
+		{input: "2024-08-02T10:00", want: time.Date(2024, 8, 2, 10, 0, 0, 0, kolkata)},
+		{input: "2024-08-02 10:00", want: time.Date(2024, 8, 2, 10, 0, 0, 0, kolkata)},
+		{input: "2024-08-02", want: time.Date(2024, 8, 2, 23, 59, 59, 0, kolkata), wantAllDay: true},
+		{input: "2024-08-02 5pm", want: time.Date(2024, 8, 2, 17, 0, 0, 0, kolkata)},
+		{input: "today", want: time.Date(2024, 7, 30, 23, 59, 59, 0, kolkata), wantAllDay: true},
+		{input: "Tomorrow", want: time.Date(2024, 7, 31, 23, 59, 59, 0, kolkata), wantAllDay: true},
+		{input: "tomorrow 5pm", want: time.Date(2024, 7, 31, 17, 0, 0, 0, kolkata)},
+		{input: "tomorrow at 9:30 am", want: time.Date(2024, 7, 31, 9, 30, 0, 0, kolkata)},
+		{input: "tomorrow 17:45", want: time.Date(2024, 7, 31, 17, 45, 0, 0, kolkata)},
+		{input: "tomorrow noon", want: time.Date(2024, 7, 31, 12, 0, 0, 0, kolkata)},
+		{input: "5pm", want: time.Date(2024, 7, 30, 17, 0, 0, 0, kolkata)},
+		{input: "9am", want: time.Date(2024, 7, 31, 9, 0, 0, 0, kolkata)},
+		{input: "12am", want: time.Date(2024, 7, 31, 0, 0, 0, 0, kolkata)},
+		{input: "friday", want: time.Date(2024, 8, 2, 23, 59, 59, 0, kolkata), wantAllDay: true},
+		{input: "next friday", want: time.Date(2024, 8, 2, 23, 59, 59, 0, kolkata), wantAllDay: true},
+		{input: "next tuesday", want: time.Date(2024, 8, 6, 23, 59, 59, 0, kolkata), wantAllDay: true},
+		{input: "fri 8am", want: time.Date(2024, 8, 2, 8, 0, 0, 0, kolkata)},
+		{input: "next week", want: time.Date(2024, 8, 6, 23, 59, 59, 0, kolkata), wantAllDay: true},
+		{input: "in 3 days", want: time.Date(2024, 8, 2, 23, 59, 59, 0, kolkata), wantAllDay: true},
+		{input: "in a week", want: time.Date(2024, 8, 6, 23, 59, 59, 0, kolkata), wantAllDay: true},
+		{input: "in 2 months", want: time.Date(2024, 9, 30, 23, 59, 59, 0, kolkata), wantAllDay: true},
+		{input: "in 2 hours", want: time.Date(2024, 7, 30, 16, 0, 0, 0, kolkata)},
+		{input: "in 45 minutes", want: time.Date(2024, 7, 30, 14, 45, 0, 0, kolkata)},
+		{input: "August 15", want: time.Date(2024, 8, 15, 23, 59, 59, 0, kolkata), wantAllDay: true},
+		{input: "15th aug 3pm", want: time.Date(2024, 8, 15, 15, 0, 0, 0, kolkata)},
+		{input: "jan 5", want: time.Date(2025, 1, 5, 23, 59, 59, 0, kolkata), wantAllDay: true},
+		{input: "march 1, 2025", want: time.Date(2025, 3, 1, 23, 59, 59, 0, kolkata), wantAllDay: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input, now, kolkata)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if !got.Time.Equal(tt.want) || got.AllDay != tt.wantAllDay {
+				t.Errorf("Parse() = %v (all day %v), want %v (all day %v)", got.Time, got.AllDay, tt.want, tt.wantAllDay)
+			}
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	now := time.Date(2024, 7, 30, 14, 0, 0, 0, time.UTC)
+	for _, input := range []string{
+		"",
+		"30-07-2024 16:00",
+		"someday",
+		"2024-02-30",
+		"february 30",
+		"tomorrow 25:00",
+		"13pm",
+		"in many days",
+	} {
+		t.Run(input, func(t *testing.T) {
+			if got, err := Parse(input, now, time.UTC); err == nil {
+				t.Errorf("Parse() = %v, want error", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+// Package oidc signs users in with OpenID Connect providers using the
+// authorization code flow with PKCE.
+//
+// A login starts with NewFlow, whose values the caller keeps until the
+// provider redirects back, and AuthCodeURL. The code the provider sends back
+// is turned into the verified identity of the user by Exchange.
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+// Config describes a provider and how TaskHub is registered with it
+type Config struct {
+	// Name identifies the provider in URLs and in the identities of users
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string `json:"-"`
+	// RedirectURL is where the provider sends users back to, it has to be
+	// registered with the provider
+	RedirectURL string
+	Scopes      []string
+}
+
+// String leaves out the client secret, the gateway logs its config
+func (c Config) String() string {
+	return fmt.Sprintf("{Name:%s Issuer:%s ClientID:%s RedirectURL:%s Scopes:%v}", c.Name, c.Issuer, c.ClientID, c.RedirectURL, c.Scopes)
+}
+
+// Flow holds the values of a login that must not leave the user's browser
+// until the provider redirects back
+type Flow struct {
+	State    string
+	Nonce    string
+	Verifier string
+}
+
+// NewFlow starts a login with fresh random values
+func NewFlow() (Flow, error) {
+	state, err := randomString()
+	if err != nil {
+		return Flow{}, err
+	}
+	nonce, err := randomString()
+	if err != nil {
+		return Flow{}, err
+	}
+	return Flow{State: state, Nonce: nonce, Verifier: oauth2.GenerateVerifier()}, nil
+}
+
+// Identity is who the provider says the user is
+type Identity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// Provider is a configured OpenID Connect provider. Its discovery document is
+// fetched on first use, so that a provider being down does not keep the
+// gateway from starting.
+type Provider struct {
+	Config Config
+
+	mu       sync.Mutex
+	oauth2   *oauth2.Config
+	verifier *oidc.IDTokenVerifier
+}
+
+func NewProvider(config Config) *Provider {
+	return &Provider{Config: config}
+}
+
+// AuthCodeURL returns the URL of the provider's login page for flow
+func (p *Provider) AuthCodeURL(ctx context.Context, flow Flow) (string, error) {
+	config, _, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+	return config.AuthCodeURL(flow.State, oauth2.S256ChallengeOption(flow.Verifier), oidc.Nonce(flow.Nonce)), nil
+}
+
+// Exchange redeems the code the provider sent back at the end of flow and
+// returns the identity from the verified ID token
+func (p *Provider) Exchange(ctx context.Context, code string, flow Flow) (*Identity, error) {
+	config, verifier, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := config.Exchange(ctx, code, oauth2.VerifierOption(flow.Verifier))
+	if err != nil {
+		return nil, fmt.Errorf("exchanging code: %w", err)
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, errors.New("no id_token in token response")
+	}
+	idToken, err := verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("verifying id_token: %w", err)
+	}
+	if idToken.Nonce != flow.Nonce {
+		return nil, errors.New("id_token nonce does not match")
+	}
+
+	var claims struct {
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+		Name          string `json:"name"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("reading id_token claims: %w", err)
+	}
+	return &Identity{
+		Subject:       idToken.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified,
+		Name:          claims.Name,
+	}, nil
+}
+
+func (p *Provider) discover(ctx context.Context) (*oauth2.Config, *oidc.IDTokenVerifier, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.oauth2 != nil {
+		return p.oauth2, p.verifier, nil
+	}
+
+	provider, err := oidc.NewProvider(ctx, p.Config.Issuer)
+	if err != nil {
+		return nil, nil, fmt.Errorf("discovering provider %s: %w", p.Config.Name, err)
+	}
+	scopes := p.Config.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{oidc.ScopeOpenID, "email", "profile"}
+	}
+	p.oauth2 = &oauth2.Config{
+		ClientID:     p.Config.ClientID,
+		ClientSecret: p.Config.ClientSecret,
+		Endpoint:     provider.Endpoint(),
+		RedirectURL:  p.Config.RedirectURL,
+		Scopes:       scopes,
+	}
+	p.verifier = provider.Verifier(&oidc.Config{ClientID: p.Config.ClientID})
+	return p.oauth2, p.verifier, nil
+}
+
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
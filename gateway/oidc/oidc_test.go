@@ -0,0 +1,92 @@
+package oidc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sejamuchhal/taskhub/gateway/oidc"
+	"github.com/sejamuchhal/taskhub/gateway/oidc/oidctest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const redirectURL = "http://gateway.test/auth/oidc/test/callback"
+
+func newProvider(t *testing.T) (*oidc.Provider, *oidctest.Provider) {
+	idp := oidctest.NewProvider(t)
+	return oidc.NewProvider(oidc.Config{
+		Name:         "test",
+		Issuer:       idp.Issuer(),
+		ClientID:     oidctest.ClientID,
+		ClientSecret: oidctest.ClientSecret,
+		RedirectURL:  redirectURL,
+	}), idp
+}
+
+// login runs flow up to the redirect back from the provider and returns the
+// code and state it carries
+func login(t *testing.T, provider *oidc.Provider, idp *oidctest.Provider, flow oidc.Flow) (string, string) {
+	authCodeURL, err := provider.AuthCodeURL(context.Background(), flow)
+	require.NoError(t, err)
+	callback := idp.Authorize(t, authCodeURL)
+	assert.Equal(t, redirectURL, callback.Scheme+"://"+callback.Host+callback.Path)
+	return callback.Query().Get("code"), callback.Query().Get("state")
+}
+
+func TestExchange(t *testing.T) {
+	provider, idp := newProvider(t)
+	idp.SetUser(oidctest.User{Subject: "42", Email: "hermione@hogwarts.edu", EmailVerified: true, Name: "Hermione Granger"})
+
+	flow, err := oidc.NewFlow()
+	require.NoError(t, err)
+	code, state := login(t, provider, idp, flow)
+	assert.Equal(t, flow.State, state)
+
+	identity, err := provider.Exchange(context.Background(), code, flow)
+	require.NoError(t, err)
+	assert.Equal(t, &oidc.Identity{
+		Subject:       "42",
+		Email:         "hermione@hogwarts.edu",
+		EmailVerified: true,
+		Name:          "Hermione Granger",
+	}, identity)
+
+	// Codes work once
+	_, err = provider.Exchange(context.Background(), code, flow)
+	assert.Error(t, err)
+}
+
+func TestExchange_WrongVerifier(t *testing.T) {
+	provider, idp := newProvider(t)
+
+	flow, err := oidc.NewFlow()
+	require.NoError(t, err)
+	code, _ := login(t, provider, idp, flow)
+
+	other, err := oidc.NewFlow()
+	require.NoError(t, err)
+	flow.Verifier = other.Verifier
+	_, err = provider.Exchange(context.Background(), code, flow)
+	assert.Error(t, err)
+}
+
+func TestExchange_WrongNonce(t *testing.T) {
+	provider, idp := newProvider(t)
+
+	flow, err := oidc.NewFlow()
+	require.NoError(t, err)
+	code, _ := login(t, provider, idp, flow)
+
+	flow.Nonce = "other"
+	_, err = provider.Exchange(context.Background(), code, flow)
+	assert.ErrorContains(t, err, "nonce")
+}
+
+func TestAuthCodeURL_DiscoveryFails(t *testing.T) {
+	provider := oidc.NewProvider(oidc.Config{Name: "down", Issuer: "http://127.0.0.1:1"})
+
+	flow, err := oidc.NewFlow()
+	require.NoError(t, err)
+	_, err = provider.AuthCodeURL(context.Background(), flow)
+	assert.Error(t, err)
+}
@@ -34,6 +34,126 @@ func (m *MockAuthServiceClient) EXPECT() *MockAuthServiceClientMockRecorder {
 	return m.recorder
 }
 
+// ChangePassword mocks base method.
+func (m *MockAuthServiceClient) ChangePassword(ctx context.Context, in *ChangePasswordRequest, opts ...grpc.CallOption) (*ChangePasswordResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ChangePassword", varargs...)
+	ret0, _ := ret[0].(*ChangePasswordResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockAuthServiceClientMockRecorder) ChangePassword(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockAuthServiceClient)(nil).ChangePassword), varargs...)
+}
+
+// ConfirmMFA mocks base method.
+func (m *MockAuthServiceClient) ConfirmMFA(ctx context.Context, in *ConfirmMFARequest, opts ...grpc.CallOption) (*ConfirmMFAResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ConfirmMFA", varargs...)
+	ret0, _ := ret[0].(*ConfirmMFAResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmMFA indicates an expected call of ConfirmMFA.
+func (mr *MockAuthServiceClientMockRecorder) ConfirmMFA(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmMFA", reflect.TypeOf((*MockAuthServiceClient)(nil).ConfirmMFA), varargs...)
+}
+
+// DisableMFA mocks base method.
+func (m *MockAuthServiceClient) DisableMFA(ctx context.Context, in *DisableMFARequest, opts ...grpc.CallOption) (*DisableMFAResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DisableMFA", varargs...)
+	ret0, _ := ret[0].(*DisableMFAResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisableMFA indicates an expected call of DisableMFA.
+func (mr *MockAuthServiceClientMockRecorder) DisableMFA(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableMFA", reflect.TypeOf((*MockAuthServiceClient)(nil).DisableMFA), varargs...)
+}
+
+// EnrollMFA mocks base method.
+func (m *MockAuthServiceClient) EnrollMFA(ctx context.Context, in *EnrollMFARequest, opts ...grpc.CallOption) (*EnrollMFAResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "EnrollMFA", varargs...)
+	ret0, _ := ret[0].(*EnrollMFAResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollMFA indicates an expected call of EnrollMFA.
+func (mr *MockAuthServiceClientMockRecorder) EnrollMFA(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollMFA", reflect.TypeOf((*MockAuthServiceClient)(nil).EnrollMFA), varargs...)
+}
+
+// GetJWKS mocks base method.
+func (m *MockAuthServiceClient) GetJWKS(ctx context.Context, in *GetJWKSRequest, opts ...grpc.CallOption) (*GetJWKSResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetJWKS", varargs...)
+	ret0, _ := ret[0].(*GetJWKSResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJWKS indicates an expected call of GetJWKS.
+func (mr *MockAuthServiceClientMockRecorder) GetJWKS(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJWKS", reflect.TypeOf((*MockAuthServiceClient)(nil).GetJWKS), varargs...)
+}
+
+// ListSessions mocks base method.
+func (m *MockAuthServiceClient) ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*ListSessionsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListSessions", varargs...)
+	ret0, _ := ret[0].(*ListSessionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockAuthServiceClientMockRecorder) ListSessions(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockAuthServiceClient)(nil).ListSessions), varargs...)
+}
+
 // Login mocks base method.
 func (m *MockAuthServiceClient) Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
 	m.ctrl.T.Helper()
@@ -74,6 +194,26 @@ func (mr *MockAuthServiceClientMockRecorder) Logout(ctx, in interface{}, opts ..
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAuthServiceClient)(nil).Logout), varargs...)
 }
 
+// OIDCLogin mocks base method.
+func (m *MockAuthServiceClient) OIDCLogin(ctx context.Context, in *OIDCLoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "OIDCLogin", varargs...)
+	ret0, _ := ret[0].(*LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OIDCLogin indicates an expected call of OIDCLogin.
+func (mr *MockAuthServiceClientMockRecorder) OIDCLogin(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OIDCLogin", reflect.TypeOf((*MockAuthServiceClient)(nil).OIDCLogin), varargs...)
+}
+
 // RenewAccessToken mocks base method.
 func (m *MockAuthServiceClient) RenewAccessToken(ctx context.Context, in *RenewAccessTokenRequest, opts ...grpc.CallOption) (*RenewAccessTokenResponse, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +234,106 @@ func (mr *MockAuthServiceClientMockRecorder) RenewAccessToken(ctx, in interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewAccessToken", reflect.TypeOf((*MockAuthServiceClient)(nil).RenewAccessToken), varargs...)
 }
 
+// RequestPasswordReset mocks base method.
+func (m *MockAuthServiceClient) RequestPasswordReset(ctx context.Context, in *RequestPasswordResetRequest, opts ...grpc.CallOption) (*RequestPasswordResetResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RequestPasswordReset", varargs...)
+	ret0, _ := ret[0].(*RequestPasswordResetResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestPasswordReset indicates an expected call of RequestPasswordReset.
+func (mr *MockAuthServiceClientMockRecorder) RequestPasswordReset(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPasswordReset", reflect.TypeOf((*MockAuthServiceClient)(nil).RequestPasswordReset), varargs...)
+}
+
+// ResendVerification mocks base method.
+func (m *MockAuthServiceClient) ResendVerification(ctx context.Context, in *ResendVerificationRequest, opts ...grpc.CallOption) (*ResendVerificationResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ResendVerification", varargs...)
+	ret0, _ := ret[0].(*ResendVerificationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResendVerification indicates an expected call of ResendVerification.
+func (mr *MockAuthServiceClientMockRecorder) ResendVerification(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendVerification", reflect.TypeOf((*MockAuthServiceClient)(nil).ResendVerification), varargs...)
+}
+
+// ResetPassword mocks base method.
+func (m *MockAuthServiceClient) ResetPassword(ctx context.Context, in *ResetPasswordRequest, opts ...grpc.CallOption) (*ResetPasswordResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ResetPassword", varargs...)
+	ret0, _ := ret[0].(*ResetPasswordResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockAuthServiceClientMockRecorder) ResetPassword(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockAuthServiceClient)(nil).ResetPassword), varargs...)
+}
+
+// RevokeAllSessions mocks base method.
+func (m *MockAuthServiceClient) RevokeAllSessions(ctx context.Context, in *RevokeAllSessionsRequest, opts ...grpc.CallOption) (*RevokeAllSessionsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RevokeAllSessions", varargs...)
+	ret0, _ := ret[0].(*RevokeAllSessionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAllSessions indicates an expected call of RevokeAllSessions.
+func (mr *MockAuthServiceClientMockRecorder) RevokeAllSessions(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllSessions", reflect.TypeOf((*MockAuthServiceClient)(nil).RevokeAllSessions), varargs...)
+}
+
+// RevokeSession mocks base method.
+func (m *MockAuthServiceClient) RevokeSession(ctx context.Context, in *RevokeSessionRequest, opts ...grpc.CallOption) (*RevokeSessionResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "RevokeSession", varargs...)
+	ret0, _ := ret[0].(*RevokeSessionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockAuthServiceClientMockRecorder) RevokeSession(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockAuthServiceClient)(nil).RevokeSession), varargs...)
+}
+
 // Signup mocks base method.
 func (m *MockAuthServiceClient) Signup(ctx context.Context, in *SignupRequest, opts ...grpc.CallOption) (*SignupResponse, error) {
 	m.ctrl.T.Helper()
@@ -114,6 +354,26 @@ func (mr *MockAuthServiceClientMockRecorder) Signup(ctx, in interface{}, opts ..
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Signup", reflect.TypeOf((*MockAuthServiceClient)(nil).Signup), varargs...)
 }
 
+// UpdateAccount mocks base method.
+func (m *MockAuthServiceClient) UpdateAccount(ctx context.Context, in *UpdateAccountRequest, opts ...grpc.CallOption) (*UpdateAccountResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateAccount", varargs...)
+	ret0, _ := ret[0].(*UpdateAccountResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAccount indicates an expected call of UpdateAccount.
+func (mr *MockAuthServiceClientMockRecorder) UpdateAccount(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccount", reflect.TypeOf((*MockAuthServiceClient)(nil).UpdateAccount), varargs...)
+}
+
 // Validate mocks base method.
 func (m *MockAuthServiceClient) Validate(ctx context.Context, in *ValidateRequest, opts ...grpc.CallOption) (*ValidateResponse, error) {
 	m.ctrl.T.Helper()
@@ -134,6 +394,46 @@ func (mr *MockAuthServiceClientMockRecorder) Validate(ctx, in interface{}, opts
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockAuthServiceClient)(nil).Validate), varargs...)
 }
 
+// VerifyEmail mocks base method.
+func (m *MockAuthServiceClient) VerifyEmail(ctx context.Context, in *VerifyEmailRequest, opts ...grpc.CallOption) (*VerifyEmailResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "VerifyEmail", varargs...)
+	ret0, _ := ret[0].(*VerifyEmailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockAuthServiceClientMockRecorder) VerifyEmail(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockAuthServiceClient)(nil).VerifyEmail), varargs...)
+}
+
+// VerifyMFA mocks base method.
+func (m *MockAuthServiceClient) VerifyMFA(ctx context.Context, in *VerifyMFARequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "VerifyMFA", varargs...)
+	ret0, _ := ret[0].(*LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyMFA indicates an expected call of VerifyMFA.
+func (mr *MockAuthServiceClientMockRecorder) VerifyMFA(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyMFA", reflect.TypeOf((*MockAuthServiceClient)(nil).VerifyMFA), varargs...)
+}
+
 // MockAuthServiceServer is a mock of AuthServiceServer interface.
 type MockAuthServiceServer struct {
 	ctrl     *gomock.Controller
@@ -157,6 +457,96 @@ func (m *MockAuthServiceServer) EXPECT() *MockAuthServiceServerMockRecorder {
 	return m.recorder
 }
 
+// ChangePassword mocks base method.
+func (m *MockAuthServiceServer) ChangePassword(ctx context.Context, in *ChangePasswordRequest) (*ChangePasswordResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, in)
+	ret0, _ := ret[0].(*ChangePasswordResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockAuthServiceServerMockRecorder) ChangePassword(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockAuthServiceServer)(nil).ChangePassword), ctx, in)
+}
+
+// ConfirmMFA mocks base method.
+func (m *MockAuthServiceServer) ConfirmMFA(ctx context.Context, in *ConfirmMFARequest) (*ConfirmMFAResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmMFA", ctx, in)
+	ret0, _ := ret[0].(*ConfirmMFAResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConfirmMFA indicates an expected call of ConfirmMFA.
+func (mr *MockAuthServiceServerMockRecorder) ConfirmMFA(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmMFA", reflect.TypeOf((*MockAuthServiceServer)(nil).ConfirmMFA), ctx, in)
+}
+
+// DisableMFA mocks base method.
+func (m *MockAuthServiceServer) DisableMFA(ctx context.Context, in *DisableMFARequest) (*DisableMFAResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableMFA", ctx, in)
+	ret0, _ := ret[0].(*DisableMFAResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DisableMFA indicates an expected call of DisableMFA.
+func (mr *MockAuthServiceServerMockRecorder) DisableMFA(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableMFA", reflect.TypeOf((*MockAuthServiceServer)(nil).DisableMFA), ctx, in)
+}
+
+// EnrollMFA mocks base method.
+func (m *MockAuthServiceServer) EnrollMFA(ctx context.Context, in *EnrollMFARequest) (*EnrollMFAResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollMFA", ctx, in)
+	ret0, _ := ret[0].(*EnrollMFAResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollMFA indicates an expected call of EnrollMFA.
+func (mr *MockAuthServiceServerMockRecorder) EnrollMFA(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollMFA", reflect.TypeOf((*MockAuthServiceServer)(nil).EnrollMFA), ctx, in)
+}
+
+// GetJWKS mocks base method.
+func (m *MockAuthServiceServer) GetJWKS(ctx context.Context, in *GetJWKSRequest) (*GetJWKSResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetJWKS", ctx, in)
+	ret0, _ := ret[0].(*GetJWKSResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetJWKS indicates an expected call of GetJWKS.
+func (mr *MockAuthServiceServerMockRecorder) GetJWKS(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetJWKS", reflect.TypeOf((*MockAuthServiceServer)(nil).GetJWKS), ctx, in)
+}
+
+// ListSessions mocks base method.
+func (m *MockAuthServiceServer) ListSessions(ctx context.Context, in *ListSessionsRequest) (*ListSessionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSessions", ctx, in)
+	ret0, _ := ret[0].(*ListSessionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSessions indicates an expected call of ListSessions.
+func (mr *MockAuthServiceServerMockRecorder) ListSessions(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSessions", reflect.TypeOf((*MockAuthServiceServer)(nil).ListSessions), ctx, in)
+}
+
 // Login mocks base method.
 func (m *MockAuthServiceServer) Login(ctx context.Context, in *LoginRequest) (*LoginResponse, error) {
 	m.ctrl.T.Helper()
@@ -187,6 +577,21 @@ func (mr *MockAuthServiceServerMockRecorder) Logout(ctx, in interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAuthServiceServer)(nil).Logout), ctx, in)
 }
 
+// OIDCLogin mocks base method.
+func (m *MockAuthServiceServer) OIDCLogin(ctx context.Context, in *OIDCLoginRequest) (*LoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "OIDCLogin", ctx, in)
+	ret0, _ := ret[0].(*LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// OIDCLogin indicates an expected call of OIDCLogin.
+func (mr *MockAuthServiceServerMockRecorder) OIDCLogin(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OIDCLogin", reflect.TypeOf((*MockAuthServiceServer)(nil).OIDCLogin), ctx, in)
+}
+
 // RenewAccessToken mocks base method.
 func (m *MockAuthServiceServer) RenewAccessToken(ctx context.Context, in *RenewAccessTokenRequest) (*RenewAccessTokenResponse, error) {
 	m.ctrl.T.Helper()
@@ -202,6 +607,81 @@ func (mr *MockAuthServiceServerMockRecorder) RenewAccessToken(ctx, in interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenewAccessToken", reflect.TypeOf((*MockAuthServiceServer)(nil).RenewAccessToken), ctx, in)
 }
 
+// RequestPasswordReset mocks base method.
+func (m *MockAuthServiceServer) RequestPasswordReset(ctx context.Context, in *RequestPasswordResetRequest) (*RequestPasswordResetResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequestPasswordReset", ctx, in)
+	ret0, _ := ret[0].(*RequestPasswordResetResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequestPasswordReset indicates an expected call of RequestPasswordReset.
+func (mr *MockAuthServiceServerMockRecorder) RequestPasswordReset(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestPasswordReset", reflect.TypeOf((*MockAuthServiceServer)(nil).RequestPasswordReset), ctx, in)
+}
+
+// ResendVerification mocks base method.
+func (m *MockAuthServiceServer) ResendVerification(ctx context.Context, in *ResendVerificationRequest) (*ResendVerificationResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResendVerification", ctx, in)
+	ret0, _ := ret[0].(*ResendVerificationResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResendVerification indicates an expected call of ResendVerification.
+func (mr *MockAuthServiceServerMockRecorder) ResendVerification(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResendVerification", reflect.TypeOf((*MockAuthServiceServer)(nil).ResendVerification), ctx, in)
+}
+
+// ResetPassword mocks base method.
+func (m *MockAuthServiceServer) ResetPassword(ctx context.Context, in *ResetPasswordRequest) (*ResetPasswordResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, in)
+	ret0, _ := ret[0].(*ResetPasswordResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockAuthServiceServerMockRecorder) ResetPassword(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockAuthServiceServer)(nil).ResetPassword), ctx, in)
+}
+
+// RevokeAllSessions mocks base method.
+func (m *MockAuthServiceServer) RevokeAllSessions(ctx context.Context, in *RevokeAllSessionsRequest) (*RevokeAllSessionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAllSessions", ctx, in)
+	ret0, _ := ret[0].(*RevokeAllSessionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeAllSessions indicates an expected call of RevokeAllSessions.
+func (mr *MockAuthServiceServerMockRecorder) RevokeAllSessions(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAllSessions", reflect.TypeOf((*MockAuthServiceServer)(nil).RevokeAllSessions), ctx, in)
+}
+
+// RevokeSession mocks base method.
+func (m *MockAuthServiceServer) RevokeSession(ctx context.Context, in *RevokeSessionRequest) (*RevokeSessionResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, in)
+	ret0, _ := ret[0].(*RevokeSessionResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockAuthServiceServerMockRecorder) RevokeSession(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockAuthServiceServer)(nil).RevokeSession), ctx, in)
+}
+
 // Signup mocks base method.
 func (m *MockAuthServiceServer) Signup(ctx context.Context, in *SignupRequest) (*SignupResponse, error) {
 	m.ctrl.T.Helper()
@@ -217,6 +697,21 @@ func (mr *MockAuthServiceServerMockRecorder) Signup(ctx, in interface{}) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Signup", reflect.TypeOf((*MockAuthServiceServer)(nil).Signup), ctx, in)
 }
 
+// UpdateAccount mocks base method.
+func (m *MockAuthServiceServer) UpdateAccount(ctx context.Context, in *UpdateAccountRequest) (*UpdateAccountResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAccount", ctx, in)
+	ret0, _ := ret[0].(*UpdateAccountResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateAccount indicates an expected call of UpdateAccount.
+func (mr *MockAuthServiceServerMockRecorder) UpdateAccount(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAccount", reflect.TypeOf((*MockAuthServiceServer)(nil).UpdateAccount), ctx, in)
+}
+
 // Validate mocks base method.
 func (m *MockAuthServiceServer) Validate(ctx context.Context, in *ValidateRequest) (*ValidateResponse, error) {
 	m.ctrl.T.Helper()
@@ -231,3 +726,324 @@ func (mr *MockAuthServiceServerMockRecorder) Validate(ctx, in interface{}) *gomo
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Validate", reflect.TypeOf((*MockAuthServiceServer)(nil).Validate), ctx, in)
 }
+
+// VerifyEmail mocks base method.
+func (m *MockAuthServiceServer) VerifyEmail(ctx context.Context, in *VerifyEmailRequest) (*VerifyEmailResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", ctx, in)
+	ret0, _ := ret[0].(*VerifyEmailResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockAuthServiceServerMockRecorder) VerifyEmail(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockAuthServiceServer)(nil).VerifyEmail), ctx, in)
+}
+
+// VerifyMFA mocks base method.
+func (m *MockAuthServiceServer) VerifyMFA(ctx context.Context, in *VerifyMFARequest) (*LoginResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyMFA", ctx, in)
+	ret0, _ := ret[0].(*LoginResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifyMFA indicates an expected call of VerifyMFA.
+func (mr *MockAuthServiceServerMockRecorder) VerifyMFA(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyMFA", reflect.TypeOf((*MockAuthServiceServer)(nil).VerifyMFA), ctx, in)
+}
+
+// MockAdminServiceClient is a mock of AdminServiceClient interface.
+type MockAdminServiceClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminServiceClientMockRecorder
+}
+
+// MockAdminServiceClientMockRecorder is the mock recorder for MockAdminServiceClient.
+type MockAdminServiceClientMockRecorder struct {
+	mock *MockAdminServiceClient
+}
+
+// NewMockAdminServiceClient creates a new mock instance.
+func NewMockAdminServiceClient(ctrl *gomock.Controller) *MockAdminServiceClient {
+	mock := &MockAdminServiceClient{ctrl: ctrl}
+	mock.recorder = &MockAdminServiceClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminServiceClient) EXPECT() *MockAdminServiceClientMockRecorder {
+	return m.recorder
+}
+
+// DeactivateUser mocks base method.
+func (m *MockAdminServiceClient) DeactivateUser(ctx context.Context, in *DeactivateUserRequest, opts ...grpc.CallOption) (*DeactivateUserResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeactivateUser", varargs...)
+	ret0, _ := ret[0].(*DeactivateUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeactivateUser indicates an expected call of DeactivateUser.
+func (mr *MockAdminServiceClientMockRecorder) DeactivateUser(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateUser", reflect.TypeOf((*MockAdminServiceClient)(nil).DeactivateUser), varargs...)
+}
+
+// GetUser mocks base method.
+func (m *MockAdminServiceClient) GetUser(ctx context.Context, in *GetUserRequest, opts ...grpc.CallOption) (*GetUserResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetUser", varargs...)
+	ret0, _ := ret[0].(*GetUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockAdminServiceClientMockRecorder) GetUser(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockAdminServiceClient)(nil).GetUser), varargs...)
+}
+
+// ListUsers mocks base method.
+func (m *MockAdminServiceClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListUsers", varargs...)
+	ret0, _ := ret[0].(*ListUsersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockAdminServiceClientMockRecorder) ListUsers(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockAdminServiceClient)(nil).ListUsers), varargs...)
+}
+
+// LogoutUser mocks base method.
+func (m *MockAdminServiceClient) LogoutUser(ctx context.Context, in *LogoutUserRequest, opts ...grpc.CallOption) (*LogoutUserResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "LogoutUser", varargs...)
+	ret0, _ := ret[0].(*LogoutUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LogoutUser indicates an expected call of LogoutUser.
+func (mr *MockAdminServiceClientMockRecorder) LogoutUser(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutUser", reflect.TypeOf((*MockAdminServiceClient)(nil).LogoutUser), varargs...)
+}
+
+// ReactivateUser mocks base method.
+func (m *MockAdminServiceClient) ReactivateUser(ctx context.Context, in *ReactivateUserRequest, opts ...grpc.CallOption) (*ReactivateUserResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ReactivateUser", varargs...)
+	ret0, _ := ret[0].(*ReactivateUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReactivateUser indicates an expected call of ReactivateUser.
+func (mr *MockAdminServiceClientMockRecorder) ReactivateUser(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReactivateUser", reflect.TypeOf((*MockAdminServiceClient)(nil).ReactivateUser), varargs...)
+}
+
+// SetUserRole mocks base method.
+func (m *MockAdminServiceClient) SetUserRole(ctx context.Context, in *SetUserRoleRequest, opts ...grpc.CallOption) (*SetUserRoleResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "SetUserRole", varargs...)
+	ret0, _ := ret[0].(*SetUserRoleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserRole indicates an expected call of SetUserRole.
+func (mr *MockAdminServiceClientMockRecorder) SetUserRole(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserRole", reflect.TypeOf((*MockAdminServiceClient)(nil).SetUserRole), varargs...)
+}
+
+// UnlockAccount mocks base method.
+func (m *MockAdminServiceClient) UnlockAccount(ctx context.Context, in *UnlockAccountRequest, opts ...grpc.CallOption) (*UnlockAccountResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UnlockAccount", varargs...)
+	ret0, _ := ret[0].(*UnlockAccountResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnlockAccount indicates an expected call of UnlockAccount.
+func (mr *MockAdminServiceClientMockRecorder) UnlockAccount(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockAccount", reflect.TypeOf((*MockAdminServiceClient)(nil).UnlockAccount), varargs...)
+}
+
+// MockAdminServiceServer is a mock of AdminServiceServer interface.
+type MockAdminServiceServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminServiceServerMockRecorder
+}
+
+// MockAdminServiceServerMockRecorder is the mock recorder for MockAdminServiceServer.
+type MockAdminServiceServerMockRecorder struct {
+	mock *MockAdminServiceServer
+}
+
+// NewMockAdminServiceServer creates a new mock instance.
+func NewMockAdminServiceServer(ctrl *gomock.Controller) *MockAdminServiceServer {
+	mock := &MockAdminServiceServer{ctrl: ctrl}
+	mock.recorder = &MockAdminServiceServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminServiceServer) EXPECT() *MockAdminServiceServerMockRecorder {
+	return m.recorder
+}
+
+// DeactivateUser mocks base method.
+func (m *MockAdminServiceServer) DeactivateUser(ctx context.Context, in *DeactivateUserRequest) (*DeactivateUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateUser", ctx, in)
+	ret0, _ := ret[0].(*DeactivateUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeactivateUser indicates an expected call of DeactivateUser.
+func (mr *MockAdminServiceServerMockRecorder) DeactivateUser(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateUser", reflect.TypeOf((*MockAdminServiceServer)(nil).DeactivateUser), ctx, in)
+}
+
+// GetUser mocks base method.
+func (m *MockAdminServiceServer) GetUser(ctx context.Context, in *GetUserRequest) (*GetUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, in)
+	ret0, _ := ret[0].(*GetUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockAdminServiceServerMockRecorder) GetUser(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockAdminServiceServer)(nil).GetUser), ctx, in)
+}
+
+// ListUsers mocks base method.
+func (m *MockAdminServiceServer) ListUsers(ctx context.Context, in *ListUsersRequest) (*ListUsersResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", ctx, in)
+	ret0, _ := ret[0].(*ListUsersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockAdminServiceServerMockRecorder) ListUsers(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockAdminServiceServer)(nil).ListUsers), ctx, in)
+}
+
+// LogoutUser mocks base method.
+func (m *MockAdminServiceServer) LogoutUser(ctx context.Context, in *LogoutUserRequest) (*LogoutUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogoutUser", ctx, in)
+	ret0, _ := ret[0].(*LogoutUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LogoutUser indicates an expected call of LogoutUser.
+func (mr *MockAdminServiceServerMockRecorder) LogoutUser(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogoutUser", reflect.TypeOf((*MockAdminServiceServer)(nil).LogoutUser), ctx, in)
+}
+
+// ReactivateUser mocks base method.
+func (m *MockAdminServiceServer) ReactivateUser(ctx context.Context, in *ReactivateUserRequest) (*ReactivateUserResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReactivateUser", ctx, in)
+	ret0, _ := ret[0].(*ReactivateUserResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReactivateUser indicates an expected call of ReactivateUser.
+func (mr *MockAdminServiceServerMockRecorder) ReactivateUser(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReactivateUser", reflect.TypeOf((*MockAdminServiceServer)(nil).ReactivateUser), ctx, in)
+}
+
+// SetUserRole mocks base method.
+func (m *MockAdminServiceServer) SetUserRole(ctx context.Context, in *SetUserRoleRequest) (*SetUserRoleResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetUserRole", ctx, in)
+	ret0, _ := ret[0].(*SetUserRoleResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetUserRole indicates an expected call of SetUserRole.
+func (mr *MockAdminServiceServerMockRecorder) SetUserRole(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetUserRole", reflect.TypeOf((*MockAdminServiceServer)(nil).SetUserRole), ctx, in)
+}
+
+// UnlockAccount mocks base method.
+func (m *MockAdminServiceServer) UnlockAccount(ctx context.Context, in *UnlockAccountRequest) (*UnlockAccountResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockAccount", ctx, in)
+	ret0, _ := ret[0].(*UnlockAccountResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnlockAccount indicates an expected call of UnlockAccount.
+func (mr *MockAdminServiceServerMockRecorder) UnlockAccount(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockAccount", reflect.TypeOf((*MockAdminServiceServer)(nil).UnlockAccount), ctx, in)
+}
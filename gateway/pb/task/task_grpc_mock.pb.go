@@ -34,6 +34,46 @@ func (m *MockTaskServiceClient) EXPECT() *MockTaskServiceClientMockRecorder {
 	return m.recorder
 }
 
+// CloneTask mocks base method.
+func (m *MockTaskServiceClient) CloneTask(ctx context.Context, in *CloneTaskRequest, opts ...grpc.CallOption) (*CloneTaskResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CloneTask", varargs...)
+	ret0, _ := ret[0].(*CloneTaskResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloneTask indicates an expected call of CloneTask.
+func (mr *MockTaskServiceClientMockRecorder) CloneTask(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloneTask", reflect.TypeOf((*MockTaskServiceClient)(nil).CloneTask), varargs...)
+}
+
+// CreateCustomField mocks base method.
+func (m *MockTaskServiceClient) CreateCustomField(ctx context.Context, in *CreateCustomFieldRequest, opts ...grpc.CallOption) (*CreateCustomFieldResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateCustomField", varargs...)
+	ret0, _ := ret[0].(*CreateCustomFieldResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCustomField indicates an expected call of CreateCustomField.
+func (mr *MockTaskServiceClientMockRecorder) CreateCustomField(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomField", reflect.TypeOf((*MockTaskServiceClient)(nil).CreateCustomField), varargs...)
+}
+
 // CreateTask mocks base method.
 func (m *MockTaskServiceClient) CreateTask(ctx context.Context, in *CreateTaskRequest, opts ...grpc.CallOption) (*CreateTaskResponse, error) {
 	m.ctrl.T.Helper()
@@ -54,6 +94,106 @@ func (mr *MockTaskServiceClientMockRecorder) CreateTask(ctx, in interface{}, opt
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockTaskServiceClient)(nil).CreateTask), varargs...)
 }
 
+// CreateTemplate mocks base method.
+func (m *MockTaskServiceClient) CreateTemplate(ctx context.Context, in *CreateTemplateRequest, opts ...grpc.CallOption) (*CreateTemplateResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateTemplate", varargs...)
+	ret0, _ := ret[0].(*CreateTemplateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTemplate indicates an expected call of CreateTemplate.
+func (mr *MockTaskServiceClientMockRecorder) CreateTemplate(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTemplate", reflect.TypeOf((*MockTaskServiceClient)(nil).CreateTemplate), varargs...)
+}
+
+// CreateTimeEntry mocks base method.
+func (m *MockTaskServiceClient) CreateTimeEntry(ctx context.Context, in *CreateTimeEntryRequest, opts ...grpc.CallOption) (*CreateTimeEntryResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateTimeEntry", varargs...)
+	ret0, _ := ret[0].(*CreateTimeEntryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTimeEntry indicates an expected call of CreateTimeEntry.
+func (mr *MockTaskServiceClientMockRecorder) CreateTimeEntry(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTimeEntry", reflect.TypeOf((*MockTaskServiceClient)(nil).CreateTimeEntry), varargs...)
+}
+
+// CreateView mocks base method.
+func (m *MockTaskServiceClient) CreateView(ctx context.Context, in *CreateViewRequest, opts ...grpc.CallOption) (*CreateViewResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateView", varargs...)
+	ret0, _ := ret[0].(*CreateViewResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateView indicates an expected call of CreateView.
+func (mr *MockTaskServiceClientMockRecorder) CreateView(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateView", reflect.TypeOf((*MockTaskServiceClient)(nil).CreateView), varargs...)
+}
+
+// DeleteCustomField mocks base method.
+func (m *MockTaskServiceClient) DeleteCustomField(ctx context.Context, in *DeleteCustomFieldRequest, opts ...grpc.CallOption) (*DeleteCustomFieldResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteCustomField", varargs...)
+	ret0, _ := ret[0].(*DeleteCustomFieldResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCustomField indicates an expected call of DeleteCustomField.
+func (mr *MockTaskServiceClientMockRecorder) DeleteCustomField(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCustomField", reflect.TypeOf((*MockTaskServiceClient)(nil).DeleteCustomField), varargs...)
+}
+
+// DeleteRetentionPolicy mocks base method.
+func (m *MockTaskServiceClient) DeleteRetentionPolicy(ctx context.Context, in *DeleteRetentionPolicyRequest, opts ...grpc.CallOption) (*DeleteRetentionPolicyResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteRetentionPolicy", varargs...)
+	ret0, _ := ret[0].(*DeleteRetentionPolicyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRetentionPolicy indicates an expected call of DeleteRetentionPolicy.
+func (mr *MockTaskServiceClientMockRecorder) DeleteRetentionPolicy(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRetentionPolicy", reflect.TypeOf((*MockTaskServiceClient)(nil).DeleteRetentionPolicy), varargs...)
+}
+
 // DeleteTask mocks base method.
 func (m *MockTaskServiceClient) DeleteTask(ctx context.Context, in *DeleteTaskRequest, opts ...grpc.CallOption) (*DeleteTaskResponse, error) {
 	m.ctrl.T.Helper()
@@ -74,6 +214,106 @@ func (mr *MockTaskServiceClientMockRecorder) DeleteTask(ctx, in interface{}, opt
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTaskServiceClient)(nil).DeleteTask), varargs...)
 }
 
+// DeleteTemplate mocks base method.
+func (m *MockTaskServiceClient) DeleteTemplate(ctx context.Context, in *DeleteTemplateRequest, opts ...grpc.CallOption) (*DeleteTemplateResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteTemplate", varargs...)
+	ret0, _ := ret[0].(*DeleteTemplateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTemplate indicates an expected call of DeleteTemplate.
+func (mr *MockTaskServiceClientMockRecorder) DeleteTemplate(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplate", reflect.TypeOf((*MockTaskServiceClient)(nil).DeleteTemplate), varargs...)
+}
+
+// DeleteTimeEntry mocks base method.
+func (m *MockTaskServiceClient) DeleteTimeEntry(ctx context.Context, in *DeleteTimeEntryRequest, opts ...grpc.CallOption) (*DeleteTimeEntryResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteTimeEntry", varargs...)
+	ret0, _ := ret[0].(*DeleteTimeEntryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTimeEntry indicates an expected call of DeleteTimeEntry.
+func (mr *MockTaskServiceClientMockRecorder) DeleteTimeEntry(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTimeEntry", reflect.TypeOf((*MockTaskServiceClient)(nil).DeleteTimeEntry), varargs...)
+}
+
+// DeleteView mocks base method.
+func (m *MockTaskServiceClient) DeleteView(ctx context.Context, in *DeleteViewRequest, opts ...grpc.CallOption) (*DeleteViewResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteView", varargs...)
+	ret0, _ := ret[0].(*DeleteViewResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteView indicates an expected call of DeleteView.
+func (mr *MockTaskServiceClientMockRecorder) DeleteView(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteView", reflect.TypeOf((*MockTaskServiceClient)(nil).DeleteView), varargs...)
+}
+
+// GetBoard mocks base method.
+func (m *MockTaskServiceClient) GetBoard(ctx context.Context, in *GetBoardRequest, opts ...grpc.CallOption) (*GetBoardResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetBoard", varargs...)
+	ret0, _ := ret[0].(*GetBoardResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoard indicates an expected call of GetBoard.
+func (mr *MockTaskServiceClientMockRecorder) GetBoard(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoard", reflect.TypeOf((*MockTaskServiceClient)(nil).GetBoard), varargs...)
+}
+
+// GetRetentionPolicy mocks base method.
+func (m *MockTaskServiceClient) GetRetentionPolicy(ctx context.Context, in *GetRetentionPolicyRequest, opts ...grpc.CallOption) (*GetRetentionPolicyResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetRetentionPolicy", varargs...)
+	ret0, _ := ret[0].(*GetRetentionPolicyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRetentionPolicy indicates an expected call of GetRetentionPolicy.
+func (mr *MockTaskServiceClientMockRecorder) GetRetentionPolicy(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRetentionPolicy", reflect.TypeOf((*MockTaskServiceClient)(nil).GetRetentionPolicy), varargs...)
+}
+
 // GetTask mocks base method.
 func (m *MockTaskServiceClient) GetTask(ctx context.Context, in *GetTaskRequest, opts ...grpc.CallOption) (*GetTaskResponse, error) {
 	m.ctrl.T.Helper()
@@ -94,140 +334,1160 @@ func (mr *MockTaskServiceClientMockRecorder) GetTask(ctx, in interface{}, opts .
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockTaskServiceClient)(nil).GetTask), varargs...)
 }
 
-// ListTasks mocks base method.
-func (m *MockTaskServiceClient) ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (*ListTasksResponse, error) {
+// GetTaskStats mocks base method.
+func (m *MockTaskServiceClient) GetTaskStats(ctx context.Context, in *GetTaskStatsRequest, opts ...grpc.CallOption) (*GetTaskStatsResponse, error) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{ctx, in}
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "ListTasks", varargs...)
-	ret0, _ := ret[0].(*ListTasksResponse)
+	ret := m.ctrl.Call(m, "GetTaskStats", varargs...)
+	ret0, _ := ret[0].(*GetTaskStatsResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListTasks indicates an expected call of ListTasks.
-func (mr *MockTaskServiceClientMockRecorder) ListTasks(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+// GetTaskStats indicates an expected call of GetTaskStats.
+func (mr *MockTaskServiceClientMockRecorder) GetTaskStats(ctx, in interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	varargs := append([]interface{}{ctx, in}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasks", reflect.TypeOf((*MockTaskServiceClient)(nil).ListTasks), varargs...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskStats", reflect.TypeOf((*MockTaskServiceClient)(nil).GetTaskStats), varargs...)
 }
 
-// UpdateTask mocks base method.
-func (m *MockTaskServiceClient) UpdateTask(ctx context.Context, in *UpdateTaskRequest, opts ...grpc.CallOption) (*UpdateTaskResponse, error) {
+// GetTemplate mocks base method.
+func (m *MockTaskServiceClient) GetTemplate(ctx context.Context, in *GetTemplateRequest, opts ...grpc.CallOption) (*GetTemplateResponse, error) {
 	m.ctrl.T.Helper()
 	varargs := []interface{}{ctx, in}
 	for _, a := range opts {
 		varargs = append(varargs, a)
 	}
-	ret := m.ctrl.Call(m, "UpdateTask", varargs...)
-	ret0, _ := ret[0].(*UpdateTaskResponse)
+	ret := m.ctrl.Call(m, "GetTemplate", varargs...)
+	ret0, _ := ret[0].(*GetTemplateResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateTask indicates an expected call of UpdateTask.
-func (mr *MockTaskServiceClientMockRecorder) UpdateTask(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+// GetTemplate indicates an expected call of GetTemplate.
+func (mr *MockTaskServiceClientMockRecorder) GetTemplate(ctx, in interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	varargs := append([]interface{}{ctx, in}, opts...)
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTaskServiceClient)(nil).UpdateTask), varargs...)
-}
-
-// MockTaskServiceServer is a mock of TaskServiceServer interface.
-type MockTaskServiceServer struct {
-	ctrl     *gomock.Controller
-	recorder *MockTaskServiceServerMockRecorder
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplate", reflect.TypeOf((*MockTaskServiceClient)(nil).GetTemplate), varargs...)
 }
 
-// MockTaskServiceServerMockRecorder is the mock recorder for MockTaskServiceServer.
-type MockTaskServiceServerMockRecorder struct {
-	mock *MockTaskServiceServer
-}
-
-// NewMockTaskServiceServer creates a new mock instance.
-func NewMockTaskServiceServer(ctrl *gomock.Controller) *MockTaskServiceServer {
-	mock := &MockTaskServiceServer{ctrl: ctrl}
-	mock.recorder = &MockTaskServiceServerMockRecorder{mock}
-	return mock
+// GetTimeReport mocks base method.
+func (m *MockTaskServiceClient) GetTimeReport(ctx context.Context, in *GetTimeReportRequest, opts ...grpc.CallOption) (*GetTimeReportResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetTimeReport", varargs...)
+	ret0, _ := ret[0].(*GetTimeReportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockTaskServiceServer) EXPECT() *MockTaskServiceServerMockRecorder {
-	return m.recorder
+// GetTimeReport indicates an expected call of GetTimeReport.
+func (mr *MockTaskServiceClientMockRecorder) GetTimeReport(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimeReport", reflect.TypeOf((*MockTaskServiceClient)(nil).GetTimeReport), varargs...)
 }
 
-// CreateTask mocks base method.
-func (m *MockTaskServiceServer) CreateTask(ctx context.Context, in *CreateTaskRequest) (*CreateTaskResponse, error) {
+// GetView mocks base method.
+func (m *MockTaskServiceClient) GetView(ctx context.Context, in *GetViewRequest, opts ...grpc.CallOption) (*GetViewResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateTask", ctx, in)
-	ret0, _ := ret[0].(*CreateTaskResponse)
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetView", varargs...)
+	ret0, _ := ret[0].(*GetViewResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateTask indicates an expected call of CreateTask.
-func (mr *MockTaskServiceServerMockRecorder) CreateTask(ctx, in interface{}) *gomock.Call {
+// GetView indicates an expected call of GetView.
+func (mr *MockTaskServiceClientMockRecorder) GetView(ctx, in interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockTaskServiceServer)(nil).CreateTask), ctx, in)
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetView", reflect.TypeOf((*MockTaskServiceClient)(nil).GetView), varargs...)
 }
 
-// DeleteTask mocks base method.
-func (m *MockTaskServiceServer) DeleteTask(ctx context.Context, in *DeleteTaskRequest) (*DeleteTaskResponse, error) {
+// InstantiateTemplate mocks base method.
+func (m *MockTaskServiceClient) InstantiateTemplate(ctx context.Context, in *InstantiateTemplateRequest, opts ...grpc.CallOption) (*InstantiateTemplateResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteTask", ctx, in)
-	ret0, _ := ret[0].(*DeleteTaskResponse)
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "InstantiateTemplate", varargs...)
+	ret0, _ := ret[0].(*InstantiateTemplateResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// DeleteTask indicates an expected call of DeleteTask.
-func (mr *MockTaskServiceServerMockRecorder) DeleteTask(ctx, in interface{}) *gomock.Call {
+// InstantiateTemplate indicates an expected call of InstantiateTemplate.
+func (mr *MockTaskServiceClientMockRecorder) InstantiateTemplate(ctx, in interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTaskServiceServer)(nil).DeleteTask), ctx, in)
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstantiateTemplate", reflect.TypeOf((*MockTaskServiceClient)(nil).InstantiateTemplate), varargs...)
 }
 
-// GetTask mocks base method.
-func (m *MockTaskServiceServer) GetTask(ctx context.Context, in *GetTaskRequest) (*GetTaskResponse, error) {
+// ListCustomFields mocks base method.
+func (m *MockTaskServiceClient) ListCustomFields(ctx context.Context, in *ListCustomFieldsRequest, opts ...grpc.CallOption) (*ListCustomFieldsResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetTask", ctx, in)
-	ret0, _ := ret[0].(*GetTaskResponse)
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListCustomFields", varargs...)
+	ret0, _ := ret[0].(*ListCustomFieldsResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetTask indicates an expected call of GetTask.
-func (mr *MockTaskServiceServerMockRecorder) GetTask(ctx, in interface{}) *gomock.Call {
+// ListCustomFields indicates an expected call of ListCustomFields.
+func (mr *MockTaskServiceClientMockRecorder) ListCustomFields(ctx, in interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockTaskServiceServer)(nil).GetTask), ctx, in)
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCustomFields", reflect.TypeOf((*MockTaskServiceClient)(nil).ListCustomFields), varargs...)
 }
 
-// ListTasks mocks base method.
-func (m *MockTaskServiceServer) ListTasks(ctx context.Context, in *ListTasksRequest) (*ListTasksResponse, error) {
+// ListNextTasks mocks base method.
+func (m *MockTaskServiceClient) ListNextTasks(ctx context.Context, in *ListNextTasksRequest, opts ...grpc.CallOption) (*ListNextTasksResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ListTasks", ctx, in)
-	ret0, _ := ret[0].(*ListTasksResponse)
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListNextTasks", varargs...)
+	ret0, _ := ret[0].(*ListNextTasksResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ListTasks indicates an expected call of ListTasks.
-func (mr *MockTaskServiceServerMockRecorder) ListTasks(ctx, in interface{}) *gomock.Call {
+// ListNextTasks indicates an expected call of ListNextTasks.
+func (mr *MockTaskServiceClientMockRecorder) ListNextTasks(ctx, in interface{}, opts ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasks", reflect.TypeOf((*MockTaskServiceServer)(nil).ListTasks), ctx, in)
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNextTasks", reflect.TypeOf((*MockTaskServiceClient)(nil).ListNextTasks), varargs...)
 }
 
-// UpdateTask mocks base method.
-func (m *MockTaskServiceServer) UpdateTask(ctx context.Context, in *UpdateTaskRequest) (*UpdateTaskResponse, error) {
+// ListTaskWatchers mocks base method.
+func (m *MockTaskServiceClient) ListTaskWatchers(ctx context.Context, in *ListTaskWatchersRequest, opts ...grpc.CallOption) (*ListTaskWatchersResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTask", ctx, in)
-	ret0, _ := ret[0].(*UpdateTaskResponse)
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListTaskWatchers", varargs...)
+	ret0, _ := ret[0].(*ListTaskWatchersResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// UpdateTask indicates an expected call of UpdateTask.
-func (mr *MockTaskServiceServerMockRecorder) UpdateTask(ctx, in interface{}) *gomock.Call {
+// ListTaskWatchers indicates an expected call of ListTaskWatchers.
+func (mr *MockTaskServiceClientMockRecorder) ListTaskWatchers(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskWatchers", reflect.TypeOf((*MockTaskServiceClient)(nil).ListTaskWatchers), varargs...)
+}
+
+// ListTasks mocks base method.
+func (m *MockTaskServiceClient) ListTasks(ctx context.Context, in *ListTasksRequest, opts ...grpc.CallOption) (*ListTasksResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListTasks", varargs...)
+	ret0, _ := ret[0].(*ListTasksResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTasks indicates an expected call of ListTasks.
+func (mr *MockTaskServiceClientMockRecorder) ListTasks(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasks", reflect.TypeOf((*MockTaskServiceClient)(nil).ListTasks), varargs...)
+}
+
+// ListTemplates mocks base method.
+func (m *MockTaskServiceClient) ListTemplates(ctx context.Context, in *ListTemplatesRequest, opts ...grpc.CallOption) (*ListTemplatesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListTemplates", varargs...)
+	ret0, _ := ret[0].(*ListTemplatesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTemplates indicates an expected call of ListTemplates.
+func (mr *MockTaskServiceClientMockRecorder) ListTemplates(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTemplates", reflect.TypeOf((*MockTaskServiceClient)(nil).ListTemplates), varargs...)
+}
+
+// ListTimeEntries mocks base method.
+func (m *MockTaskServiceClient) ListTimeEntries(ctx context.Context, in *ListTimeEntriesRequest, opts ...grpc.CallOption) (*ListTimeEntriesResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListTimeEntries", varargs...)
+	ret0, _ := ret[0].(*ListTimeEntriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTimeEntries indicates an expected call of ListTimeEntries.
+func (mr *MockTaskServiceClientMockRecorder) ListTimeEntries(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTimeEntries", reflect.TypeOf((*MockTaskServiceClient)(nil).ListTimeEntries), varargs...)
+}
+
+// ListTimeEntryRevisions mocks base method.
+func (m *MockTaskServiceClient) ListTimeEntryRevisions(ctx context.Context, in *ListTimeEntryRevisionsRequest, opts ...grpc.CallOption) (*ListTimeEntryRevisionsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListTimeEntryRevisions", varargs...)
+	ret0, _ := ret[0].(*ListTimeEntryRevisionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTimeEntryRevisions indicates an expected call of ListTimeEntryRevisions.
+func (mr *MockTaskServiceClientMockRecorder) ListTimeEntryRevisions(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTimeEntryRevisions", reflect.TypeOf((*MockTaskServiceClient)(nil).ListTimeEntryRevisions), varargs...)
+}
+
+// ListViewTasks mocks base method.
+func (m *MockTaskServiceClient) ListViewTasks(ctx context.Context, in *ListViewTasksRequest, opts ...grpc.CallOption) (*ListViewTasksResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListViewTasks", varargs...)
+	ret0, _ := ret[0].(*ListViewTasksResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListViewTasks indicates an expected call of ListViewTasks.
+func (mr *MockTaskServiceClientMockRecorder) ListViewTasks(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListViewTasks", reflect.TypeOf((*MockTaskServiceClient)(nil).ListViewTasks), varargs...)
+}
+
+// ListViews mocks base method.
+func (m *MockTaskServiceClient) ListViews(ctx context.Context, in *ListViewsRequest, opts ...grpc.CallOption) (*ListViewsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListViews", varargs...)
+	ret0, _ := ret[0].(*ListViewsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListViews indicates an expected call of ListViews.
+func (mr *MockTaskServiceClientMockRecorder) ListViews(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListViews", reflect.TypeOf((*MockTaskServiceClient)(nil).ListViews), varargs...)
+}
+
+// MoveTask mocks base method.
+func (m *MockTaskServiceClient) MoveTask(ctx context.Context, in *MoveTaskRequest, opts ...grpc.CallOption) (*MoveTaskResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MoveTask", varargs...)
+	ret0, _ := ret[0].(*MoveTaskResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveTask indicates an expected call of MoveTask.
+func (mr *MockTaskServiceClientMockRecorder) MoveTask(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTask", reflect.TypeOf((*MockTaskServiceClient)(nil).MoveTask), varargs...)
+}
+
+// StartTimer mocks base method.
+func (m *MockTaskServiceClient) StartTimer(ctx context.Context, in *StartTimerRequest, opts ...grpc.CallOption) (*StartTimerResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StartTimer", varargs...)
+	ret0, _ := ret[0].(*StartTimerResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartTimer indicates an expected call of StartTimer.
+func (mr *MockTaskServiceClientMockRecorder) StartTimer(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartTimer", reflect.TypeOf((*MockTaskServiceClient)(nil).StartTimer), varargs...)
+}
+
+// StopTimer mocks base method.
+func (m *MockTaskServiceClient) StopTimer(ctx context.Context, in *StopTimerRequest, opts ...grpc.CallOption) (*StopTimerResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "StopTimer", varargs...)
+	ret0, _ := ret[0].(*StopTimerResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopTimer indicates an expected call of StopTimer.
+func (mr *MockTaskServiceClientMockRecorder) StopTimer(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopTimer", reflect.TypeOf((*MockTaskServiceClient)(nil).StopTimer), varargs...)
+}
+
+// UnarchiveTask mocks base method.
+func (m *MockTaskServiceClient) UnarchiveTask(ctx context.Context, in *UnarchiveTaskRequest, opts ...grpc.CallOption) (*UnarchiveTaskResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UnarchiveTask", varargs...)
+	ret0, _ := ret[0].(*UnarchiveTaskResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnarchiveTask indicates an expected call of UnarchiveTask.
+func (mr *MockTaskServiceClientMockRecorder) UnarchiveTask(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnarchiveTask", reflect.TypeOf((*MockTaskServiceClient)(nil).UnarchiveTask), varargs...)
+}
+
+// UnwatchTask mocks base method.
+func (m *MockTaskServiceClient) UnwatchTask(ctx context.Context, in *UnwatchTaskRequest, opts ...grpc.CallOption) (*UnwatchTaskResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UnwatchTask", varargs...)
+	ret0, _ := ret[0].(*UnwatchTaskResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnwatchTask indicates an expected call of UnwatchTask.
+func (mr *MockTaskServiceClientMockRecorder) UnwatchTask(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnwatchTask", reflect.TypeOf((*MockTaskServiceClient)(nil).UnwatchTask), varargs...)
+}
+
+// UpdateCustomField mocks base method.
+func (m *MockTaskServiceClient) UpdateCustomField(ctx context.Context, in *UpdateCustomFieldRequest, opts ...grpc.CallOption) (*UpdateCustomFieldResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateCustomField", varargs...)
+	ret0, _ := ret[0].(*UpdateCustomFieldResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCustomField indicates an expected call of UpdateCustomField.
+func (mr *MockTaskServiceClientMockRecorder) UpdateCustomField(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomField", reflect.TypeOf((*MockTaskServiceClient)(nil).UpdateCustomField), varargs...)
+}
+
+// UpdateRetentionPolicy mocks base method.
+func (m *MockTaskServiceClient) UpdateRetentionPolicy(ctx context.Context, in *UpdateRetentionPolicyRequest, opts ...grpc.CallOption) (*UpdateRetentionPolicyResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateRetentionPolicy", varargs...)
+	ret0, _ := ret[0].(*UpdateRetentionPolicyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRetentionPolicy indicates an expected call of UpdateRetentionPolicy.
+func (mr *MockTaskServiceClientMockRecorder) UpdateRetentionPolicy(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRetentionPolicy", reflect.TypeOf((*MockTaskServiceClient)(nil).UpdateRetentionPolicy), varargs...)
+}
+
+// UpdateTask mocks base method.
+func (m *MockTaskServiceClient) UpdateTask(ctx context.Context, in *UpdateTaskRequest, opts ...grpc.CallOption) (*UpdateTaskResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateTask", varargs...)
+	ret0, _ := ret[0].(*UpdateTaskResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTask indicates an expected call of UpdateTask.
+func (mr *MockTaskServiceClientMockRecorder) UpdateTask(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTaskServiceClient)(nil).UpdateTask), varargs...)
+}
+
+// UpdateTemplate mocks base method.
+func (m *MockTaskServiceClient) UpdateTemplate(ctx context.Context, in *UpdateTemplateRequest, opts ...grpc.CallOption) (*UpdateTemplateResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateTemplate", varargs...)
+	ret0, _ := ret[0].(*UpdateTemplateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTemplate indicates an expected call of UpdateTemplate.
+func (mr *MockTaskServiceClientMockRecorder) UpdateTemplate(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTemplate", reflect.TypeOf((*MockTaskServiceClient)(nil).UpdateTemplate), varargs...)
+}
+
+// UpdateTimeEntry mocks base method.
+func (m *MockTaskServiceClient) UpdateTimeEntry(ctx context.Context, in *UpdateTimeEntryRequest, opts ...grpc.CallOption) (*UpdateTimeEntryResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateTimeEntry", varargs...)
+	ret0, _ := ret[0].(*UpdateTimeEntryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTimeEntry indicates an expected call of UpdateTimeEntry.
+func (mr *MockTaskServiceClientMockRecorder) UpdateTimeEntry(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTimeEntry", reflect.TypeOf((*MockTaskServiceClient)(nil).UpdateTimeEntry), varargs...)
+}
+
+// UpdateView mocks base method.
+func (m *MockTaskServiceClient) UpdateView(ctx context.Context, in *UpdateViewRequest, opts ...grpc.CallOption) (*UpdateViewResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "UpdateView", varargs...)
+	ret0, _ := ret[0].(*UpdateViewResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateView indicates an expected call of UpdateView.
+func (mr *MockTaskServiceClientMockRecorder) UpdateView(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateView", reflect.TypeOf((*MockTaskServiceClient)(nil).UpdateView), varargs...)
+}
+
+// WatchTask mocks base method.
+func (m *MockTaskServiceClient) WatchTask(ctx context.Context, in *WatchTaskRequest, opts ...grpc.CallOption) (*WatchTaskResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, in}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "WatchTask", varargs...)
+	ret0, _ := ret[0].(*WatchTaskResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchTask indicates an expected call of WatchTask.
+func (mr *MockTaskServiceClientMockRecorder) WatchTask(ctx, in interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, in}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchTask", reflect.TypeOf((*MockTaskServiceClient)(nil).WatchTask), varargs...)
+}
+
+// MockTaskServiceServer is a mock of TaskServiceServer interface.
+type MockTaskServiceServer struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskServiceServerMockRecorder
+}
+
+// MockTaskServiceServerMockRecorder is the mock recorder for MockTaskServiceServer.
+type MockTaskServiceServerMockRecorder struct {
+	mock *MockTaskServiceServer
+}
+
+// NewMockTaskServiceServer creates a new mock instance.
+func NewMockTaskServiceServer(ctrl *gomock.Controller) *MockTaskServiceServer {
+	mock := &MockTaskServiceServer{ctrl: ctrl}
+	mock.recorder = &MockTaskServiceServerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskServiceServer) EXPECT() *MockTaskServiceServerMockRecorder {
+	return m.recorder
+}
+
+// CloneTask mocks base method.
+func (m *MockTaskServiceServer) CloneTask(ctx context.Context, in *CloneTaskRequest) (*CloneTaskResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloneTask", ctx, in)
+	ret0, _ := ret[0].(*CloneTaskResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CloneTask indicates an expected call of CloneTask.
+func (mr *MockTaskServiceServerMockRecorder) CloneTask(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloneTask", reflect.TypeOf((*MockTaskServiceServer)(nil).CloneTask), ctx, in)
+}
+
+// CreateCustomField mocks base method.
+func (m *MockTaskServiceServer) CreateCustomField(ctx context.Context, in *CreateCustomFieldRequest) (*CreateCustomFieldResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCustomField", ctx, in)
+	ret0, _ := ret[0].(*CreateCustomFieldResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCustomField indicates an expected call of CreateCustomField.
+func (mr *MockTaskServiceServerMockRecorder) CreateCustomField(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomField", reflect.TypeOf((*MockTaskServiceServer)(nil).CreateCustomField), ctx, in)
+}
+
+// CreateTask mocks base method.
+func (m *MockTaskServiceServer) CreateTask(ctx context.Context, in *CreateTaskRequest) (*CreateTaskResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTask", ctx, in)
+	ret0, _ := ret[0].(*CreateTaskResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTask indicates an expected call of CreateTask.
+func (mr *MockTaskServiceServerMockRecorder) CreateTask(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockTaskServiceServer)(nil).CreateTask), ctx, in)
+}
+
+// CreateTemplate mocks base method.
+func (m *MockTaskServiceServer) CreateTemplate(ctx context.Context, in *CreateTemplateRequest) (*CreateTemplateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTemplate", ctx, in)
+	ret0, _ := ret[0].(*CreateTemplateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTemplate indicates an expected call of CreateTemplate.
+func (mr *MockTaskServiceServerMockRecorder) CreateTemplate(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTemplate", reflect.TypeOf((*MockTaskServiceServer)(nil).CreateTemplate), ctx, in)
+}
+
+// CreateTimeEntry mocks base method.
+func (m *MockTaskServiceServer) CreateTimeEntry(ctx context.Context, in *CreateTimeEntryRequest) (*CreateTimeEntryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTimeEntry", ctx, in)
+	ret0, _ := ret[0].(*CreateTimeEntryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTimeEntry indicates an expected call of CreateTimeEntry.
+func (mr *MockTaskServiceServerMockRecorder) CreateTimeEntry(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTimeEntry", reflect.TypeOf((*MockTaskServiceServer)(nil).CreateTimeEntry), ctx, in)
+}
+
+// CreateView mocks base method.
+func (m *MockTaskServiceServer) CreateView(ctx context.Context, in *CreateViewRequest) (*CreateViewResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateView", ctx, in)
+	ret0, _ := ret[0].(*CreateViewResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateView indicates an expected call of CreateView.
+func (mr *MockTaskServiceServerMockRecorder) CreateView(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateView", reflect.TypeOf((*MockTaskServiceServer)(nil).CreateView), ctx, in)
+}
+
+// DeleteCustomField mocks base method.
+func (m *MockTaskServiceServer) DeleteCustomField(ctx context.Context, in *DeleteCustomFieldRequest) (*DeleteCustomFieldResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCustomField", ctx, in)
+	ret0, _ := ret[0].(*DeleteCustomFieldResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCustomField indicates an expected call of DeleteCustomField.
+func (mr *MockTaskServiceServerMockRecorder) DeleteCustomField(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCustomField", reflect.TypeOf((*MockTaskServiceServer)(nil).DeleteCustomField), ctx, in)
+}
+
+// DeleteRetentionPolicy mocks base method.
+func (m *MockTaskServiceServer) DeleteRetentionPolicy(ctx context.Context, in *DeleteRetentionPolicyRequest) (*DeleteRetentionPolicyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRetentionPolicy", ctx, in)
+	ret0, _ := ret[0].(*DeleteRetentionPolicyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteRetentionPolicy indicates an expected call of DeleteRetentionPolicy.
+func (mr *MockTaskServiceServerMockRecorder) DeleteRetentionPolicy(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRetentionPolicy", reflect.TypeOf((*MockTaskServiceServer)(nil).DeleteRetentionPolicy), ctx, in)
+}
+
+// DeleteTask mocks base method.
+func (m *MockTaskServiceServer) DeleteTask(ctx context.Context, in *DeleteTaskRequest) (*DeleteTaskResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTask", ctx, in)
+	ret0, _ := ret[0].(*DeleteTaskResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTask indicates an expected call of DeleteTask.
+func (mr *MockTaskServiceServerMockRecorder) DeleteTask(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockTaskServiceServer)(nil).DeleteTask), ctx, in)
+}
+
+// DeleteTemplate mocks base method.
+func (m *MockTaskServiceServer) DeleteTemplate(ctx context.Context, in *DeleteTemplateRequest) (*DeleteTemplateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemplate", ctx, in)
+	ret0, _ := ret[0].(*DeleteTemplateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTemplate indicates an expected call of DeleteTemplate.
+func (mr *MockTaskServiceServerMockRecorder) DeleteTemplate(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplate", reflect.TypeOf((*MockTaskServiceServer)(nil).DeleteTemplate), ctx, in)
+}
+
+// DeleteTimeEntry mocks base method.
+func (m *MockTaskServiceServer) DeleteTimeEntry(ctx context.Context, in *DeleteTimeEntryRequest) (*DeleteTimeEntryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTimeEntry", ctx, in)
+	ret0, _ := ret[0].(*DeleteTimeEntryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteTimeEntry indicates an expected call of DeleteTimeEntry.
+func (mr *MockTaskServiceServerMockRecorder) DeleteTimeEntry(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTimeEntry", reflect.TypeOf((*MockTaskServiceServer)(nil).DeleteTimeEntry), ctx, in)
+}
+
+// DeleteView mocks base method.
+func (m *MockTaskServiceServer) DeleteView(ctx context.Context, in *DeleteViewRequest) (*DeleteViewResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteView", ctx, in)
+	ret0, _ := ret[0].(*DeleteViewResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteView indicates an expected call of DeleteView.
+func (mr *MockTaskServiceServerMockRecorder) DeleteView(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteView", reflect.TypeOf((*MockTaskServiceServer)(nil).DeleteView), ctx, in)
+}
+
+// GetBoard mocks base method.
+func (m *MockTaskServiceServer) GetBoard(ctx context.Context, in *GetBoardRequest) (*GetBoardResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBoard", ctx, in)
+	ret0, _ := ret[0].(*GetBoardResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBoard indicates an expected call of GetBoard.
+func (mr *MockTaskServiceServerMockRecorder) GetBoard(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBoard", reflect.TypeOf((*MockTaskServiceServer)(nil).GetBoard), ctx, in)
+}
+
+// GetRetentionPolicy mocks base method.
+func (m *MockTaskServiceServer) GetRetentionPolicy(ctx context.Context, in *GetRetentionPolicyRequest) (*GetRetentionPolicyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRetentionPolicy", ctx, in)
+	ret0, _ := ret[0].(*GetRetentionPolicyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRetentionPolicy indicates an expected call of GetRetentionPolicy.
+func (mr *MockTaskServiceServerMockRecorder) GetRetentionPolicy(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRetentionPolicy", reflect.TypeOf((*MockTaskServiceServer)(nil).GetRetentionPolicy), ctx, in)
+}
+
+// GetTask mocks base method.
+func (m *MockTaskServiceServer) GetTask(ctx context.Context, in *GetTaskRequest) (*GetTaskResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTask", ctx, in)
+	ret0, _ := ret[0].(*GetTaskResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTask indicates an expected call of GetTask.
+func (mr *MockTaskServiceServerMockRecorder) GetTask(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTask", reflect.TypeOf((*MockTaskServiceServer)(nil).GetTask), ctx, in)
+}
+
+// GetTaskStats mocks base method.
+func (m *MockTaskServiceServer) GetTaskStats(ctx context.Context, in *GetTaskStatsRequest) (*GetTaskStatsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskStats", ctx, in)
+	ret0, _ := ret[0].(*GetTaskStatsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskStats indicates an expected call of GetTaskStats.
+func (mr *MockTaskServiceServerMockRecorder) GetTaskStats(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskStats", reflect.TypeOf((*MockTaskServiceServer)(nil).GetTaskStats), ctx, in)
+}
+
+// GetTemplate mocks base method.
+func (m *MockTaskServiceServer) GetTemplate(ctx context.Context, in *GetTemplateRequest) (*GetTemplateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplate", ctx, in)
+	ret0, _ := ret[0].(*GetTemplateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplate indicates an expected call of GetTemplate.
+func (mr *MockTaskServiceServerMockRecorder) GetTemplate(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplate", reflect.TypeOf((*MockTaskServiceServer)(nil).GetTemplate), ctx, in)
+}
+
+// GetTimeReport mocks base method.
+func (m *MockTaskServiceServer) GetTimeReport(ctx context.Context, in *GetTimeReportRequest) (*GetTimeReportResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTimeReport", ctx, in)
+	ret0, _ := ret[0].(*GetTimeReportResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTimeReport indicates an expected call of GetTimeReport.
+func (mr *MockTaskServiceServerMockRecorder) GetTimeReport(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimeReport", reflect.TypeOf((*MockTaskServiceServer)(nil).GetTimeReport), ctx, in)
+}
+
+// GetView mocks base method.
+func (m *MockTaskServiceServer) GetView(ctx context.Context, in *GetViewRequest) (*GetViewResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetView", ctx, in)
+	ret0, _ := ret[0].(*GetViewResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetView indicates an expected call of GetView.
+func (mr *MockTaskServiceServerMockRecorder) GetView(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetView", reflect.TypeOf((*MockTaskServiceServer)(nil).GetView), ctx, in)
+}
+
+// InstantiateTemplate mocks base method.
+func (m *MockTaskServiceServer) InstantiateTemplate(ctx context.Context, in *InstantiateTemplateRequest) (*InstantiateTemplateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InstantiateTemplate", ctx, in)
+	ret0, _ := ret[0].(*InstantiateTemplateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// InstantiateTemplate indicates an expected call of InstantiateTemplate.
+func (mr *MockTaskServiceServerMockRecorder) InstantiateTemplate(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InstantiateTemplate", reflect.TypeOf((*MockTaskServiceServer)(nil).InstantiateTemplate), ctx, in)
+}
+
+// ListCustomFields mocks base method.
+func (m *MockTaskServiceServer) ListCustomFields(ctx context.Context, in *ListCustomFieldsRequest) (*ListCustomFieldsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCustomFields", ctx, in)
+	ret0, _ := ret[0].(*ListCustomFieldsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCustomFields indicates an expected call of ListCustomFields.
+func (mr *MockTaskServiceServerMockRecorder) ListCustomFields(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCustomFields", reflect.TypeOf((*MockTaskServiceServer)(nil).ListCustomFields), ctx, in)
+}
+
+// ListNextTasks mocks base method.
+func (m *MockTaskServiceServer) ListNextTasks(ctx context.Context, in *ListNextTasksRequest) (*ListNextTasksResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNextTasks", ctx, in)
+	ret0, _ := ret[0].(*ListNextTasksResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNextTasks indicates an expected call of ListNextTasks.
+func (mr *MockTaskServiceServerMockRecorder) ListNextTasks(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNextTasks", reflect.TypeOf((*MockTaskServiceServer)(nil).ListNextTasks), ctx, in)
+}
+
+// ListTaskWatchers mocks base method.
+func (m *MockTaskServiceServer) ListTaskWatchers(ctx context.Context, in *ListTaskWatchersRequest) (*ListTaskWatchersResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskWatchers", ctx, in)
+	ret0, _ := ret[0].(*ListTaskWatchersResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskWatchers indicates an expected call of ListTaskWatchers.
+func (mr *MockTaskServiceServerMockRecorder) ListTaskWatchers(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskWatchers", reflect.TypeOf((*MockTaskServiceServer)(nil).ListTaskWatchers), ctx, in)
+}
+
+// ListTasks mocks base method.
+func (m *MockTaskServiceServer) ListTasks(ctx context.Context, in *ListTasksRequest) (*ListTasksResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTasks", ctx, in)
+	ret0, _ := ret[0].(*ListTasksResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTasks indicates an expected call of ListTasks.
+func (mr *MockTaskServiceServerMockRecorder) ListTasks(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasks", reflect.TypeOf((*MockTaskServiceServer)(nil).ListTasks), ctx, in)
+}
+
+// ListTemplates mocks base method.
+func (m *MockTaskServiceServer) ListTemplates(ctx context.Context, in *ListTemplatesRequest) (*ListTemplatesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTemplates", ctx, in)
+	ret0, _ := ret[0].(*ListTemplatesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTemplates indicates an expected call of ListTemplates.
+func (mr *MockTaskServiceServerMockRecorder) ListTemplates(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTemplates", reflect.TypeOf((*MockTaskServiceServer)(nil).ListTemplates), ctx, in)
+}
+
+// ListTimeEntries mocks base method.
+func (m *MockTaskServiceServer) ListTimeEntries(ctx context.Context, in *ListTimeEntriesRequest) (*ListTimeEntriesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTimeEntries", ctx, in)
+	ret0, _ := ret[0].(*ListTimeEntriesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTimeEntries indicates an expected call of ListTimeEntries.
+func (mr *MockTaskServiceServerMockRecorder) ListTimeEntries(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTimeEntries", reflect.TypeOf((*MockTaskServiceServer)(nil).ListTimeEntries), ctx, in)
+}
+
+// ListTimeEntryRevisions mocks base method.
+func (m *MockTaskServiceServer) ListTimeEntryRevisions(ctx context.Context, in *ListTimeEntryRevisionsRequest) (*ListTimeEntryRevisionsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTimeEntryRevisions", ctx, in)
+	ret0, _ := ret[0].(*ListTimeEntryRevisionsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTimeEntryRevisions indicates an expected call of ListTimeEntryRevisions.
+func (mr *MockTaskServiceServerMockRecorder) ListTimeEntryRevisions(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTimeEntryRevisions", reflect.TypeOf((*MockTaskServiceServer)(nil).ListTimeEntryRevisions), ctx, in)
+}
+
+// ListViewTasks mocks base method.
+func (m *MockTaskServiceServer) ListViewTasks(ctx context.Context, in *ListViewTasksRequest) (*ListViewTasksResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListViewTasks", ctx, in)
+	ret0, _ := ret[0].(*ListViewTasksResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListViewTasks indicates an expected call of ListViewTasks.
+func (mr *MockTaskServiceServerMockRecorder) ListViewTasks(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListViewTasks", reflect.TypeOf((*MockTaskServiceServer)(nil).ListViewTasks), ctx, in)
+}
+
+// ListViews mocks base method.
+func (m *MockTaskServiceServer) ListViews(ctx context.Context, in *ListViewsRequest) (*ListViewsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListViews", ctx, in)
+	ret0, _ := ret[0].(*ListViewsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListViews indicates an expected call of ListViews.
+func (mr *MockTaskServiceServerMockRecorder) ListViews(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListViews", reflect.TypeOf((*MockTaskServiceServer)(nil).ListViews), ctx, in)
+}
+
+// MoveTask mocks base method.
+func (m *MockTaskServiceServer) MoveTask(ctx context.Context, in *MoveTaskRequest) (*MoveTaskResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveTask", ctx, in)
+	ret0, _ := ret[0].(*MoveTaskResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveTask indicates an expected call of MoveTask.
+func (mr *MockTaskServiceServerMockRecorder) MoveTask(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTask", reflect.TypeOf((*MockTaskServiceServer)(nil).MoveTask), ctx, in)
+}
+
+// StartTimer mocks base method.
+func (m *MockTaskServiceServer) StartTimer(ctx context.Context, in *StartTimerRequest) (*StartTimerResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartTimer", ctx, in)
+	ret0, _ := ret[0].(*StartTimerResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StartTimer indicates an expected call of StartTimer.
+func (mr *MockTaskServiceServerMockRecorder) StartTimer(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartTimer", reflect.TypeOf((*MockTaskServiceServer)(nil).StartTimer), ctx, in)
+}
+
+// StopTimer mocks base method.
+func (m *MockTaskServiceServer) StopTimer(ctx context.Context, in *StopTimerRequest) (*StopTimerResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopTimer", ctx, in)
+	ret0, _ := ret[0].(*StopTimerResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopTimer indicates an expected call of StopTimer.
+func (mr *MockTaskServiceServerMockRecorder) StopTimer(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopTimer", reflect.TypeOf((*MockTaskServiceServer)(nil).StopTimer), ctx, in)
+}
+
+// UnarchiveTask mocks base method.
+func (m *MockTaskServiceServer) UnarchiveTask(ctx context.Context, in *UnarchiveTaskRequest) (*UnarchiveTaskResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnarchiveTask", ctx, in)
+	ret0, _ := ret[0].(*UnarchiveTaskResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnarchiveTask indicates an expected call of UnarchiveTask.
+func (mr *MockTaskServiceServerMockRecorder) UnarchiveTask(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnarchiveTask", reflect.TypeOf((*MockTaskServiceServer)(nil).UnarchiveTask), ctx, in)
+}
+
+// UnwatchTask mocks base method.
+func (m *MockTaskServiceServer) UnwatchTask(ctx context.Context, in *UnwatchTaskRequest) (*UnwatchTaskResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnwatchTask", ctx, in)
+	ret0, _ := ret[0].(*UnwatchTaskResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnwatchTask indicates an expected call of UnwatchTask.
+func (mr *MockTaskServiceServerMockRecorder) UnwatchTask(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnwatchTask", reflect.TypeOf((*MockTaskServiceServer)(nil).UnwatchTask), ctx, in)
+}
+
+// UpdateCustomField mocks base method.
+func (m *MockTaskServiceServer) UpdateCustomField(ctx context.Context, in *UpdateCustomFieldRequest) (*UpdateCustomFieldResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCustomField", ctx, in)
+	ret0, _ := ret[0].(*UpdateCustomFieldResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateCustomField indicates an expected call of UpdateCustomField.
+func (mr *MockTaskServiceServerMockRecorder) UpdateCustomField(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomField", reflect.TypeOf((*MockTaskServiceServer)(nil).UpdateCustomField), ctx, in)
+}
+
+// UpdateRetentionPolicy mocks base method.
+func (m *MockTaskServiceServer) UpdateRetentionPolicy(ctx context.Context, in *UpdateRetentionPolicyRequest) (*UpdateRetentionPolicyResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRetentionPolicy", ctx, in)
+	ret0, _ := ret[0].(*UpdateRetentionPolicyResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRetentionPolicy indicates an expected call of UpdateRetentionPolicy.
+func (mr *MockTaskServiceServerMockRecorder) UpdateRetentionPolicy(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRetentionPolicy", reflect.TypeOf((*MockTaskServiceServer)(nil).UpdateRetentionPolicy), ctx, in)
+}
+
+// UpdateTask mocks base method.
+func (m *MockTaskServiceServer) UpdateTask(ctx context.Context, in *UpdateTaskRequest) (*UpdateTaskResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTask", ctx, in)
+	ret0, _ := ret[0].(*UpdateTaskResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTask indicates an expected call of UpdateTask.
+func (mr *MockTaskServiceServerMockRecorder) UpdateTask(ctx, in interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockTaskServiceServer)(nil).UpdateTask), ctx, in)
 }
+
+// UpdateTemplate mocks base method.
+func (m *MockTaskServiceServer) UpdateTemplate(ctx context.Context, in *UpdateTemplateRequest) (*UpdateTemplateResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTemplate", ctx, in)
+	ret0, _ := ret[0].(*UpdateTemplateResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTemplate indicates an expected call of UpdateTemplate.
+func (mr *MockTaskServiceServerMockRecorder) UpdateTemplate(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTemplate", reflect.TypeOf((*MockTaskServiceServer)(nil).UpdateTemplate), ctx, in)
+}
+
+// UpdateTimeEntry mocks base method.
+func (m *MockTaskServiceServer) UpdateTimeEntry(ctx context.Context, in *UpdateTimeEntryRequest) (*UpdateTimeEntryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTimeEntry", ctx, in)
+	ret0, _ := ret[0].(*UpdateTimeEntryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateTimeEntry indicates an expected call of UpdateTimeEntry.
+func (mr *MockTaskServiceServerMockRecorder) UpdateTimeEntry(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTimeEntry", reflect.TypeOf((*MockTaskServiceServer)(nil).UpdateTimeEntry), ctx, in)
+}
+
+// UpdateView mocks base method.
+func (m *MockTaskServiceServer) UpdateView(ctx context.Context, in *UpdateViewRequest) (*UpdateViewResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateView", ctx, in)
+	ret0, _ := ret[0].(*UpdateViewResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateView indicates an expected call of UpdateView.
+func (mr *MockTaskServiceServerMockRecorder) UpdateView(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateView", reflect.TypeOf((*MockTaskServiceServer)(nil).UpdateView), ctx, in)
+}
+
+// WatchTask mocks base method.
+func (m *MockTaskServiceServer) WatchTask(ctx context.Context, in *WatchTaskRequest) (*WatchTaskResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchTask", ctx, in)
+	ret0, _ := ret[0].(*WatchTaskResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// WatchTask indicates an expected call of WatchTask.
+func (mr *MockTaskServiceServerMockRecorder) WatchTask(ctx, in interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchTask", reflect.TypeOf((*MockTaskServiceServer)(nil).WatchTask), ctx, in)
+}
@@ -0,0 +1,262 @@
+// Package quickadd turns a line of text into the parts of a task.
+//
+// Words with a marker are picked out of the text wherever they appear:
+// "#finance" is a tag, "!high" a priority (low, medium, high or urgent),
+// "@alice" an assignee and "every month" a recurrence. The due date is the
+// longest run of the remaining words that dateparse understands, such as
+// "tomorrow 9am" or "next friday". What is left is the title. A leading
+// backslash keeps a word in the title as it is, as in "\#1" or "\friday".
+package quickadd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sejamuchhal/taskhub/gateway/dateparse"
+)
+
+// ErrNoTitle is returned when nothing is left for the title
+var ErrNoTitle = errors.New("quick add text has no title")
+
+// Priorities are the priority names that can follow "!", lowest first
+var Priorities = []string{"low", "medium", "high", "urgent"}
+
+// maxDateWords is the most words a due date phrase may span, as in
+// "march 1 2025 at 9:30 am"
+const maxDateWords = 6
+
+// Task is the parsed line. Fields that were not in the text are left empty.
+type Task struct {
+	Title      string
+	Due        *dateparse.Result
+	Tags       []string
+	Priority   string
+	Assignee   string
+	Recurrence *Recurrence
+}
+
+// Recurrence repeats a task every Interval units. Weekly recurrences may be
+// limited to some days of the week.
+type Recurrence struct {
+	// Unit is day, week, month or year
+	Unit     string
+	Interval int
+	Weekdays []time.Weekday
+}
+
+// String describes the recurrence the way it is typed, "every 2 weeks"
+func (r Recurrence) String() string {
+	if len(r.Weekdays) == 5 && r.Interval == 1 {
+		return "every weekday"
+	}
+	if len(r.Weekdays) > 0 {
+		names := make([]string, len(r.Weekdays))
+		for i, weekday := range r.Weekdays {
+			names[i] = strings.ToLower(weekday.String())
+		}
+		return "every " + strings.Join(names, " and ")
+	}
+	if r.Interval == 1 {
+		return "every " + r.Unit
+	}
+	return fmt.Sprintf("every %d %ss", r.Interval, r.Unit)
+}
+
+// word is a word of the input that is not a marker yet
+type word struct {
+	text string
+	// literal words were escaped and always belong to the title
+	literal bool
+	used    bool
+}
+
+// Parse splits input into the parts of a task. Relative due dates are
+// resolved against now in loc.
+func Parse(input string, now time.Time, loc *time.Location) (Task, error) {
+	var task Task
+	var words []*word
+	for _, text := range strings.Fields(input) {
+		if strings.HasPrefix(text, `\`) && len(text) > 1 {
+			words = append(words, &word{text: text[1:], literal: true})
+		} else {
+			words = append(words, &word{text: text})
+		}
+	}
+
+	for i, w := range words {
+		if w.literal || w.used {
+			continue
+		}
+		var err error
+		switch {
+		case w.text[0] == '#' && trimPunctuation(w.text[1:]) != "":
+			task.Tags = appendTag(task.Tags, trimPunctuation(w.text[1:]))
+			w.used = true
+		case w.text[0] == '!':
+			if priority, ok := parsePriority(w.text[1:]); ok {
+				err = setOnce("priority", &task.Priority, priority)
+				w.used = true
+			}
+		case w.text[0] == '@' && trimPunctuation(w.text[1:]) != "":
+			err = setOnce("assignee", &task.Assignee, trimPunctuation(w.text[1:]))
+			w.used = true
+		case strings.EqualFold(w.text, "every"):
+			if recurrence, n := parseRecurrence(words[i+1:]); n > 0 {
+				if task.Recurrence != nil {
+					return Task{}, fmt.Errorf("more than one recurrence: %s and %s", task.Recurrence, recurrence)
+				}
+				task.Recurrence = recurrence
+				for _, used := range words[i : i+1+n] {
+					used.used = true
+				}
+			}
+		}
+		if err != nil {
+			return Task{}, err
+		}
+	}
+
+	task.Due = findDueDate(words, now, loc)
+
+	var title []string
+	for _, w := range words {
+		if !w.used {
+			title = append(title, w.text)
+		}
+	}
+	task.Title = strings.Join(title, " ")
+	if task.Title == "" {
+		return Task{}, ErrNoTitle
+	}
+	return task, nil
+}
+
+// findDueDate picks the longest run of unused words that is a date, the last
+// one when several runs are as long, and marks its words as used
+func findDueDate(words []*word, now time.Time, loc *time.Location) *dateparse.Result {
+	for size := maxDateWords; size > 0; size-- {
+		for start := len(words) - size; start >= 0; start-- {
+			run := words[start : start+size]
+			texts := make([]string, 0, size)
+			for _, w := range run {
+				if w.used || w.literal {
+					break
+				}
+				texts = append(texts, w.text)
+			}
+			if len(texts) < size {
+				continue
+			}
+			due, err := dateparse.Parse(strings.Join(texts, " "), now, loc)
+			if err != nil {
+				continue
+			}
+			for _, w := range run {
+				w.used = true
+			}
+			due.Time = due.Time.In(loc)
+			return &due
+		}
+	}
+	return nil
+}
+
+func parsePriority(text string) (string, bool) {
+	text = strings.ToLower(trimPunctuation(text))
+	if text == "med" {
+		text = "medium"
+	}
+	for _, priority := range Priorities {
+		if text == priority {
+			return priority, true
+		}
+	}
+	return "", false
+}
+
+// parseRecurrence reads what follows "every" and reports how many words it
+// took, zero when they are not a recurrence
+func parseRecurrence(words []*word) (*Recurrence, int) {
+	var texts []string
+	for _, w := range words {
+		if w.used || w.literal || len(texts) == 3 {
+			break
+		}
+		texts = append(texts, strings.ToLower(trimPunctuation(w.text)))
+	}
+	if len(texts) == 0 {
+		return nil, 0
+	}
+
+	if texts[0] == "weekday" {
+		weekdays := []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}
+		return &Recurrence{Unit: "week", Interval: 1, Weekdays: weekdays}, 1
+	}
+	if weekday, ok := dateparse.Weekday(texts[0]); ok {
+		recurrence := &Recurrence{Unit: "week", Interval: 1, Weekdays: []time.Weekday{weekday}}
+		// "every monday and thursday"
+		if len(texts) == 3 && texts[1] == "and" {
+			if other, ok := dateparse.Weekday(texts[2]); ok && other != weekday {
+				recurrence.Weekdays = append(recurrence.Weekdays, other)
+				return recurrence, 3
+			}
+		}
+		return recurrence, 1
+	}
+	if unit, ok := recurrenceUnit(texts[0], false); ok {
+		return &Recurrence{Unit: unit, Interval: 1}, 1
+	}
+
+	if len(texts) < 2 {
+		return nil, 0
+	}
+	interval, err := strconv.Atoi(texts[0])
+	plural := true
+	if texts[0] == "other" {
+		interval, err, plural = 2, nil, false
+	}
+	if err != nil || interval < 1 {
+		return nil, 0
+	}
+	if unit, ok := recurrenceUnit(texts[1], plural && interval > 1); ok {
+		return &Recurrence{Unit: unit, Interval: interval}, 2
+	}
+	return nil, 0
+}
+
+// recurrenceUnit accepts "day" or, when plural is set, "days" too
+func recurrenceUnit(text string, plural bool) (string, bool) {
+	if plural {
+		text = strings.TrimSuffix(text, "s")
+	}
+	switch text {
+	case "day", "week", "month", "year":
+		return text, true
+	}
+	return "", false
+}
+
+func setOnce(name string, field *string, value string) error {
+	if *field != "" && *field != value {
+		return fmt.Errorf("more than one %s: %s and %s", name, *field, value)
+	}
+	*field = value
+	return nil
+}
+
+func appendTag(tags []string, tag string) []string {
+	for _, existing := range tags {
+		if existing == tag {
+			return tags
+		}
+	}
+	return append(tags, tag)
+}
+
+// trimPunctuation drops what ends a word in a sentence, as in "#home,"
+func trimPunctuation(text string) string {
+	return strings.TrimRight(text, ",.;:!?")
+}
@@ -0,0 +1,134 @@
+package quickadd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Tuesday
+	now := time.Date(2024, 7, 30, 14, 0, 0, 0, kolkata)
+
+	tests := []struct {
+		input          string
+		wantTitle      string
+		wantDue        time.Time
+		wantAllDay     bool
+		wantTags       []string
+		wantPriority   string
+		wantAssignee   string
+		wantRecurrence string
+	}{
+		{
+			input:          "Pay rent tomorrow 9am #finance !high @alice every month",
+			wantTitle:      "Pay rent",
+			wantDue:        time.Date(2024, 7, 31, 9, 0, 0, 0, kolkata),
+			wantTags:       []string{"finance"},
+			wantPriority:   "high",
+			wantAssignee:   "alice",
+			wantRecurrence: "every month",
+		},
+		{input: "Buy milk", wantTitle: "Buy milk"},
+		{
+			input:      "Submit report by next friday #work #q3 #work",
+			wantTitle:  "Submit report by",
+			wantDue:    time.Date(2024, 8, 2, 23, 59, 59, 0, kolkata),
+			wantAllDay: true,
+			wantTags:   []string{"work", "q3"},
+		},
+		{
+			input:     "Call mom at noon, #family!",
+			wantTitle: "Call mom",
+			wantDue:   time.Date(2024, 7, 30, 12, 0, 0, 0, kolkata).AddDate(0, 0, 1),
+			wantTags:  []string{"family"},
+		},
+		{input: "Renew passport march 1 2025 !URGENT", wantTitle: "Renew passport", wantDue: time.Date(2025, 3, 1, 23, 59, 59, 0, kolkata), wantAllDay: true, wantPriority: "urgent"},
+		{input: "Stand-up every weekday 9:30 am", wantTitle: "Stand-up", wantDue: time.Date(2024, 7, 31, 9, 30, 0, 0, kolkata), wantRecurrence: "every weekday"},
+		{input: "Gym every monday and thursday", wantTitle: "Gym", wantRecurrence: "every monday and thursday"},
+		{input: "Water plants every 3 days !low", wantTitle: "Water plants", wantPriority: "low", wantRecurrence: "every 3 days"},
+		{input: "Payroll every other week", wantTitle: "Payroll", wantRecurrence: "every 2 weeks"},
+		{input: "Read every page", wantTitle: "Read every page"},
+		{input: "Ship it in 2 hours !med", wantTitle: "Ship it", wantDue: time.Date(2024, 7, 30, 16, 0, 0, 0, kolkata), wantPriority: "medium"},
+		// Escaped words, unknown priorities and lone markers stay in the title
+		{input: `Fix bug \#42 before \friday !important`, wantTitle: "Fix bug #42 before friday !important"},
+		{input: "Ask @bob, about the # key", wantTitle: "Ask about the # key", wantAssignee: "bob"},
+		// The date is the longest phrase, the last one among equally long ones
+		{input: "Plan today's talk for tomorrow", wantTitle: "Plan today's talk for", wantDue: time.Date(2024, 7, 31, 23, 59, 59, 0, kolkata), wantAllDay: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input, now, kolkata)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+			if got.Title != tt.wantTitle {
+				t.Errorf("Title = %q, want %q", got.Title, tt.wantTitle)
+			}
+			if tt.wantDue.IsZero() {
+				if got.Due != nil {
+					t.Errorf("Due = %v, want none", got.Due.Time)
+				}
+			} else if got.Due == nil {
+				t.Errorf("Due = none, want %v", tt.wantDue)
+			} else if !got.Due.Time.Equal(tt.wantDue) || got.Due.AllDay != tt.wantAllDay {
+				t.Errorf("Due = %v (all day %v), want %v (all day %v)", got.Due.Time, got.Due.AllDay, tt.wantDue, tt.wantAllDay)
+			}
+			if !reflect.DeepEqual(got.Tags, tt.wantTags) {
+				t.Errorf("Tags = %v, want %v", got.Tags, tt.wantTags)
+			}
+			if got.Priority != tt.wantPriority {
+				t.Errorf("Priority = %q, want %q", got.Priority, tt.wantPriority)
+			}
+			if got.Assignee != tt.wantAssignee {
+				t.Errorf("Assignee = %q, want %q", got.Assignee, tt.wantAssignee)
+			}
+			recurrence := ""
+			if got.Recurrence != nil {
+				recurrence = got.Recurrence.String()
+			}
+			if recurrence != tt.wantRecurrence {
+				t.Errorf("Recurrence = %q, want %q", recurrence, tt.wantRecurrence)
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	now := time.Date(2024, 7, 30, 14, 0, 0, 0, time.UTC)
+
+	tests := []string{
+		"",
+		"tomorrow 9am #finance",
+		"Pay rent !high !low",
+		"Pay rent @alice @bob",
+		"Pay rent every week every month",
+	}
+	for _, input := range tests {
+		t.Run(input, func(t *testing.T) {
+			if _, err := Parse(input, now, time.UTC); err == nil {
+				t.Errorf("Parse(%q) error = nil, want an error", input)
+			}
+		})
+	}
+}
+
+func TestRecurrenceString(t *testing.T) {
+	tests := []struct {
+		recurrence Recurrence
+		want       string
+	}{
+		{Recurrence{Unit: "day", Interval: 1}, "every day"},
+		{Recurrence{Unit: "year", Interval: 2}, "every 2 years"},
+		{Recurrence{Unit: "week", Interval: 1, Weekdays: []time.Weekday{time.Sunday}}, "every sunday"},
+	}
+	for _, tt := range tests {
+		if got := tt.recurrence.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
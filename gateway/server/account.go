@@ -0,0 +1,72 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sejamuchhal/taskhub/gateway/pb/auth"
+)
+
+// ChangePassword replaces the caller's password. Other sessions are logged
+// out, the one of the Refresh header is kept if it is sent. Access tokens
+// handed out so far stop working, the response carries a new one.
+func (s *Server) ChangePassword(c *gin.Context) {
+	logger := s.Logger.WithField("method", "ChangePassword")
+	logger.Debug("Incoming request")
+
+	var req ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.WithError(err).Error("Error parsing change password request")
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	res, err := s.AuthClient.ChangePassword(clientContext(c), &auth.ChangePasswordRequest{
+		AccessToken:     c.GetHeader("Access"),
+		RefreshToken:    c.GetHeader("Refresh"),
+		CurrentPassword: req.CurrentPassword,
+		NewPassword:     req.NewPassword,
+	})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "change password")
+		return
+	}
+
+	logger.Info("Password changed")
+	c.JSON(http.StatusOK, ChangePasswordResponse{
+		Message:              res.Message,
+		AccessToken:          res.AccessToken,
+		AccessTokenExpiresAt: res.AccessTokenExpiresAt.AsTime(),
+	})
+}
+
+// UpdateAccount changes the caller's name and email. A new email is pending
+// until the user follows the link sent to it.
+func (s *Server) UpdateAccount(c *gin.Context) {
+	logger := s.Logger.WithField("method", "UpdateAccount")
+	logger.Debug("Incoming request")
+
+	var req UpdateAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.WithError(err).Error("Error parsing update account request")
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	res, err := s.AuthClient.UpdateAccount(context.Background(), &auth.UpdateAccountRequest{
+		AccessToken: c.GetHeader("Access"),
+		Name:        req.Name,
+		Email:       req.Email,
+	})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "update account")
+		return
+	}
+
+	c.JSON(http.StatusOK, UpdateAccountResponse{
+		Message:      res.Message,
+		User:         UserDetail{Name: res.User.GetName(), Email: res.User.GetEmail()},
+		PendingEmail: res.PendingEmail,
+	})
+}
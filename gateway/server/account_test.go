@@ -0,0 +1,118 @@
+package server_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/sejamuchhal/taskhub/gateway/pb/auth"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (suite *ServerTestSuite) TestChangePassword_Success() {
+	req := httptest.NewRequest("PUT", "/auth/password", bytes.NewBufferString(`{"current_password":"old-password","new_password":"new-password"}`))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Refresh", "refresh_token")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	expiresAt := time.Date(2024, 7, 30, 10, 0, 0, 0, time.UTC)
+	suite.expectValidate()
+	suite.mockAuth.EXPECT().ChangePassword(fromClient(testClientIP), &auth.ChangePasswordRequest{
+		AccessToken:     "access_token",
+		RefreshToken:    "refresh_token",
+		CurrentPassword: "old-password",
+		NewPassword:     "new-password",
+	}).Return(&auth.ChangePasswordResponse{
+		Message:              "Password changed",
+		AccessToken:          "new_access_token",
+		AccessTokenExpiresAt: timestamppb.New(expiresAt),
+	}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"message":"Password changed","access_token":"new_access_token","access_token_expires_at":"2024-07-30T10:00:00Z"}`, w.Body.String())
+}
+
+func (suite *ServerTestSuite) TestChangePassword_WrongCurrentPassword() {
+	req := httptest.NewRequest("PUT", "/auth/password", bytes.NewBufferString(`{"current_password":"guess","new_password":"new-password"}`))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockAuth.EXPECT().ChangePassword(gomock.Any(), gomock.Any()).
+		Return(nil, status.Error(codes.InvalidArgument, "Current password is incorrect"))
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.JSONEq(suite.T(), `{"message":"Current password is incorrect"}`, w.Body.String())
+}
+
+func (suite *ServerTestSuite) TestChangePassword_Unauthenticated() {
+	req := httptest.NewRequest("PUT", "/auth/password", bytes.NewBufferString(`{"current_password":"old-password","new_password":"new-password"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
+}
+
+func (suite *ServerTestSuite) TestUpdateAccount_Success() {
+	req := httptest.NewRequest("PATCH", "/me", bytes.NewBufferString(`{"name":"Harry Potter","email":"potter@ministry.gov"}`))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockAuth.EXPECT().UpdateAccount(gomock.Any(), &auth.UpdateAccountRequest{
+		AccessToken: "access_token",
+		Name:        "Harry Potter",
+		Email:       "potter@ministry.gov",
+	}).Return(&auth.UpdateAccountResponse{
+		Message:      "Account updated",
+		User:         &auth.UserDetail{Name: "Harry Potter", Email: "harry@hogwarts.edu"},
+		PendingEmail: "potter@ministry.gov",
+	}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"message":"Account updated","user":{"name":"Harry Potter","email":"harry@hogwarts.edu"},"pending_email":"potter@ministry.gov"}`, w.Body.String())
+}
+
+func (suite *ServerTestSuite) TestUpdateAccount_InvalidEmail() {
+	req := httptest.NewRequest("PATCH", "/me", bytes.NewBufferString(`{"email":"not-an-email"}`))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *ServerTestSuite) TestUpdateAccount_EmailTaken() {
+	req := httptest.NewRequest("PATCH", "/me", bytes.NewBufferString(`{"email":"ron@hogwarts.edu"}`))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockAuth.EXPECT().UpdateAccount(gomock.Any(), gomock.Any()).
+		Return(nil, status.Error(codes.AlreadyExists, "Email is already in use"))
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+}
@@ -0,0 +1,219 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sejamuchhal/taskhub/gateway/pb/auth"
+	"github.com/sirupsen/logrus"
+)
+
+// ListUsers lets an admin page through and search the users
+func (s *Server) ListUsers(c *gin.Context) {
+	logger := s.Logger.WithField("method", "ListUsers")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	var req ListUsersRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		logger.WithError(err).Error("Failed to bind query parameters")
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	res, err := s.AdminClient.ListUsers(context.Background(), &auth.ListUsersRequest{
+		AccessToken: c.GetHeader("Access"),
+		Limit:       int32(req.Limit),
+		Offset:      int32(req.Offset),
+		Query:       req.Query,
+		Role:        req.Role,
+		Status:      req.Status,
+	})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "list users")
+		return
+	}
+
+	users := make([]AccountDetails, 0, len(res.Users))
+	for _, user := range res.Users {
+		users = append(users, accountDetails(user))
+	}
+	c.JSON(http.StatusOK, ListUsersResponse{Count: int(res.TotalCount), Users: users})
+}
+
+func (s *Server) GetUser(c *gin.Context) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"method":         "GetUser",
+		"target_user_id": c.Param("id"),
+	})
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	res, err := s.AdminClient.GetUser(context.Background(), &auth.GetUserRequest{
+		AccessToken: c.GetHeader("Access"),
+		UserId:      c.Param("id"),
+	})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "get user")
+		return
+	}
+	c.JSON(http.StatusOK, accountDetails(res.User))
+}
+
+// SetUserRole makes a user an admin or a plain user again. It applies to
+// their next request.
+func (s *Server) SetUserRole(c *gin.Context) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"method":         "SetUserRole",
+		"target_user_id": c.Param("id"),
+	})
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	var req SetUserRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.WithError(err).Error("Error parsing set role request")
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	res, err := s.AdminClient.SetUserRole(context.Background(), &auth.SetUserRoleRequest{
+		AccessToken: c.GetHeader("Access"),
+		UserId:      c.Param("id"),
+		Role:        req.Role,
+	})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "set role")
+		return
+	}
+
+	logger.WithField("role", req.Role).Info("Role set")
+	c.JSON(http.StatusOK, accountDetails(res.User))
+}
+
+// DeactivateUser keeps a user from logging in and logs them out everywhere
+func (s *Server) DeactivateUser(c *gin.Context) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"method":         "DeactivateUser",
+		"target_user_id": c.Param("id"),
+	})
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	res, err := s.AdminClient.DeactivateUser(context.Background(), &auth.DeactivateUserRequest{
+		AccessToken: c.GetHeader("Access"),
+		UserId:      c.Param("id"),
+	})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "deactivate user")
+		return
+	}
+
+	logger.Info("User deactivated")
+	c.JSON(http.StatusOK, accountDetails(res.User))
+}
+
+func (s *Server) ReactivateUser(c *gin.Context) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"method":         "ReactivateUser",
+		"target_user_id": c.Param("id"),
+	})
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	res, err := s.AdminClient.ReactivateUser(context.Background(), &auth.ReactivateUserRequest{
+		AccessToken: c.GetHeader("Access"),
+		UserId:      c.Param("id"),
+	})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "reactivate user")
+		return
+	}
+
+	logger.Info("User reactivated")
+	c.JSON(http.StatusOK, accountDetails(res.User))
+}
+
+// LogoutUser signs a user out of every session
+func (s *Server) LogoutUser(c *gin.Context) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"method":         "LogoutUser",
+		"target_user_id": c.Param("id"),
+	})
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	res, err := s.AdminClient.LogoutUser(context.Background(), &auth.LogoutUserRequest{
+		AccessToken: c.GetHeader("Access"),
+		UserId:      c.Param("id"),
+	})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "log out user")
+		return
+	}
+
+	logger.Info("User logged out")
+	c.JSON(http.StatusOK, gin.H{"message": res.Message})
+}
+
+// UnlockAccount lets an admin lift the lockout of an account after too many
+// failed logins
+func (s *Server) UnlockAccount(c *gin.Context) {
+	logger := s.Logger.WithField("method", "UnlockAccount")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	var req UnlockAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.WithError(err).Error("Error parsing unlock account request")
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	res, err := s.AdminClient.UnlockAccount(context.Background(), &auth.UnlockAccountRequest{
+		AccessToken: c.GetHeader("Access"),
+		Email:       req.Email,
+	})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "unlock account")
+		return
+	}
+
+	logger.Info("Account unlocked")
+	c.JSON(http.StatusOK, gin.H{"message": res.Message})
+}
+
+func accountDetails(user *auth.AccountDetail) AccountDetails {
+	return AccountDetails{
+		ID:            user.Id,
+		Name:          user.Name,
+		Email:         user.Email,
+		Role:          user.Role,
+		Active:        user.Active,
+		EmailVerified: user.EmailVerified,
+		MFAEnabled:    user.MfaEnabled,
+		CreatedAt:     user.CreatedAt.AsTime(),
+	}
+}
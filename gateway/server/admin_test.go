@@ -0,0 +1,218 @@
+package server_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/sejamuchhal/taskhub/gateway/pb/auth"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// expectAdminValidate accepts "access_token" as the token of an admin
+func (suite *ServerTestSuite) expectAdminValidate() {
+	suite.mockAuth.EXPECT().Validate(gomock.Any(), &auth.ValidateRequest{Token: "access_token"}).Return(&auth.ValidateResponse{
+		UserId: "admin-id",
+		Email:  "dumbledore@hogwarts.edu",
+		Role:   "admin",
+	}, nil)
+}
+
+func (suite *ServerTestSuite) TestUnlockAccount_Success() {
+	req := httptest.NewRequest("POST", "/admin/users/unlock", bytes.NewBufferString(`{"email":"harry@hogwarts.edu"}`))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.expectAdminValidate()
+	suite.mockAdmin.EXPECT().UnlockAccount(gomock.Any(), &auth.UnlockAccountRequest{
+		AccessToken: "access_token",
+		Email:       "harry@hogwarts.edu",
+	}).Return(&auth.UnlockAccountResponse{Message: "Account unlocked"}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"message":"Account unlocked"}`, w.Body.String())
+}
+
+func (suite *ServerTestSuite) TestUnlockAccount_NotAdmin() {
+	req := httptest.NewRequest("POST", "/admin/users/unlock", bytes.NewBufferString(`{"email":"harry@hogwarts.edu"}`))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusForbidden, w.Code)
+}
+
+func (suite *ServerTestSuite) TestListUsers_Success() {
+	req := httptest.NewRequest("GET", "/admin/users?query=harry&status=active&limit=5&offset=10", nil)
+	req.Header.Set("Access", "access_token")
+	w := httptest.NewRecorder()
+
+	createdAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	suite.expectAdminValidate()
+	suite.mockAdmin.EXPECT().ListUsers(gomock.Any(), &auth.ListUsersRequest{
+		AccessToken: "access_token",
+		Limit:       5,
+		Offset:      10,
+		Query:       "harry",
+		Status:      "active",
+	}).Return(&auth.ListUsersResponse{
+		Users: []*auth.AccountDetail{{
+			Id:            "1",
+			Name:          "Harry Potter",
+			Email:         "harry@hogwarts.edu",
+			Role:          "user",
+			Active:        true,
+			EmailVerified: true,
+			CreatedAt:     timestamppb.New(createdAt),
+		}},
+		TotalCount: 11,
+	}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"count":11,"users":[{
+		"id":"1",
+		"name":"Harry Potter",
+		"email":"harry@hogwarts.edu",
+		"role":"user",
+		"active":true,
+		"email_verified":true,
+		"mfa_enabled":false,
+		"created_at":"2026-10-19T12:00:00Z"
+	}]}`, w.Body.String())
+}
+
+func (suite *ServerTestSuite) TestListUsers_NotAdmin() {
+	req := httptest.NewRequest("GET", "/admin/users", nil)
+	req.Header.Set("Access", "access_token")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusForbidden, w.Code)
+}
+
+func (suite *ServerTestSuite) TestGetUser_NotFound() {
+	req := httptest.NewRequest("GET", "/admin/users/missing", nil)
+	req.Header.Set("Access", "access_token")
+	w := httptest.NewRecorder()
+
+	suite.expectAdminValidate()
+	suite.mockAdmin.EXPECT().GetUser(gomock.Any(), &auth.GetUserRequest{
+		AccessToken: "access_token",
+		UserId:      "missing",
+	}).Return(nil, status.Error(codes.NotFound, "User not found"))
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
+
+func (suite *ServerTestSuite) TestSetUserRole_Success() {
+	req := httptest.NewRequest("PUT", "/admin/users/1/role", bytes.NewBufferString(`{"role":"admin"}`))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.expectAdminValidate()
+	suite.mockAdmin.EXPECT().SetUserRole(gomock.Any(), &auth.SetUserRoleRequest{
+		AccessToken: "access_token",
+		UserId:      "1",
+		Role:        "admin",
+	}).Return(&auth.SetUserRoleResponse{User: &auth.AccountDetail{Id: "1", Role: "admin", Active: true}}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"role":"admin"`)
+}
+
+func (suite *ServerTestSuite) TestSetUserRole_InvalidRole() {
+	req := httptest.NewRequest("PUT", "/admin/users/1/role", bytes.NewBufferString(`{"role":"headmaster"}`))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.expectAdminValidate()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *ServerTestSuite) TestDeactivateUser_Success() {
+	req := httptest.NewRequest("POST", "/admin/users/1/deactivate", nil)
+	req.Header.Set("Access", "access_token")
+	w := httptest.NewRecorder()
+
+	suite.expectAdminValidate()
+	suite.mockAdmin.EXPECT().DeactivateUser(gomock.Any(), &auth.DeactivateUserRequest{
+		AccessToken: "access_token",
+		UserId:      "1",
+	}).Return(&auth.DeactivateUserResponse{User: &auth.AccountDetail{Id: "1", Role: "user"}}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"active":false`)
+}
+
+func (suite *ServerTestSuite) TestDeactivateUser_Self() {
+	req := httptest.NewRequest("POST", "/admin/users/admin-id/deactivate", nil)
+	req.Header.Set("Access", "access_token")
+	w := httptest.NewRecorder()
+
+	suite.expectAdminValidate()
+	suite.mockAdmin.EXPECT().DeactivateUser(gomock.Any(), gomock.Any()).
+		Return(nil, status.Error(codes.FailedPrecondition, "Admins cannot deactivate themselves"))
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+}
+
+func (suite *ServerTestSuite) TestLogoutUser_Success() {
+	req := httptest.NewRequest("POST", "/admin/users/1/logout", nil)
+	req.Header.Set("Access", "access_token")
+	w := httptest.NewRecorder()
+
+	suite.expectAdminValidate()
+	suite.mockAdmin.EXPECT().LogoutUser(gomock.Any(), &auth.LogoutUserRequest{
+		AccessToken: "access_token",
+		UserId:      "1",
+	}).Return(&auth.LogoutUserResponse{Message: "User logged out"}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"message":"User logged out"}`, w.Body.String())
+}
+
+func (suite *ServerTestSuite) TestLoginUser_Deactivated() {
+	req := httptest.NewRequest("POST", "/auth/login", bytes.NewBufferString(`{"email":"harry@hogwarts.edu","password":"password"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.mockAuth.EXPECT().Login(gomock.Any(), gomock.Any()).
+		Return(nil, status.Error(codes.PermissionDenied, "Account is deactivated"))
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusForbidden, w.Code)
+	assert.JSONEq(suite.T(), `{"message":"Account is deactivated"}`, w.Body.String())
+}
@@ -0,0 +1,77 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sejamuchhal/taskhub/gateway/pb/task"
+	"google.golang.org/grpc/metadata"
+)
+
+func (s *Server) MoveTask(c *gin.Context) {
+	logger := s.Logger.WithField("method", "MoveTask")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	var req MoveTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.WithError(err).Error("Failed to bind JSON")
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := s.TaskClient.MoveTask(ctxWithMetadata, &task.MoveTaskRequest{
+		Id:       c.Param("id"),
+		Status:   req.Status,
+		AfterId:  req.AfterID,
+		BeforeId: req.BeforeID,
+	})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "move task")
+		return
+	}
+
+	c.JSON(http.StatusOK, TransformTask(resp.Task))
+}
+
+func (s *Server) GetBoard(c *gin.Context) {
+	logger := s.Logger.WithField("method", "GetBoard")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := s.TaskClient.GetBoard(ctxWithMetadata, &task.GetBoardRequest{})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "load board")
+		return
+	}
+
+	columns := make([]BoardColumn, len(resp.GetColumns()))
+	for i, column := range resp.GetColumns() {
+		tasks := make([]*TaskDetails, len(column.GetTasks()))
+		for j, t := range column.GetTasks() {
+			tasks[j] = TransformTask(t)
+		}
+		columns[i] = BoardColumn{Status: column.Status, Tasks: tasks}
+	}
+
+	c.JSON(http.StatusOK, BoardResponse{Columns: columns})
+}
@@ -0,0 +1,76 @@
+package server_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/sejamuchhal/taskhub/gateway/pb/task"
+	"github.com/sejamuchhal/taskhub/gateway/server"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (suite *ServerTestSuite) TestMoveTask_Success() {
+	reqBody := `{"status":"in_progress","after_id":"task-2","before_id":"task-3"}`
+	req := httptest.NewRequest("PUT", "/tasks/task-1/move", bytes.NewBufferString(reqBody))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().MoveTask(gomock.Any(), &task.MoveTaskRequest{
+		Id:       "task-1",
+		Status:   "in_progress",
+		AfterId:  "task-2",
+		BeforeId: "task-3",
+	}).Return(&task.MoveTaskResponse{Task: &task.Task{Id: "task-1", Title: "Board task", Status: "in_progress", Position: "ai"}}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"Position":"ai"`)
+}
+
+func (suite *ServerTestSuite) TestMoveTask_InvalidMove() {
+	req := httptest.NewRequest("PUT", "/tasks/task-1/move", bytes.NewBufferString(`{"status":"completed","after_id":"task-2"}`))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().MoveTask(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.InvalidArgument, "Invalid move"))
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.JSONEq(suite.T(), `{"message":"Invalid move"}`, w.Body.String())
+}
+
+func (suite *ServerTestSuite) TestGetBoard_Success() {
+	req := httptest.NewRequest("GET", "/board", nil)
+	req.Header.Set("Access", "access_token")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().GetBoard(gomock.Any(), &task.GetBoardRequest{}).Return(&task.GetBoardResponse{
+		Columns: []*task.BoardColumn{
+			{Status: "created", Tasks: []*task.Task{{Id: "task-1", Title: "First", Status: "created", Position: "9"}}},
+			{Status: "in_progress"},
+		},
+	}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	var res server.BoardResponse
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Len(suite.T(), res.Columns, 2)
+	assert.Equal(suite.T(), "created", res.Columns[0].Status)
+	assert.Equal(suite.T(), "task-1", res.Columns[0].Tasks[0].ID)
+	assert.Equal(suite.T(), "9", res.Columns[0].Tasks[0].Position)
+	assert.Empty(suite.T(), res.Columns[1].Tasks)
+}
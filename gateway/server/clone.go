@@ -0,0 +1,49 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sejamuchhal/taskhub/gateway/pb/task"
+	"google.golang.org/grpc/metadata"
+)
+
+func (s *Server) CloneTask(c *gin.Context) {
+	logger := s.Logger.WithField("method", "CloneTask")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	var req CloneTaskRequest
+	// Without a body the copy takes over neither tags nor custom fields
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			logger.WithError(err).Error("Failed to bind JSON")
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := s.TaskClient.CloneTask(ctxWithMetadata, &task.CloneTaskRequest{
+		Id:                  c.Param("id"),
+		IncludeTags:         req.IncludeTags,
+		IncludeCustomFields: req.IncludeCustomFields,
+		IncludeReminders:    req.IncludeReminders,
+		DueOffsetMinutes:    req.DueOffsetMinutes,
+	})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "clone task")
+		return
+	}
+
+	c.JSON(http.StatusOK, TransformTask(resp.Task))
+}
@@ -0,0 +1,50 @@
+package server_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/sejamuchhal/taskhub/gateway/pb/task"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (suite *ServerTestSuite) TestCloneTask_Success() {
+	body := `{"include_tags":true,"include_custom_fields":true,"include_reminders":true,"due_offset_minutes":10080}`
+	req := httptest.NewRequest("POST", "/tasks/task-1/clone", bytes.NewBufferString(body))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().CloneTask(gomock.Any(), &task.CloneTaskRequest{
+		Id:                  "task-1",
+		IncludeTags:         true,
+		IncludeCustomFields: true,
+		IncludeReminders:    true,
+		DueOffsetMinutes:    10080,
+	}).Return(&task.CloneTaskResponse{Task: &task.Task{Id: "task-2", Title: "Weekly review", Tags: []string{"review"}}}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"ID":"task-2"`)
+	assert.Contains(suite.T(), w.Body.String(), `"Tags":["review"]`)
+}
+
+func (suite *ServerTestSuite) TestCloneTask_NotFound() {
+	req := httptest.NewRequest("POST", "/tasks/task-1/clone", nil)
+	req.Header.Set("Access", "access_token")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().CloneTask(gomock.Any(), &task.CloneTaskRequest{Id: "task-1"}).
+		Return(nil, status.Error(codes.NotFound, "Task not found"))
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
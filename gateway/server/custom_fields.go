@@ -0,0 +1,199 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sejamuchhal/taskhub/gateway/pb/task"
+	"google.golang.org/grpc/metadata"
+)
+
+func (s *Server) CreateCustomField(c *gin.Context) {
+	logger := s.Logger.WithField("method", "CreateCustomField")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	var req CustomFieldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.WithError(err).Error("Failed to bind JSON")
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := s.TaskClient.CreateCustomField(ctxWithMetadata, &task.CreateCustomFieldRequest{
+		Field: &task.CustomField{Name: req.Name, Type: req.Type, Options: req.Options},
+	})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "create custom field")
+		return
+	}
+
+	c.JSON(http.StatusOK, TransformCustomField(resp.Field))
+}
+
+func (s *Server) ListCustomFields(c *gin.Context) {
+	logger := s.Logger.WithField("method", "ListCustomFields")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := s.TaskClient.ListCustomFields(ctxWithMetadata, &task.ListCustomFieldsRequest{})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "list custom fields")
+		return
+	}
+
+	fields := make([]*CustomFieldDetails, len(resp.GetFields()))
+	for i, field := range resp.GetFields() {
+		fields[i] = TransformCustomField(field)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"custom_fields": fields})
+}
+
+func (s *Server) UpdateCustomField(c *gin.Context) {
+	logger := s.Logger.WithField("method", "UpdateCustomField")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	var req CustomFieldRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.WithError(err).Error("Failed to bind JSON")
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := s.TaskClient.UpdateCustomField(ctxWithMetadata, &task.UpdateCustomFieldRequest{
+		Field: &task.CustomField{Id: c.Param("id"), Name: req.Name, Type: req.Type, Options: req.Options},
+	})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "update custom field")
+		return
+	}
+
+	c.JSON(http.StatusOK, TransformCustomField(resp.Field))
+}
+
+func (s *Server) DeleteCustomField(c *gin.Context) {
+	logger := s.Logger.WithField("method", "DeleteCustomField")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	_, err := s.TaskClient.DeleteCustomField(ctxWithMetadata, &task.DeleteCustomFieldRequest{Id: c.Param("id")})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "delete custom field")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Custom field deleted successfully"})
+}
+
+// customFieldValuesFromRequest converts JSON custom field values. Strings,
+// numbers, booleans and lists of strings are accepted, null leaves a field
+// unset. Whether a value fits its field is checked by the task service.
+func customFieldValuesFromRequest(values map[string]interface{}) (map[string]*task.CustomFieldValue, error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	res := make(map[string]*task.CustomFieldValue, len(values))
+	for id, value := range values {
+		switch value := value.(type) {
+		case nil:
+		case string:
+			res[id] = &task.CustomFieldValue{Kind: &task.CustomFieldValue_StringValue{StringValue: value}}
+		case float64:
+			res[id] = &task.CustomFieldValue{Kind: &task.CustomFieldValue_NumberValue{NumberValue: value}}
+		case bool:
+			res[id] = &task.CustomFieldValue{Kind: &task.CustomFieldValue_BoolValue{BoolValue: value}}
+		case []interface{}:
+			list := make([]string, len(value))
+			for i, item := range value {
+				option, ok := item.(string)
+				if !ok {
+					return nil, fmt.Errorf("custom field %s: lists may only contain strings", id)
+				}
+				list[i] = option
+			}
+			res[id] = &task.CustomFieldValue{Kind: &task.CustomFieldValue_ListValue{ListValue: &task.StringList{Values: list}}}
+		default:
+			return nil, fmt.Errorf("custom field %s: unsupported value", id)
+		}
+	}
+	return res, nil
+}
+
+var customFieldFilterOps = []string{"eq", "lt", "lte", "gt", "gte"}
+
+// customFieldFiltersFromQuery reads filters given as cf[<field id>]=value.
+// The value may start with an operator, as in cf[<id>]=gte:3, and a field
+// may be repeated to filter by a range.
+func customFieldFiltersFromQuery(query url.Values) []*task.CustomFieldFilter {
+	var keys []string
+	for key := range query {
+		if strings.HasPrefix(key, "cf[") && strings.HasSuffix(key, "]") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var filters []*task.CustomFieldFilter
+	for _, key := range keys {
+		fieldID := strings.TrimSuffix(strings.TrimPrefix(key, "cf["), "]")
+		for _, value := range query[key] {
+			op := "eq"
+			for _, candidate := range customFieldFilterOps {
+				if strings.HasPrefix(value, candidate+":") {
+					op = candidate
+					value = strings.TrimPrefix(value, candidate+":")
+					break
+				}
+			}
+			filters = append(filters, &task.CustomFieldFilter{
+				FieldId: fieldID,
+				Op:      op,
+				Value:   &task.CustomFieldValue{Kind: &task.CustomFieldValue_StringValue{StringValue: value}},
+			})
+		}
+	}
+	return filters
+}
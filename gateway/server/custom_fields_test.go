@@ -0,0 +1,163 @@
+package server_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/sejamuchhal/taskhub/gateway/pb/task"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (suite *ServerTestSuite) TestCreateCustomField_Success() {
+	reqBody := `{"name":"Client","type":"single_select","options":["Acme","Globex"]}`
+	req := httptest.NewRequest("POST", "/custom-fields", bytes.NewBufferString(reqBody))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().CreateCustomField(gomock.Any(), &task.CreateCustomFieldRequest{
+		Field: &task.CustomField{Name: "Client", Type: "single_select", Options: []string{"Acme", "Globex"}},
+	}).Return(&task.CreateCustomFieldResponse{Field: &task.CustomField{
+		Id:      "field-1",
+		Name:    "Client",
+		Type:    "single_select",
+		Options: []string{"Acme", "Globex"},
+	}}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{
+		"id": "field-1",
+		"name": "Client",
+		"type": "single_select",
+		"options": ["Acme", "Globex"],
+		"created_at": "",
+		"updated_at": ""
+	}`, w.Body.String())
+}
+
+func (suite *ServerTestSuite) TestUpdateCustomField_TypeChange() {
+	req := httptest.NewRequest("PUT", "/custom-fields/field-1", bytes.NewBufferString(`{"name":"Points","type":"text"}`))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().UpdateCustomField(gomock.Any(), gomock.Any()).
+		Return(nil, status.Error(codes.InvalidArgument, "The type of a custom field cannot change"))
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.JSONEq(suite.T(), `{"message":"The type of a custom field cannot change"}`, w.Body.String())
+}
+
+func (suite *ServerTestSuite) TestCreateTask_CustomFields() {
+	reqBody := `{"title":"New Task","custom_fields":{"points":3,"client":"Acme","labels":["ui"],"billable":true,"due":null}}`
+	req := httptest.NewRequest("POST", "/tasks", bytes.NewBufferString(reqBody))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().CreateTask(gomock.Any(), &task.CreateTaskRequest{
+		Task: &task.Task{
+			Title: "New Task",
+			CustomFields: map[string]*task.CustomFieldValue{
+				"points":   {Kind: &task.CustomFieldValue_NumberValue{NumberValue: 3}},
+				"client":   {Kind: &task.CustomFieldValue_StringValue{StringValue: "Acme"}},
+				"labels":   {Kind: &task.CustomFieldValue_ListValue{ListValue: &task.StringList{Values: []string{"ui"}}}},
+				"billable": {Kind: &task.CustomFieldValue_BoolValue{BoolValue: true}},
+			},
+		},
+	}).Return(&task.CreateTaskResponse{Id: "12345"}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
+func (suite *ServerTestSuite) TestCreateTask_UnsupportedCustomFieldValue() {
+	req := httptest.NewRequest("POST", "/tasks", bytes.NewBufferString(`{"title":"New Task","custom_fields":{"points":{"value":3}}}`))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.JSONEq(suite.T(), `{"message":"custom field points: unsupported value"}`, w.Body.String())
+}
+
+func (suite *ServerTestSuite) TestCreateTask_InvalidCustomFieldValue() {
+	req := httptest.NewRequest("POST", "/tasks", bytes.NewBufferString(`{"title":"New Task","custom_fields":{"client":"Initech"}}`))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().CreateTask(gomock.Any(), gomock.Any()).
+		Return(nil, status.Error(codes.InvalidArgument, `Invalid value for custom field "Client": not one of the options`))
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.JSONEq(suite.T(), `{"message":"Invalid value for custom field \"Client\": not one of the options"}`, w.Body.String())
+}
+
+func (suite *ServerTestSuite) TestListTasks_CustomFieldFilters() {
+	req := httptest.NewRequest("GET", "/tasks?cf[points]=gte:3&cf[points]=lt:8&cf[client]=Acme&sort_field=points&sort_desc=true", nil)
+	req.Header.Set("Access", "access_token")
+	w := httptest.NewRecorder()
+
+	stringValue := func(value string) *task.CustomFieldValue {
+		return &task.CustomFieldValue{Kind: &task.CustomFieldValue_StringValue{StringValue: value}}
+	}
+	suite.expectValidate()
+	suite.mockTask.EXPECT().ListTasks(gomock.Any(), &task.ListTasksRequest{
+		CustomFieldFilters: []*task.CustomFieldFilter{
+			{FieldId: "client", Op: "eq", Value: stringValue("Acme")},
+			{FieldId: "points", Op: "gte", Value: stringValue("3")},
+			{FieldId: "points", Op: "lt", Value: stringValue("8")},
+		},
+		SortFieldId: "points",
+		SortDesc:    true,
+	}).Return(&task.ListTasksResponse{
+		Tasks: []*task.Task{{
+			Id:    "12345",
+			Title: "Task",
+			CustomFields: map[string]*task.CustomFieldValue{
+				"points": {Kind: &task.CustomFieldValue_NumberValue{NumberValue: 5}},
+				"client": stringValue("Acme"),
+			},
+		}},
+		TotalCount: 1,
+	}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"CustomFields":{"client":"Acme","points":5}`)
+}
+
+func (suite *ServerTestSuite) TestListTasks_InvalidCustomFieldFilter() {
+	req := httptest.NewRequest("GET", "/tasks?cf[points]=many", nil)
+	req.Header.Set("Access", "access_token")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().ListTasks(gomock.Any(), gomock.Any()).
+		Return(nil, status.Error(codes.InvalidArgument, `Invalid filter value for custom field "Points": expected a number`))
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,77 @@
+package server_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/sejamuchhal/taskhub/gateway/pb/task"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func (suite *ServerTestSuite) TestCreateTask_NaturalDueDate() {
+	reqBody := `{"title":"New Task","due_date_time":"tomorrow 5pm"}`
+	req := httptest.NewRequest("POST", "/tasks", bytes.NewBufferString(reqBody))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Timezone", "Asia/Kolkata")
+	w := httptest.NewRecorder()
+
+	kolkata, _ := time.LoadLocation("Asia/Kolkata")
+	tomorrow := time.Now().In(kolkata).AddDate(0, 0, 1)
+	want := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 17, 0, 0, 0, kolkata)
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().CreateTask(gomock.Any(), gomock.Any()).DoAndReturn(func(_ any, req *task.CreateTaskRequest, _ ...any) (*task.CreateTaskResponse, error) {
+		assert.True(suite.T(), req.Task.DueDate.AsTime().Equal(want))
+		assert.False(suite.T(), req.Task.DueAllDay)
+		return &task.CreateTaskResponse{Id: "12345"}, nil
+	})
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	var res map[string]any
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(suite.T(), want.Format(time.RFC3339), res["due_date"])
+	assert.Equal(suite.T(), false, res["due_all_day"])
+}
+
+func (suite *ServerTestSuite) TestCreateTask_AllDayDueDate() {
+	reqBody := `{"title":"New Task","due_date_time":"2024-08-02"}`
+	req := httptest.NewRequest("POST", "/tasks", bytes.NewBufferString(reqBody))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Timezone", "Europe/Berlin")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().CreateTask(gomock.Any(), gomock.Any()).DoAndReturn(func(_ any, req *task.CreateTaskRequest, _ ...any) (*task.CreateTaskResponse, error) {
+		assert.True(suite.T(), req.Task.DueAllDay)
+		return &task.CreateTaskResponse{Id: "12345"}, nil
+	})
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"task_id": "12345", "due_date": "2024-08-02T23:59:59+02:00", "due_all_day": true}`, w.Body.String())
+}
+
+func (suite *ServerTestSuite) TestUpdateTask_InvalidTimezone() {
+	reqBody := `{"title":"New Task","due_date_time":"next friday"}`
+	req := httptest.NewRequest("PUT", "/tasks/12345", bytes.NewBufferString(reqBody))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Timezone", "Mars/Olympus_Mons")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.JSONEq(suite.T(), `{"message":"Invalid Timezone header, please use an IANA name such as Europe/Berlin"}`, w.Body.String())
+}
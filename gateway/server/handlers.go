@@ -3,7 +3,6 @@ package server
 import (
 	"context"
 	"net/http"
-	"time"
 
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
@@ -13,6 +12,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/golodash/galidator"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/sejamuchhal/taskhub/gateway/dateparse"
 	"github.com/sejamuchhal/taskhub/gateway/pb/auth"
 	"github.com/sejamuchhal/taskhub/gateway/pb/task"
 )
@@ -81,9 +81,10 @@ func (s *Server) LoginUser(c *gin.Context) {
 		return
 	}
 
-	res, err := s.AuthClient.Login(context.Background(), &auth.LoginRequest{
+	res, err := s.AuthClient.Login(clientContext(c), &auth.LoginRequest{
 		Email:    req.Email,
 		Password: req.Password,
+		Client:   clientInfo(c),
 	})
 	if err != nil {
 		st, ok := status.FromError(err)
@@ -93,6 +94,17 @@ func (s *Server) LoginUser(c *gin.Context) {
 				logger.WithError(err).Error("Invalid email or password")
 				c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid email or password"})
 				return
+			case codes.FailedPrecondition:
+				logger.WithError(err).Error("Email not verified")
+				c.JSON(http.StatusForbidden, gin.H{"message": st.Message()})
+				return
+			case codes.PermissionDenied:
+				logger.WithError(err).Error("Account deactivated")
+				c.JSON(http.StatusForbidden, gin.H{"message": st.Message()})
+				return
+			case codes.ResourceExhausted:
+				respondTooManyRequests(c, logger, st)
+				return
 			default:
 				logger.WithError(err).Error("Internal server error")
 				c.JSON(http.StatusInternalServerError, gin.H{"message": "Internal server error"})
@@ -104,8 +116,27 @@ func (s *Server) LoginUser(c *gin.Context) {
 		return
 	}
 
+	if res.MfaRequired {
+		logger.Info("Second factor required")
+		c.JSON(http.StatusOK, mfaChallengeResponse(res))
+		return
+	}
+
 	logger.Info("User login successful")
-	c.JSON(http.StatusOK, LoginUserResponse{
+	c.JSON(http.StatusOK, loginUserResponse(res))
+}
+
+func mfaChallengeResponse(res *auth.LoginResponse) MFAChallengeResponse {
+	return MFAChallengeResponse{
+		MFARequired:           true,
+		MFAToken:              res.MfaToken,
+		MFATokenExpiresAt:     res.MfaTokenExpiresAt.AsTime(),
+		MFAEnrollmentRequired: res.MfaEnrollmentRequired,
+	}
+}
+
+func loginUserResponse(res *auth.LoginResponse) LoginUserResponse {
+	return LoginUserResponse{
 		AccessToken:           res.AccessToken,
 		AccessTokenExpiresAt:  res.AccessTokenExpiresAt.AsTime(),
 		RefreshToken:          res.RefreshToken,
@@ -113,10 +144,10 @@ func (s *Server) LoginUser(c *gin.Context) {
 		SessionID:             res.SessionId,
 
 		User: UserDetail{
-			Name:  res.User.Name,
-			Email: res.User.Email,
+			Name:  res.User.GetName(),
+			Email: res.User.GetEmail(),
 		},
-	})
+	}
 }
 
 func (s *Server) RenewAccessToken(c *gin.Context) {
@@ -159,8 +190,10 @@ func (s *Server) RenewAccessToken(c *gin.Context) {
 
 	logger.Info("Token renewed")
 	c.JSON(http.StatusOK, RenewAccessTokenResponse{
-		AccessToken:          res.AccessToken,
-		AccessTokenExpiresAt: res.AccessTokenExpiresAt.AsTime(),
+		AccessToken:           res.AccessToken,
+		AccessTokenExpiresAt:  res.AccessTokenExpiresAt.AsTime(),
+		RefreshToken:          res.RefreshToken,
+		RefreshTokenExpiresAt: res.RefreshTokenExpiresAt.AsTime(),
 	})
 }
 
@@ -227,15 +260,21 @@ func (s *Server) CreateTask(c *gin.Context) {
 	}
 
 	var dueDate *timestamppb.Timestamp
+	var due *dateparse.Result
 	if taskReq.DueDateTime != "" {
-		const layout = "January 2, 2006 3:04 PM MST"
-		dueDateTime, err := time.Parse(layout, taskReq.DueDateTime)
-		if err != nil {
-			logger.WithError(err).Error("Invalid date time format")
-			c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid date time format, please use: January 2, 2006 3:04 PM MST"})
+		var ok bool
+		due, ok = parseDueDate(c, logger, taskReq.DueDateTime)
+		if !ok {
 			return
 		}
-		dueDate = timestamppb.New(dueDateTime)
+		dueDate = timestamppb.New(due.Time)
+	}
+
+	customFields, err := customFieldValuesFromRequest(taskReq.CustomFields)
+	if err != nil {
+		logger.WithError(err).Error("Invalid custom field values")
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
 	}
 
 	md, ok := getGRPCMetadataFromGin(c, logger)
@@ -247,11 +286,25 @@ func (s *Server) CreateTask(c *gin.Context) {
 	logger.Debug("Sending CreateTask gRPC request")
 	resp, err := s.TaskClient.CreateTask(ctxWithMetadata, &task.CreateTaskRequest{
 		Task: &task.Task{
-			Title:       taskReq.Title,
-			Description: taskReq.Description,
-			DueDate:     dueDate,
+			Title:           taskReq.Title,
+			Description:     taskReq.Description,
+			DueDate:         dueDate,
+			DueAllDay:       due != nil && due.AllDay,
+			EstimateMinutes: taskReq.EstimateMinutes,
+			Tags:            taskReq.Tags,
+			Priority:        taskReq.Priority,
+			CustomFields:    customFields,
 		},
 	})
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		logger.WithError(err).Error("Invalid task")
+		c.JSON(http.StatusBadRequest, gin.H{"message": status.Convert(err).Message()})
+		return
+	case codes.Aborted, codes.FailedPrecondition:
+		respondWithGRPCError(c, logger, err, "create task")
+		return
+	}
 	if err != nil {
 		logger.WithError(err).Error("Failed to create task via gRPC")
 		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
@@ -259,7 +312,9 @@ func (s *Server) CreateTask(c *gin.Context) {
 	}
 
 	logger.WithField("response", resp).Debug("Received response from gRPC")
-	c.JSON(http.StatusOK, gin.H{"task_id": resp.Id})
+	res := gin.H{"task_id": resp.Id}
+	addResolvedDueDate(res, due)
+	c.JSON(http.StatusOK, res)
 }
 
 func (s *Server) GetTask(c *gin.Context) {
@@ -332,9 +387,18 @@ func (s *Server) ListTasks(c *gin.Context) {
 	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
 
 	resp, err := s.TaskClient.ListTasks(ctxWithMetadata, &task.ListTasksRequest{
-		Limit:   int32(req.Limit),
-		Offset:  int32(req.Offset),
-		Pending: req.Pending,
+		Limit:              int32(req.Limit),
+		Offset:             int32(req.Offset),
+		Pending:            req.Pending,
+		Statuses:           req.Statuses,
+		Tags:               req.Tags,
+		Due:                req.Due,
+		CustomFieldFilters: customFieldFiltersFromQuery(c.Request.URL.Query()),
+		SortBy:             req.SortBy,
+		SortFieldId:        req.SortField,
+		SortDesc:           req.SortDesc,
+		Timezone:           req.Timezone,
+		Archived:           req.Archived,
 	})
 	if err != nil {
 		st, ok := status.FromError(err)
@@ -344,6 +408,10 @@ func (s *Server) ListTasks(c *gin.Context) {
 				logger.WithError(err).Error("No tasks found")
 				c.JSON(http.StatusNoContent, gin.H{"message": "No tasks found"})
 				return
+			case codes.InvalidArgument:
+				logger.WithError(err).Error("Invalid task query")
+				c.JSON(http.StatusBadRequest, gin.H{"message": st.Message()})
+				return
 			default:
 				logger.WithError(err).Error("Failed to list task.")
 				c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to list tasks. Please try again"})
@@ -428,15 +496,20 @@ func (s *Server) UpdateTask(c *gin.Context) {
 	}
 
 	var dueDate *timestamppb.Timestamp
+	var due *dateparse.Result
 	if taskReq.DueDateTime != "" {
-		const layout = "January 2, 2006 3:04 PM MST"
-		dueDateTime, err := time.Parse(layout, taskReq.DueDateTime)
-		if err != nil {
-			logger.WithError(err).Error("Invalid date time format")
-			c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid date time format, please use: January 2, 2006 3:04 PM MST"})
+		var ok bool
+		due, ok = parseDueDate(c, logger, taskReq.DueDateTime)
+		if !ok {
 			return
 		}
-		dueDate = timestamppb.New(dueDateTime)
+		dueDate = timestamppb.New(due.Time)
+	}
+	customFields, err := customFieldValuesFromRequest(taskReq.CustomFields)
+	if err != nil {
+		logger.WithError(err).Error("Invalid custom field values")
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
 	}
 	md, ok := getGRPCMetadataFromGin(c, logger)
 	if !ok {
@@ -444,12 +517,17 @@ func (s *Server) UpdateTask(c *gin.Context) {
 	}
 	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
 
-	_, err := s.TaskClient.UpdateTask(ctxWithMetadata, &task.UpdateTaskRequest{
+	_, err = s.TaskClient.UpdateTask(ctxWithMetadata, &task.UpdateTaskRequest{
 		Task: &task.Task{
-			Id:          taskID,
-			Title:       taskReq.Title,
-			Description: taskReq.Description,
-			DueDate:     dueDate,
+			Id:              taskID,
+			Title:           taskReq.Title,
+			Description:     taskReq.Description,
+			DueDate:         dueDate,
+			DueAllDay:       due != nil && due.AllDay,
+			EstimateMinutes: taskReq.EstimateMinutes,
+			Tags:            taskReq.Tags,
+			Priority:        taskReq.Priority,
+			CustomFields:    customFields,
 		},
 	})
 	if err != nil {
@@ -460,6 +538,13 @@ func (s *Server) UpdateTask(c *gin.Context) {
 				logger.WithError(err).Error("Task not found")
 				c.JSON(http.StatusNotFound, gin.H{"message": "Task not found"})
 				return
+			case codes.InvalidArgument:
+				logger.WithError(err).Error("Invalid task")
+				c.JSON(http.StatusBadRequest, gin.H{"message": st.Message()})
+				return
+			case codes.Aborted, codes.FailedPrecondition:
+				respondWithGRPCError(c, logger, err, "update task")
+				return
 			default:
 				logger.WithError(err).Error("Failed to update task.")
 				c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to update task. Please try again"})
@@ -468,7 +553,9 @@ func (s *Server) UpdateTask(c *gin.Context) {
 		}
 	}
 
-	c.JSON(http.StatusOK, gin.H{"message": "Task updated successfully", "task_id": taskID})
+	res := gin.H{"message": "Task updated successfully", "task_id": taskID}
+	addResolvedDueDate(res, due)
+	c.JSON(http.StatusOK, res)
 }
 
 func (s *Server) CompleteTask(c *gin.Context) {
@@ -514,6 +601,10 @@ func (s *Server) CompleteTask(c *gin.Context) {
 	_, err = s.TaskClient.UpdateTask(ctxWithMetadata, &task.UpdateTaskRequest{
 		Task: getTaskResp.Task,
 	})
+	if code := status.Code(err); code == codes.Aborted || code == codes.FailedPrecondition {
+		respondWithGRPCError(c, logger, err, "complete task")
+		return
+	}
 	if err != nil {
 		logger.WithError(err).Error("Failed to mark task as complete. Please try again")
 		c.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
@@ -14,9 +14,11 @@ import (
 	"github.com/sejamuchhal/taskhub/gateway/server"
 	"github.com/stretchr/testify/assert"
 	gomock "go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -114,12 +116,14 @@ func (suite *ServerTestSuite) TestLoginUser_Success() {
 	reqBody := `{"email":"harry@hogwarts.edu","password":"password"}`
 	req := httptest.NewRequest("POST", "/auth/login", bytes.NewBufferString(reqBody))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Mozilla/5.0")
 	w := httptest.NewRecorder()
 
 	expireTime := time.Now().UTC().Add(time.Hour)
-	suite.mockAuth.EXPECT().Login(gomock.Any(), &auth.LoginRequest{
+	suite.mockAuth.EXPECT().Login(fromClient(testClientIP), &auth.LoginRequest{
 		Email:    "harry@hogwarts.edu",
 		Password: "password",
+		Client:   &auth.ClientInfo{UserAgent: "Mozilla/5.0"},
 	}).Return(&auth.LoginResponse{
 		AccessToken:           "access_token",
 		AccessTokenExpiresAt:  timestamppb.New(expireTime),
@@ -156,9 +160,10 @@ func (suite *ServerTestSuite) TestLoginUser_InvalidCredentials() {
 	req.Header.Set("Content-Type", "application/json")
 	w := httptest.NewRecorder()
 
-	suite.mockAuth.EXPECT().Login(gomock.Any(), &auth.LoginRequest{
+	suite.mockAuth.EXPECT().Login(fromClient(testClientIP), &auth.LoginRequest{
 		Email:    "jerry@hogwarts.edu",
 		Password: "wrong-password",
+		Client:   testClient,
 	}).Return(nil, status.Errorf(codes.Unauthenticated, "Invalid email or password"))
 
 	suite.router.ServeHTTP(w, req)
@@ -167,6 +172,25 @@ func (suite *ServerTestSuite) TestLoginUser_InvalidCredentials() {
 	assert.JSONEq(suite.T(), `{"message": "Invalid email or password"}`, w.Body.String())
 }
 
+func (suite *ServerTestSuite) TestLoginUser_TooManyAttempts() {
+	reqBody := `{"email":"jerry@hogwarts.edu","password":"wrong-password"}`
+	req := httptest.NewRequest("POST", "/auth/login", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	st, err := status.New(codes.ResourceExhausted, "Too many failed login attempts, try again later").
+		WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(14*time.Minute + 500*time.Millisecond)})
+	suite.Require().NoError(err)
+	suite.mockAuth.EXPECT().Login(gomock.Any(), gomock.Any()).Return(nil, st.Err())
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusTooManyRequests, w.Code)
+	// Rounded up to whole seconds
+	assert.Equal(suite.T(), "841", w.Header().Get("Retry-After"))
+	assert.JSONEq(suite.T(), `{"message": "Too many failed login attempts, try again later"}`, w.Body.String())
+}
+
 func (suite *ServerTestSuite) TestRenewAccessToken_Success() {
 	req := httptest.NewRequest("POST", "/auth/renew", nil)
 	req.Header.Set("Refresh", "refresh_token")
@@ -176,15 +200,19 @@ func (suite *ServerTestSuite) TestRenewAccessToken_Success() {
 	suite.mockAuth.EXPECT().RenewAccessToken(gomock.Any(), &auth.RenewAccessTokenRequest{
 		RefreshToken: "refresh_token",
 	}).Return(&auth.RenewAccessTokenResponse{
-		AccessToken:          "new_access_token",
-		AccessTokenExpiresAt: timestamppb.New(expireTime),
+		AccessToken:           "new_access_token",
+		AccessTokenExpiresAt:  timestamppb.New(expireTime),
+		RefreshToken:          "new_refresh_token",
+		RefreshTokenExpiresAt: timestamppb.New(expireTime),
 	}, nil)
 
 	suite.router.ServeHTTP(w, req)
 
 	expectedResponse := `{
 		"access_token": "new_access_token",
-		"access_token_expires_at": "` + expireTime.Format(time.RFC3339Nano) + `"
+		"access_token_expires_at": "` + expireTime.Format(time.RFC3339Nano) + `",
+		"refresh_token": "new_refresh_token",
+		"refresh_token_expires_at": "` + expireTime.Format(time.RFC3339Nano) + `"
 	}`
 
 	assert.Equal(suite.T(), http.StatusOK, w.Code)
@@ -288,7 +316,7 @@ func (suite *ServerTestSuite) TestCreateTask_Success() {
 
 	suite.router.ServeHTTP(w, req)
 	assert.Equal(suite.T(), http.StatusOK, w.Code)
-	assert.JSONEq(suite.T(), `{"task_id": "12345" }`, w.Body.String())
+	assert.JSONEq(suite.T(), `{"task_id": "12345", "due_date": "2024-07-24T15:04:00Z", "due_all_day": false}`, w.Body.String())
 }
 
 func (suite *ServerTestSuite) TestCreateTask_InvalidDateFormat() {
@@ -307,7 +335,7 @@ func (suite *ServerTestSuite) TestCreateTask_InvalidDateFormat() {
 	suite.router.ServeHTTP(w, req)
 
 	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
-	assert.JSONEq(suite.T(), `{"message":"Invalid date time format, please use: January 2, 2006 3:04 PM MST"}`, w.Body.String())
+	assert.JSONEq(suite.T(), `{"message":"Invalid date time format, please use RFC 3339, a date such as 2024-07-30 or a phrase such as \"tomorrow 5pm\""}`, w.Body.String())
 }
 
 func (suite *ServerTestSuite) TestGetTask_Success() {
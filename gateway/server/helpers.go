@@ -1,36 +1,307 @@
 package server
 
 import (
+	"context"
+	"math"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sejamuchhal/taskhub/gateway/dateparse"
+	"github.com/sejamuchhal/taskhub/gateway/pb/auth"
 	pb "github.com/sejamuchhal/taskhub/gateway/pb/task"
+	"github.com/sejamuchhal/taskhub/gateway/quickadd"
 	"github.com/sirupsen/logrus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TransformTask(task *pb.Task) *TaskDetails {
-	// Convert protobuf Timestamp to a formatted string
-	formatTimestamp := func(ts *timestamppb.Timestamp) string {
-		if ts == nil {
-			return ""
+	return &TaskDetails{
+		ID:              task.Id,
+		Title:           task.Title,
+		Description:     task.Description,
+		Status:          task.Status,
+		DueDate:         formatTimestamp(task.DueDate),
+		DueAllDay:       task.DueAllDay,
+		CreatedAt:       formatTimestamp(task.CreatedAt),
+		UpdatedAt:       formatTimestamp(task.UpdatedAt),
+		CompletedAt:     formatTimestamp(task.CompletedAt),
+		ArchivedAt:      formatTimestamp(task.ArchivedAt),
+		EstimateMinutes: task.EstimateMinutes,
+		TrackedMinutes:  task.TrackedMinutes,
+		Position:        task.Position,
+		Priority:        task.Priority,
+		Tags:            task.Tags,
+		CustomFields:    TransformCustomFieldValues(task.CustomFields),
+	}
+}
+
+func TransformCustomField(field *pb.CustomField) *CustomFieldDetails {
+	return &CustomFieldDetails{
+		ID:        field.Id,
+		Name:      field.Name,
+		Type:      field.Type,
+		Options:   field.Options,
+		CreatedAt: formatTimestamp(field.CreatedAt),
+		UpdatedAt: formatTimestamp(field.UpdatedAt),
+	}
+}
+
+// TransformView converts a saved view. Filter values are returned as the
+// text they are stored as.
+func TransformView(view *pb.SavedView) *ViewDetails {
+	query := view.GetQuery()
+	var filters []CustomFieldFilter
+	for _, filter := range query.GetCustomFieldFilters() {
+		filters = append(filters, CustomFieldFilter{
+			FieldID: filter.FieldId,
+			Op:      filter.Op,
+			Value:   filter.GetValue().GetStringValue(),
+		})
+	}
+	return &ViewDetails{
+		ID:   view.GetId(),
+		Name: view.GetName(),
+		Query: TaskQuery{
+			Statuses:           query.GetStatuses(),
+			Tags:               query.GetTags(),
+			Due:                query.GetDue(),
+			CustomFieldFilters: filters,
+			SortBy:             query.GetSortBy(),
+			SortFieldID:        query.GetSortFieldId(),
+			SortDesc:           query.GetSortDesc(),
+		},
+		GroupBy:    view.GetGroupBy(),
+		Visibility: view.GetVisibility(),
+		UserID:     view.GetUserId(),
+		CreatedAt:  formatTimestamp(view.GetCreatedAt()),
+		UpdatedAt:  formatTimestamp(view.GetUpdatedAt()),
+	}
+}
+
+func TransformRetentionPolicy(policy *pb.RetentionPolicy) *RetentionPolicyDetails {
+	return &RetentionPolicyDetails{
+		ArchiveAfterDays: policy.GetArchiveAfterDays(),
+		PurgeAfterDays:   policy.GetPurgeAfterDays(),
+		IsDefault:        policy.GetIsDefault(),
+	}
+}
+
+func TransformTaskWatcher(watcher *pb.TaskWatcher) *TaskWatcherDetails {
+	return &TaskWatcherDetails{
+		UserID:    watcher.UserId,
+		Email:     watcher.Email,
+		CreatedAt: formatTimestamp(watcher.CreatedAt),
+	}
+}
+
+func TransformQuickAdd(parsed quickadd.Task) QuickAddDetails {
+	details := QuickAddDetails{
+		Title:    parsed.Title,
+		Tags:     parsed.Tags,
+		Priority: parsed.Priority,
+		Assignee: parsed.Assignee,
+	}
+	if parsed.Due != nil {
+		details.DueDate = parsed.Due.Time.Format(time.RFC3339)
+		details.DueAllDay = parsed.Due.AllDay
+	}
+	if parsed.Recurrence != nil {
+		details.Recurrence = parsed.Recurrence.String()
+	}
+	return details
+}
+
+// TransformCustomFieldValues converts custom field values to plain JSON values
+func TransformCustomFieldValues(values map[string]*pb.CustomFieldValue) map[string]interface{} {
+	if len(values) == 0 {
+		return nil
+	}
+	res := make(map[string]interface{}, len(values))
+	for id, value := range values {
+		switch kind := value.GetKind().(type) {
+		case *pb.CustomFieldValue_StringValue:
+			res[id] = kind.StringValue
+		case *pb.CustomFieldValue_NumberValue:
+			res[id] = kind.NumberValue
+		case *pb.CustomFieldValue_BoolValue:
+			res[id] = kind.BoolValue
+		case *pb.CustomFieldValue_ListValue:
+			res[id] = kind.ListValue.GetValues()
 		}
-		t := ts.AsTime()
-		return t.Format(time.RFC3339)
 	}
+	return res
+}
 
-	return &TaskDetails{
-		Title:       task.Title,
-		Description: task.Description,
-		Status:      task.Status,
-		DueDate:     formatTimestamp(task.DueDate),
-		CreatedAt:   formatTimestamp(task.CreatedAt),
-		UpdatedAt:   formatTimestamp(task.UpdatedAt),
+func TransformTemplate(template *pb.TaskTemplate) *TemplateDetails {
+	return &TemplateDetails{
+		ID:                     template.Id,
+		Name:                   template.Name,
+		Title:                  template.Title,
+		Description:            template.Description,
+		Checklist:              template.Checklist,
+		Tags:                   template.Tags,
+		DueOffsetMinutes:       template.DueOffsetMinutes,
+		ReminderOffsetsMinutes: template.ReminderOffsetsMinutes,
+		EstimateMinutes:        template.EstimateMinutes,
+		CreatedAt:              formatTimestamp(template.CreatedAt),
+		UpdatedAt:              formatTimestamp(template.UpdatedAt),
 	}
 }
 
+func formatTimestamp(ts *timestamppb.Timestamp) string {
+	if ts == nil {
+		return ""
+	}
+	return ts.AsTime().Format(time.RFC3339)
+}
+
+func TransformTimeEntry(entry *pb.TimeEntry) *TimeEntryDetails {
+	return &TimeEntryDetails{
+		ID:              entry.Id,
+		TaskID:          entry.TaskId,
+		StartedAt:       formatTimestamp(entry.StartedAt),
+		EndedAt:         formatTimestamp(entry.EndedAt),
+		Note:            entry.Note,
+		DurationSeconds: entry.DurationSeconds,
+		Running:         entry.Running,
+		CreatedAt:       formatTimestamp(entry.CreatedAt),
+		UpdatedAt:       formatTimestamp(entry.UpdatedAt),
+	}
+}
+
+func TransformTimeReportBuckets(buckets []*pb.TimeReportBucket) []TimeReportBucket {
+	res := make([]TimeReportBucket, len(buckets))
+	for i, b := range buckets {
+		res[i] = TimeReportBucket{
+			Key:             b.Key,
+			Label:           b.Label,
+			DurationSeconds: b.DurationSeconds,
+		}
+	}
+	return res
+}
+
+// parseOptionalTimestamp parses an RFC 3339 value, returning nil when empty
+func parseOptionalTimestamp(value string) (*timestamppb.Timestamp, error) {
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return timestamppb.New(t), nil
+}
+
+// requestLocation returns the caller's time zone from the Timezone header,
+// UTC when it is not set
+func requestLocation(c *gin.Context) (*time.Location, error) {
+	name := c.GetHeader("Timezone")
+	if name == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(name)
+}
+
+// parseDueDate resolves a user supplied due date in the caller's time zone.
+// It writes the error response itself and reports whether parsing worked.
+func parseDueDate(c *gin.Context, logger *logrus.Entry, value string) (*dateparse.Result, bool) {
+	loc, err := requestLocation(c)
+	if err != nil {
+		logger.WithError(err).Error("Invalid timezone")
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid Timezone header, please use an IANA name such as Europe/Berlin"})
+		return nil, false
+	}
+
+	due, err := dateparse.Parse(value, time.Now(), loc)
+	if err != nil {
+		logger.WithError(err).Error("Invalid date time format")
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid date time format, please use RFC 3339, a date such as 2024-07-30 or a phrase such as \"tomorrow 5pm\""})
+		return nil, false
+	}
+	due.Time = due.Time.In(loc)
+	return &due, true
+}
+
+// addResolvedDueDate echoes a parsed due date so clients can confirm it
+func addResolvedDueDate(res gin.H, due *dateparse.Result) {
+	if due == nil {
+		return
+	}
+	res["due_date"] = due.Time.Format(time.RFC3339)
+	res["due_all_day"] = due.AllDay
+}
+
+// respondWithGRPCError maps a gRPC error from a backend service to an HTTP response
+func respondWithGRPCError(c *gin.Context, logger *logrus.Entry, err error, action string) {
+	st, ok := status.FromError(err)
+	if !ok {
+		logger.WithError(err).Error("Unknown error")
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Unknown error"})
+		return
+	}
+
+	switch st.Code() {
+	case codes.NotFound:
+		logger.WithError(err).Error("Resource not found")
+		c.JSON(http.StatusNotFound, gin.H{"message": st.Message()})
+	case codes.InvalidArgument:
+		logger.WithError(err).Error("Invalid request")
+		c.JSON(http.StatusBadRequest, gin.H{"message": st.Message()})
+	case codes.Unauthenticated:
+		logger.WithError(err).Error("Unauthenticated")
+		c.JSON(http.StatusUnauthorized, gin.H{"message": st.Message()})
+	case codes.FailedPrecondition, codes.AlreadyExists:
+		logger.WithError(err).Error("Conflicting request")
+		c.JSON(http.StatusConflict, gin.H{"message": st.Message()})
+	case codes.PermissionDenied:
+		logger.WithError(err).Error("Permission denied")
+		c.JSON(http.StatusForbidden, gin.H{"message": "Permission denied"})
+	case codes.ResourceExhausted:
+		respondTooManyRequests(c, logger, st)
+	case codes.Aborted:
+		// The idempotency key was used for a different request
+		logger.WithError(err).Error("Idempotency key reused")
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"message": st.Message()})
+	default:
+		logger.WithError(err).Errorf("Failed to %s", action)
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Failed to " + action + ". Please try again"})
+	}
+}
+
+// respondTooManyRequests tells the client to back off, for as long as the
+// RetryInfo of st says
+func respondTooManyRequests(c *gin.Context, logger *logrus.Entry, st *status.Status) {
+	for _, detail := range st.Details() {
+		if retryInfo, ok := detail.(*errdetails.RetryInfo); ok {
+			seconds := math.Ceil(retryInfo.RetryDelay.AsDuration().Seconds())
+			c.Header("Retry-After", strconv.FormatInt(int64(seconds), 10))
+		}
+	}
+	logger.WithError(st.Err()).Warn("Too many requests")
+	c.JSON(http.StatusTooManyRequests, gin.H{"message": st.Message()})
+}
+
+// clientInfo describes the client of a request that starts a session
+func clientInfo(c *gin.Context) *auth.ClientInfo {
+	return &auth.ClientInfo{
+		UserAgent: c.Request.UserAgent(),
+	}
+}
+
+// clientContext returns the context of an auth call that checks credentials.
+// It passes the IP address of the client in metadata, where the client
+// cannot set it, for the auth service to limit failed logins by.
+func clientContext(c *gin.Context) context.Context {
+	return metadata.NewOutgoingContext(context.Background(), metadata.Pairs("client_ip", c.ClientIP()))
+}
+
 func getGRPCMetadataFromGin(c *gin.Context, logger *logrus.Entry) (metadata.MD, bool) {
 	userID, exists := c.Get("user_id")
 	if !exists {
@@ -53,6 +324,10 @@ func getGRPCMetadataFromGin(c *gin.Context, logger *logrus.Entry) (metadata.MD,
 		"user_id": userIDStr,
 		"email":   emailStr,
 	})
+	if key := c.GetString("idempotency_key"); key != "" {
+		metadata.Set("idempotency_key", key)
+		metadata.Set("idempotency_fingerprint", c.GetString("idempotency_fingerprint"))
+	}
 
 	return metadata, true
 }
@@ -33,6 +33,7 @@ func TestTransformTask(t *testing.T) {
 				},
 			},
 			want: &TaskDetails{
+				ID:          "123",
 				Title:       "Test Task",
 				Description: "This is a test task",
 				Status:      "created",
@@ -55,6 +56,7 @@ func TestTransformTask(t *testing.T) {
 				},
 			},
 			want: &TaskDetails{
+				ID:          "456",
 				Title:       "Partial Task",
 				Description: "Partially filled task",
 				Status:      "In Progress",
@@ -0,0 +1,108 @@
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/sejamuchhal/taskhub/gateway/pb/task"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func (suite *ServerTestSuite) createTaskWithKey(key, timezone, body string) (*httptest.ResponseRecorder, metadata.MD) {
+	req := httptest.NewRequest("POST", "/tasks", bytes.NewBufferString(body))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", key)
+	if timezone != "" {
+		req.Header.Set("Timezone", timezone)
+	}
+	w := httptest.NewRecorder()
+
+	var md metadata.MD
+	suite.expectValidate()
+	suite.mockTask.EXPECT().CreateTask(gomock.Any(), &task.CreateTaskRequest{Task: &task.Task{Title: "New Task"}}).
+		DoAndReturn(func(ctx context.Context, req *task.CreateTaskRequest, opts ...grpc.CallOption) (*task.CreateTaskResponse, error) {
+			md, _ = metadata.FromOutgoingContext(ctx)
+			return &task.CreateTaskResponse{Id: "12345"}, nil
+		})
+
+	suite.router.ServeHTTP(w, req)
+	return w, md
+}
+
+func (suite *ServerTestSuite) TestCreateTask_IdempotencyKey() {
+	w, md := suite.createTaskWithKey("retry-1", "", `{"title":"New Task"}`)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Equal(suite.T(), []string{"retry-1"}, md.Get("idempotency_key"))
+	fingerprint := md.Get("idempotency_fingerprint")
+	assert.Len(suite.T(), fingerprint, 1)
+	assert.NotEmpty(suite.T(), fingerprint[0])
+
+	// The same request has the same fingerprint, a different body or time
+	// zone does not
+	_, md = suite.createTaskWithKey("retry-1", "", `{"title":"New Task"}`)
+	assert.Equal(suite.T(), fingerprint, md.Get("idempotency_fingerprint"))
+	_, md = suite.createTaskWithKey("retry-1", "", `{"title":"New Task","description":""}`)
+	assert.NotEqual(suite.T(), fingerprint, md.Get("idempotency_fingerprint"))
+	_, md = suite.createTaskWithKey("retry-1", "Asia/Kolkata", `{"title":"New Task"}`)
+	assert.NotEqual(suite.T(), fingerprint, md.Get("idempotency_fingerprint"))
+}
+
+func (suite *ServerTestSuite) TestCreateTask_WithoutIdempotencyKey() {
+	req := httptest.NewRequest("POST", "/tasks", bytes.NewBufferString(`{"title":"New Task"}`))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().CreateTask(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, req *task.CreateTaskRequest, opts ...grpc.CallOption) (*task.CreateTaskResponse, error) {
+			md, _ := metadata.FromOutgoingContext(ctx)
+			assert.Empty(suite.T(), md.Get("idempotency_key"))
+			return &task.CreateTaskResponse{Id: "12345"}, nil
+		})
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
+func (suite *ServerTestSuite) TestCreateTask_IdempotencyKeyReused() {
+	req := httptest.NewRequest("POST", "/tasks", bytes.NewBufferString(`{"title":"Other Task"}`))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", "retry-1")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().CreateTask(gomock.Any(), gomock.Any()).
+		Return(nil, status.Error(codes.Aborted, "This idempotency key was already used for a different request"))
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusUnprocessableEntity, w.Code)
+	assert.JSONEq(suite.T(), `{"message":"This idempotency key was already used for a different request"}`, w.Body.String())
+}
+
+func (suite *ServerTestSuite) TestMoveTask_IdempotencyKeyInProgress() {
+	req := httptest.NewRequest("PUT", "/tasks/12345/move", bytes.NewBufferString(`{"status":"completed"}`))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Idempotency-Key", "retry-2")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().MoveTask(gomock.Any(), gomock.Any()).
+		Return(nil, status.Error(codes.FailedPrecondition, "A request with this idempotency key is still in progress"))
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+}
@@ -18,6 +18,7 @@ type ServerTestSuite struct {
 	suite.Suite
 	mockCtrl    *gomock.Controller
 	mockAuth    *auth.MockAuthServiceClient
+	mockAdmin   *auth.MockAdminServiceClient
 	mockTask    *task.MockTaskServiceClient
 	server      *server.Server
 	ginRecorder *httptest.ResponseRecorder
@@ -27,12 +28,14 @@ type ServerTestSuite struct {
 func (suite *ServerTestSuite) SetupTest() {
 	suite.mockCtrl = gomock.NewController(suite.T())
 	suite.mockAuth = auth.NewMockAuthServiceClient(suite.mockCtrl)
+	suite.mockAdmin = auth.NewMockAdminServiceClient(suite.mockCtrl)
 	suite.mockTask = task.NewMockTaskServiceClient(suite.mockCtrl)
 
 	suite.server = &server.Server{
-		AuthClient: suite.mockAuth,
-		TaskClient: suite.mockTask,
-		Logger:     logrus.NewEntry(logrus.New()),
+		AuthClient:  suite.mockAuth,
+		AdminClient: suite.mockAdmin,
+		TaskClient:  suite.mockTask,
+		Logger:      logrus.NewEntry(logrus.New()),
 	}
 
 	gin.SetMode(gin.TestMode)
@@ -0,0 +1,38 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sejamuchhal/taskhub/gateway/pb/auth"
+)
+
+// GetJWKS publishes the public keys tokens are signed with. Keys change
+// rarely and new ones are published ahead of use, so clients may cache it.
+func (s *Server) GetJWKS(c *gin.Context) {
+	logger := s.Logger.WithField("method", "GetJWKS")
+	logger.Debug("Incoming request")
+
+	res, err := s.AuthClient.GetJWKS(context.Background(), &auth.GetJWKSRequest{})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "get keys")
+		return
+	}
+
+	keys := make([]JWK, len(res.Keys))
+	for i, key := range res.Keys {
+		keys[i] = JWK{
+			Kty: key.Kty,
+			Kid: key.Kid,
+			Use: key.Use,
+			Alg: key.Alg,
+			N:   key.N,
+			E:   key.E,
+			Crv: key.Crv,
+			X:   key.X,
+		}
+	}
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, JWKSResponse{Keys: keys})
+}
@@ -0,0 +1,43 @@
+package server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/sejamuchhal/taskhub/gateway/pb/auth"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (suite *ServerTestSuite) TestGetJWKS() {
+	req := httptest.NewRequest("GET", "/.well-known/jwks.json", nil)
+	w := httptest.NewRecorder()
+
+	suite.mockAuth.EXPECT().GetJWKS(gomock.Any(), gomock.Any()).Return(&auth.GetJWKSResponse{Keys: []*auth.JWK{
+		{Kty: "OKP", Kid: "20261101", Use: "sig", Alg: "EdDSA", Crv: "Ed25519", X: "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"},
+		{Kty: "RSA", Kid: "20261001", Use: "sig", Alg: "RS256", N: "sXch", E: "AQAB"},
+	}}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Equal(suite.T(), "public, max-age=300", w.Header().Get("Cache-Control"))
+	assert.JSONEq(suite.T(), `{"keys": [
+		{"kty": "OKP", "kid": "20261101", "use": "sig", "alg": "EdDSA", "crv": "Ed25519", "x": "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"},
+		{"kty": "RSA", "kid": "20261001", "use": "sig", "alg": "RS256", "n": "sXch", "e": "AQAB"}
+	]}`, w.Body.String())
+}
+
+func (suite *ServerTestSuite) TestGetJWKS_AuthUnavailable() {
+	req := httptest.NewRequest("GET", "/.well-known/jwks.json", nil)
+	w := httptest.NewRecorder()
+
+	suite.mockAuth.EXPECT().GetJWKS(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.Unavailable, "connection refused"))
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusInternalServerError, w.Code)
+	assert.Empty(suite.T(), w.Header().Get("Cache-Control"))
+}
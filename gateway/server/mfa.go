@@ -0,0 +1,133 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sejamuchhal/taskhub/gateway/pb/auth"
+)
+
+// VerifyMFA finishes a login that needs a second factor
+func (s *Server) VerifyMFA(c *gin.Context) {
+	logger := s.Logger.WithField("method", "VerifyMFA")
+	logger.Debug("Incoming request")
+
+	var req VerifyMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.WithError(err).Error("Error parsing verify mfa request")
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	res, err := s.AuthClient.VerifyMFA(clientContext(c), &auth.VerifyMFARequest{
+		MfaToken:     req.MFAToken,
+		Code:         req.Code,
+		RecoveryCode: req.RecoveryCode,
+		Client:       clientInfo(c),
+	})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "verify code")
+		return
+	}
+
+	logger.Info("User login successful")
+	c.JSON(http.StatusOK, loginUserResponse(res))
+}
+
+// EnrollMFA starts two-factor authentication. Logged in users send their
+// Access header, users who have to enroll to log in send their mfa_token.
+func (s *Server) EnrollMFA(c *gin.Context) {
+	logger := s.Logger.WithField("method", "EnrollMFA")
+	logger.Debug("Incoming request")
+
+	var req EnrollMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil && c.Request.ContentLength > 0 {
+		logger.WithError(err).Error("Error parsing enroll mfa request")
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	access := c.GetHeader("Access")
+	if access == "" && req.MFAToken == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Access header or mfa_token is required"})
+		return
+	}
+
+	res, err := s.AuthClient.EnrollMFA(context.Background(), &auth.EnrollMFARequest{
+		AccessToken: access,
+		MfaToken:    req.MFAToken,
+	})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "enroll in two-factor authentication")
+		return
+	}
+
+	c.JSON(http.StatusOK, EnrollMFAResponse{Secret: res.Secret, URI: res.Uri})
+}
+
+// ConfirmMFA enables two-factor authentication with a code from the newly
+// set up TOTP app. Users who enrolled to log in are logged in too.
+func (s *Server) ConfirmMFA(c *gin.Context) {
+	logger := s.Logger.WithField("method", "ConfirmMFA")
+	logger.Debug("Incoming request")
+
+	var req ConfirmMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.WithError(err).Error("Error parsing confirm mfa request")
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	access := c.GetHeader("Access")
+	if access == "" && req.MFAToken == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Access header or mfa_token is required"})
+		return
+	}
+
+	res, err := s.AuthClient.ConfirmMFA(clientContext(c), &auth.ConfirmMFARequest{
+		AccessToken: access,
+		MfaToken:    req.MFAToken,
+		Code:        req.Code,
+		Client:      clientInfo(c),
+	})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "confirm two-factor authentication")
+		return
+	}
+
+	logger.Info("Two-factor authentication enabled")
+	resp := ConfirmMFAResponse{RecoveryCodes: res.RecoveryCodes}
+	if res.Login != nil {
+		login := loginUserResponse(res.Login)
+		resp.Login = &login
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// DisableMFA turns off two-factor authentication for the caller
+func (s *Server) DisableMFA(c *gin.Context) {
+	logger := s.Logger.WithField("method", "DisableMFA")
+	logger.Debug("Incoming request")
+
+	var req DisableMFARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.WithError(err).Error("Error parsing disable mfa request")
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	res, err := s.AuthClient.DisableMFA(clientContext(c), &auth.DisableMFARequest{
+		AccessToken:  c.GetHeader("Access"),
+		Password:     req.Password,
+		Code:         req.Code,
+		RecoveryCode: req.RecoveryCode,
+	})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "disable two-factor authentication")
+		return
+	}
+
+	logger.Info("Two-factor authentication disabled")
+	c.JSON(http.StatusOK, gin.H{"message": res.Message})
+}
@@ -0,0 +1,143 @@
+package server_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/sejamuchhal/taskhub/gateway/pb/auth"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (suite *ServerTestSuite) TestLoginUser_MFARequired() {
+	req := httptest.NewRequest("POST", "/auth/login", bytes.NewBufferString(`{"email":"harry@hogwarts.edu","password":"password"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	expiresAt := time.Date(2024, 7, 30, 10, 5, 0, 0, time.UTC)
+	suite.mockAuth.EXPECT().Login(gomock.Any(), gomock.Any()).Return(&auth.LoginResponse{
+		MfaRequired:       true,
+		MfaToken:          "mfa_token",
+		MfaTokenExpiresAt: timestamppb.New(expiresAt),
+	}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{
+		"mfa_required": true,
+		"mfa_token": "mfa_token",
+		"mfa_token_expires_at": "2024-07-30T10:05:00Z",
+		"mfa_enrollment_required": false
+	}`, w.Body.String())
+}
+
+func (suite *ServerTestSuite) TestVerifyMFA_Success() {
+	req := httptest.NewRequest("POST", "/auth/mfa/verify", bytes.NewBufferString(`{"mfa_token":"mfa_token","code":"123456"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.mockAuth.EXPECT().VerifyMFA(fromClient(testClientIP), &auth.VerifyMFARequest{MfaToken: "mfa_token", Code: "123456", Client: testClient}).
+		Return(&auth.LoginResponse{
+			AccessToken:           "access_token",
+			AccessTokenExpiresAt:  timestamppb.Now(),
+			RefreshToken:          "refresh_token",
+			RefreshTokenExpiresAt: timestamppb.Now(),
+			SessionId:             "session_id",
+			User:                  &auth.UserDetail{Name: "Harry Potter", Email: "harry@hogwarts.edu"},
+		}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"access_token":"access_token"`)
+}
+
+func (suite *ServerTestSuite) TestVerifyMFA_InvalidCode() {
+	req := httptest.NewRequest("POST", "/auth/mfa/verify", bytes.NewBufferString(`{"mfa_token":"mfa_token","recovery_code":"abcde-fghij"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.mockAuth.EXPECT().VerifyMFA(fromClient(testClientIP), &auth.VerifyMFARequest{MfaToken: "mfa_token", RecoveryCode: "abcde-fghij", Client: testClient}).
+		Return(nil, status.Error(codes.InvalidArgument, "Invalid code"))
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.JSONEq(suite.T(), `{"message":"Invalid code"}`, w.Body.String())
+}
+
+func (suite *ServerTestSuite) TestVerifyMFA_MissingCode() {
+	req := httptest.NewRequest("POST", "/auth/mfa/verify", bytes.NewBufferString(`{"mfa_token":"mfa_token"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *ServerTestSuite) TestEnrollMFA_WithAccessToken() {
+	req := httptest.NewRequest("POST", "/auth/mfa/enroll", nil)
+	req.Header.Set("Access", "access_token")
+	w := httptest.NewRecorder()
+
+	suite.mockAuth.EXPECT().EnrollMFA(gomock.Any(), &auth.EnrollMFARequest{AccessToken: "access_token"}).
+		Return(&auth.EnrollMFAResponse{Secret: "JBSWY3DPEHPK3PXP", Uri: "otpauth://totp/TaskHub:harry@hogwarts.edu?secret=JBSWY3DPEHPK3PXP"}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"secret":"JBSWY3DPEHPK3PXP","uri":"otpauth://totp/TaskHub:harry@hogwarts.edu?secret=JBSWY3DPEHPK3PXP"}`, w.Body.String())
+}
+
+func (suite *ServerTestSuite) TestEnrollMFA_Unauthenticated() {
+	req := httptest.NewRequest("POST", "/auth/mfa/enroll", nil)
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
+}
+
+func (suite *ServerTestSuite) TestConfirmMFA_DuringLogin() {
+	req := httptest.NewRequest("POST", "/auth/mfa/confirm", bytes.NewBufferString(`{"mfa_token":"mfa_token","code":"123456"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.mockAuth.EXPECT().ConfirmMFA(fromClient(testClientIP), &auth.ConfirmMFARequest{MfaToken: "mfa_token", Code: "123456", Client: testClient}).
+		Return(&auth.ConfirmMFAResponse{
+			RecoveryCodes: []string{"abcde-fghij"},
+			Login: &auth.LoginResponse{
+				AccessToken:           "access_token",
+				AccessTokenExpiresAt:  timestamppb.Now(),
+				RefreshTokenExpiresAt: timestamppb.Now(),
+			},
+		}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"recovery_codes":["abcde-fghij"]`)
+	assert.Contains(suite.T(), w.Body.String(), `"access_token":"access_token"`)
+}
+
+func (suite *ServerTestSuite) TestDisableMFA_RequiredRole() {
+	req := httptest.NewRequest("POST", "/auth/mfa/disable", bytes.NewBufferString(`{"password":"password","code":"123456"}`))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockAuth.EXPECT().DisableMFA(fromClient(testClientIP), &auth.DisableMFARequest{AccessToken: "access_token", Password: "password", Code: "123456"}).
+		Return(nil, status.Error(codes.FailedPrecondition, "Two-factor authentication is required for your role"))
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+}
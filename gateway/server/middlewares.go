@@ -1,7 +1,11 @@
 package server
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
 	"net/http"
 	"time"
 
@@ -39,6 +43,40 @@ func Authenticate(server *Server) gin.HandlerFunc {
 	}
 }
 
+// idempotencyHeaders are the request headers that change what a request
+// means, so they are part of its fingerprint
+var idempotencyHeaders = []string{"Content-Type", "Timezone"}
+
+// IdempotencyKey passes the Idempotency-Key header on to the task service,
+// along with a fingerprint of the request. The task service replays its first
+// response to retries with the same key and fingerprint.
+func IdempotencyKey(ctx *gin.Context) {
+	key := ctx.GetHeader("Idempotency-Key")
+	if key == "" {
+		ctx.Next()
+		return
+	}
+
+	body, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"message": "Failed to read request body"})
+		ctx.Abort()
+		return
+	}
+	ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	fingerprint := sha256.New()
+	fingerprint.Write([]byte(ctx.Request.Method + " " + ctx.Request.URL.RequestURI() + "\n"))
+	for _, header := range idempotencyHeaders {
+		fingerprint.Write([]byte(header + ": " + ctx.GetHeader(header) + "\n"))
+	}
+	fingerprint.Write(body)
+
+	ctx.Set("idempotency_key", key)
+	ctx.Set("idempotency_fingerprint", hex.EncodeToString(fingerprint.Sum(nil)))
+	ctx.Next()
+}
+
 func RegisterPrometheusMatrics() {
 	prometheus.MustRegister(latency)
 }
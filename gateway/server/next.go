@@ -0,0 +1,52 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sejamuchhal/taskhub/gateway/pb/task"
+	"google.golang.org/grpc/metadata"
+)
+
+// ListNextTasks lists the caller's open tasks in the order they should be
+// worked on, as ranked by the task service
+func (s *Server) ListNextTasks(c *gin.Context) {
+	logger := s.Logger.WithField("method", "ListNextTasks")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	var req ListNextTasksRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		logger.WithError(err).Error("Failed to bind query parameters")
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := s.TaskClient.ListNextTasks(ctxWithMetadata, &task.ListNextTasksRequest{
+		Limit: int32(req.Limit),
+	})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "list next tasks")
+		return
+	}
+
+	tasks := make([]*NextTaskDetails, len(resp.GetTasks()))
+	for i, next := range resp.GetTasks() {
+		tasks[i] = &NextTaskDetails{
+			Task:    TransformTask(next.Task),
+			Score:   next.Score,
+			Reasons: next.Reasons,
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"tasks": tasks})
+}
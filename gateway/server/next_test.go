@@ -0,0 +1,50 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/sejamuchhal/taskhub/gateway/pb/task"
+	"github.com/sejamuchhal/taskhub/gateway/server"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+)
+
+func (suite *ServerTestSuite) TestListNextTasks_Success() {
+	req := httptest.NewRequest("GET", "/tasks/next?limit=5", nil)
+	req.Header.Set("Access", "access_token")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().ListNextTasks(gomock.Any(), &task.ListNextTasksRequest{Limit: 5}).Return(&task.ListNextTasksResponse{
+		Tasks: []*task.NextTask{
+			{Task: &task.Task{Id: "task-1", Priority: "high"}, Score: 60, Reasons: []string{"high priority", "due within a day"}},
+		},
+	}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	var res struct {
+		Tasks []*server.NextTaskDetails `json:"tasks"`
+	}
+	suite.Require().NoError(json.Unmarshal(w.Body.Bytes(), &res))
+	suite.Require().Len(res.Tasks, 1)
+	assert.Equal(suite.T(), "task-1", res.Tasks[0].Task.ID)
+	assert.Equal(suite.T(), "high", res.Tasks[0].Task.Priority)
+	assert.Equal(suite.T(), int32(60), res.Tasks[0].Score)
+	assert.Equal(suite.T(), []string{"high priority", "due within a day"}, res.Tasks[0].Reasons)
+}
+
+func (suite *ServerTestSuite) TestListNextTasks_InvalidLimit() {
+	req := httptest.NewRequest("GET", "/tasks/next?limit=-1", nil)
+	req.Header.Set("Access", "access_token")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
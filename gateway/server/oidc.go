@@ -0,0 +1,142 @@
+package server
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sejamuchhal/taskhub/gateway/oidc"
+	"github.com/sejamuchhal/taskhub/gateway/pb/auth"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// oidcFlowCookie keeps the state, nonce and PKCE verifier of a login
+	// until the provider sends the user back
+	oidcFlowCookie = "oidc_flow"
+	// oidcFlowDuration is how long users have to log in at the provider
+	oidcFlowDuration = 10 * time.Minute
+)
+
+// StartOIDCLogin sends the user to the login page of a provider
+func (s *Server) StartOIDCLogin(c *gin.Context) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"method":   "StartOIDCLogin",
+		"provider": c.Param("provider"),
+	})
+	logger.Debug("Incoming request")
+
+	provider, ok := s.OIDCProviders[c.Param("provider")]
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"message": "Unknown provider"})
+		return
+	}
+
+	flow, err := oidc.NewFlow()
+	if err != nil {
+		logger.WithError(err).Error("Error starting login")
+		c.JSON(http.StatusInternalServerError, gin.H{"message": "Internal server error"})
+		return
+	}
+	authCodeURL, err := provider.AuthCodeURL(c.Request.Context(), flow)
+	if err != nil {
+		logger.WithError(err).Error("Error reaching provider")
+		c.JSON(http.StatusBadGateway, gin.H{"message": "Provider is unavailable, please try again later"})
+		return
+	}
+
+	setOIDCFlowCookie(c, provider, strings.Join([]string{flow.State, flow.Nonce, flow.Verifier}, "."), int(oidcFlowDuration.Seconds()))
+	c.Redirect(http.StatusFound, authCodeURL)
+}
+
+// FinishOIDCLogin is where providers send users back to. It logs the user in
+// with the identity the provider vouches for, or asks for their second
+// factor like LoginUser.
+func (s *Server) FinishOIDCLogin(c *gin.Context) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"method":   "FinishOIDCLogin",
+		"provider": c.Param("provider"),
+	})
+	logger.Debug("Incoming request")
+
+	provider, ok := s.OIDCProviders[c.Param("provider")]
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"message": "Unknown provider"})
+		return
+	}
+
+	// The flow is good for one try
+	cookie, _ := c.Cookie(oidcFlowCookie)
+	setOIDCFlowCookie(c, provider, "", -1)
+
+	if errorCode := c.Query("error"); errorCode != "" {
+		logger.WithFields(logrus.Fields{
+			"error":             errorCode,
+			"error_description": c.Query("error_description"),
+		}).Warn("Provider refused login")
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Login at the provider was not completed"})
+		return
+	}
+
+	var flow oidc.Flow
+	parts := strings.Split(cookie, ".")
+	if len(parts) == 3 {
+		flow = oidc.Flow{State: parts[0], Nonce: parts[1], Verifier: parts[2]}
+	}
+	state := c.Query("state")
+	if flow.State == "" || subtle.ConstantTimeCompare([]byte(state), []byte(flow.State)) != 1 || c.Query("code") == "" {
+		logger.Warn("Missing or mismatched login state")
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid or expired login, please start again"})
+		return
+	}
+
+	identity, err := provider.Exchange(c.Request.Context(), c.Query("code"), flow)
+	if err != nil {
+		logger.WithError(err).Warn("Error exchanging code")
+		c.JSON(http.StatusUnauthorized, gin.H{"message": "Login with the provider failed"})
+		return
+	}
+
+	res, err := s.AuthClient.OIDCLogin(clientContext(c), &auth.OIDCLoginRequest{
+		Provider:      provider.Config.Name,
+		Subject:       identity.Subject,
+		Email:         identity.Email,
+		EmailVerified: identity.EmailVerified,
+		Name:          identity.Name,
+		Client:        clientInfo(c),
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.PermissionDenied {
+			logger.WithError(err).Warn("Identity refused")
+			c.JSON(http.StatusForbidden, gin.H{"message": st.Message()})
+			return
+		}
+		respondWithGRPCError(c, logger, err, "log in")
+		return
+	}
+
+	if res.MfaRequired {
+		logger.Info("Second factor required")
+		c.JSON(http.StatusOK, mfaChallengeResponse(res))
+		return
+	}
+
+	logger.Info("User login successful")
+	c.JSON(http.StatusOK, loginUserResponse(res))
+}
+
+// setOIDCFlowCookie scopes the cookie to the callback of provider, so that
+// logins with different providers do not clash
+func setOIDCFlowCookie(c *gin.Context, provider *oidc.Provider, value string, maxAge int) {
+	path, secure := "/", false
+	if callback, err := url.Parse(provider.Config.RedirectURL); err == nil {
+		path, secure = callback.Path, callback.Scheme == "https"
+	}
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcFlowCookie, value, maxAge, path, "", secure, true)
+}
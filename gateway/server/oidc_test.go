@@ -0,0 +1,156 @@
+package server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	"github.com/sejamuchhal/taskhub/gateway/oidc"
+	"github.com/sejamuchhal/taskhub/gateway/oidc/oidctest"
+	"github.com/sejamuchhal/taskhub/gateway/pb/auth"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// oidcProvider runs a provider and configures it as test
+func (suite *ServerTestSuite) oidcProvider() *oidctest.Provider {
+	idp := oidctest.NewProvider(suite.T())
+	suite.server.OIDCProviders = map[string]*oidc.Provider{
+		"test": oidc.NewProvider(oidc.Config{
+			Name:         "test",
+			Issuer:       idp.Issuer(),
+			ClientID:     oidctest.ClientID,
+			ClientSecret: oidctest.ClientSecret,
+			RedirectURL:  "http://gateway.test/auth/oidc/test/callback",
+		}),
+	}
+	return idp
+}
+
+// startOIDCLogin starts a login with idp and returns the flow cookie and the
+// URL the provider sends the user back to
+func (suite *ServerTestSuite) startOIDCLogin(idp *oidctest.Provider) (*http.Cookie, *url.URL) {
+	req := httptest.NewRequest("GET", "/auth/oidc/test/login", nil)
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	suite.Require().Equal(http.StatusFound, w.Code)
+
+	cookies := w.Result().Cookies()
+	suite.Require().Len(cookies, 1)
+	suite.Equal("/auth/oidc/test/callback", cookies[0].Path)
+	suite.True(cookies[0].HttpOnly)
+
+	return cookies[0], idp.Authorize(suite.T(), w.Header().Get("Location"))
+}
+
+func (suite *ServerTestSuite) finishOIDCLogin(callback *url.URL, cookie *http.Cookie) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", callback.RequestURI(), nil)
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+	w := httptest.NewRecorder()
+	suite.router.ServeHTTP(w, req)
+	return w
+}
+
+func (suite *ServerTestSuite) TestOIDCLogin_Success() {
+	cookie, callback := suite.startOIDCLogin(suite.oidcProvider())
+
+	suite.mockAuth.EXPECT().OIDCLogin(fromClient(testClientIP), &auth.OIDCLoginRequest{
+		Provider:      "test",
+		Subject:       "108",
+		Email:         "harry@hogwarts.edu",
+		EmailVerified: true,
+		Name:          "Harry Potter",
+		Client:        testClient,
+	}).Return(&auth.LoginResponse{
+		AccessToken:           "access_token",
+		AccessTokenExpiresAt:  timestamppb.Now(),
+		RefreshToken:          "refresh_token",
+		RefreshTokenExpiresAt: timestamppb.Now(),
+		SessionId:             "session_id",
+		User:                  &auth.UserDetail{Name: "Harry Potter", Email: "harry@hogwarts.edu"},
+	}, nil)
+
+	w := suite.finishOIDCLogin(callback, cookie)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"access_token":"access_token"`)
+	// The flow cookie is cleared
+	suite.Require().Len(w.Result().Cookies(), 1)
+	assert.Empty(suite.T(), w.Result().Cookies()[0].Value)
+}
+
+func (suite *ServerTestSuite) TestOIDCLogin_MFARequired() {
+	cookie, callback := suite.startOIDCLogin(suite.oidcProvider())
+
+	suite.mockAuth.EXPECT().OIDCLogin(gomock.Any(), gomock.Any()).Return(&auth.LoginResponse{
+		MfaRequired:       true,
+		MfaToken:          "mfa_token",
+		MfaTokenExpiresAt: timestamppb.Now(),
+	}, nil)
+
+	w := suite.finishOIDCLogin(callback, cookie)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"mfa_token":"mfa_token"`)
+}
+
+func (suite *ServerTestSuite) TestOIDCLogin_UnverifiedEmail() {
+	idp := suite.oidcProvider()
+	idp.SetUser(oidctest.User{Subject: "7", Email: "draco@hogwarts.edu", Name: "Draco Malfoy"})
+	cookie, callback := suite.startOIDCLogin(idp)
+
+	suite.mockAuth.EXPECT().OIDCLogin(fromClient(testClientIP), &auth.OIDCLoginRequest{
+		Provider: "test",
+		Subject:  "7",
+		Email:    "draco@hogwarts.edu",
+		Name:     "Draco Malfoy",
+		Client:   testClient,
+	}).Return(nil, status.Error(codes.PermissionDenied, "The provider has not verified your email address"))
+
+	w := suite.finishOIDCLogin(callback, cookie)
+
+	assert.Equal(suite.T(), http.StatusForbidden, w.Code)
+	assert.JSONEq(suite.T(), `{"message":"The provider has not verified your email address"}`, w.Body.String())
+}
+
+func (suite *ServerTestSuite) TestOIDCLogin_MissingCookie() {
+	_, callback := suite.startOIDCLogin(suite.oidcProvider())
+
+	w := suite.finishOIDCLogin(callback, nil)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *ServerTestSuite) TestOIDCLogin_StateMismatch() {
+	cookie, callback := suite.startOIDCLogin(suite.oidcProvider())
+	query := callback.Query()
+	query.Set("state", "forged")
+	callback.RawQuery = query.Encode()
+
+	w := suite.finishOIDCLogin(callback, cookie)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *ServerTestSuite) TestOIDCLogin_ProviderError() {
+	cookie, _ := suite.startOIDCLogin(suite.oidcProvider())
+	callback, _ := url.Parse("/auth/oidc/test/callback?error=access_denied")
+
+	w := suite.finishOIDCLogin(callback, cookie)
+
+	assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
+}
+
+func (suite *ServerTestSuite) TestOIDCLogin_UnknownProvider() {
+	req := httptest.NewRequest("GET", "/auth/oidc/nope/login", nil)
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
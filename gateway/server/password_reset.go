@@ -0,0 +1,57 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sejamuchhal/taskhub/gateway/pb/auth"
+)
+
+// RequestPasswordReset emails a password reset link. The response does not
+// tell whether the email has an account.
+func (s *Server) RequestPasswordReset(c *gin.Context) {
+	logger := s.Logger.WithField("method", "RequestPasswordReset")
+	logger.Debug("Incoming request")
+
+	var req RequestPasswordResetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.WithError(err).Error("Error parsing password reset request")
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	res, err := s.AuthClient.RequestPasswordReset(context.Background(), &auth.RequestPasswordResetRequest{Email: req.Email})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "request password reset")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": res.Message})
+}
+
+// ResetPassword sets a new password with the token from a password reset
+// link. The user is logged out everywhere.
+func (s *Server) ResetPassword(c *gin.Context) {
+	logger := s.Logger.WithField("method", "ResetPassword")
+	logger.Debug("Incoming request")
+
+	var req ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.WithError(err).Error("Error parsing reset password request")
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	res, err := s.AuthClient.ResetPassword(context.Background(), &auth.ResetPasswordRequest{
+		Token:    req.Token,
+		Password: req.Password,
+	})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "reset password")
+		return
+	}
+
+	logger.Info("Password reset")
+	c.JSON(http.StatusOK, gin.H{"message": res.Message})
+}
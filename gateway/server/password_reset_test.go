@@ -0,0 +1,64 @@
+package server_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/sejamuchhal/taskhub/gateway/pb/auth"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (suite *ServerTestSuite) TestRequestPasswordReset() {
+	req := httptest.NewRequest("POST", "/auth/password/forgot", bytes.NewBufferString(`{"email":"harry@hogwarts.edu"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.mockAuth.EXPECT().RequestPasswordReset(gomock.Any(), &auth.RequestPasswordResetRequest{Email: "harry@hogwarts.edu"}).
+		Return(&auth.RequestPasswordResetResponse{Message: "A password reset link is on its way"}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"message": "A password reset link is on its way"}`, w.Body.String())
+}
+
+func (suite *ServerTestSuite) TestResetPassword_Success() {
+	req := httptest.NewRequest("POST", "/auth/password/reset", bytes.NewBufferString(`{"token":"abc","password":"new-password"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.mockAuth.EXPECT().ResetPassword(gomock.Any(), &auth.ResetPasswordRequest{Token: "abc", Password: "new-password"}).
+		Return(&auth.ResetPasswordResponse{Message: "Password reset, please log in again"}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
+func (suite *ServerTestSuite) TestResetPassword_InvalidToken() {
+	req := httptest.NewRequest("POST", "/auth/password/reset", bytes.NewBufferString(`{"token":"abc","password":"new-password"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.mockAuth.EXPECT().ResetPassword(gomock.Any(), gomock.Any()).
+		Return(nil, status.Error(codes.InvalidArgument, "Invalid or expired token"))
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.JSONEq(suite.T(), `{"message": "Invalid or expired token"}`, w.Body.String())
+}
+
+func (suite *ServerTestSuite) TestResetPassword_ShortPassword() {
+	req := httptest.NewRequest("POST", "/auth/password/reset", bytes.NewBufferString(`{"token":"abc","password":"123"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sejamuchhal/taskhub/gateway/pb/task"
+	"github.com/sejamuchhal/taskhub/gateway/quickadd"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// QuickAddTask creates a task from a line of text such as
+// "Pay rent tomorrow 9am #finance". With preview set it only returns what
+// the text was parsed into.
+func (s *Server) QuickAddTask(c *gin.Context) {
+	logger := s.Logger.WithField("method", "QuickAddTask")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	var req QuickAddRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.WithError(err).Error("Failed to bind JSON")
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	loc, err := requestLocation(c)
+	if err != nil {
+		logger.WithError(err).Error("Invalid timezone")
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid Timezone header, please use an IANA name such as Europe/Berlin"})
+		return
+	}
+
+	parsed, err := quickadd.Parse(req.Text, time.Now(), loc)
+	if err != nil {
+		logger.WithError(err).Error("Invalid quick add text")
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	res := QuickAddResponse{Parsed: TransformQuickAdd(parsed)}
+	if parsed.Assignee != "" {
+		res.Ignored = append(res.Ignored, "assignee")
+	}
+	if parsed.Recurrence != nil {
+		res.Ignored = append(res.Ignored, "recurrence")
+	}
+	if req.Preview {
+		c.JSON(http.StatusOK, res)
+		return
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	newTask := &task.Task{
+		Title:    parsed.Title,
+		Tags:     parsed.Tags,
+		Priority: parsed.Priority,
+	}
+	if parsed.Due != nil {
+		newTask.DueDate = timestamppb.New(parsed.Due.Time)
+		newTask.DueAllDay = parsed.Due.AllDay
+	}
+	resp, err := s.TaskClient.CreateTask(ctxWithMetadata, &task.CreateTaskRequest{Task: newTask})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "create task")
+		return
+	}
+
+	res.TaskID = resp.Id
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,87 @@
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/sejamuchhal/taskhub/gateway/pb/task"
+	"github.com/sejamuchhal/taskhub/gateway/server"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+)
+
+func (suite *ServerTestSuite) TestQuickAddTask_Preview() {
+	body := `{"text":"Pay rent tomorrow 9am #finance !high @alice every month","preview":true}`
+	req := httptest.NewRequest("POST", "/tasks/quick", bytes.NewBufferString(body))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Timezone", "Asia/Kolkata")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	var res server.QuickAddResponse
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Empty(suite.T(), res.TaskID)
+	assert.Equal(suite.T(), "Pay rent", res.Parsed.Title)
+	assert.Equal(suite.T(), []string{"finance"}, res.Parsed.Tags)
+	assert.Equal(suite.T(), "high", res.Parsed.Priority)
+	assert.Equal(suite.T(), "alice", res.Parsed.Assignee)
+	assert.Equal(suite.T(), "every month", res.Parsed.Recurrence)
+	assert.Equal(suite.T(), []string{"assignee", "recurrence"}, res.Ignored)
+
+	due, err := time.Parse(time.RFC3339, res.Parsed.DueDate)
+	assert.NoError(suite.T(), err)
+	kolkata, _ := time.LoadLocation("Asia/Kolkata")
+	assert.Equal(suite.T(), "09:00", due.In(kolkata).Format("15:04"))
+	assert.False(suite.T(), res.Parsed.DueAllDay)
+}
+
+func (suite *ServerTestSuite) TestQuickAddTask_Create() {
+	body := `{"text":"Water plants friday #home !high"}`
+	req := httptest.NewRequest("POST", "/tasks/quick", bytes.NewBufferString(body))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().CreateTask(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, req *task.CreateTaskRequest, opts ...grpc.CallOption) (*task.CreateTaskResponse, error) {
+			assert.Equal(suite.T(), "Water plants", req.Task.Title)
+			assert.Equal(suite.T(), []string{"home"}, req.Task.Tags)
+			assert.Equal(suite.T(), "high", req.Task.Priority)
+			assert.True(suite.T(), req.Task.DueAllDay)
+			assert.Equal(suite.T(), time.Friday, req.Task.DueDate.AsTime().Weekday())
+			return &task.CreateTaskResponse{Id: "12345"}, nil
+		})
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	var res server.QuickAddResponse
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &res))
+	assert.Equal(suite.T(), "12345", res.TaskID)
+	assert.Empty(suite.T(), res.Ignored)
+}
+
+func (suite *ServerTestSuite) TestQuickAddTask_NoTitle() {
+	body := `{"text":"tomorrow #home"}`
+	req := httptest.NewRequest("POST", "/tasks/quick", bytes.NewBufferString(body))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sejamuchhal/taskhub/gateway/pb/task"
+	"google.golang.org/grpc/metadata"
+)
+
+func (s *Server) UnarchiveTask(c *gin.Context) {
+	logger := s.Logger.WithField("method", "UnarchiveTask")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := s.TaskClient.UnarchiveTask(ctxWithMetadata, &task.UnarchiveTaskRequest{Id: c.Param("id")})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "unarchive task")
+		return
+	}
+
+	c.JSON(http.StatusOK, TransformTask(resp.Task))
+}
+
+func (s *Server) GetRetentionPolicy(c *gin.Context) {
+	logger := s.Logger.WithField("method", "GetRetentionPolicy")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := s.TaskClient.GetRetentionPolicy(ctxWithMetadata, &task.GetRetentionPolicyRequest{})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "get retention policy")
+		return
+	}
+
+	c.JSON(http.StatusOK, TransformRetentionPolicy(resp.Policy))
+}
+
+func (s *Server) UpdateRetentionPolicy(c *gin.Context) {
+	logger := s.Logger.WithField("method", "UpdateRetentionPolicy")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	var req RetentionPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.WithError(err).Error("Failed to bind JSON")
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := s.TaskClient.UpdateRetentionPolicy(ctxWithMetadata, &task.UpdateRetentionPolicyRequest{
+		Policy: &task.RetentionPolicy{
+			ArchiveAfterDays: req.ArchiveAfterDays,
+			PurgeAfterDays:   req.PurgeAfterDays,
+		},
+	})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "update retention policy")
+		return
+	}
+
+	c.JSON(http.StatusOK, TransformRetentionPolicy(resp.Policy))
+}
+
+func (s *Server) DeleteRetentionPolicy(c *gin.Context) {
+	logger := s.Logger.WithField("method", "DeleteRetentionPolicy")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := s.TaskClient.DeleteRetentionPolicy(ctxWithMetadata, &task.DeleteRetentionPolicyRequest{})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "delete retention policy")
+		return
+	}
+
+	c.JSON(http.StatusOK, TransformRetentionPolicy(resp.Policy))
+}
@@ -0,0 +1,74 @@
+package server_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/sejamuchhal/taskhub/gateway/pb/task"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (suite *ServerTestSuite) TestListTasks_Archived() {
+	req := httptest.NewRequest("GET", "/tasks?archived=true", nil)
+	req.Header.Set("Access", "access_token")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().ListTasks(gomock.Any(), &task.ListTasksRequest{Archived: true}).
+		Return(&task.ListTasksResponse{Tasks: []*task.Task{{Id: "12345", Title: "Task"}}, TotalCount: 1}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
+func (suite *ServerTestSuite) TestUnarchiveTask_NotArchived() {
+	req := httptest.NewRequest("PUT", "/tasks/12345/unarchive", nil)
+	req.Header.Set("Access", "access_token")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().UnarchiveTask(gomock.Any(), &task.UnarchiveTaskRequest{Id: "12345"}).
+		Return(nil, status.Error(codes.FailedPrecondition, "Task is not archived"))
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	assert.JSONEq(suite.T(), `{"message":"Task is not archived"}`, w.Body.String())
+}
+
+func (suite *ServerTestSuite) TestUpdateRetentionPolicy_Success() {
+	req := httptest.NewRequest("PUT", "/retention-policy", bytes.NewBufferString(`{"archive_after_days":30,"purge_after_days":365}`))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().UpdateRetentionPolicy(gomock.Any(), &task.UpdateRetentionPolicyRequest{
+		Policy: &task.RetentionPolicy{ArchiveAfterDays: 30, PurgeAfterDays: 365},
+	}).Return(&task.UpdateRetentionPolicyResponse{Policy: &task.RetentionPolicy{ArchiveAfterDays: 30, PurgeAfterDays: 365}}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"archive_after_days":30,"purge_after_days":365,"is_default":false}`, w.Body.String())
+}
+
+func (suite *ServerTestSuite) TestDeleteRetentionPolicy_Success() {
+	req := httptest.NewRequest("DELETE", "/retention-policy", nil)
+	req.Header.Set("Access", "access_token")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().DeleteRetentionPolicy(gomock.Any(), &task.DeleteRetentionPolicyRequest{}).
+		Return(&task.DeleteRetentionPolicyResponse{Policy: &task.RetentionPolicy{ArchiveAfterDays: 90, IsDefault: true}}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"archive_after_days":90,"purge_after_days":0,"is_default":true}`, w.Body.String())
+}
@@ -9,9 +9,14 @@ import (
 // RegisterRoutes sets up all the required routes
 func (s *Server) RegisterRoutes() http.Handler {
 	r := gin.Default()
+	if err := r.SetTrustedProxies(s.TrustedProxies); err != nil {
+		s.Logger.WithError(err).Error("Invalid trusted proxies, trusting none")
+		r.SetTrustedProxies(nil)
+	}
 	r.Use(RecordRequestLatency)
 	r.GET("/health", s.Health)
 	r.GET("/metrics", prometheusHandler())
+	r.GET("/.well-known/jwks.json", s.GetJWKS)
 
 	authRoutes := r.Group("/auth")
 	{
@@ -19,18 +24,119 @@ func (s *Server) RegisterRoutes() http.Handler {
 		authRoutes.POST("/login", s.LoginUser)
 		authRoutes.POST("/renew", s.RenewAccessToken)
 		authRoutes.POST("/logout", s.Logout)
+		authRoutes.GET("/verify-email", s.VerifyEmail)
+		authRoutes.POST("/verify-email", s.VerifyEmail)
+		authRoutes.POST("/verify-email/resend", s.ResendVerification)
+		authRoutes.POST("/password/forgot", s.RequestPasswordReset)
+		authRoutes.POST("/password/reset", s.ResetPassword)
+		authRoutes.PUT("/password", Authenticate(s), s.ChangePassword)
+		authRoutes.POST("/mfa/verify", s.VerifyMFA)
+		authRoutes.POST("/mfa/enroll", s.EnrollMFA)
+		authRoutes.POST("/mfa/confirm", s.ConfirmMFA)
+		authRoutes.POST("/mfa/disable", Authenticate(s), s.DisableMFA)
+		authRoutes.GET("/oidc/:provider/login", s.StartOIDCLogin)
+		authRoutes.GET("/oidc/:provider/callback", s.FinishOIDCLogin)
+		authRoutes.GET("/sessions", Authenticate(s), s.ListSessions)
+		authRoutes.DELETE("/sessions", Authenticate(s), s.RevokeAllSessions)
+		authRoutes.DELETE("/sessions/:id", Authenticate(s), s.RevokeSession)
+	}
+
+	adminRoutes := r.Group("/admin")
+	{
+		adminRoutes.Use(Authenticate(s))
+		adminRoutes.GET("/users", s.ListUsers)
+		adminRoutes.POST("/users/unlock", s.UnlockAccount)
+		adminRoutes.GET("/users/:id", s.GetUser)
+		adminRoutes.PUT("/users/:id/role", s.SetUserRole)
+		adminRoutes.POST("/users/:id/deactivate", s.DeactivateUser)
+		adminRoutes.POST("/users/:id/reactivate", s.ReactivateUser)
+		adminRoutes.POST("/users/:id/logout", s.LogoutUser)
+	}
 
+	meRoutes := r.Group("/me")
+	{
+		meRoutes.Use(Authenticate(s))
+		meRoutes.PATCH("", s.UpdateAccount)
 	}
 
 	taskRoutes := r.Group("/tasks")
 	{
-		taskRoutes.Use(Authenticate(s))
+		taskRoutes.Use(Authenticate(s), IdempotencyKey)
 		taskRoutes.POST("", s.CreateTask)
+		taskRoutes.POST("/quick", s.QuickAddTask)
+		taskRoutes.GET("/stats", s.GetTaskStats)
+		taskRoutes.GET("/next", s.ListNextTasks)
 		taskRoutes.GET("/:id", s.GetTask)
 		taskRoutes.GET("", s.ListTasks)
 		taskRoutes.DELETE("/:id", s.DeleteTask)
 		taskRoutes.PUT("/:id", s.UpdateTask)
 		taskRoutes.PUT("/:id/complete", s.CompleteTask)
+		taskRoutes.PUT("/:id/move", s.MoveTask)
+		taskRoutes.PUT("/:id/unarchive", s.UnarchiveTask)
+		taskRoutes.POST("/:id/clone", s.CloneTask)
+		taskRoutes.PUT("/:id/watch", s.WatchTask)
+		taskRoutes.DELETE("/:id/watch", s.UnwatchTask)
+		taskRoutes.GET("/:id/watchers", s.ListTaskWatchers)
+		taskRoutes.PUT("/:id/watchers/:user_id", s.WatchTask)
+		taskRoutes.DELETE("/:id/watchers/:user_id", s.UnwatchTask)
+	}
+
+	boardRoutes := r.Group("/board")
+	{
+		boardRoutes.Use(Authenticate(s))
+		boardRoutes.GET("", s.GetBoard)
+	}
+
+	templateRoutes := r.Group("/templates")
+	{
+		templateRoutes.Use(Authenticate(s), IdempotencyKey)
+		templateRoutes.POST("", s.CreateTemplate)
+		templateRoutes.GET("", s.ListTemplates)
+		templateRoutes.GET("/:id", s.GetTemplate)
+		templateRoutes.PUT("/:id", s.UpdateTemplate)
+		templateRoutes.DELETE("/:id", s.DeleteTemplate)
+		templateRoutes.POST("/:id/instantiate", s.InstantiateTemplate)
+	}
+
+	customFieldRoutes := r.Group("/custom-fields")
+	{
+		customFieldRoutes.Use(Authenticate(s))
+		customFieldRoutes.POST("", s.CreateCustomField)
+		customFieldRoutes.GET("", s.ListCustomFields)
+		customFieldRoutes.PUT("/:id", s.UpdateCustomField)
+		customFieldRoutes.DELETE("/:id", s.DeleteCustomField)
+	}
+
+	viewRoutes := r.Group("/views")
+	{
+		viewRoutes.Use(Authenticate(s))
+		viewRoutes.POST("", s.CreateView)
+		viewRoutes.GET("", s.ListViews)
+		viewRoutes.GET("/:id", s.GetView)
+		viewRoutes.PUT("/:id", s.UpdateView)
+		viewRoutes.DELETE("/:id", s.DeleteView)
+		viewRoutes.GET("/:id/tasks", s.ListViewTasks)
+	}
+
+	retentionRoutes := r.Group("/retention-policy")
+	{
+		retentionRoutes.Use(Authenticate(s))
+		retentionRoutes.GET("", s.GetRetentionPolicy)
+		retentionRoutes.PUT("", s.UpdateRetentionPolicy)
+		retentionRoutes.DELETE("", s.DeleteRetentionPolicy)
+	}
+
+	timeEntryRoutes := r.Group("/time-entries")
+	{
+		timeEntryRoutes.Use(Authenticate(s), IdempotencyKey)
+		timeEntryRoutes.POST("/timer/start", s.StartTimer)
+		timeEntryRoutes.POST("/timer/stop", s.StopTimer)
+		timeEntryRoutes.GET("/report", s.GetTimeReport)
+		timeEntryRoutes.POST("", s.CreateTimeEntry)
+		timeEntryRoutes.GET("", s.ListTimeEntries)
+		timeEntryRoutes.PUT("/:id", s.UpdateTimeEntry)
+		timeEntryRoutes.DELETE("/:id", s.DeleteTimeEntry)
+		timeEntryRoutes.GET("/:id/history", s.ListTimeEntryRevisions)
 	}
 
 	return r
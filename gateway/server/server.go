@@ -12,19 +12,26 @@ import (
 	"github.com/sejamuchhal/taskhub/gateway/pb/auth"
 	"github.com/sejamuchhal/taskhub/gateway/pb/task"
 	"github.com/sejamuchhal/taskhub/gateway/common"
+	"github.com/sejamuchhal/taskhub/gateway/oidc"
 )
 
 type Server struct {
 	Logger     *logrus.Entry
 	AuthClient auth.AuthServiceClient
-	TaskClient task.TaskServiceClient
+	// AdminClient shares the connection of AuthClient
+	AdminClient auth.AdminServiceClient
+	TaskClient  task.TaskServiceClient
+	// OIDCProviders are the providers users can sign in with, by name
+	OIDCProviders map[string]*oidc.Provider
+	// TrustedProxies may tell the client IP in X-Forwarded-For
+	TrustedProxies []string
 }
 
 func NewServer(config *common.Config) (*http.Server, error) {
 	logger := common.Logger
 	logger.WithField("config", config).Info("Got config")
 
-	authClient, err := NewAuthClient(config)
+	authClient, adminClient, err := NewAuthClients(config)
 	if err != nil {
 		return nil, err
 	}
@@ -35,9 +42,15 @@ func NewServer(config *common.Config) (*http.Server, error) {
 	}
 
 	newServer := &Server{
-		Logger:     logger,
-		AuthClient: authClient,
-		TaskClient: taskClient,
+		Logger:         logger,
+		AuthClient:     authClient,
+		AdminClient:    adminClient,
+		TaskClient:     taskClient,
+		OIDCProviders:  map[string]*oidc.Provider{},
+		TrustedProxies: config.TrustedProxies,
+	}
+	for _, provider := range config.OIDCProviders {
+		newServer.OIDCProviders[provider.Name] = oidc.NewProvider(provider)
 	}
 
 	// Declare Server config
@@ -52,7 +65,9 @@ func NewServer(config *common.Config) (*http.Server, error) {
 	return server, nil
 }
 
-func NewAuthClient(config *common.Config) (auth.AuthServiceClient, error) {
+// NewAuthClients connects to the auth service, which serves both the auth and
+// admin APIs
+func NewAuthClients(config *common.Config) (auth.AuthServiceClient, auth.AdminServiceClient, error) {
 	var opts []grpc.DialOption
 	opts = append(opts, grpc.WithInsecure())
 
@@ -60,13 +75,14 @@ func NewAuthClient(config *common.Config) (auth.AuthServiceClient, error) {
 	authConn, err := grpc.Dial(config.AuthServiceUrl, grpc.WithInsecure())
 	if err != nil {
 		config.Logger.WithError(err).Error("Error connecting to auth grpc server")
-		return nil, fmt.Errorf("cannot connect to auth grpc server: %v", err)
+		return nil, nil, fmt.Errorf("cannot connect to auth grpc server: %v", err)
 	} else {
 		config.Logger.Info("Connected to auth grpc server successfully")
 	}
 
 	authClient := auth.NewAuthServiceClient(authConn)
-	return authClient, nil
+	adminClient := auth.NewAdminServiceClient(authConn)
+	return authClient, adminClient, nil
 }
 
 func NewTaskClient(config *common.Config) (task.TaskServiceClient, error) {
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sejamuchhal/taskhub/gateway/pb/auth"
+	"github.com/sirupsen/logrus"
+)
+
+// ListSessions shows where the caller is signed in. The session of the
+// Refresh header, if it is sent, is marked as current.
+func (s *Server) ListSessions(c *gin.Context) {
+	logger := s.Logger.WithField("method", "ListSessions")
+	logger.Debug("Incoming request")
+
+	res, err := s.AuthClient.ListSessions(context.Background(), &auth.ListSessionsRequest{
+		AccessToken:  c.GetHeader("Access"),
+		RefreshToken: c.GetHeader("Refresh"),
+	})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "list sessions")
+		return
+	}
+
+	sessions := make([]SessionDetails, 0, len(res.Sessions))
+	for _, session := range res.Sessions {
+		sessions = append(sessions, SessionDetails{
+			ID:         session.Id,
+			CreatedAt:  session.CreatedAt.AsTime(),
+			LastUsedAt: session.LastUsedAt.AsTime(),
+			ExpiresAt:  session.ExpiresAt.AsTime(),
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IpAddress,
+			Current:    session.Current,
+		})
+	}
+	c.JSON(http.StatusOK, ListSessionsResponse{Sessions: sessions})
+}
+
+// RevokeSession signs the caller out of one session, its refresh token stops
+// working right away
+func (s *Server) RevokeSession(c *gin.Context) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"method":     "RevokeSession",
+		"session_id": c.Param("id"),
+	})
+	logger.Debug("Incoming request")
+
+	res, err := s.AuthClient.RevokeSession(context.Background(), &auth.RevokeSessionRequest{
+		AccessToken: c.GetHeader("Access"),
+		SessionId:   c.Param("id"),
+	})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "revoke session")
+		return
+	}
+
+	logger.Info("Session revoked")
+	c.JSON(http.StatusOK, gin.H{"message": res.Message})
+}
+
+// RevokeAllSessions signs the caller out everywhere but the session of the
+// Refresh header, if it is sent. Access tokens handed out so far stop
+// working, the response carries a new one.
+func (s *Server) RevokeAllSessions(c *gin.Context) {
+	logger := s.Logger.WithField("method", "RevokeAllSessions")
+	logger.Debug("Incoming request")
+
+	res, err := s.AuthClient.RevokeAllSessions(context.Background(), &auth.RevokeAllSessionsRequest{
+		AccessToken:  c.GetHeader("Access"),
+		RefreshToken: c.GetHeader("Refresh"),
+	})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "revoke sessions")
+		return
+	}
+
+	logger.Info("Sessions revoked")
+	c.JSON(http.StatusOK, RevokeAllSessionsResponse{
+		Message:              res.Message,
+		AccessToken:          res.AccessToken,
+		AccessTokenExpiresAt: res.AccessTokenExpiresAt.AsTime(),
+	})
+}
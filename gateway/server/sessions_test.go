@@ -0,0 +1,166 @@
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sejamuchhal/taskhub/gateway/pb/auth"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// testClient is the client httptest requests come from
+var testClient = &auth.ClientInfo{}
+
+// testClientIP is the IP address httptest requests come from
+const testClientIP = "192.0.2.1"
+
+// fromClient matches the context of an auth call made for a client at ip
+func fromClient(ip string) gomock.Matcher {
+	return gomock.Cond(func(x any) bool {
+		ctx, ok := x.(context.Context)
+		if !ok {
+			return false
+		}
+		md, _ := metadata.FromOutgoingContext(ctx)
+		return slices.Equal(md.Get("client_ip"), []string{ip})
+	})
+}
+
+func (suite *ServerTestSuite) TestListSessions_Success() {
+	req := httptest.NewRequest("GET", "/auth/sessions", nil)
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Refresh", "refresh_token")
+	w := httptest.NewRecorder()
+
+	at := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	suite.expectValidate()
+	suite.mockAuth.EXPECT().ListSessions(gomock.Any(), &auth.ListSessionsRequest{
+		AccessToken:  "access_token",
+		RefreshToken: "refresh_token",
+	}).Return(&auth.ListSessionsResponse{
+		Sessions: []*auth.SessionDetail{{
+			Id:         "session_id",
+			CreatedAt:  timestamppb.New(at),
+			LastUsedAt: timestamppb.New(at),
+			ExpiresAt:  timestamppb.New(at.Add(24 * time.Hour)),
+			UserAgent:  "Mozilla/5.0",
+			IpAddress:  "203.0.113.7",
+			Current:    true,
+		}},
+	}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"sessions":[{
+		"id":"session_id",
+		"created_at":"2026-10-19T12:00:00Z",
+		"last_used_at":"2026-10-19T12:00:00Z",
+		"expires_at":"2026-10-20T12:00:00Z",
+		"user_agent":"Mozilla/5.0",
+		"ip_address":"203.0.113.7",
+		"current":true
+	}]}`, w.Body.String())
+}
+
+func (suite *ServerTestSuite) TestListSessions_Unauthenticated() {
+	req := httptest.NewRequest("GET", "/auth/sessions", nil)
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusUnauthorized, w.Code)
+}
+
+func (suite *ServerTestSuite) TestRevokeSession_Success() {
+	req := httptest.NewRequest("DELETE", "/auth/sessions/session_id", nil)
+	req.Header.Set("Access", "access_token")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockAuth.EXPECT().RevokeSession(gomock.Any(), &auth.RevokeSessionRequest{
+		AccessToken: "access_token",
+		SessionId:   "session_id",
+	}).Return(&auth.RevokeSessionResponse{Message: "Session revoked"}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"message":"Session revoked"}`, w.Body.String())
+}
+
+func (suite *ServerTestSuite) TestRevokeSession_NotFound() {
+	req := httptest.NewRequest("DELETE", "/auth/sessions/someone_elses", nil)
+	req.Header.Set("Access", "access_token")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockAuth.EXPECT().RevokeSession(gomock.Any(), gomock.Any()).
+		Return(nil, status.Error(codes.NotFound, "Session not found"))
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
+
+func (suite *ServerTestSuite) TestRevokeAllSessions_Success() {
+	req := httptest.NewRequest("DELETE", "/auth/sessions", nil)
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Refresh", "refresh_token")
+	w := httptest.NewRecorder()
+
+	expiresAt := time.Date(2026, 10, 19, 12, 20, 0, 0, time.UTC)
+	suite.expectValidate()
+	suite.mockAuth.EXPECT().RevokeAllSessions(gomock.Any(), &auth.RevokeAllSessionsRequest{
+		AccessToken:  "access_token",
+		RefreshToken: "refresh_token",
+	}).Return(&auth.RevokeAllSessionsResponse{
+		Message:              "Sessions revoked",
+		AccessToken:          "new_access_token",
+		AccessTokenExpiresAt: timestamppb.New(expiresAt),
+	}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"message":"Sessions revoked","access_token":"new_access_token","access_token_expires_at":"2026-10-19T12:20:00Z"}`, w.Body.String())
+}
+
+func (suite *ServerTestSuite) TestLoginUser_ForwardedFor() {
+	login := func() {
+		req := httptest.NewRequest("POST", "/auth/login", bytes.NewBufferString(`{"email":"harry@hogwarts.edu","password":"password"}`))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Forwarded-For", "203.0.113.7")
+		w := httptest.NewRecorder()
+		suite.router.ServeHTTP(w, req)
+		suite.Equal(http.StatusInternalServerError, w.Code)
+	}
+
+	// The header is ignored unless the request comes from a trusted proxy
+	suite.mockAuth.EXPECT().Login(fromClient(testClientIP), &auth.LoginRequest{
+		Email:    "harry@hogwarts.edu",
+		Password: "password",
+		Client:   testClient,
+	}).Return(nil, status.Error(codes.Internal, "stop"))
+	login()
+
+	suite.server.TrustedProxies = []string{"192.0.2.1"}
+	gin.SetMode(gin.TestMode)
+	suite.router = suite.server.RegisterRoutes()
+	suite.mockAuth.EXPECT().Login(fromClient("203.0.113.7"), &auth.LoginRequest{
+		Email:    "harry@hogwarts.edu",
+		Password: "password",
+		Client:   testClient,
+	}).Return(nil, status.Error(codes.Internal, "stop"))
+	login()
+}
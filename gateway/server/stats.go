@@ -0,0 +1,71 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sejamuchhal/taskhub/gateway/pb/task"
+	"google.golang.org/grpc/metadata"
+)
+
+func (s *Server) GetTaskStats(c *gin.Context) {
+	logger := s.Logger.WithField("method", "GetTaskStats")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	var req TaskStatsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		logger.WithError(err).Error("Failed to bind query parameters")
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	from, err := parseOptionalTimestamp(req.From)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid from, please use RFC 3339"})
+		return
+	}
+	to, err := parseOptionalTimestamp(req.To)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid to, please use RFC 3339"})
+		return
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := s.TaskClient.GetTaskStats(ctxWithMetadata, &task.GetTaskStatsRequest{
+		From:     from,
+		To:       to,
+		Timezone: req.Timezone,
+		Interval: req.Interval,
+	})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "compute task statistics")
+		return
+	}
+
+	res := TaskStatsResponse{
+		ByStatus:                 make([]StatusCount, len(resp.GetByStatus())),
+		Timeline:                 make([]TaskCountBucket, len(resp.GetTimeline())),
+		OverdueCount:             resp.OverdueCount,
+		AverageCompletionSeconds: resp.AverageCompletionSeconds,
+		CurrentStreakDays:        resp.CurrentStreakDays,
+		LongestStreakDays:        resp.LongestStreakDays,
+	}
+	for i, count := range resp.GetByStatus() {
+		res.ByStatus[i] = StatusCount{Status: count.Status, Count: count.Count}
+	}
+	for i, bucket := range resp.GetTimeline() {
+		res.Timeline[i] = TaskCountBucket{Period: bucket.Period, Created: bucket.Created, Completed: bucket.Completed}
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,55 @@
+package server_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/sejamuchhal/taskhub/gateway/pb/task"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (suite *ServerTestSuite) TestGetTaskStats_Success() {
+	req := httptest.NewRequest("GET", "/tasks/stats?from=2024-07-01T00:00:00Z&interval=week", nil)
+	req.Header.Set("Access", "access_token")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().GetTaskStats(gomock.Any(), &task.GetTaskStatsRequest{
+		From:     timestamppb.New(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)),
+		Interval: "week",
+	}).Return(&task.GetTaskStatsResponse{
+		ByStatus:                 []*task.StatusCount{{Status: "completed", Count: 2}},
+		Timeline:                 []*task.TaskCountBucket{{Period: "2024-07-01", Created: 3, Completed: 2}},
+		OverdueCount:             1,
+		AverageCompletionSeconds: 3600,
+		CurrentStreakDays:        1,
+		LongestStreakDays:        2,
+	}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{
+		"by_status": [{"status": "completed", "count": 2}],
+		"timeline": [{"period": "2024-07-01", "created": 3, "completed": 2}],
+		"overdue_count": 1,
+		"average_completion_seconds": 3600,
+		"current_streak_days": 1,
+		"longest_streak_days": 2
+	}`, w.Body.String())
+}
+
+func (suite *ServerTestSuite) TestGetTaskStats_InvalidInterval() {
+	req := httptest.NewRequest("GET", "/tasks/stats?interval=month", nil)
+	req.Header.Set("Access", "access_token")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,199 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sejamuchhal/taskhub/gateway/pb/task"
+	"google.golang.org/grpc/metadata"
+)
+
+func (s *Server) CreateTemplate(c *gin.Context) {
+	logger := s.Logger.WithField("method", "CreateTemplate")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	var req TemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.WithError(err).Error("Failed to bind JSON")
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := s.TaskClient.CreateTemplate(ctxWithMetadata, &task.CreateTemplateRequest{
+		Template: templateFromRequest(req),
+		TaskId:   req.TaskID,
+	})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "create template")
+		return
+	}
+
+	c.JSON(http.StatusOK, TransformTemplate(resp.Template))
+}
+
+func (s *Server) ListTemplates(c *gin.Context) {
+	logger := s.Logger.WithField("method", "ListTemplates")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := s.TaskClient.ListTemplates(ctxWithMetadata, &task.ListTemplatesRequest{})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "list templates")
+		return
+	}
+
+	templates := make([]*TemplateDetails, len(resp.GetTemplates()))
+	for i, template := range resp.GetTemplates() {
+		templates[i] = TransformTemplate(template)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"templates": templates})
+}
+
+func (s *Server) GetTemplate(c *gin.Context) {
+	logger := s.Logger.WithField("method", "GetTemplate")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := s.TaskClient.GetTemplate(ctxWithMetadata, &task.GetTemplateRequest{Id: c.Param("id")})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "get template")
+		return
+	}
+
+	c.JSON(http.StatusOK, TransformTemplate(resp.Template))
+}
+
+func (s *Server) UpdateTemplate(c *gin.Context) {
+	logger := s.Logger.WithField("method", "UpdateTemplate")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	var req TemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.WithError(err).Error("Failed to bind JSON")
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	template := templateFromRequest(req)
+	template.Id = c.Param("id")
+	resp, err := s.TaskClient.UpdateTemplate(ctxWithMetadata, &task.UpdateTemplateRequest{Template: template})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "update template")
+		return
+	}
+
+	c.JSON(http.StatusOK, TransformTemplate(resp.Template))
+}
+
+func (s *Server) DeleteTemplate(c *gin.Context) {
+	logger := s.Logger.WithField("method", "DeleteTemplate")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	_, err := s.TaskClient.DeleteTemplate(ctxWithMetadata, &task.DeleteTemplateRequest{Id: c.Param("id")})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "delete template")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Template deleted successfully"})
+}
+
+func (s *Server) InstantiateTemplate(c *gin.Context) {
+	logger := s.Logger.WithField("method", "InstantiateTemplate")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	var req InstantiateTemplateRequest
+	// The body is optional for templates without variables
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			logger.WithError(err).Error("Failed to bind JSON")
+			c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+			return
+		}
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := s.TaskClient.InstantiateTemplate(ctxWithMetadata, &task.InstantiateTemplateRequest{
+		Id:        c.Param("id"),
+		Variables: req.Variables,
+		Timezone:  req.Timezone,
+	})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "create task from template")
+		return
+	}
+
+	c.JSON(http.StatusOK, TransformTask(resp.Task))
+}
+
+func templateFromRequest(req TemplateRequest) *task.TaskTemplate {
+	return &task.TaskTemplate{
+		Name:                   req.Name,
+		Title:                  req.Title,
+		Description:            req.Description,
+		Checklist:              req.Checklist,
+		Tags:                   req.Tags,
+		DueOffsetMinutes:       req.DueOffsetMinutes,
+		ReminderOffsetsMinutes: req.ReminderOffsetsMinutes,
+		EstimateMinutes:        req.EstimateMinutes,
+	}
+}
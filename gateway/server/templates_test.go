@@ -0,0 +1,103 @@
+package server_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/sejamuchhal/taskhub/gateway/pb/task"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (suite *ServerTestSuite) TestCreateTemplate_Success() {
+	reqBody := `{"name":"Onboarding","title":"Onboard {{client}}","checklist":["Create workspace"],"due_offset_minutes":1440}`
+	req := httptest.NewRequest("POST", "/templates", bytes.NewBufferString(reqBody))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	offset := int64(1440)
+	suite.expectValidate()
+	suite.mockTask.EXPECT().CreateTemplate(gomock.Any(), &task.CreateTemplateRequest{
+		Template: &task.TaskTemplate{
+			Name:             "Onboarding",
+			Title:            "Onboard {{client}}",
+			Checklist:        []string{"Create workspace"},
+			DueOffsetMinutes: &offset,
+		},
+	}).Return(&task.CreateTemplateResponse{Template: &task.TaskTemplate{
+		Id:               "template-1",
+		Name:             "Onboarding",
+		Title:            "Onboard {{client}}",
+		Checklist:        []string{"Create workspace"},
+		DueOffsetMinutes: &offset,
+	}}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{
+		"id": "template-1",
+		"name": "Onboarding",
+		"title": "Onboard {{client}}",
+		"description": "",
+		"checklist": ["Create workspace"],
+		"tags": null,
+		"due_offset_minutes": 1440,
+		"reminder_offsets_minutes": null,
+		"estimate_minutes": 0,
+		"created_at": "",
+		"updated_at": ""
+	}`, w.Body.String())
+}
+
+func (suite *ServerTestSuite) TestCreateTemplate_DuplicateName() {
+	req := httptest.NewRequest("POST", "/templates", bytes.NewBufferString(`{"name":"Onboarding","title":"Onboard"}`))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().CreateTemplate(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.AlreadyExists, "A template with this name already exists"))
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+}
+
+func (suite *ServerTestSuite) TestInstantiateTemplate_Success() {
+	req := httptest.NewRequest("POST", "/templates/template-1/instantiate", bytes.NewBufferString(`{"variables":{"client":"Acme"}}`))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().InstantiateTemplate(gomock.Any(), &task.InstantiateTemplateRequest{
+		Id:        "template-1",
+		Variables: map[string]string{"client": "Acme"},
+	}).Return(&task.InstantiateTemplateResponse{Task: &task.Task{Id: "task-1", Title: "Onboard Acme", Tags: []string{"onboarding"}}}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"Title":"Onboard Acme"`)
+	assert.Contains(suite.T(), w.Body.String(), `"Tags":["onboarding"]`)
+}
+
+func (suite *ServerTestSuite) TestInstantiateTemplate_WithoutBody() {
+	req := httptest.NewRequest("POST", "/templates/template-1/instantiate", nil)
+	req.Header.Set("Access", "access_token")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().InstantiateTemplate(gomock.Any(), &task.InstantiateTemplateRequest{Id: "template-1"}).
+		Return(nil, status.Error(codes.InvalidArgument, "Missing template variables: client"))
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.JSONEq(suite.T(), `{"message":"Missing template variables: client"}`, w.Body.String())
+}
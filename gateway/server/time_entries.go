@@ -0,0 +1,324 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sejamuchhal/taskhub/gateway/pb/task"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/metadata"
+)
+
+func (s *Server) StartTimer(c *gin.Context) {
+	logger := s.Logger.WithField("method", "StartTimer")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	var req StartTimerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.WithError(err).Error("Failed to bind JSON")
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := s.TaskClient.StartTimer(ctxWithMetadata, &task.StartTimerRequest{
+		TaskId: req.TaskID,
+		Note:   req.Note,
+	})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "start timer")
+		return
+	}
+
+	c.JSON(http.StatusOK, TransformTimeEntry(resp.Entry))
+}
+
+func (s *Server) StopTimer(c *gin.Context) {
+	logger := s.Logger.WithField("method", "StopTimer")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := s.TaskClient.StopTimer(ctxWithMetadata, &task.StopTimerRequest{})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "stop timer")
+		return
+	}
+
+	c.JSON(http.StatusOK, TransformTimeEntry(resp.Entry))
+}
+
+func (s *Server) CreateTimeEntry(c *gin.Context) {
+	logger := s.Logger.WithField("method", "CreateTimeEntry")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	var req TimeEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.WithError(err).Error("Failed to bind JSON")
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	entry, ok := timeEntryFromRequest(c, logger, req)
+	if !ok {
+		return
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := s.TaskClient.CreateTimeEntry(ctxWithMetadata, &task.CreateTimeEntryRequest{Entry: entry})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "create time entry")
+		return
+	}
+
+	c.JSON(http.StatusOK, TransformTimeEntry(resp.Entry))
+}
+
+func (s *Server) ListTimeEntries(c *gin.Context) {
+	logger := s.Logger.WithField("method", "ListTimeEntries")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	var req ListTimeEntriesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		logger.WithError(err).Error("Failed to bind query parameters")
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	from, err := parseOptionalTimestamp(req.From)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid from, please use RFC 3339"})
+		return
+	}
+	to, err := parseOptionalTimestamp(req.To)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid to, please use RFC 3339"})
+		return
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := s.TaskClient.ListTimeEntries(ctxWithMetadata, &task.ListTimeEntriesRequest{
+		Limit:  int32(req.Limit),
+		Offset: int32(req.Offset),
+		TaskId: req.TaskID,
+		From:   from,
+		To:     to,
+	})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "list time entries")
+		return
+	}
+
+	entries := make([]*TimeEntryDetails, len(resp.GetEntries()))
+	for i, entry := range resp.GetEntries() {
+		entries[i] = TransformTimeEntry(entry)
+	}
+
+	c.JSON(http.StatusOK, ListTimeEntriesResponse{
+		Count:   int(resp.TotalCount),
+		Entries: entries,
+	})
+}
+
+func (s *Server) UpdateTimeEntry(c *gin.Context) {
+	logger := s.Logger.WithField("method", "UpdateTimeEntry")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	var req TimeEntryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.WithError(err).Error("Failed to bind JSON")
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	entry, ok := timeEntryFromRequest(c, logger, req)
+	if !ok {
+		return
+	}
+	entry.Id = c.Param("id")
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := s.TaskClient.UpdateTimeEntry(ctxWithMetadata, &task.UpdateTimeEntryRequest{Entry: entry})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "update time entry")
+		return
+	}
+
+	c.JSON(http.StatusOK, TransformTimeEntry(resp.Entry))
+}
+
+func (s *Server) DeleteTimeEntry(c *gin.Context) {
+	logger := s.Logger.WithField("method", "DeleteTimeEntry")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	_, err := s.TaskClient.DeleteTimeEntry(ctxWithMetadata, &task.DeleteTimeEntryRequest{Id: c.Param("id")})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "delete time entry")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Time entry deleted successfully"})
+}
+
+func (s *Server) ListTimeEntryRevisions(c *gin.Context) {
+	logger := s.Logger.WithField("method", "ListTimeEntryRevisions")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := s.TaskClient.ListTimeEntryRevisions(ctxWithMetadata, &task.ListTimeEntryRevisionsRequest{Id: c.Param("id")})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "list time entry history")
+		return
+	}
+
+	revisions := make([]TimeEntryRevisionDetails, len(resp.GetRevisions()))
+	for i, revision := range resp.GetRevisions() {
+		revisions[i] = TimeEntryRevisionDetails{
+			Action:    revision.Action,
+			StartedAt: formatTimestamp(revision.StartedAt),
+			EndedAt:   formatTimestamp(revision.EndedAt),
+			Note:      revision.Note,
+			CreatedAt: formatTimestamp(revision.CreatedAt),
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"revisions": revisions})
+}
+
+func (s *Server) GetTimeReport(c *gin.Context) {
+	logger := s.Logger.WithField("method", "GetTimeReport")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	var req TimeReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		logger.WithError(err).Error("Failed to bind query parameters")
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	from, err := parseOptionalTimestamp(req.From)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid from, please use RFC 3339"})
+		return
+	}
+	to, err := parseOptionalTimestamp(req.To)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid to, please use RFC 3339"})
+		return
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := s.TaskClient.GetTimeReport(ctxWithMetadata, &task.GetTimeReportRequest{
+		From:           from,
+		To:             to,
+		Timezone:       req.Timezone,
+		ProjectFieldId: req.ProjectFieldID,
+	})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "build time report")
+		return
+	}
+
+	c.JSON(http.StatusOK, TimeReportResponse{
+		ByDay:        TransformTimeReportBuckets(resp.ByDay),
+		ByTask:       TransformTimeReportBuckets(resp.ByTask),
+		ByTag:        TransformTimeReportBuckets(resp.ByTag),
+		ByProject:    TransformTimeReportBuckets(resp.ByProject),
+		TotalSeconds: resp.TotalSeconds,
+	})
+}
+
+func timeEntryFromRequest(c *gin.Context, logger *logrus.Entry, req TimeEntryRequest) (*task.TimeEntry, bool) {
+	startedAt, err := parseOptionalTimestamp(req.StartedAt)
+	if err != nil {
+		logger.WithError(err).Error("Invalid started_at")
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid started_at, please use RFC 3339"})
+		return nil, false
+	}
+	endedAt, err := parseOptionalTimestamp(req.EndedAt)
+	if err != nil {
+		logger.WithError(err).Error("Invalid ended_at")
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Invalid ended_at, please use RFC 3339"})
+		return nil, false
+	}
+
+	return &task.TimeEntry{
+		TaskId:    req.TaskID,
+		StartedAt: startedAt,
+		EndedAt:   endedAt,
+		Note:      req.Note,
+	}, true
+}
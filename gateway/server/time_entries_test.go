@@ -0,0 +1,131 @@
+package server_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/sejamuchhal/taskhub/gateway/pb/auth"
+	"github.com/sejamuchhal/taskhub/gateway/pb/task"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (suite *ServerTestSuite) expectValidate() {
+	suite.mockAuth.EXPECT().Validate(gomock.Any(), &auth.ValidateRequest{Token: "access_token"}).Return(&auth.ValidateResponse{
+		UserId: "test-user-id",
+		Email:  "harry@hogwarts.edu",
+		Role:   "user",
+	}, nil)
+}
+
+func (suite *ServerTestSuite) TestStartTimer_Success() {
+	req := httptest.NewRequest("POST", "/time-entries/timer/start", bytes.NewBufferString(`{"task_id":"12345"}`))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	startedAt := time.Date(2024, 7, 30, 9, 0, 0, 0, time.UTC)
+	suite.expectValidate()
+	suite.mockTask.EXPECT().StartTimer(gomock.Any(), &task.StartTimerRequest{TaskId: "12345"}).Return(&task.StartTimerResponse{
+		Entry: &task.TimeEntry{
+			Id:        "entry-1",
+			TaskId:    "12345",
+			StartedAt: timestamppb.New(startedAt),
+			Running:   true,
+			CreatedAt: timestamppb.New(startedAt),
+			UpdatedAt: timestamppb.New(startedAt),
+		},
+	}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{
+		"id": "entry-1",
+		"task_id": "12345",
+		"started_at": "2024-07-30T09:00:00Z",
+		"note": "",
+		"duration_seconds": 0,
+		"running": true,
+		"created_at": "2024-07-30T09:00:00Z",
+		"updated_at": "2024-07-30T09:00:00Z"
+	}`, w.Body.String())
+}
+
+func (suite *ServerTestSuite) TestStartTimer_AlreadyRunning() {
+	req := httptest.NewRequest("POST", "/time-entries/timer/start", bytes.NewBufferString(`{"task_id":"12345"}`))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().StartTimer(gomock.Any(), gomock.Any()).Return(nil, status.Error(codes.FailedPrecondition, "A timer is already running, stop it first"))
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusConflict, w.Code)
+	assert.JSONEq(suite.T(), `{"message":"A timer is already running, stop it first"}`, w.Body.String())
+}
+
+func (suite *ServerTestSuite) TestCreateTimeEntry_InvalidDate() {
+	reqBody := `{"task_id":"12345","started_at":"yesterday","ended_at":"2024-07-30T10:00:00Z"}`
+	req := httptest.NewRequest("POST", "/time-entries", bytes.NewBufferString(reqBody))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.JSONEq(suite.T(), `{"message":"Invalid started_at, please use RFC 3339"}`, w.Body.String())
+}
+
+func (suite *ServerTestSuite) TestGetTimeReport_Success() {
+	req := httptest.NewRequest("GET", "/time-entries/report?from=2024-07-01T00:00:00Z&to=2024-08-01T00:00:00Z&timezone=Asia/Kolkata&project_field_id=cf-1", nil)
+	req.Header.Set("Access", "access_token")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().GetTimeReport(gomock.Any(), &task.GetTimeReportRequest{
+		From:           timestamppb.New(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)),
+		To:             timestamppb.New(time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC)),
+		Timezone:       "Asia/Kolkata",
+		ProjectFieldId: "cf-1",
+	}).Return(&task.GetTimeReportResponse{
+		ByDay:        []*task.TimeReportBucket{{Key: "2024-07-29", Label: "2024-07-29", DurationSeconds: 3600}},
+		ByTask:       []*task.TimeReportBucket{{Key: "12345", Label: "New Task", DurationSeconds: 3600}},
+		ByTag:        []*task.TimeReportBucket{{Key: "work", Label: "work", DurationSeconds: 3600}},
+		ByProject:    []*task.TimeReportBucket{{Key: "Apollo", Label: "Apollo", DurationSeconds: 3600}},
+		TotalSeconds: 3600,
+	}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{
+		"by_day": [{"key": "2024-07-29", "label": "2024-07-29", "duration_seconds": 3600}],
+		"by_task": [{"key": "12345", "label": "New Task", "duration_seconds": 3600}],
+		"by_tag": [{"key": "work", "label": "work", "duration_seconds": 3600}],
+		"by_project": [{"key": "Apollo", "label": "Apollo", "duration_seconds": 3600}],
+		"total_seconds": 3600
+	}`, w.Body.String())
+}
+
+func (suite *ServerTestSuite) TestGetTimeReport_MissingRange() {
+	req := httptest.NewRequest("GET", "/time-entries/report", nil)
+	req.Header.Set("Access", "access_token")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
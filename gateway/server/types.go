@@ -24,6 +24,52 @@ type LoginUserRequest struct {
 	Password string `json:"password" binding:"required,min=6"`
 }
 
+// VerifyEmailRequest takes the token from the query of the emailed link or
+// from a JSON body
+type VerifyEmailRequest struct {
+	Token string `form:"token" json:"token" binding:"required"`
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type RequestPasswordResetRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=6,max=100"`
+}
+
+type UnlockAccountRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=6,max=100"`
+}
+
+type ChangePasswordResponse struct {
+	Message              string    `json:"message"`
+	AccessToken          string    `json:"access_token"`
+	AccessTokenExpiresAt time.Time `json:"access_token_expires_at"`
+}
+
+// UpdateAccountRequest leaves out fields that are not sent
+type UpdateAccountRequest struct {
+	Name  string `json:"name" binding:"omitempty,min=3,max=100"`
+	Email string `json:"email" binding:"omitempty,email"`
+}
+
+type UpdateAccountResponse struct {
+	Message      string     `json:"message"`
+	User         UserDetail `json:"user"`
+	PendingEmail string     `json:"pending_email,omitempty"`
+}
+
 type LoginUserResponse struct {
 	SessionID             string     `json:"session_id"`
 	AccessToken           string     `json:"access_token"`
@@ -33,10 +79,59 @@ type LoginUserResponse struct {
 	User                  UserDetail `json:"user"`
 }
 
+// MFAChallengeResponse answers a login that needs a second factor. The
+// mfa_token goes to /auth/mfa/verify, or to /auth/mfa/enroll and
+// /auth/mfa/confirm when enrollment is required.
+type MFAChallengeResponse struct {
+	MFARequired           bool      `json:"mfa_required"`
+	MFAToken              string    `json:"mfa_token"`
+	MFATokenExpiresAt     time.Time `json:"mfa_token_expires_at"`
+	MFAEnrollmentRequired bool      `json:"mfa_enrollment_required"`
+}
+
+// VerifyMFARequest takes a code from the TOTP app or a recovery code
+type VerifyMFARequest struct {
+	MFAToken     string `json:"mfa_token" binding:"required"`
+	Code         string `json:"code" binding:"required_without=RecoveryCode"`
+	RecoveryCode string `json:"recovery_code"`
+}
+
+// EnrollMFARequest takes an mfa_token when there is no Access header
+type EnrollMFARequest struct {
+	MFAToken string `json:"mfa_token"`
+}
+
+type EnrollMFAResponse struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+type ConfirmMFARequest struct {
+	MFAToken string `json:"mfa_token"`
+	Code     string `json:"code" binding:"required"`
+}
+
+type ConfirmMFAResponse struct {
+	RecoveryCodes []string           `json:"recovery_codes"`
+	Login         *LoginUserResponse `json:"login,omitempty"`
+}
+
+type DisableMFARequest struct {
+	Password     string `json:"password" binding:"required"`
+	Code         string `json:"code" binding:"required_without=RecoveryCode"`
+	RecoveryCode string `json:"recovery_code"`
+}
+
 type CreateTaskRequest struct {
-	Title       string `form:"title" json:"title" binding:"required"`
-	Description string `form:"description" json:"description"`
-	DueDateTime string `form:"due_date_time" json:"due_date_time"`
+	Title           string   `form:"title" json:"title" binding:"required"`
+	Description     string   `form:"description" json:"description"`
+	DueDateTime     string   `form:"due_date_time" json:"due_date_time"`
+	EstimateMinutes int32    `form:"estimate_minutes" json:"estimate_minutes" binding:"min=0"`
+	Tags            []string `form:"tags" json:"tags"`
+	// none, low, medium, high or urgent
+	Priority string `form:"priority" json:"priority"`
+	// Values keyed by custom field ID
+	CustomFields map[string]interface{} `json:"custom_fields"`
 }
 
 type CreateTaskResponse struct {
@@ -44,18 +139,39 @@ type CreateTaskResponse struct {
 }
 
 type ListTasksRequest struct {
-	Limit   int  `form:"limit"`
-	Offset  int  `form:"offset"`
-	Pending bool `form:"pending"`
+	Limit    int      `form:"limit"`
+	Offset   int      `form:"offset"`
+	Pending  bool     `form:"pending"`
+	Statuses []string `form:"status"`
+	Tags     []string `form:"tag"`
+	// overdue, today, tomorrow, this_week, next_7_days or none
+	Due string `form:"due"`
+	// created_at, updated_at, due_date, title or priority
+	SortBy    string `form:"sort_by"`
+	SortField string `form:"sort_field"`
+	SortDesc  bool   `form:"sort_desc"`
+	Timezone  string `form:"timezone"`
+	// Archived lists archived tasks instead of the ones that are not
+	Archived bool `form:"archived"`
 }
 
 type TaskDetails struct {
-	Title       string
-	Description string
-	Status      string
-	DueDate     string
-	CreatedAt   string
-	UpdatedAt   string
+	ID              string
+	Title           string
+	Description     string
+	Status          string
+	DueDate         string
+	DueAllDay       bool
+	CreatedAt       string
+	UpdatedAt       string
+	CompletedAt     string
+	ArchivedAt      string
+	EstimateMinutes int32
+	TrackedMinutes  int64
+	Position        string
+	Priority        string
+	Tags            []string
+	CustomFields    map[string]interface{}
 }
 
 type GetTaskResponse struct {
@@ -67,13 +183,370 @@ type ListTasksResponse struct {
 	Tasks []*TaskDetails `json:"tasks"`
 }
 
+type ListNextTasksRequest struct {
+	// 10 when left out
+	Limit int `form:"limit" binding:"min=0"`
+}
+
+// NextTaskDetails is a task with its next-up score and what it was given for
+type NextTaskDetails struct {
+	Task    *TaskDetails `json:"task"`
+	Score   int32        `json:"score"`
+	Reasons []string     `json:"reasons"`
+}
+
 type UpdateTaskRequest struct {
-	Title       string `form:"title" json:"title"`
-	Description string `form:"description" json:"description"`
-	DueDateTime string `form:"due_date_time" json:"due_date_time"`
+	Title           string   `form:"title" json:"title"`
+	Description     string   `form:"description" json:"description"`
+	DueDateTime     string   `form:"due_date_time" json:"due_date_time"`
+	EstimateMinutes int32    `form:"estimate_minutes" json:"estimate_minutes" binding:"min=0"`
+	Tags            []string `form:"tags" json:"tags"`
+	// none, low, medium, high or urgent, none when left out
+	Priority string `form:"priority" json:"priority"`
+	// Values keyed by custom field ID, replacing the current ones
+	CustomFields map[string]interface{} `json:"custom_fields"`
 }
 
+// RenewAccessTokenResponse carries a new refresh token as well, which
+// replaces the one sent. The old one must not be used again.
 type RenewAccessTokenResponse struct {
+	AccessToken           string    `json:"access_token"`
+	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+}
+
+type StartTimerRequest struct {
+	TaskID string `json:"task_id" binding:"required"`
+	Note   string `json:"note"`
+}
+
+// TimeEntryRequest uses RFC 3339 timestamps
+type TimeEntryRequest struct {
+	TaskID    string `json:"task_id"`
+	StartedAt string `json:"started_at" binding:"required"`
+	EndedAt   string `json:"ended_at"`
+	Note      string `json:"note"`
+}
+
+type ListTimeEntriesRequest struct {
+	Limit  int    `form:"limit"`
+	Offset int    `form:"offset"`
+	TaskID string `form:"task_id"`
+	From   string `form:"from"`
+	To     string `form:"to"`
+}
+
+type TimeEntryDetails struct {
+	ID              string `json:"id"`
+	TaskID          string `json:"task_id"`
+	StartedAt       string `json:"started_at"`
+	EndedAt         string `json:"ended_at,omitempty"`
+	Note            string `json:"note"`
+	DurationSeconds int64  `json:"duration_seconds"`
+	Running         bool   `json:"running"`
+	CreatedAt       string `json:"created_at"`
+	UpdatedAt       string `json:"updated_at"`
+}
+
+type ListTimeEntriesResponse struct {
+	Count   int                 `json:"count"`
+	Entries []*TimeEntryDetails `json:"entries"`
+}
+
+type TimeEntryRevisionDetails struct {
+	Action    string `json:"action"`
+	StartedAt string `json:"started_at"`
+	EndedAt   string `json:"ended_at,omitempty"`
+	Note      string `json:"note"`
+	CreatedAt string `json:"created_at"`
+}
+
+type TimeReportRequest struct {
+	From     string `form:"from" binding:"required"`
+	To       string `form:"to" binding:"required"`
+	Timezone string `form:"timezone"`
+	// ProjectFieldID is the custom field holding the project of a task
+	ProjectFieldID string `form:"project_field_id"`
+}
+
+type TimeReportBucket struct {
+	Key             string `json:"key"`
+	Label           string `json:"label"`
+	DurationSeconds int64  `json:"duration_seconds"`
+}
+
+type TimeReportResponse struct {
+	ByDay        []TimeReportBucket `json:"by_day"`
+	ByTask       []TimeReportBucket `json:"by_task"`
+	ByTag        []TimeReportBucket `json:"by_tag"`
+	ByProject    []TimeReportBucket `json:"by_project"`
+	TotalSeconds int64              `json:"total_seconds"`
+}
+
+type TaskStatsRequest struct {
+	From     string `form:"from"`
+	To       string `form:"to"`
+	Timezone string `form:"timezone"`
+	Interval string `form:"interval" binding:"omitempty,oneof=day week"`
+}
+
+type StatusCount struct {
+	Status string `json:"status"`
+	Count  int64  `json:"count"`
+}
+
+type TaskCountBucket struct {
+	Period    string `json:"period"`
+	Created   int64  `json:"created"`
+	Completed int64  `json:"completed"`
+}
+
+type TaskStatsResponse struct {
+	ByStatus                 []StatusCount     `json:"by_status"`
+	Timeline                 []TaskCountBucket `json:"timeline"`
+	OverdueCount             int64             `json:"overdue_count"`
+	AverageCompletionSeconds int64             `json:"average_completion_seconds"`
+	CurrentStreakDays        int32             `json:"current_streak_days"`
+	LongestStreakDays        int32             `json:"longest_streak_days"`
+}
+
+type MoveTaskRequest struct {
+	Status   string `json:"status" binding:"required"`
+	AfterID  string `json:"after_id"`
+	BeforeID string `json:"before_id"`
+}
+
+type BoardColumn struct {
+	Status string         `json:"status"`
+	Tasks  []*TaskDetails `json:"tasks"`
+}
+
+type BoardResponse struct {
+	Columns []BoardColumn `json:"columns"`
+}
+
+type TemplateRequest struct {
+	TaskID                 string   `json:"task_id"`
+	Name                   string   `json:"name" binding:"required"`
+	Title                  string   `json:"title"`
+	Description            string   `json:"description"`
+	Checklist              []string `json:"checklist"`
+	Tags                   []string `json:"tags"`
+	DueOffsetMinutes       *int64   `json:"due_offset_minutes"`
+	ReminderOffsetsMinutes []int64  `json:"reminder_offsets_minutes" binding:"dive,min=0"`
+	EstimateMinutes        int32    `json:"estimate_minutes" binding:"min=0"`
+}
+
+type TemplateDetails struct {
+	ID                     string   `json:"id"`
+	Name                   string   `json:"name"`
+	Title                  string   `json:"title"`
+	Description            string   `json:"description"`
+	Checklist              []string `json:"checklist"`
+	Tags                   []string `json:"tags"`
+	DueOffsetMinutes       *int64   `json:"due_offset_minutes"`
+	ReminderOffsetsMinutes []int64  `json:"reminder_offsets_minutes"`
+	EstimateMinutes        int32    `json:"estimate_minutes"`
+	CreatedAt              string   `json:"created_at"`
+	UpdatedAt              string   `json:"updated_at"`
+}
+
+type InstantiateTemplateRequest struct {
+	Variables map[string]string `json:"variables"`
+	Timezone  string            `json:"timezone"`
+}
+
+// CloneTaskRequest picks what a copy of a task takes over besides its title,
+// description, due date, estimate and priority
+type CloneTaskRequest struct {
+	IncludeTags         bool `json:"include_tags"`
+	IncludeCustomFields bool `json:"include_custom_fields"`
+	IncludeReminders    bool `json:"include_reminders"`
+	// Moves the copy's due date, if the task has one
+	DueOffsetMinutes int64 `json:"due_offset_minutes"`
+}
+
+type CustomFieldRequest struct {
+	Name string `json:"name" binding:"required"`
+	// Required on create, cannot change afterwards
+	Type    string   `json:"type"`
+	Options []string `json:"options"`
+}
+
+type CustomFieldDetails struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Type      string   `json:"type"`
+	Options   []string `json:"options"`
+	CreatedAt string   `json:"created_at"`
+	UpdatedAt string   `json:"updated_at"`
+}
+
+type TaskQuery struct {
+	Statuses           []string            `json:"statuses"`
+	Tags               []string            `json:"tags"`
+	Due                string              `json:"due"`
+	CustomFieldFilters []CustomFieldFilter `json:"custom_field_filters"`
+	SortBy             string              `json:"sort_by"`
+	SortFieldID        string              `json:"sort_field_id"`
+	SortDesc           bool                `json:"sort_desc"`
+}
+
+type CustomFieldFilter struct {
+	FieldID string `json:"field_id" binding:"required"`
+	// eq when empty, or one of lt, lte, gt and gte
+	Op    string      `json:"op"`
+	Value interface{} `json:"value"`
+}
+
+type ViewRequest struct {
+	Name  string    `json:"name" binding:"required"`
+	Query TaskQuery `json:"query"`
+	// status, tag or a custom field ID
+	GroupBy string `json:"group_by"`
+	// private, the default, or workspace
+	Visibility string `json:"visibility"`
+}
+
+type ViewDetails struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	Query      TaskQuery `json:"query"`
+	GroupBy    string    `json:"group_by"`
+	Visibility string    `json:"visibility"`
+	// UserID is the owner, the only one who can change the view
+	UserID    string `json:"user_id"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type ListViewTasksRequest struct {
+	Limit    int    `form:"limit"`
+	Offset   int    `form:"offset"`
+	Timezone string `form:"timezone"`
+}
+
+type TaskGroup struct {
+	Key     string   `json:"key"`
+	TaskIDs []string `json:"task_ids"`
+}
+
+type ListViewTasksResponse struct {
+	View   *ViewDetails   `json:"view"`
+	Count  int            `json:"count"`
+	Tasks  []*TaskDetails `json:"tasks"`
+	Groups []TaskGroup    `json:"groups,omitempty"`
+}
+
+type RetentionPolicyRequest struct {
+	// Zero turns a step off
+	ArchiveAfterDays int32 `json:"archive_after_days"`
+	PurgeAfterDays   int32 `json:"purge_after_days"`
+}
+
+type RetentionPolicyDetails struct {
+	ArchiveAfterDays int32 `json:"archive_after_days"`
+	PurgeAfterDays   int32 `json:"purge_after_days"`
+	// IsDefault is set while the global policy applies
+	IsDefault bool `json:"is_default"`
+}
+
+type TaskWatcherDetails struct {
+	UserID    string `json:"user_id"`
+	Email     string `json:"email"`
+	CreatedAt string `json:"created_at"`
+}
+
+type QuickAddRequest struct {
+	Text string `json:"text" binding:"required"`
+	// Preview parses the text without creating the task
+	Preview bool `json:"preview"`
+}
+
+type QuickAddDetails struct {
+	Title      string   `json:"title"`
+	DueDate    string   `json:"due_date,omitempty"`
+	DueAllDay  bool     `json:"due_all_day"`
+	Tags       []string `json:"tags"`
+	Priority   string   `json:"priority,omitempty"`
+	Assignee   string   `json:"assignee,omitempty"`
+	Recurrence string   `json:"recurrence,omitempty"`
+}
+
+type QuickAddResponse struct {
+	// TaskID is empty for previews
+	TaskID string          `json:"task_id,omitempty"`
+	Parsed QuickAddDetails `json:"parsed"`
+	// Ignored names the parsed parts tasks cannot hold yet
+	Ignored []string `json:"ignored,omitempty"`
+}
+
+// JWK is a public key tokens are signed with, see RFC 7517
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKSResponse struct {
+	Keys []JWK `json:"keys"`
+}
+
+// SessionDetails is a session the user is signed in with
+type SessionDetails struct {
+	ID         string    `json:"id"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	// Current is set for the session of the Refresh header
+	Current bool `json:"current"`
+}
+
+type ListSessionsResponse struct {
+	Sessions []SessionDetails `json:"sessions"`
+}
+
+type RevokeAllSessionsResponse struct {
+	Message              string    `json:"message"`
 	AccessToken          string    `json:"access_token"`
 	AccessTokenExpiresAt time.Time `json:"access_token_expires_at"`
 }
+
+type ListUsersRequest struct {
+	// 10 when left out, at most 100
+	Limit  int `form:"limit" binding:"min=0"`
+	Offset int `form:"offset" binding:"min=0"`
+	// Matches part of the name or email
+	Query string `form:"query"`
+	Role  string `form:"role"`
+	// all, active or inactive
+	Status string `form:"status" binding:"omitempty,oneof=all active inactive"`
+}
+
+type AccountDetails struct {
+	ID            string    `json:"id"`
+	Name          string    `json:"name"`
+	Email         string    `json:"email"`
+	Role          string    `json:"role"`
+	Active        bool      `json:"active"`
+	EmailVerified bool      `json:"email_verified"`
+	MFAEnabled    bool      `json:"mfa_enabled"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type ListUsersResponse struct {
+	Count int              `json:"count"`
+	Users []AccountDetails `json:"users"`
+}
+
+type SetUserRoleRequest struct {
+	Role string `json:"role" binding:"required,oneof=user admin"`
+}
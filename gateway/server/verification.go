@@ -0,0 +1,52 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sejamuchhal/taskhub/gateway/pb/auth"
+)
+
+// VerifyEmail confirms a user's email with the token from their verification
+// link. The link itself points here, so GET works as well as POST.
+func (s *Server) VerifyEmail(c *gin.Context) {
+	logger := s.Logger.WithField("method", "VerifyEmail")
+	logger.Debug("Incoming request")
+
+	var req VerifyEmailRequest
+	if err := c.ShouldBind(&req); err != nil {
+		logger.WithError(err).Error("Error parsing verify email request")
+		c.JSON(http.StatusBadRequest, gin.H{"message": "Token is required"})
+		return
+	}
+
+	res, err := s.AuthClient.VerifyEmail(context.Background(), &auth.VerifyEmailRequest{Token: req.Token})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "verify email")
+		return
+	}
+
+	logger.Info("Email verified")
+	c.JSON(http.StatusOK, gin.H{"message": res.Message})
+}
+
+func (s *Server) ResendVerification(c *gin.Context) {
+	logger := s.Logger.WithField("method", "ResendVerification")
+	logger.Debug("Incoming request")
+
+	var req ResendVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.WithError(err).Error("Error parsing resend verification request")
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	res, err := s.AuthClient.ResendVerification(context.Background(), &auth.ResendVerificationRequest{Email: req.Email})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "resend verification email")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": res.Message})
+}
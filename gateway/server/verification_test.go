@@ -0,0 +1,76 @@
+package server_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/sejamuchhal/taskhub/gateway/pb/auth"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (suite *ServerTestSuite) TestVerifyEmail_FromLink() {
+	req := httptest.NewRequest("GET", "/auth/verify-email?token=abc", nil)
+	w := httptest.NewRecorder()
+
+	suite.mockAuth.EXPECT().VerifyEmail(gomock.Any(), &auth.VerifyEmailRequest{Token: "abc"}).
+		Return(&auth.VerifyEmailResponse{Message: "Email verified"}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{"message": "Email verified"}`, w.Body.String())
+}
+
+func (suite *ServerTestSuite) TestVerifyEmail_InvalidToken() {
+	req := httptest.NewRequest("POST", "/auth/verify-email", bytes.NewBufferString(`{"token":"abc"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.mockAuth.EXPECT().VerifyEmail(gomock.Any(), &auth.VerifyEmailRequest{Token: "abc"}).
+		Return(nil, status.Error(codes.InvalidArgument, "Invalid or expired verification token"))
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+	assert.JSONEq(suite.T(), `{"message": "Invalid or expired verification token"}`, w.Body.String())
+}
+
+func (suite *ServerTestSuite) TestVerifyEmail_MissingToken() {
+	req := httptest.NewRequest("GET", "/auth/verify-email", nil)
+	w := httptest.NewRecorder()
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *ServerTestSuite) TestResendVerification() {
+	req := httptest.NewRequest("POST", "/auth/verify-email/resend", bytes.NewBufferString(`{"email":"harry@hogwarts.edu"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.mockAuth.EXPECT().ResendVerification(gomock.Any(), &auth.ResendVerificationRequest{Email: "harry@hogwarts.edu"}).
+		Return(&auth.ResendVerificationResponse{Message: "A new verification link is on its way"}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
+func (suite *ServerTestSuite) TestLoginUser_EmailNotVerified() {
+	req := httptest.NewRequest("POST", "/auth/login", bytes.NewBufferString(`{"email":"harry@hogwarts.edu","password":"password"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.mockAuth.EXPECT().Login(gomock.Any(), gomock.Any()).
+		Return(nil, status.Error(codes.FailedPrecondition, "Email address is not verified"))
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusForbidden, w.Code)
+	assert.JSONEq(suite.T(), `{"message": "Email address is not verified"}`, w.Body.String())
+}
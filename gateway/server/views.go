@@ -0,0 +1,244 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sejamuchhal/taskhub/gateway/pb/task"
+	"google.golang.org/grpc/metadata"
+)
+
+func (s *Server) CreateView(c *gin.Context) {
+	logger := s.Logger.WithField("method", "CreateView")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	var req ViewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.WithError(err).Error("Failed to bind JSON")
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+	view, err := viewFromRequest("", &req)
+	if err != nil {
+		logger.WithError(err).Error("Invalid view query")
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := s.TaskClient.CreateView(ctxWithMetadata, &task.CreateViewRequest{View: view})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "create view")
+		return
+	}
+
+	c.JSON(http.StatusOK, TransformView(resp.View))
+}
+
+func (s *Server) ListViews(c *gin.Context) {
+	logger := s.Logger.WithField("method", "ListViews")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := s.TaskClient.ListViews(ctxWithMetadata, &task.ListViewsRequest{})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "list views")
+		return
+	}
+
+	views := make([]*ViewDetails, len(resp.GetViews()))
+	for i, view := range resp.GetViews() {
+		views[i] = TransformView(view)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"views": views})
+}
+
+func (s *Server) GetView(c *gin.Context) {
+	logger := s.Logger.WithField("method", "GetView")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := s.TaskClient.GetView(ctxWithMetadata, &task.GetViewRequest{Id: c.Param("id")})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "get view")
+		return
+	}
+
+	c.JSON(http.StatusOK, TransformView(resp.View))
+}
+
+func (s *Server) UpdateView(c *gin.Context) {
+	logger := s.Logger.WithField("method", "UpdateView")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	var req ViewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.WithError(err).Error("Failed to bind JSON")
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+	view, err := viewFromRequest(c.Param("id"), &req)
+	if err != nil {
+		logger.WithError(err).Error("Invalid view query")
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := s.TaskClient.UpdateView(ctxWithMetadata, &task.UpdateViewRequest{View: view})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "update view")
+		return
+	}
+
+	c.JSON(http.StatusOK, TransformView(resp.View))
+}
+
+func (s *Server) DeleteView(c *gin.Context) {
+	logger := s.Logger.WithField("method", "DeleteView")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	_, err := s.TaskClient.DeleteView(ctxWithMetadata, &task.DeleteViewRequest{Id: c.Param("id")})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "delete view")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "View deleted successfully"})
+}
+
+// ListViewTasks lists the tasks matching a saved view, evaluated the same way
+// as GET /tasks
+func (s *Server) ListViewTasks(c *gin.Context) {
+	logger := s.Logger.WithField("method", "ListViewTasks")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	var req ListViewTasksRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		logger.WithError(err).Error("Failed to bind query parameters")
+		c.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		return
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := s.TaskClient.ListViewTasks(ctxWithMetadata, &task.ListViewTasksRequest{
+		Id:       c.Param("id"),
+		Limit:    int32(req.Limit),
+		Offset:   int32(req.Offset),
+		Timezone: req.Timezone,
+	})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "list view tasks")
+		return
+	}
+
+	tasks := make([]*TaskDetails, len(resp.GetTasks()))
+	for i, t := range resp.GetTasks() {
+		tasks[i] = TransformTask(t)
+	}
+	var groups []TaskGroup
+	for _, group := range resp.GetGroups() {
+		groups = append(groups, TaskGroup{Key: group.Key, TaskIDs: group.TaskIds})
+	}
+
+	c.JSON(http.StatusOK, ListViewTasksResponse{
+		View:   TransformView(resp.View),
+		Count:  int(resp.TotalCount),
+		Tasks:  tasks,
+		Groups: groups,
+	})
+}
+
+// viewFromRequest converts a view from JSON. Filter values may be strings,
+// numbers or booleans.
+func viewFromRequest(id string, req *ViewRequest) (*task.SavedView, error) {
+	var filters []*task.CustomFieldFilter
+	for _, filter := range req.Query.CustomFieldFilters {
+		var value *task.CustomFieldValue
+		switch v := filter.Value.(type) {
+		case string:
+			value = &task.CustomFieldValue{Kind: &task.CustomFieldValue_StringValue{StringValue: v}}
+		case float64:
+			value = &task.CustomFieldValue{Kind: &task.CustomFieldValue_NumberValue{NumberValue: v}}
+		case bool:
+			value = &task.CustomFieldValue{Kind: &task.CustomFieldValue_BoolValue{BoolValue: v}}
+		default:
+			return nil, fmt.Errorf("custom field filter %s: unsupported value", filter.FieldID)
+		}
+		filters = append(filters, &task.CustomFieldFilter{FieldId: filter.FieldID, Op: filter.Op, Value: value})
+	}
+
+	return &task.SavedView{
+		Id:   id,
+		Name: req.Name,
+		Query: &task.TaskQuery{
+			Statuses:           req.Query.Statuses,
+			Tags:               req.Query.Tags,
+			Due:                req.Query.Due,
+			CustomFieldFilters: filters,
+			SortBy:             req.Query.SortBy,
+			SortFieldId:        req.Query.SortFieldID,
+			SortDesc:           req.Query.SortDesc,
+		},
+		GroupBy:    req.GroupBy,
+		Visibility: req.Visibility,
+	}, nil
+}
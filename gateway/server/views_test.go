@@ -0,0 +1,170 @@
+package server_test
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/sejamuchhal/taskhub/gateway/pb/task"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (suite *ServerTestSuite) TestCreateView_Success() {
+	reqBody := `{
+		"name": "Work this week",
+		"query": {
+			"statuses": ["created", "in_progress"],
+			"tags": ["work"],
+			"due": "this_week",
+			"custom_field_filters": [{"field_id": "points", "op": "gte", "value": 3}],
+			"sort_by": "due_date"
+		},
+		"group_by": "status"
+	}`
+	req := httptest.NewRequest("POST", "/views", bytes.NewBufferString(reqBody))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	query := &task.TaskQuery{
+		Statuses: []string{"created", "in_progress"},
+		Tags:     []string{"work"},
+		Due:      "this_week",
+		CustomFieldFilters: []*task.CustomFieldFilter{
+			{FieldId: "points", Op: "gte", Value: &task.CustomFieldValue{Kind: &task.CustomFieldValue_NumberValue{NumberValue: 3}}},
+		},
+		SortBy: "due_date",
+	}
+	suite.expectValidate()
+	suite.mockTask.EXPECT().CreateView(gomock.Any(), &task.CreateViewRequest{
+		View: &task.SavedView{Name: "Work this week", Query: query, GroupBy: "status"},
+	}).Return(&task.CreateViewResponse{View: &task.SavedView{
+		Id:   "view-1",
+		Name: "Work this week",
+		Query: &task.TaskQuery{
+			Statuses: query.Statuses,
+			Tags:     query.Tags,
+			Due:      query.Due,
+			CustomFieldFilters: []*task.CustomFieldFilter{
+				{FieldId: "points", Op: "gte", Value: &task.CustomFieldValue{Kind: &task.CustomFieldValue_StringValue{StringValue: "3"}}},
+			},
+			SortBy: query.SortBy,
+		},
+		GroupBy:    "status",
+		Visibility: "private",
+		UserId:     "1234",
+	}}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.JSONEq(suite.T(), `{
+		"id": "view-1",
+		"name": "Work this week",
+		"query": {
+			"statuses": ["created", "in_progress"],
+			"tags": ["work"],
+			"due": "this_week",
+			"custom_field_filters": [{"field_id": "points", "op": "gte", "value": "3"}],
+			"sort_by": "due_date",
+			"sort_field_id": "",
+			"sort_desc": false
+		},
+		"group_by": "status",
+		"visibility": "private",
+		"user_id": "1234",
+		"created_at": "",
+		"updated_at": ""
+	}`, w.Body.String())
+}
+
+func (suite *ServerTestSuite) TestCreateView_InvalidQuery() {
+	req := httptest.NewRequest("POST", "/views", bytes.NewBufferString(`{"name":"View","query":{"due":"someday"}}`))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().CreateView(gomock.Any(), gomock.Any()).
+		Return(nil, status.Error(codes.InvalidArgument, "due must be one of overdue, today, tomorrow, this_week, next_7_days, none"))
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *ServerTestSuite) TestGetView_NotFound() {
+	req := httptest.NewRequest("GET", "/views/view-1", nil)
+	req.Header.Set("Access", "access_token")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().GetView(gomock.Any(), &task.GetViewRequest{Id: "view-1"}).
+		Return(nil, status.Error(codes.NotFound, "View not found"))
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
+
+func (suite *ServerTestSuite) TestUpdateView_SharedByOther() {
+	req := httptest.NewRequest("PUT", "/views/view-1", bytes.NewBufferString(`{"name":"Team backlog","visibility":"private"}`))
+	req.Header.Set("Access", "access_token")
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().UpdateView(gomock.Any(), &task.UpdateViewRequest{
+		View: &task.SavedView{Id: "view-1", Name: "Team backlog", Query: &task.TaskQuery{}, Visibility: "private"},
+	}).Return(nil, status.Error(codes.PermissionDenied, "Only the owner can change a shared view"))
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusForbidden, w.Code)
+}
+
+func (suite *ServerTestSuite) TestListViewTasks_Success() {
+	req := httptest.NewRequest("GET", "/views/view-1/tasks?limit=20&timezone=Europe/Berlin", nil)
+	req.Header.Set("Access", "access_token")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().ListViewTasks(gomock.Any(), &task.ListViewTasksRequest{
+		Id:       "view-1",
+		Limit:    20,
+		Timezone: "Europe/Berlin",
+	}).Return(&task.ListViewTasksResponse{
+		View:       &task.SavedView{Id: "view-1", Name: "Open work", GroupBy: "tag"},
+		Tasks:      []*task.Task{{Id: "task-1", Title: "Task", Tags: []string{"work"}}},
+		TotalCount: 1,
+		Groups:     []*task.TaskGroup{{Key: "work", TaskIds: []string{"task-1"}}},
+	}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Contains(suite.T(), w.Body.String(), `"count":1`)
+	assert.Contains(suite.T(), w.Body.String(), `"groups":[{"key":"work","task_ids":["task-1"]}]`)
+}
+
+func (suite *ServerTestSuite) TestListTasks_QueryFilters() {
+	req := httptest.NewRequest("GET", "/tasks?status=created&status=in_progress&tag=work&due=today&sort_by=title&timezone=Asia/Kolkata", nil)
+	req.Header.Set("Access", "access_token")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().ListTasks(gomock.Any(), &task.ListTasksRequest{
+		Statuses: []string{"created", "in_progress"},
+		Tags:     []string{"work"},
+		Due:      "today",
+		SortBy:   "title",
+		Timezone: "Asia/Kolkata",
+	}).Return(&task.ListTasksResponse{Tasks: []*task.Task{{Id: "12345", Title: "Task"}}, TotalCount: 1}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
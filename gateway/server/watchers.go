@@ -0,0 +1,97 @@
+package server
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sejamuchhal/taskhub/gateway/pb/task"
+	"google.golang.org/grpc/metadata"
+)
+
+// WatchTask makes the caller watch a task they were invited to, or with a
+// user ID in the path invites that user to one of the caller's tasks
+func (s *Server) WatchTask(c *gin.Context) {
+	logger := s.Logger.WithField("method", "WatchTask")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	_, err := s.TaskClient.WatchTask(ctxWithMetadata, &task.WatchTaskRequest{
+		TaskId: c.Param("id"),
+		UserId: c.Param("user_id"),
+	})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "watch task")
+		return
+	}
+
+	if c.Param("user_id") != "" {
+		c.JSON(http.StatusOK, gin.H{"message": "Task watcher invited successfully"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Task watched successfully"})
+}
+
+// UnwatchTask stops the caller from watching a task, or with a user ID in the
+// path removes that watcher from one of the caller's tasks
+func (s *Server) UnwatchTask(c *gin.Context) {
+	logger := s.Logger.WithField("method", "UnwatchTask")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	_, err := s.TaskClient.UnwatchTask(ctxWithMetadata, &task.UnwatchTaskRequest{
+		TaskId: c.Param("id"),
+		UserId: c.Param("user_id"),
+	})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "unwatch task")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Task unwatched successfully"})
+}
+
+func (s *Server) ListTaskWatchers(c *gin.Context) {
+	logger := s.Logger.WithField("method", "ListTaskWatchers")
+	logger.Debug("Incoming request")
+	if !hasPermission(c, []string{"user", "admin"}) {
+		c.JSON(http.StatusForbidden, gin.H{"message": "Insufficient permissions"})
+		return
+	}
+
+	md, ok := getGRPCMetadataFromGin(c, logger)
+	if !ok {
+		return
+	}
+	ctxWithMetadata := metadata.NewOutgoingContext(context.Background(), md)
+
+	resp, err := s.TaskClient.ListTaskWatchers(ctxWithMetadata, &task.ListTaskWatchersRequest{TaskId: c.Param("id")})
+	if err != nil {
+		respondWithGRPCError(c, logger, err, "list task watchers")
+		return
+	}
+
+	watchers := make([]*TaskWatcherDetails, len(resp.GetWatchers()))
+	for i, watcher := range resp.GetWatchers() {
+		watchers[i] = TransformTaskWatcher(watcher)
+	}
+	c.JSON(http.StatusOK, gin.H{"watchers": watchers})
+}
@@ -0,0 +1,125 @@
+package server_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	"github.com/sejamuchhal/taskhub/gateway/pb/task"
+	"github.com/stretchr/testify/assert"
+	gomock "go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (suite *ServerTestSuite) TestWatchTask() {
+	req := httptest.NewRequest("PUT", "/tasks/12345/watch", nil)
+	req.Header.Set("Access", "access_token")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().WatchTask(gomock.Any(), &task.WatchTaskRequest{TaskId: "12345"}).
+		Return(&task.WatchTaskResponse{}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
+func (suite *ServerTestSuite) TestWatchTask_OwnTask() {
+	req := httptest.NewRequest("PUT", "/tasks/12345/watch", nil)
+	req.Header.Set("Access", "access_token")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().WatchTask(gomock.Any(), &task.WatchTaskRequest{TaskId: "12345"}).
+		Return(nil, status.Error(codes.InvalidArgument, "Owners cannot watch their own tasks"))
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, w.Code)
+}
+
+func (suite *ServerTestSuite) TestWatchTask_NotInvited() {
+	req := httptest.NewRequest("PUT", "/tasks/12345/watch", nil)
+	req.Header.Set("Access", "access_token")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().WatchTask(gomock.Any(), &task.WatchTaskRequest{TaskId: "12345"}).
+		Return(nil, status.Error(codes.PermissionDenied, "Only users the owner invited can watch this task"))
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusForbidden, w.Code)
+}
+
+func (suite *ServerTestSuite) TestInviteTaskWatcher() {
+	req := httptest.NewRequest("PUT", "/tasks/12345/watchers/5678", nil)
+	req.Header.Set("Access", "access_token")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().WatchTask(gomock.Any(), &task.WatchTaskRequest{TaskId: "12345", UserId: "5678"}).
+		Return(&task.WatchTaskResponse{}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
+func (suite *ServerTestSuite) TestUnwatchTask() {
+	req := httptest.NewRequest("DELETE", "/tasks/12345/watch", nil)
+	req.Header.Set("Access", "access_token")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().UnwatchTask(gomock.Any(), &task.UnwatchTaskRequest{TaskId: "12345"}).
+		Return(&task.UnwatchTaskResponse{}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+}
+
+func (suite *ServerTestSuite) TestRemoveTaskWatcher() {
+	req := httptest.NewRequest("DELETE", "/tasks/12345/watchers/5678", nil)
+	req.Header.Set("Access", "access_token")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().UnwatchTask(gomock.Any(), &task.UnwatchTaskRequest{TaskId: "12345", UserId: "5678"}).
+		Return(nil, status.Error(codes.NotFound, "Task not found"))
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
+
+func (suite *ServerTestSuite) TestListTaskWatchers() {
+	req := httptest.NewRequest("GET", "/tasks/12345/watchers", nil)
+	req.Header.Set("Access", "access_token")
+	w := httptest.NewRecorder()
+
+	suite.expectValidate()
+	suite.mockTask.EXPECT().ListTaskWatchers(gomock.Any(), &task.ListTaskWatchersRequest{TaskId: "12345"}).
+		Return(&task.ListTaskWatchersResponse{Watchers: []*task.TaskWatcher{
+			{UserId: "5678", Email: "watcher@example.com", CreatedAt: timestamppb.Now()},
+		}}, nil)
+
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	var body struct {
+		Watchers []struct {
+			UserID string `json:"user_id"`
+			Email  string `json:"email"`
+		} `json:"watchers"`
+	}
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &body))
+	if assert.Len(suite.T(), body.Watchers, 1) {
+		assert.Equal(suite.T(), "5678", body.Watchers[0].UserID)
+		assert.Equal(suite.T(), "watcher@example.com", body.Watchers[0].Email)
+	}
+}
@@ -50,7 +50,15 @@ func main() {
 	worker := worker.NewWorker(emailSender, rmq)
 	rmq.MsgHandler = worker.NotificationHandler
 
+	userRMQ := &rabbitmq.RabbitMQBroker{
+		Connection: conn,
+		Logger:     logger,
+		QueueName:  conf.UserQueue,
+		MsgHandler: worker.UserEventHandler,
+	}
+
 	// Start consuming messages
 	logger.Info("Starting message consumption from RabbitMQ")
+	go userRMQ.Consume()
 	rmq.Consume()
 }
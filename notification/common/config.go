@@ -14,11 +14,14 @@ type Config struct {
 	MailersendSenderEmail string
 	RabbitMQURL           string
 	TaskQueue             string
+	// UserQueue carries the emails the auth service asks for
+	UserQueue string
 }
 
 func LoadConfig() *Config {
 
 	taskQueuequeue := GetEnvDefault("TASK_QUEUE", "task_queue")
+	userQueue := GetEnvDefault("USER_QUEUE", "user_queue")
 	mailersendAPIKey := os.Getenv("MAILERSEND_API_KEY")
 	senderName := os.Getenv("MAILERSEND_SENDER_NAME")
 	senderEmail := os.Getenv("MAILERSEND_SENDER_EMAIL")
@@ -31,6 +34,7 @@ func LoadConfig() *Config {
 		MailersendSenderEmail: senderEmail,
 		RabbitMQURL:           rabbitMQURL,
 		TaskQueue:             taskQueuequeue,
+		UserQueue:             userQueue,
 	}
 	return config
 }
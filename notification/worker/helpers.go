@@ -11,3 +11,38 @@ func prepareEmailContent(message event.TaskUpdateEvent) (string, string) {
 	mailBody := fmt.Sprintf("Task Title: %s\nStatus: %s", message.Title, message.Status)
 	return mailSubject, mailBody
 }
+
+// prepareUserEmailContent writes the email for a user event. It reports false
+// for event types it does not know.
+func prepareUserEmailContent(message *event.UserEvent) (string, string, bool) {
+	switch message.Type {
+	case "user.verification_requested":
+		mailSubject := "Verify your TaskHub email address"
+		mailBody := fmt.Sprintf("Hi %s,\n\nPlease confirm your email address by opening this link:\n%s\n\nIf you did not sign up for TaskHub, you can ignore this email.", message.Name, message.Link)
+		return mailSubject, mailBody, true
+	case "user.password_reset_requested":
+		mailSubject := "Reset your TaskHub password"
+		mailBody := fmt.Sprintf("Hi %s,\n\nYou can choose a new password by opening this link:\n%s\n\nThe link works once and expires soon. If you did not ask for a password reset, you can ignore this email.", message.Name, message.Link)
+		return mailSubject, mailBody, true
+	case "user.email_change_requested":
+		mailSubject := "Confirm your new TaskHub email address"
+		mailBody := fmt.Sprintf("Hi %s,\n\nPlease confirm that you want to use this address for TaskHub by opening this link:\n%s\n\nYou will need to log in again afterwards. If you did not ask for this change, you can ignore this email.", message.Name, message.Link)
+		return mailSubject, mailBody, true
+	case "user.session_token_reused":
+		mailSubject := "A TaskHub session was signed out"
+		mailBody := fmt.Sprintf("Hi %s,\n\nA sign-in token of one of your sessions was used after it had been replaced, which can mean it was stolen. We signed that session out to be safe.\n\nDevice: %s\nIP address: %s\n\nIf this was not you, please change your password.", message.Name, valueOrUnknown(message.UserAgent), valueOrUnknown(message.IpAddress))
+		return mailSubject, mailBody, true
+	case "user.account_locked":
+		mailSubject := "Your TaskHub account was locked"
+		mailBody := fmt.Sprintf("Hi %s,\n\nThere were too many failed attempts to sign in to your account, so we locked it for a while. It unlocks by itself, or an administrator can unlock it for you.\n\nLast attempt from IP address: %s\n\nIf this was not you, someone may be guessing your password. Please choose a strong one once you can sign in again.", message.Name, valueOrUnknown(message.IpAddress))
+		return mailSubject, mailBody, true
+	}
+	return "", "", false
+}
+
+func valueOrUnknown(value string) string {
+	if value == "" {
+		return "unknown"
+	}
+	return value
+}
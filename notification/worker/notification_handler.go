@@ -8,7 +8,6 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-
 func (w *Worker) NotificationHandler(queue string, msg amqp.Delivery, err error) {
 	logger := log.WithFields(log.Fields{"method": "NotificationHandler"})
 
@@ -26,13 +25,23 @@ func (w *Worker) NotificationHandler(queue string, msg amqp.Delivery, err error)
 
 	mailSubject, mailBody := prepareEmailContent(message)
 
-	_, err = w.EmailSender.SendEmail(message.Email, mailSubject, mailBody)
-	if err != nil {
-		logger.WithFields(log.Fields{
-			"task_title": message.Title,
-			"email":      message.Email,
-		}).WithError(err).Error("Failed to send email reminder")
+	recipients := message.Recipients
+	if len(recipients) == 0 && message.Email != "" {
+		// Queued before events carried recipients
+		recipients = []string{message.Email}
 	}
 
-	logger.WithFields(log.Fields{"reminder_id": message.Title, "email": message.Email}).Info("Email sent successfully")
+	// A failed recipient does not stop the others from being notified
+	for _, email := range recipients {
+		_, err = w.EmailSender.SendEmail(email, mailSubject, mailBody)
+		if err != nil {
+			logger.WithFields(log.Fields{
+				"task_title": message.Title,
+				"email":      email,
+			}).WithError(err).Error("Failed to send email reminder")
+			continue
+		}
+
+		logger.WithFields(log.Fields{"reminder_id": message.Title, "email": email}).Info("Email sent successfully")
+	}
 }
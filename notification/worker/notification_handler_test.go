@@ -2,6 +2,7 @@ package worker_test
 
 import (
 	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/mailersend/mailersend-go"
@@ -37,6 +38,32 @@ func TestNotificationHandler_EmailSentSuccessfully(t *testing.T) {
 
 }
 
+func TestNotificationHandler_EmailSentToEachRecipient(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEmailSender := mock_email_sender.NewMockEmailSenderInterface(ctrl)
+	mockRabbitMQ := mock_rabbitmq.NewMockRabbitMQBrokerInterface(ctrl)
+	w := worker.Worker{
+		EmailSender:    mockEmailSender,
+		RabbitMQBroker: mockRabbitMQ,
+	}
+
+	taskUpdateEvent := event.TaskUpdateEvent{
+		Title:      "Test Task",
+		Status:     "updated",
+		Recipients: []string{"owner@example.com", "watcher@example.com"},
+	}
+	msgBody, _ := json.Marshal(&taskUpdateEvent)
+	msg := amqp.Delivery{Body: msgBody}
+
+	// The second recipient still gets the email when the first one fails
+	mockEmailSender.EXPECT().SendEmail("owner@example.com", gomock.Any(), gomock.Any()).Return(nil, errors.New("send error")).Times(1)
+	mockEmailSender.EXPECT().SendEmail("watcher@example.com", gomock.Any(), gomock.Any()).Return(&mailersend.Response{}, nil).Times(1)
+
+	w.NotificationHandler("test-queue", msg, nil)
+}
+
 func TestNotificationHandler_UnmarshallingError(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -0,0 +1,41 @@
+package worker
+
+import (
+	"encoding/json"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	event "github.com/sejamuchhal/taskhub/notification/pb"
+	log "github.com/sirupsen/logrus"
+)
+
+// UserEventHandler emails users about their account, as asked by the auth
+// service
+func (w *Worker) UserEventHandler(queue string, msg amqp.Delivery, err error) {
+	logger := log.WithFields(log.Fields{"method": "UserEventHandler"})
+
+	if err != nil {
+		logger.WithError(err).Error("Error occurred in RMQ consumer")
+		return
+	}
+
+	var message event.UserEvent
+	if err := json.Unmarshal(msg.Body, &message); err != nil {
+		logger.WithError(err).Error("Error while unmarshalling user event")
+		return
+	}
+	// The body holds a one-time token, so it is not logged
+	logger = logger.WithFields(log.Fields{"type": message.Type, "email": message.Email})
+	logger.Infof("Message received on '%s' queue", queue)
+
+	mailSubject, mailBody, ok := prepareUserEmailContent(&message)
+	if !ok {
+		logger.Error("Unknown user event type")
+		return
+	}
+
+	if _, err := w.EmailSender.SendEmail(message.Email, mailSubject, mailBody); err != nil {
+		logger.WithError(err).Error("Failed to send user email")
+		return
+	}
+	logger.Info("Email sent successfully")
+}
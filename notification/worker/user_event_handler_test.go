@@ -0,0 +1,158 @@
+package worker_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mailersend/mailersend-go"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/sejamuchhal/taskhub/notification/mocks/mock_email_sender"
+	event "github.com/sejamuchhal/taskhub/notification/pb"
+	"github.com/sejamuchhal/taskhub/notification/worker"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUserEventHandler_VerificationRequested(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEmailSender := mock_email_sender.NewMockEmailSenderInterface(ctrl)
+	w := worker.Worker{EmailSender: mockEmailSender}
+
+	userEvent := event.UserEvent{
+		Type:  "user.verification_requested",
+		Email: "harry@hogwarts.edu",
+		Name:  "Harry",
+		Link:  "https://taskhub.example.com/verify?token=abc",
+	}
+	msgBody, _ := json.Marshal(&userEvent)
+
+	mockEmailSender.EXPECT().SendEmail("harry@hogwarts.edu", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(to, subject, body string) (*mailersend.Response, error) {
+			if !strings.Contains(body, userEvent.Link) {
+				t.Errorf("body %q does not contain the link", body)
+			}
+			return &mailersend.Response{}, nil
+		}).Times(1)
+
+	w.UserEventHandler("user_queue", amqp.Delivery{Body: msgBody}, nil)
+}
+
+func TestUserEventHandler_PasswordResetRequested(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEmailSender := mock_email_sender.NewMockEmailSenderInterface(ctrl)
+	w := worker.Worker{EmailSender: mockEmailSender}
+
+	userEvent := event.UserEvent{
+		Type:  "user.password_reset_requested",
+		Email: "harry@hogwarts.edu",
+		Name:  "Harry",
+		Link:  "https://taskhub.example.com/reset?token=abc",
+	}
+	msgBody, _ := json.Marshal(&userEvent)
+
+	mockEmailSender.EXPECT().SendEmail("harry@hogwarts.edu", "Reset your TaskHub password", gomock.Any()).
+		DoAndReturn(func(to, subject, body string) (*mailersend.Response, error) {
+			if !strings.Contains(body, userEvent.Link) {
+				t.Errorf("body %q does not contain the link", body)
+			}
+			return &mailersend.Response{}, nil
+		}).Times(1)
+
+	w.UserEventHandler("user_queue", amqp.Delivery{Body: msgBody}, nil)
+}
+
+func TestUserEventHandler_EmailChangeRequested(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEmailSender := mock_email_sender.NewMockEmailSenderInterface(ctrl)
+	w := worker.Worker{EmailSender: mockEmailSender}
+
+	userEvent := event.UserEvent{
+		Type:  "user.email_change_requested",
+		Email: "potter@ministry.gov",
+		Name:  "Harry",
+		Link:  "https://taskhub.example.com/verify?token=abc",
+	}
+	msgBody, _ := json.Marshal(&userEvent)
+
+	mockEmailSender.EXPECT().SendEmail("potter@ministry.gov", "Confirm your new TaskHub email address", gomock.Any()).
+		DoAndReturn(func(to, subject, body string) (*mailersend.Response, error) {
+			if !strings.Contains(body, userEvent.Link) {
+				t.Errorf("body %q does not contain the link", body)
+			}
+			return &mailersend.Response{}, nil
+		}).Times(1)
+
+	w.UserEventHandler("user_queue", amqp.Delivery{Body: msgBody}, nil)
+}
+
+func TestUserEventHandler_SessionTokenReused(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEmailSender := mock_email_sender.NewMockEmailSenderInterface(ctrl)
+	w := worker.Worker{EmailSender: mockEmailSender}
+
+	userEvent := event.UserEvent{
+		Type:      "user.session_token_reused",
+		Email:     "harry@hogwarts.edu",
+		Name:      "Harry",
+		UserAgent: "Mozilla/5.0",
+		IpAddress: "203.0.113.7",
+	}
+	msgBody, _ := json.Marshal(&userEvent)
+
+	mockEmailSender.EXPECT().SendEmail("harry@hogwarts.edu", "A TaskHub session was signed out", gomock.Any()).
+		DoAndReturn(func(to, subject, body string) (*mailersend.Response, error) {
+			if !strings.Contains(body, "Mozilla/5.0") || !strings.Contains(body, "203.0.113.7") {
+				t.Errorf("body %q does not name the session", body)
+			}
+			return &mailersend.Response{}, nil
+		}).Times(1)
+
+	w.UserEventHandler("user_queue", amqp.Delivery{Body: msgBody}, nil)
+}
+
+func TestUserEventHandler_AccountLocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEmailSender := mock_email_sender.NewMockEmailSenderInterface(ctrl)
+	w := worker.Worker{EmailSender: mockEmailSender}
+
+	userEvent := event.UserEvent{
+		Type:      "user.account_locked",
+		Email:     "harry@hogwarts.edu",
+		Name:      "Harry",
+		IpAddress: "203.0.113.7",
+	}
+	msgBody, _ := json.Marshal(&userEvent)
+
+	mockEmailSender.EXPECT().SendEmail("harry@hogwarts.edu", "Your TaskHub account was locked", gomock.Any()).
+		DoAndReturn(func(to, subject, body string) (*mailersend.Response, error) {
+			if !strings.Contains(body, "203.0.113.7") {
+				t.Errorf("body %q does not name the IP address", body)
+			}
+			return &mailersend.Response{}, nil
+		}).Times(1)
+
+	w.UserEventHandler("user_queue", amqp.Delivery{Body: msgBody}, nil)
+}
+
+func TestUserEventHandler_UnknownType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEmailSender := mock_email_sender.NewMockEmailSenderInterface(ctrl)
+	w := worker.Worker{EmailSender: mockEmailSender}
+
+	msgBody, _ := json.Marshal(&event.UserEvent{Type: "user.unknown", Email: "harry@hogwarts.edu"})
+
+	// Nothing is sent
+	w.UserEventHandler("user_queue", amqp.Delivery{Body: msgBody}, nil)
+}
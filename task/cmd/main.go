@@ -1,9 +1,11 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net"
 	"time"
+	_ "time/tzdata"
 
 	"github.com/sejamuchhal/taskhub/task/common"
 	pb "github.com/sejamuchhal/taskhub/task/pb/task"
@@ -28,20 +30,23 @@ func main() {
 	}
 	defer listener.Close()
 
+	srv, err := server.NewServer(config)
+	if err != nil {
+		log.Fatalf("Failed to create server: %v", err)
+	}
+
 	grpcServer := grpc.NewServer(grpc.KeepaliveParams(keepalive.ServerParameters{
 		MaxConnectionIdle: 3 * time.Minute,
 		Timeout:           10 * time.Second,
 		MaxConnectionAge:  50 * time.Minute,
 		Time:              10 * time.Minute,
-	}))
+	}), grpc.UnaryInterceptor(srv.UnaryIdempotency))
 
 	reflection.Register(grpcServer)
-	srv, err := server.NewServer(config)
-	if err != nil {
-		log.Fatalf("Failed to create server: %v", err)
-	}
 
 	pb.RegisterTaskServiceServer(grpcServer, srv)
+	go srv.RunRetention(context.Background(), config.RetentionInterval)
+	go srv.RunReminders(context.Background(), config.ReminderInterval)
 
 	grpc_health_v1.RegisterHealthServer(grpcServer, health.NewServer())
 
@@ -0,0 +1,82 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	task_pb "github.com/sejamuchhal/taskhub/task/pb/task"
+	"github.com/sejamuchhal/taskhub/task/storage"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// MoveTask moves a task to a board column, between two neighbouring tasks
+func (s *Server) MoveTask(ctx context.Context, req *task_pb.MoveTaskRequest) (*task_pb.MoveTaskResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "MoveTask",
+	})
+	logger.Info("Received MoveTask request")
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Task ID is required")
+	}
+	if !slices.Contains(storage.BoardStatuses, req.GetStatus()) {
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid status %q", req.GetStatus())
+	}
+
+	task, err := s.Storage.MoveTask(storage.TaskMove{
+		TaskID:   req.GetId(),
+		UserID:   userID,
+		Status:   req.GetStatus(),
+		AfterID:  req.GetAfterId(),
+		BeforeID: req.GetBeforeId(),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			logger.WithError(err).Error("Task not found")
+			return nil, status.Errorf(codes.NotFound, "Task not found: %v", err)
+		case errors.Is(err, storage.ErrInvalidMove), errors.Is(err, storage.ErrInvalidRankRange):
+			logger.WithError(err).Warn("Invalid move")
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid move: %v", err)
+		}
+		logger.WithError(err).Error("Could not move task")
+		return nil, status.Errorf(codes.Internal, "Could not move task: %v", err)
+	}
+
+	logger.WithField("position", task.Position).Info("Task moved")
+	return &task_pb.MoveTaskResponse{Task: TransformTask(task)}, nil
+}
+
+// GetBoard returns the tasks of the calling user grouped by status, every
+// column in rank order
+func (s *Server) GetBoard(ctx context.Context, req *task_pb.GetBoardRequest) (*task_pb.GetBoardResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "GetBoard",
+	})
+	logger.Info("Received GetBoard request")
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := s.Storage.ListBoardTasks(userID)
+	if err != nil {
+		errMsg := "Failed to retrieve tasks from the database"
+		logger.WithError(err).Error(errMsg)
+		return nil, status.Errorf(codes.Internal, "%s: %v", errMsg, err)
+	}
+
+	return &task_pb.GetBoardResponse{Columns: BuildBoardColumns(tasks)}, nil
+}
@@ -0,0 +1,87 @@
+package server_test
+
+import (
+	task_pb "github.com/sejamuchhal/taskhub/task/pb/task"
+	"github.com/sejamuchhal/taskhub/task/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func (s *ServerTestSuite) TestMoveTask_Success() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().MoveTask(storage.TaskMove{
+		TaskID:   "task-1",
+		UserID:   "1234",
+		Status:   storage.TaskStatusInProgress,
+		AfterID:  "task-2",
+		BeforeID: "task-3",
+	}).Return(&storage.Task{ID: "task-1", Status: storage.TaskStatusInProgress, Position: "ai"}, nil)
+
+	resp, err := s.Server.MoveTask(ctx, &task_pb.MoveTaskRequest{
+		Id:       "task-1",
+		Status:   storage.TaskStatusInProgress,
+		AfterId:  "task-2",
+		BeforeId: "task-3",
+	})
+	s.NoError(err)
+	s.Equal("ai", resp.Task.Position)
+	s.Equal(storage.TaskStatusInProgress, resp.Task.Status)
+}
+
+func (s *ServerTestSuite) TestMoveTask_InvalidStatus() {
+	ctx := createTestContext()
+
+	resp, err := s.Server.MoveTask(ctx, &task_pb.MoveTaskRequest{Id: "task-1", Status: "archived"})
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestMoveTask_NeighbourInOtherColumn() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().MoveTask(storage.TaskMove{
+		TaskID:  "task-1",
+		UserID:  "1234",
+		Status:  storage.TaskStatusCompleted,
+		AfterID: "task-2",
+	}).Return(nil, storage.ErrInvalidMove)
+
+	resp, err := s.Server.MoveTask(ctx, &task_pb.MoveTaskRequest{Id: "task-1", Status: storage.TaskStatusCompleted, AfterId: "task-2"})
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestMoveTask_NotFound() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().MoveTask(storage.TaskMove{
+		TaskID: "task-1",
+		UserID: "1234",
+		Status: storage.TaskStatusCreated,
+	}).Return(nil, gorm.ErrRecordNotFound)
+
+	resp, err := s.Server.MoveTask(ctx, &task_pb.MoveTaskRequest{Id: "task-1", Status: storage.TaskStatusCreated})
+	s.Nil(resp)
+	s.Equal(codes.NotFound, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestGetBoard_Success() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().ListBoardTasks("1234").Return([]*storage.Task{
+		{ID: "task-1", Status: storage.TaskStatusCompleted, Position: "i"},
+		{ID: "task-2", Status: storage.TaskStatusCreated, Position: "9"},
+		{ID: "task-3", Status: storage.TaskStatusCreated, Position: "i"},
+	}, nil)
+
+	resp, err := s.Server.GetBoard(ctx, &task_pb.GetBoardRequest{})
+	s.NoError(err)
+	s.Len(resp.Columns, 3)
+	s.Equal(storage.TaskStatusCreated, resp.Columns[0].Status)
+	s.Len(resp.Columns[0].Tasks, 2)
+	s.Equal("task-2", resp.Columns[0].Tasks[0].Id)
+	s.Empty(resp.Columns[1].Tasks)
+	s.Equal("task-1", resp.Columns[2].Tasks[0].Id)
+}
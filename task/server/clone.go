@@ -0,0 +1,90 @@
+package server
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	task_pb "github.com/sejamuchhal/taskhub/task/pb/task"
+	"github.com/sejamuchhal/taskhub/task/storage"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// CloneTask copies one of the caller's tasks into a new open task. Tags,
+// custom field values and reminders are only copied when asked for.
+func (s *Server) CloneTask(ctx context.Context, req *task_pb.CloneTaskRequest) (*task_pb.CloneTaskResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "CloneTask",
+	})
+	logger.Info("Received CloneTask request")
+
+	email, err := ExtractUserEmail(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := s.getOwnedTask(logger, req.GetId(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	clone := &storage.Task{
+		ID:              uuid.NewString(),
+		UserID:          userID,
+		Title:           source.Title,
+		Description:     source.Description,
+		DueDate:         source.DueDate,
+		DueAllDay:       source.DueAllDay,
+		EstimateMinutes: source.EstimateMinutes,
+		Priority:        source.Priority,
+		UserEmail:       email,
+	}
+	if hasDueDate(source) {
+		clone.DueDate = source.DueDate.Add(time.Duration(req.GetDueOffsetMinutes()) * time.Minute)
+	}
+	if req.GetIncludeTags() {
+		clone.Tags = slices.Clone(source.Tags)
+	}
+	if req.GetIncludeCustomFields() {
+		clone.CustomFields = maps.Clone(source.CustomFields)
+	}
+
+	var reminders []*storage.TaskReminder
+	if req.GetIncludeReminders() {
+		sourceReminders, err := s.Storage.ListTaskReminders(source.ID)
+		if err != nil {
+			errMsg := "Failed to retrieve task reminders from the database"
+			logger.WithError(err).Error(errMsg)
+			return nil, status.Errorf(codes.Internal, "%s: %v", errMsg, err)
+		}
+		offsets := make([]int64, 0, len(sourceReminders))
+		for _, reminder := range sourceReminders {
+			offsets = append(offsets, reminder.OffsetMinutes)
+		}
+		reminders = dueDateReminders(clone, offsets, time.Now())
+	}
+
+	if err := s.Storage.CreateTaskWithReminders(clone, reminders); err != nil {
+		errMsg := "Could not insert Task into the database"
+		logger.WithError(err).Error(errMsg)
+		return nil, status.Errorf(codes.Internal, "%s: %v", errMsg, err)
+	}
+
+	logger.WithFields(logrus.Fields{
+		"task_id":   clone.ID,
+		"source_id": source.ID,
+	}).Info("Task cloned")
+	s.publishTaskEvent(logger, "created", clone.Title, s.taskRecipients(logger, clone, userID))
+
+	return &task_pb.CloneTaskResponse{Task: TransformTask(clone)}, nil
+}
@@ -0,0 +1,126 @@
+package server_test
+
+import (
+	"time"
+
+	task_pb "github.com/sejamuchhal/taskhub/task/pb/task"
+	"github.com/sejamuchhal/taskhub/task/storage"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func testCloneSource() *storage.Task {
+	completedAt := time.Date(2024, 7, 2, 9, 0, 0, 0, time.UTC)
+	return &storage.Task{
+		ID:              "task-1",
+		UserID:          "1234",
+		Title:           "Monthly report",
+		Description:     "Send it to finance",
+		Status:          storage.TaskStatusCompleted,
+		DueDate:         time.Date(2024, 7, 1, 17, 0, 0, 0, time.UTC),
+		EstimateMinutes: 90,
+		Priority:        storage.TaskPriorityHigh,
+		CompletedAt:     &completedAt,
+		Position:        "a0",
+		Tags:            []string{"finance"},
+		CustomFields:    storage.CustomFieldValues{"billable": true},
+	}
+}
+
+func (s *ServerTestSuite) TestCloneTask_Everything() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().GetTaskByID("task-1").Return(testCloneSource(), nil)
+	s.MockStorage.EXPECT().CreateTaskWithReminders(gomock.Any(), gomock.Nil()).DoAndReturn(func(task *storage.Task, reminders []*storage.TaskReminder) error {
+		s.NotEqual("task-1", task.ID)
+		s.Equal("1234", task.UserID)
+		s.Equal("user@example.com", task.UserEmail)
+		s.Equal("Monthly report", task.Title)
+		s.Equal("Send it to finance", task.Description)
+		s.Equal(time.Date(2024, 7, 31, 17, 0, 0, 0, time.UTC), task.DueDate)
+		s.Equal(int32(90), task.EstimateMinutes)
+		s.Equal(storage.TaskPriorityHigh, task.Priority)
+		s.Equal([]string{"finance"}, task.Tags)
+		s.Equal(storage.CustomFieldValues{"billable": true}, task.CustomFields)
+		// The copy starts over in the created column
+		s.Empty(task.Status)
+		s.Nil(task.CompletedAt)
+		s.Empty(task.Position)
+		return nil
+	})
+	// The copy is new and the caller owns it, nobody else is told
+	s.MockStorage.EXPECT().ListTaskWatchers(gomock.Any()).Return(nil, nil)
+
+	resp, err := s.Server.CloneTask(ctx, &task_pb.CloneTaskRequest{
+		Id:                  "task-1",
+		IncludeTags:         true,
+		IncludeCustomFields: true,
+		DueOffsetMinutes:    30 * 24 * 60,
+	})
+	s.NoError(err)
+	s.Equal("Monthly report", resp.Task.Title)
+	s.Equal("high", resp.Task.Priority)
+}
+
+func (s *ServerTestSuite) TestCloneTask_WithoutTagsOrFields() {
+	ctx := createTestContext()
+
+	source := testCloneSource()
+	source.DueDate = time.Unix(0, 0)
+	s.MockStorage.EXPECT().GetTaskByID("task-1").Return(source, nil)
+	s.MockStorage.EXPECT().CreateTaskWithReminders(gomock.Any(), gomock.Nil()).DoAndReturn(func(task *storage.Task, reminders []*storage.TaskReminder) error {
+		// Tasks without a due date do not get one from the offset
+		s.Equal(time.Unix(0, 0), task.DueDate)
+		s.Empty(task.Tags)
+		s.Empty(task.CustomFields)
+		return nil
+	})
+	s.MockStorage.EXPECT().ListTaskWatchers(gomock.Any()).Return(nil, nil)
+
+	_, err := s.Server.CloneTask(ctx, &task_pb.CloneTaskRequest{Id: "task-1", DueOffsetMinutes: 60})
+	s.NoError(err)
+}
+
+func (s *ServerTestSuite) TestCloneTask_Reminders() {
+	ctx := createTestContext()
+
+	source := testCloneSource()
+	source.DueDate = time.Now().Add(24 * time.Hour).Truncate(time.Second)
+	s.MockStorage.EXPECT().GetTaskByID("task-1").Return(source, nil)
+	s.MockStorage.EXPECT().ListTaskReminders("task-1").Return([]*storage.TaskReminder{
+		{ID: "reminder-1", TaskID: "task-1", OffsetMinutes: 60, RemindAt: source.DueDate.Add(-time.Hour)},
+		// Already due for the copy, so it is left out
+		{ID: "reminder-2", TaskID: "task-1", OffsetMinutes: 3 * 24 * 60, RemindAt: source.DueDate.Add(-72 * time.Hour)},
+	}, nil)
+	s.MockStorage.EXPECT().CreateTaskWithReminders(gomock.Any(), gomock.Any()).DoAndReturn(func(task *storage.Task, reminders []*storage.TaskReminder) error {
+		dueDate := source.DueDate.Add(24 * time.Hour)
+		s.Equal(dueDate, task.DueDate)
+		s.Require().Len(reminders, 1)
+		s.Empty(reminders[0].ID)
+		s.Equal(int64(60), reminders[0].OffsetMinutes)
+		s.Equal(dueDate.Add(-time.Hour), reminders[0].RemindAt)
+		s.Nil(reminders[0].SentAt)
+		return nil
+	})
+	s.MockStorage.EXPECT().ListTaskWatchers(gomock.Any()).Return(nil, nil)
+
+	_, err := s.Server.CloneTask(ctx, &task_pb.CloneTaskRequest{
+		Id:               "task-1",
+		IncludeReminders: true,
+		DueOffsetMinutes: 24 * 60,
+	})
+	s.NoError(err)
+}
+
+func (s *ServerTestSuite) TestCloneTask_NotOwner() {
+	ctx := createTestContext()
+
+	source := testCloneSource()
+	source.UserID = "5678"
+	s.MockStorage.EXPECT().GetTaskByID("task-1").Return(source, nil)
+
+	resp, err := s.Server.CloneTask(ctx, &task_pb.CloneTaskRequest{Id: "task-1"})
+	s.Nil(resp)
+	s.Equal(codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,363 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	task_pb "github.com/sejamuchhal/taskhub/task/pb/task"
+	"github.com/sejamuchhal/taskhub/task/storage"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// CreateCustomField defines a new typed field the user can set on tasks
+func (s *Server) CreateCustomField(ctx context.Context, req *task_pb.CreateCustomFieldRequest) (*task_pb.CreateCustomFieldResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "CreateCustomField",
+	})
+	logger.Info("Received CreateCustomField request")
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	field := &storage.CustomField{
+		UserID:  userID,
+		Name:    strings.TrimSpace(req.GetField().GetName()),
+		Type:    req.GetField().GetType(),
+		Options: NormalizeTags(req.GetField().GetOptions()),
+	}
+	if err := validateCustomField(field); err != nil {
+		return nil, err
+	}
+
+	err = s.Storage.CreateCustomField(field)
+	if err != nil {
+		if errors.Is(err, storage.ErrDuplicateCustomFieldName) {
+			logger.WithError(err).Warn("Duplicate custom field name")
+			return nil, status.Error(codes.AlreadyExists, "A custom field with this name already exists")
+		}
+		logger.WithError(err).Error("Could not insert custom field into the database")
+		return nil, status.Errorf(codes.Internal, "Could not insert custom field into the database: %v", err)
+	}
+
+	logger.WithField("field_id", field.ID).Info("Custom field created")
+	return &task_pb.CreateCustomFieldResponse{Field: TransformCustomField(field)}, nil
+}
+
+func (s *Server) ListCustomFields(ctx context.Context, req *task_pb.ListCustomFieldsRequest) (*task_pb.ListCustomFieldsResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "ListCustomFields",
+	})
+	logger.Info("Received ListCustomFields request")
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, err := s.Storage.ListCustomFields(userID)
+	if err != nil {
+		errMsg := "Failed to retrieve custom fields from the database"
+		logger.WithError(err).Error(errMsg)
+		return nil, status.Errorf(codes.Internal, "%s: %v", errMsg, err)
+	}
+
+	res := make([]*task_pb.CustomField, len(fields))
+	for i, field := range fields {
+		res[i] = TransformCustomField(field)
+	}
+	return &task_pb.ListCustomFieldsResponse{Fields: res}, nil
+}
+
+// UpdateCustomField renames a field or changes its options. Values already
+// set on tasks are kept even if their option is removed.
+func (s *Server) UpdateCustomField(ctx context.Context, req *task_pb.UpdateCustomFieldRequest) (*task_pb.UpdateCustomFieldResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "UpdateCustomField",
+	})
+	logger.Info("Received UpdateCustomField request")
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	field, err := s.getOwnedCustomField(logger, req.GetField().GetId(), userID)
+	if err != nil {
+		return nil, err
+	}
+	if req.GetField().GetType() != "" && req.GetField().GetType() != field.Type {
+		return nil, status.Error(codes.InvalidArgument, "The type of a custom field cannot change")
+	}
+
+	field.Name = strings.TrimSpace(req.GetField().GetName())
+	field.Options = NormalizeTags(req.GetField().GetOptions())
+	if err := validateCustomField(field); err != nil {
+		return nil, err
+	}
+
+	err = s.Storage.UpdateCustomField(field)
+	if err != nil {
+		if errors.Is(err, storage.ErrDuplicateCustomFieldName) {
+			logger.WithError(err).Warn("Duplicate custom field name")
+			return nil, status.Error(codes.AlreadyExists, "A custom field with this name already exists")
+		}
+		logger.WithError(err).Error("Could not update custom field in the database")
+		return nil, status.Errorf(codes.Internal, "Could not update custom field in the database: %v", err)
+	}
+
+	logger.Info("Custom field updated")
+	return &task_pb.UpdateCustomFieldResponse{Field: TransformCustomField(field)}, nil
+}
+
+// DeleteCustomField deletes a field and removes its values from all tasks
+func (s *Server) DeleteCustomField(ctx context.Context, req *task_pb.DeleteCustomFieldRequest) (*task_pb.DeleteCustomFieldResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "DeleteCustomField",
+	})
+	logger.Info("Received DeleteCustomField request")
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getOwnedCustomField(logger, req.GetId(), userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.Storage.DeleteCustomField(req.GetId()); err != nil {
+		logger.WithError(err).Error("Could not delete custom field from the database")
+		return nil, status.Errorf(codes.Internal, "Could not delete custom field from the database: %v", err)
+	}
+
+	logger.Info("Custom field deleted")
+	return &task_pb.DeleteCustomFieldResponse{}, nil
+}
+
+func (s *Server) getOwnedCustomField(logger *logrus.Entry, fieldID, userID string) (*storage.CustomField, error) {
+	if fieldID == "" {
+		return nil, status.Error(codes.InvalidArgument, "Custom field ID is required")
+	}
+
+	field, err := s.Storage.GetCustomFieldByID(fieldID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.WithError(err).Error("Custom field not found")
+			return nil, status.Errorf(codes.NotFound, "Custom field not found: %v", err)
+		}
+		logger.WithError(err).Error("Failed to retrieve custom field from the database")
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve custom field from the database: %v", err)
+	}
+	if field.UserID != userID {
+		logger.Warn("Custom field belongs to another user")
+		return nil, status.Error(codes.NotFound, "Custom field not found")
+	}
+	return field, nil
+}
+
+// getCustomFields returns the user's custom fields keyed by ID
+func (s *Server) getCustomFields(logger *logrus.Entry, userID string) (map[string]*storage.CustomField, error) {
+	fields, err := s.Storage.ListCustomFields(userID)
+	if err != nil {
+		errMsg := "Failed to retrieve custom fields from the database"
+		logger.WithError(err).Error(errMsg)
+		return nil, status.Errorf(codes.Internal, "%s: %v", errMsg, err)
+	}
+
+	byID := make(map[string]*storage.CustomField, len(fields))
+	for _, field := range fields {
+		byID[field.ID] = field
+	}
+	return byID, nil
+}
+
+// customFieldValues validates the custom field values of a task against the
+// user's field definitions
+func (s *Server) customFieldValues(logger *logrus.Entry, userID string, values map[string]*task_pb.CustomFieldValue) (storage.CustomFieldValues, error) {
+	if len(values) == 0 {
+		return storage.CustomFieldValues{}, nil
+	}
+
+	fields, err := s.getCustomFields(logger, userID)
+	if err != nil {
+		return nil, err
+	}
+	return CustomFieldValuesFromProto(fields, values)
+}
+
+func validateCustomField(field *storage.CustomField) error {
+	if field.Name == "" {
+		return status.Error(codes.InvalidArgument, "Custom field name is required")
+	}
+	if !slices.Contains(storage.CustomFieldTypes, field.Type) {
+		return status.Errorf(codes.InvalidArgument, "Custom field type must be one of %s", strings.Join(storage.CustomFieldTypes, ", "))
+	}
+
+	isSelect := field.Type == storage.CustomFieldTypeSingleSelect || field.Type == storage.CustomFieldTypeMultiSelect
+	if isSelect && len(field.Options) == 0 {
+		return status.Error(codes.InvalidArgument, "Select fields need at least one option")
+	}
+	if !isSelect && len(field.Options) > 0 {
+		return status.Error(codes.InvalidArgument, "Only select fields have options")
+	}
+	return nil
+}
+
+// CustomFieldValuesFromProto checks task values against their field
+// definitions and converts them for storage
+func CustomFieldValuesFromProto(fields map[string]*storage.CustomField, values map[string]*task_pb.CustomFieldValue) (storage.CustomFieldValues, error) {
+	res := make(storage.CustomFieldValues, len(values))
+	for id, value := range values {
+		field, ok := fields[id]
+		if !ok {
+			return nil, status.Errorf(codes.InvalidArgument, "Unknown custom field: %s", id)
+		}
+
+		converted, err := customFieldValueFromProto(field, value)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid value for custom field %q: %v", field.Name, err)
+		}
+		res[id] = converted
+	}
+	return res, nil
+}
+
+func customFieldValueFromProto(field *storage.CustomField, value *task_pb.CustomFieldValue) (interface{}, error) {
+	switch field.Type {
+	case storage.CustomFieldTypeText:
+		if _, ok := value.GetKind().(*task_pb.CustomFieldValue_StringValue); !ok {
+			return nil, errors.New("expected a string")
+		}
+		return value.GetStringValue(), nil
+
+	case storage.CustomFieldTypeNumber:
+		if _, ok := value.GetKind().(*task_pb.CustomFieldValue_NumberValue); !ok {
+			return nil, errors.New("expected a number")
+		}
+		number := value.GetNumberValue()
+		if math.IsNaN(number) || math.IsInf(number, 0) {
+			return nil, errors.New("expected a finite number")
+		}
+		return number, nil
+
+	case storage.CustomFieldTypeDate:
+		if _, ok := value.GetKind().(*task_pb.CustomFieldValue_StringValue); !ok {
+			return nil, errors.New("expected a date")
+		}
+		return parseCustomFieldDate(value.GetStringValue())
+
+	case storage.CustomFieldTypeSingleSelect:
+		if _, ok := value.GetKind().(*task_pb.CustomFieldValue_StringValue); !ok {
+			return nil, errors.New("expected an option")
+		}
+		if !slices.Contains(field.Options, value.GetStringValue()) {
+			return nil, errors.New("not one of the options")
+		}
+		return value.GetStringValue(), nil
+
+	case storage.CustomFieldTypeMultiSelect:
+		if _, ok := value.GetKind().(*task_pb.CustomFieldValue_ListValue); !ok {
+			return nil, errors.New("expected a list of options")
+		}
+		options := NormalizeTags(value.GetListValue().GetValues())
+		for _, option := range options {
+			if !slices.Contains(field.Options, option) {
+				return nil, errors.New("not one of the options")
+			}
+		}
+		return options, nil
+
+	case storage.CustomFieldTypeCheckbox:
+		if _, ok := value.GetKind().(*task_pb.CustomFieldValue_BoolValue); !ok {
+			return nil, errors.New("expected a boolean")
+		}
+		return value.GetBoolValue(), nil
+	}
+	return nil, errors.New("unsupported field type")
+}
+
+// CustomFieldFilterFromProto validates a ListTasks filter. Numbers and
+// booleans may be given as strings, since that is how they arrive in query
+// strings.
+func CustomFieldFilterFromProto(fields map[string]*storage.CustomField, filter *task_pb.CustomFieldFilter) (storage.CustomFieldFilter, error) {
+	field, ok := fields[filter.GetFieldId()]
+	if !ok {
+		return storage.CustomFieldFilter{}, status.Errorf(codes.InvalidArgument, "Unknown custom field: %s", filter.GetFieldId())
+	}
+
+	op := filter.GetOp()
+	if op == "" {
+		op = "eq"
+	}
+	switch op {
+	case "eq":
+	case "lt", "lte", "gt", "gte":
+		if field.Type == storage.CustomFieldTypeCheckbox || field.Type == storage.CustomFieldTypeMultiSelect {
+			return storage.CustomFieldFilter{}, status.Errorf(codes.InvalidArgument, "Custom field %q only supports eq", field.Name)
+		}
+	default:
+		return storage.CustomFieldFilter{}, status.Errorf(codes.InvalidArgument, "Unsupported filter operator: %s", op)
+	}
+
+	value, err := customFieldFilterValue(field, filter.GetValue())
+	if err != nil {
+		return storage.CustomFieldFilter{}, status.Errorf(codes.InvalidArgument, "Invalid filter value for custom field %q: %v", field.Name, err)
+	}
+	return storage.CustomFieldFilter{
+		FieldID:   field.ID,
+		FieldType: field.Type,
+		Op:        op,
+		Value:     value,
+	}, nil
+}
+
+func customFieldFilterValue(field *storage.CustomField, value *task_pb.CustomFieldValue) (interface{}, error) {
+	text, isString := value.GetKind().(*task_pb.CustomFieldValue_StringValue)
+	switch field.Type {
+	case storage.CustomFieldTypeNumber:
+		if isString {
+			number, err := strconv.ParseFloat(strings.TrimSpace(text.StringValue), 64)
+			if err != nil {
+				return nil, errors.New("expected a number")
+			}
+			value = &task_pb.CustomFieldValue{Kind: &task_pb.CustomFieldValue_NumberValue{NumberValue: number}}
+		}
+	case storage.CustomFieldTypeCheckbox:
+		if isString {
+			checked, err := strconv.ParseBool(strings.TrimSpace(text.StringValue))
+			if err != nil {
+				return nil, errors.New("expected a boolean")
+			}
+			value = &task_pb.CustomFieldValue{Kind: &task_pb.CustomFieldValue_BoolValue{BoolValue: checked}}
+		}
+	case storage.CustomFieldTypeSingleSelect, storage.CustomFieldTypeMultiSelect:
+		// Filtering by an option that was removed still finds old values
+		if !isString {
+			return nil, errors.New("expected an option")
+		}
+		return text.StringValue, nil
+	}
+	return customFieldValueFromProto(field, value)
+}
+
+func parseCustomFieldDate(value string) (string, error) {
+	date, err := time.Parse(storage.CustomFieldDateLayout, strings.TrimSpace(value))
+	if err != nil {
+		return "", errors.New("expected a date as YYYY-MM-DD")
+	}
+	return date.Format(storage.CustomFieldDateLayout), nil
+}
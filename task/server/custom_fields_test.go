@@ -0,0 +1,171 @@
+package server_test
+
+import (
+	"math"
+
+	task_pb "github.com/sejamuchhal/taskhub/task/pb/task"
+	"github.com/sejamuchhal/taskhub/task/storage"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func testCustomFields() []*storage.CustomField {
+	return []*storage.CustomField{
+		{ID: "points", UserID: "1234", Name: "Points", Type: storage.CustomFieldTypeNumber},
+		{ID: "due", UserID: "1234", Name: "Review date", Type: storage.CustomFieldTypeDate},
+		{ID: "client", UserID: "1234", Name: "Client", Type: storage.CustomFieldTypeSingleSelect, Options: []string{"Acme", "Globex"}},
+		{ID: "labels", UserID: "1234", Name: "Labels", Type: storage.CustomFieldTypeMultiSelect, Options: []string{"ui", "api"}},
+		{ID: "billable", UserID: "1234", Name: "Billable", Type: storage.CustomFieldTypeCheckbox},
+	}
+}
+
+func numberValue(number float64) *task_pb.CustomFieldValue {
+	return &task_pb.CustomFieldValue{Kind: &task_pb.CustomFieldValue_NumberValue{NumberValue: number}}
+}
+
+func stringValue(text string) *task_pb.CustomFieldValue {
+	return &task_pb.CustomFieldValue{Kind: &task_pb.CustomFieldValue_StringValue{StringValue: text}}
+}
+
+func (s *ServerTestSuite) TestCreateCustomField_SelectWithoutOptions() {
+	ctx := createTestContext()
+
+	resp, err := s.Server.CreateCustomField(ctx, &task_pb.CreateCustomFieldRequest{
+		Field: &task_pb.CustomField{Name: "Client", Type: storage.CustomFieldTypeSingleSelect},
+	})
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestCreateCustomField_DuplicateName() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().CreateCustomField(gomock.Any()).Return(storage.ErrDuplicateCustomFieldName)
+
+	resp, err := s.Server.CreateCustomField(ctx, &task_pb.CreateCustomFieldRequest{
+		Field: &task_pb.CustomField{Name: "Points", Type: storage.CustomFieldTypeNumber},
+	})
+	s.Nil(resp)
+	s.Equal(codes.AlreadyExists, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestUpdateCustomField_TypeChange() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().GetCustomFieldByID("points").Return(testCustomFields()[0], nil)
+
+	resp, err := s.Server.UpdateCustomField(ctx, &task_pb.UpdateCustomFieldRequest{
+		Field: &task_pb.CustomField{Id: "points", Name: "Points", Type: storage.CustomFieldTypeText},
+	})
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestDeleteCustomField_OtherUser() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().GetCustomFieldByID("points").Return(&storage.CustomField{ID: "points", UserID: "other"}, nil)
+
+	resp, err := s.Server.DeleteCustomField(ctx, &task_pb.DeleteCustomFieldRequest{Id: "points"})
+	s.Nil(resp)
+	s.Equal(codes.NotFound, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestCreateTask_CustomFields() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().ListCustomFields("1234").Return(testCustomFields(), nil)
+	s.MockStorage.EXPECT().CreateTask(gomock.Any()).DoAndReturn(func(task *storage.Task) error {
+		s.Equal(storage.CustomFieldValues{
+			"points":   5.0,
+			"due":      "2024-08-01",
+			"client":   "Acme",
+			"labels":   []string{"ui", "api"},
+			"billable": true,
+		}, task.CustomFields)
+		return nil
+	})
+	s.MockStorage.EXPECT().ListTaskWatchers(gomock.Any()).Return(nil, nil)
+
+	_, err := s.Server.CreateTask(ctx, &task_pb.CreateTaskRequest{Task: &task_pb.Task{
+		Title: "Task",
+		CustomFields: map[string]*task_pb.CustomFieldValue{
+			"points":   numberValue(5),
+			"due":      stringValue("2024-08-01"),
+			"client":   stringValue("Acme"),
+			"labels":   {Kind: &task_pb.CustomFieldValue_ListValue{ListValue: &task_pb.StringList{Values: []string{"ui", "api", "ui"}}}},
+			"billable": {Kind: &task_pb.CustomFieldValue_BoolValue{BoolValue: true}},
+		},
+	}})
+	s.NoError(err)
+}
+
+func (s *ServerTestSuite) TestCreateTask_InvalidCustomFields() {
+	tests := map[string]map[string]*task_pb.CustomFieldValue{
+		"unknown field": {"missing": numberValue(1)},
+		"wrong kind":    {"points": stringValue("5")},
+		"not finite":    {"points": numberValue(math.Inf(1))},
+		"bad date":      {"due": stringValue("01/08/2024")},
+		"bad option":    {"client": stringValue("Initech")},
+	}
+	for name, values := range tests {
+		s.Run(name, func() {
+			ctx := createTestContext()
+			s.MockStorage.EXPECT().ListCustomFields("1234").Return(testCustomFields(), nil)
+
+			resp, err := s.Server.CreateTask(ctx, &task_pb.CreateTaskRequest{Task: &task_pb.Task{
+				Title:        "Task",
+				CustomFields: values,
+			}})
+			s.Nil(resp)
+			s.Equal(codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
+
+func (s *ServerTestSuite) TestListTasks_CustomFieldFilterAndSort() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().ListCustomFields("1234").Return(testCustomFields(), nil)
+	s.MockStorage.EXPECT().ListTasksWithCount(storage.TaskFilter{
+		UserID: "1234",
+		CustomFields: []storage.CustomFieldFilter{
+			{FieldID: "points", FieldType: storage.CustomFieldTypeNumber, Op: "gte", Value: 3.0},
+			{FieldID: "billable", FieldType: storage.CustomFieldTypeCheckbox, Op: "eq", Value: true},
+			{FieldID: "labels", FieldType: storage.CustomFieldTypeMultiSelect, Op: "eq", Value: "ui"},
+		},
+		SortField: &storage.CustomFieldSort{FieldID: "due", FieldType: storage.CustomFieldTypeDate, Desc: true},
+	}, 10, 0).Return([]*storage.Task{}, int64(0), nil)
+
+	_, err := s.Server.ListTasks(ctx, &task_pb.ListTasksRequest{
+		CustomFieldFilters: []*task_pb.CustomFieldFilter{
+			{FieldId: "points", Op: "gte", Value: stringValue("3")},
+			{FieldId: "billable", Value: stringValue("true")},
+			{FieldId: "labels", Op: "eq", Value: stringValue("ui")},
+		},
+		SortFieldId: "due",
+		SortDesc:    true,
+	})
+	s.NoError(err)
+}
+
+func (s *ServerTestSuite) TestListTasks_InvalidCustomFieldQuery() {
+	tests := map[string]*task_pb.ListTasksRequest{
+		"unknown field":     {CustomFieldFilters: []*task_pb.CustomFieldFilter{{FieldId: "missing", Value: stringValue("1")}}},
+		"bad operator":      {CustomFieldFilters: []*task_pb.CustomFieldFilter{{FieldId: "points", Op: "ne", Value: stringValue("1")}}},
+		"range on checkbox": {CustomFieldFilters: []*task_pb.CustomFieldFilter{{FieldId: "billable", Op: "gt", Value: stringValue("true")}}},
+		"bad number":        {CustomFieldFilters: []*task_pb.CustomFieldFilter{{FieldId: "points", Value: stringValue("many")}}},
+		"sort multi-select": {SortFieldId: "labels"},
+	}
+	for name, req := range tests {
+		s.Run(name, func() {
+			ctx := createTestContext()
+			s.MockStorage.EXPECT().ListCustomFields("1234").Return(testCustomFields(), nil)
+
+			resp, err := s.Server.ListTasks(ctx, req)
+			s.Nil(resp)
+			s.Equal(codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
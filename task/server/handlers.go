@@ -2,10 +2,11 @@ package server
 
 import (
 	"context"
-	"encoding/json"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
-	event_pb "github.com/sejamuchhal/taskhub/task/pb/event"
 	task_pb "github.com/sejamuchhal/taskhub/task/pb/task"
 	"github.com/sejamuchhal/taskhub/task/storage"
 	"github.com/sirupsen/logrus"
@@ -38,14 +39,30 @@ func (s *Server) CreateTask(ctx context.Context, req *task_pb.CreateTaskRequest)
 		return nil, status.Error(codes.InvalidArgument, "Title is required")
 	}
 
+	customFields, err := s.customFieldValues(logger, userID, req.GetTask().GetCustomFields())
+	if err != nil {
+		return nil, err
+	}
+
+	priority, err := PriorityLevel(req.GetTask().GetPriority())
+	if err != nil {
+		return nil, err
+	}
+
 	taskID := uuid.NewString()
 
 	storageTask := &storage.Task{
-		ID:          taskID,
-		UserID:      userID,
-		Title:       req.GetTask().GetTitle(),
-		Description: req.GetTask().GetDescription(),
-		DueDate:     req.GetTask().GetDueDate().AsTime(),
+		ID:              taskID,
+		UserID:          userID,
+		Title:           req.GetTask().GetTitle(),
+		Description:     req.GetTask().GetDescription(),
+		DueDate:         req.GetTask().GetDueDate().AsTime(),
+		DueAllDay:       req.GetTask().GetDueAllDay(),
+		EstimateMinutes: req.GetTask().GetEstimateMinutes(),
+		Tags:            NormalizeTags(req.GetTask().GetTags()),
+		CustomFields:    customFields,
+		UserEmail:       email,
+		Priority:        priority,
 	}
 
 	err = s.Storage.CreateTask(storageTask)
@@ -59,24 +76,7 @@ func (s *Server) CreateTask(ctx context.Context, req *task_pb.CreateTaskRequest)
 		"title":   storageTask.Title,
 	}).Info("Task successfully inserted into the database")
 
-	event := event_pb.TaskUpdateEvent{
-		Status: "created",
-		Title:  storageTask.Title,
-		Email:  email,
-	}
-
-	eventJSON, err := json.Marshal(event)
-	if err != nil {
-		s.Logger.WithError(err).Error("Failed to marshal task update event to JSON")
-		return &task_pb.CreateTaskResponse{}, status.Errorf(codes.Internal, "Failed to marshal event: %v", err)
-	}
-
-	err = s.Publisher.Publish(eventJSON)
-	if err != nil {
-		s.Logger.WithError(err).Error("failed to send task update event")
-	} else {
-		s.Logger.Info("Task update event sent to queue")
-	}
+	s.publishTaskEvent(logger, "created", storageTask.Title, s.taskRecipients(logger, storageTask, userID))
 
 	s.Logger.Info("Task created successfully")
 	return &task_pb.CreateTaskResponse{Id: taskID}, nil
@@ -108,9 +108,17 @@ func (s *Server) GetTask(ctx context.Context, req *task_pb.GetTaskRequest) (*tas
 		return nil, status.Errorf(codes.Internal, "%s: %v", errMsg, err)
 	}
 
+	entries, _, err := s.Storage.ListTimeEntriesWithCount(storage.TimeEntryFilter{TaskID: task.ID}, -1, 0)
+	if err != nil {
+		errMsg := "Failed to retrieve time entries from the database"
+		logger.WithError(err).Error(errMsg)
+		return nil, status.Errorf(codes.Internal, "%s: %v", errMsg, err)
+	}
+
 	res := &task_pb.GetTaskResponse{
 		Task: TransformTask(task),
 	}
+	res.Task.TrackedMinutes = TrackedMinutes(entries, time.Now())
 	logger.Info("GetTask request processed successfully")
 	return res, nil
 }
@@ -123,6 +131,17 @@ func (s *Server) ListTasks(ctx context.Context, req *task_pb.ListTasksRequest) (
 
 	logger.Info("Received ListTasks request")
 
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.listTasks(logger, userID, req)
+}
+
+// listTasks runs a task query of the user. Saved views go through it as
+// well, so that they filter exactly like ListTasks.
+func (s *Server) listTasks(logger *logrus.Entry, userID string, req *task_pb.ListTasksRequest) (*task_pb.ListTasksResponse, error) {
 	// Set default values if not provided
 	if req.Offset == 0 {
 		req.Offset = 0
@@ -132,12 +151,12 @@ func (s *Server) ListTasks(ctx context.Context, req *task_pb.ListTasksRequest) (
 		req.Limit = 10
 	}
 
-	userID, err := ExtractUserID(ctx)
+	filter, err := s.taskFilter(logger, userID, req, time.Now())
 	if err != nil {
 		return nil, err
 	}
 
-	tasks, count, err := s.Storage.ListTasksWithCount(userID, int(req.Limit), int(req.Offset))
+	tasks, count, err := s.Storage.ListTasksWithCount(filter, int(req.Limit), int(req.Offset))
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			errMsg := "No tasks found for the given user ID"
@@ -165,6 +184,79 @@ func (s *Server) ListTasks(ctx context.Context, req *task_pb.ListTasksRequest) (
 	}, nil
 }
 
+// taskFilter builds the storage filter of a ListTasks request. Relative due
+// windows are resolved against now.
+func (s *Server) taskFilter(logger *logrus.Entry, userID string, req *task_pb.ListTasksRequest, now time.Time) (storage.TaskFilter, error) {
+	filter := storage.TaskFilter{
+		UserID:   userID,
+		Statuses: req.GetStatuses(),
+		Archived: req.GetArchived(),
+	}
+	if len(req.GetTags()) > 0 {
+		filter.Tags = NormalizeTags(req.GetTags())
+	}
+	for _, st := range filter.Statuses {
+		if !slices.Contains(storage.BoardStatuses, st) {
+			return filter, status.Errorf(codes.InvalidArgument, "Status must be one of %s", strings.Join(storage.BoardStatuses, ", "))
+		}
+	}
+
+	if req.GetDue() != "" {
+		loc := time.UTC
+		if req.GetTimezone() != "" {
+			var err error
+			loc, err = time.LoadLocation(req.GetTimezone())
+			if err != nil {
+				logger.WithError(err).Error("Invalid timezone")
+				return filter, status.Errorf(codes.InvalidArgument, "Invalid timezone: %s", req.GetTimezone())
+			}
+		}
+		if err := applyDueWindow(&filter, req.GetDue(), now.In(loc)); err != nil {
+			return filter, err
+		}
+	}
+
+	if req.GetSortBy() != "" {
+		if !slices.Contains(storage.TaskSortColumns, req.GetSortBy()) {
+			return filter, status.Errorf(codes.InvalidArgument, "sort_by must be one of %s", strings.Join(storage.TaskSortColumns, ", "))
+		}
+		filter.Sort = &storage.TaskSort{Column: req.GetSortBy(), Desc: req.GetSortDesc()}
+	}
+
+	if len(req.GetCustomFieldFilters()) == 0 && req.GetSortFieldId() == "" {
+		return filter, nil
+	}
+
+	fields, err := s.getCustomFields(logger, userID)
+	if err != nil {
+		return filter, err
+	}
+
+	for _, pbFilter := range req.GetCustomFieldFilters() {
+		fieldFilter, err := CustomFieldFilterFromProto(fields, pbFilter)
+		if err != nil {
+			return filter, err
+		}
+		filter.CustomFields = append(filter.CustomFields, fieldFilter)
+	}
+
+	if req.GetSortFieldId() != "" {
+		field, ok := fields[req.GetSortFieldId()]
+		if !ok {
+			return filter, status.Errorf(codes.InvalidArgument, "Unknown custom field: %s", req.GetSortFieldId())
+		}
+		if field.Type == storage.CustomFieldTypeMultiSelect {
+			return filter, status.Errorf(codes.InvalidArgument, "Cannot sort by multi-select field %q", field.Name)
+		}
+		filter.SortField = &storage.CustomFieldSort{
+			FieldID:   field.ID,
+			FieldType: field.Type,
+			Desc:      req.GetSortDesc(),
+		}
+	}
+	return filter, nil
+}
+
 func (s *Server) DeleteTask(ctx context.Context, req *task_pb.DeleteTaskRequest) (*task_pb.DeleteTaskResponse, error) {
 	logger := s.Logger.WithFields(logrus.Fields{
 		"req":    req,
@@ -172,7 +264,7 @@ func (s *Server) DeleteTask(ctx context.Context, req *task_pb.DeleteTaskRequest)
 	})
 
 	logger.Info("Received DeleteTask request")
-	email, err := ExtractUserEmail(ctx)
+	userID, err := ExtractUserID(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -189,6 +281,9 @@ func (s *Server) DeleteTask(ctx context.Context, req *task_pb.DeleteTaskRequest)
 		return nil, status.Errorf(codes.Internal, "%s: %v", errMsg, err)
 	}
 
+	// Watchers are deleted with the task
+	recipients := s.taskRecipients(logger, task, userID)
+
 	err = s.Storage.DeleteTask(req.GetId())
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -203,24 +298,7 @@ func (s *Server) DeleteTask(ctx context.Context, req *task_pb.DeleteTaskRequest)
 		"task_id": req.GetId(),
 	}).Info("Task successfully deleted from the database")
 
-	event := event_pb.TaskUpdateEvent{
-		Status: "deleted",
-		Title:  task.Title,
-		Email:  email,
-	}
-
-	eventJSON, err := json.Marshal(event)
-	if err != nil {
-		s.Logger.WithError(err).Error("Failed to marshal task update event to JSON")
-		return &task_pb.DeleteTaskResponse{}, status.Errorf(codes.Internal, "Failed to marshal event: %v", err)
-	}
-
-	err = s.Publisher.Publish(eventJSON)
-	if err != nil {
-		s.Logger.WithError(err).Error("failed to send task update event")
-	} else {
-		s.Logger.Info("Task update event sent to queue")
-	}
+	s.publishTaskEvent(logger, "deleted", task.Title, recipients)
 
 	s.Logger.Info("Task deleted successfully")
 	return &task_pb.DeleteTaskResponse{}, nil
@@ -239,6 +317,11 @@ func (s *Server) UpdateTask(ctx context.Context, req *task_pb.UpdateTaskRequest)
 		return nil, err
 	}
 
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	if req.GetTask().GetTitle() == "" {
 		s.Logger.Error("UpdateTask failed: Title is required")
 		return nil, status.Error(codes.InvalidArgument, "Title is required")
@@ -259,8 +342,25 @@ func (s *Server) UpdateTask(ctx context.Context, req *task_pb.UpdateTaskRequest)
 	task.Title = req.Task.Title
 	task.Description = req.Task.Description
 	task.DueDate = req.Task.DueDate.AsTime()
-	if req.Task.Status == "completed" {
-		task.Title = req.Task.Status
+	task.DueAllDay = req.Task.DueAllDay
+	task.EstimateMinutes = req.Task.EstimateMinutes
+	task.Tags = NormalizeTags(req.Task.Tags)
+	task.Priority, err = PriorityLevel(req.Task.Priority)
+	if err != nil {
+		return nil, err
+	}
+	if task.UserID == userID {
+		// Keeps the owner's email current, and fills it in for older tasks
+		task.UserEmail = email
+	}
+	task.CustomFields, err = s.customFieldValues(logger, task.UserID, req.Task.CustomFields)
+	if err != nil {
+		return nil, err
+	}
+	if req.Task.Status == "completed" && task.Status != "completed" {
+		completedAt := time.Now()
+		task.Status = "completed"
+		task.CompletedAt = &completedAt
 	}
 
 	err = s.Storage.UpdateTask(task)
@@ -278,24 +378,7 @@ func (s *Server) UpdateTask(ctx context.Context, req *task_pb.UpdateTaskRequest)
 		"title":   task.Title,
 	}).Info("Task successfully updated in the database")
 
-	event := event_pb.TaskUpdateEvent{
-		Status: "updated",
-		Title:  task.Title,
-		Email:  email,
-	}
-
-	eventJSON, err := json.Marshal(event)
-	if err != nil {
-		s.Logger.WithError(err).Error("Failed to marshal task update event to JSON")
-		return &task_pb.UpdateTaskResponse{}, status.Errorf(codes.Internal, "Failed to marshal event: %v", err)
-	}
-
-	err = s.Publisher.Publish(eventJSON)
-	if err != nil {
-		s.Logger.WithError(err).Error("failed to send task update event")
-	} else {
-		s.Logger.Info("Task update event sent to queue")
-	}
+	s.publishTaskEvent(logger, "updated", task.Title, s.taskRecipients(logger, task, userID))
 
 	s.Logger.Info("Task updated successfully")
 	return &task_pb.UpdateTaskResponse{}, nil
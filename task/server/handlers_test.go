@@ -10,6 +10,7 @@ import (
 	// event_pb "github.com/sejamuchhal/taskhub/task/pb/event"
 	task_pb "github.com/sejamuchhal/taskhub/task/pb/task"
 	"github.com/sejamuchhal/taskhub/task/server"
+	"github.com/sejamuchhal/taskhub/task/storage"
 	"github.com/sejamuchhal/taskhub/task/storage/mock_storage"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/suite"
@@ -74,8 +75,12 @@ func (s *ServerTestSuite) TestCreateTask_Success() {
 
 	s.Server.Logger.WithField("task_request", taskRequest).Info("Create task request")
 
-	s.MockStorage.EXPECT().CreateTask(gomock.Any()).Return(nil)
-	s.MockRabbitMQ.EXPECT().Publish(gomock.Any()).Return(nil)
+	s.MockStorage.EXPECT().CreateTask(gomock.Any()).DoAndReturn(func(task *storage.Task) error {
+		s.Equal("user@example.com", task.UserEmail)
+		return nil
+	})
+	// The owner created it and nobody watches it yet, nothing is sent
+	s.MockStorage.EXPECT().ListTaskWatchers(gomock.Any()).Return(nil, nil)
 
 	resp, err := s.Server.CreateTask(ctx, taskRequest)
 	s.NoError(err)
@@ -132,6 +137,9 @@ func (s *ServerTestSuite) TestCreateTask_EventPublishError() {
 	}
 
 	s.MockStorage.EXPECT().CreateTask(gomock.Any()).Return(nil)
+	s.MockStorage.EXPECT().ListTaskWatchers(gomock.Any()).Return([]*storage.TaskWatcher{
+		{UserID: "5678", Email: "watcher@example.com"},
+	}, nil)
 	s.MockRabbitMQ.EXPECT().Publish(gomock.Any()).Return(errors.New("publish error"))
 
 	resp, err := s.Server.CreateTask(ctx, taskRequest)
@@ -140,3 +148,42 @@ func (s *ServerTestSuite) TestCreateTask_EventPublishError() {
 	s.NotEmpty(resp.Id)
 }
 
+func (s *ServerTestSuite) TestUpdateTask_EventPublishError() {
+	ctx := createTestContext()
+
+	taskRequest := &task_pb.UpdateTaskRequest{
+		Task: &task_pb.Task{
+			Id:          "task-1",
+			Title:       "Test Task",
+			Description: "This is a test task",
+			DueDate:     timestamppb.New(time.Now().Add(24 * time.Hour)),
+		},
+	}
+
+	s.MockStorage.EXPECT().GetTaskByID("task-1").Return(&storage.Task{ID: "task-1", UserID: "1234"}, nil)
+	s.MockStorage.EXPECT().UpdateTask(gomock.Any()).Return(nil)
+	s.MockStorage.EXPECT().ListTaskWatchers("task-1").Return([]*storage.TaskWatcher{
+		{TaskID: "task-1", UserID: "5678", Email: "watcher@example.com"},
+	}, nil)
+	s.MockRabbitMQ.EXPECT().Publish(gomock.Any()).Return(errors.New("publish error"))
+
+	resp, err := s.Server.UpdateTask(ctx, taskRequest)
+	s.NoError(err)
+	s.NotNil(resp)
+}
+
+func (s *ServerTestSuite) TestGetTask_TrackedTime() {
+	ctx := createTestContext()
+	start := time.Now().Add(-2 * time.Hour)
+	end := start.Add(90 * time.Minute)
+
+	s.MockStorage.EXPECT().GetTaskByID("task-1").Return(&storage.Task{ID: "task-1", UserID: "1234", EstimateMinutes: 120}, nil)
+	s.MockStorage.EXPECT().ListTimeEntriesWithCount(storage.TimeEntryFilter{TaskID: "task-1"}, -1, 0).Return([]*storage.TimeEntry{
+		{TaskID: "task-1", StartedAt: start, EndedAt: &end},
+	}, int64(1), nil)
+
+	resp, err := s.Server.GetTask(ctx, &task_pb.GetTaskRequest{Id: "task-1"})
+	s.NoError(err)
+	s.Equal(int32(120), resp.Task.EstimateMinutes)
+	s.Equal(int64(90), resp.Task.TrackedMinutes)
+}
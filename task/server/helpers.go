@@ -2,6 +2,11 @@ package server
 
 import (
 	"context"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	task_pb "github.com/sejamuchhal/taskhub/task/pb/task"
@@ -13,15 +18,160 @@ import (
 )
 
 func TransformTask(st *storage.Task) *task_pb.Task {
-	return &task_pb.Task{
-		Id:          st.ID,
-		Title:       st.Title,
-		Description: st.Description,
-		Status:      st.Status,
-		DueDate:     timestamppb.New(st.DueDate),
-		CreatedAt:   timestamppb.New(st.CreatedAt.In(time.Local)),
-		UpdatedAt:   timestamppb.New(st.UpdatedAt.In(time.Local)),
+	res := &task_pb.Task{
+		Id:              st.ID,
+		Title:           st.Title,
+		Description:     st.Description,
+		Status:          st.Status,
+		DueDate:         timestamppb.New(st.DueDate),
+		DueAllDay:       st.DueAllDay,
+		CreatedAt:       timestamppb.New(st.CreatedAt.In(time.Local)),
+		UpdatedAt:       timestamppb.New(st.UpdatedAt.In(time.Local)),
+		EstimateMinutes: st.EstimateMinutes,
+		Position:        st.Position,
+		Tags:            st.Tags,
+		CustomFields:    TransformCustomFieldValues(st.CustomFields),
+		Priority:        PriorityName(st.Priority),
 	}
+	if st.CompletedAt != nil {
+		res.CompletedAt = timestamppb.New(*st.CompletedAt)
+	}
+	if st.ArchivedAt != nil {
+		res.ArchivedAt = timestamppb.New(*st.ArchivedAt)
+	}
+	return res
+}
+
+func TransformTimeEntry(entry *storage.TimeEntry) *task_pb.TimeEntry {
+	res := &task_pb.TimeEntry{
+		Id:              entry.ID,
+		TaskId:          entry.TaskID,
+		StartedAt:       timestamppb.New(entry.StartedAt),
+		Note:            entry.Note,
+		DurationSeconds: int64(entry.Duration(time.Now()).Seconds()),
+		Running:         entry.EndedAt == nil,
+		CreatedAt:       timestamppb.New(entry.CreatedAt.In(time.Local)),
+		UpdatedAt:       timestamppb.New(entry.UpdatedAt.In(time.Local)),
+	}
+	if entry.EndedAt != nil {
+		res.EndedAt = timestamppb.New(*entry.EndedAt)
+	}
+	return res
+}
+
+func TransformTimeEntryRevision(revision *storage.TimeEntryRevision) *task_pb.TimeEntryRevision {
+	res := &task_pb.TimeEntryRevision{
+		Id:          revision.ID,
+		TimeEntryId: revision.TimeEntryID,
+		Action:      revision.Action,
+		StartedAt:   timestamppb.New(revision.StartedAt),
+		Note:        revision.Note,
+		CreatedAt:   timestamppb.New(revision.CreatedAt.In(time.Local)),
+	}
+	if revision.EndedAt != nil {
+		res.EndedAt = timestamppb.New(*revision.EndedAt)
+	}
+	return res
+}
+
+// TrackedMinutes sums the tracked time of the given entries in whole minutes
+func TrackedMinutes(entries []*storage.TimeEntry, now time.Time) int64 {
+	var total time.Duration
+	for _, entry := range entries {
+		total += entry.Duration(now)
+	}
+	return int64(total.Minutes())
+}
+
+// BuildTimeReport aggregates entries per calendar day in loc, per task, per
+// tag and, with a project field, per project. Only the part of an entry
+// between from and to counts, split at midnight in loc between the days it
+// ran on. tasks are the tasks of the entries by ID, entries of deleted tasks
+// only count towards their days and task.
+func BuildTimeReport(entries []*storage.TimeEntry, tasks map[string]*storage.Task, projectFieldID string, loc *time.Location, from, to, now time.Time) *task_pb.GetTimeReportResponse {
+	byDay := map[string]int64{}
+	byTask := map[string]int64{}
+	byTag := map[string]int64{}
+	byProject := map[string]int64{}
+	var total int64
+
+	for _, entry := range entries {
+		start, end := entry.StartedAt, now
+		if entry.EndedAt != nil {
+			end = *entry.EndedAt
+		}
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+
+		var seconds int64
+		for start.Before(end) {
+			year, month, day := start.In(loc).Date()
+			midnight := time.Date(year, month, day+1, 0, 0, 0, 0, loc)
+			if midnight.After(end) {
+				midnight = end
+			}
+			daySeconds := int64(midnight.Sub(start).Seconds())
+			byDay[start.In(loc).Format(time.DateOnly)] += daySeconds
+			seconds += daySeconds
+			start = midnight
+		}
+		if seconds == 0 {
+			continue
+		}
+		byTask[entry.TaskID] += seconds
+		total += seconds
+
+		task, ok := tasks[entry.TaskID]
+		if !ok {
+			continue
+		}
+		if len(task.Tags) == 0 {
+			byTag[""] += seconds
+		}
+		for _, tag := range task.Tags {
+			byTag[tag] += seconds
+		}
+		if projectFieldID != "" {
+			project, _ := task.CustomFields[projectFieldID].(string)
+			byProject[project] += seconds
+		}
+	}
+
+	res := &task_pb.GetTimeReportResponse{TotalSeconds: total}
+	for day, seconds := range byDay {
+		res.ByDay = append(res.ByDay, &task_pb.TimeReportBucket{Key: day, Label: day, DurationSeconds: seconds})
+	}
+	sort.Slice(res.ByDay, func(i, j int) bool { return res.ByDay[i].Key < res.ByDay[j].Key })
+	res.ByTask = timeReportBuckets(byTask, func(taskID string) string {
+		if task, ok := tasks[taskID]; ok {
+			return task.Title
+		}
+		return ""
+	})
+	sameLabel := func(key string) string { return key }
+	res.ByTag = timeReportBuckets(byTag, sameLabel)
+	res.ByProject = timeReportBuckets(byProject, sameLabel)
+
+	return res
+}
+
+// timeReportBuckets returns the buckets of durations, the longest first
+func timeReportBuckets(durations map[string]int64, label func(key string) string) []*task_pb.TimeReportBucket {
+	var buckets []*task_pb.TimeReportBucket
+	for key, seconds := range durations {
+		buckets = append(buckets, &task_pb.TimeReportBucket{Key: key, Label: label(key), DurationSeconds: seconds})
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		if buckets[i].DurationSeconds != buckets[j].DurationSeconds {
+			return buckets[i].DurationSeconds > buckets[j].DurationSeconds
+		}
+		return buckets[i].Key < buckets[j].Key
+	})
+	return buckets
 }
 
 func ExtractUserEmail(ctx context.Context) (string, error) {
@@ -49,3 +199,343 @@ func ExtractUserID(ctx context.Context) (string, error) {
 	}
 	return userIds[0], nil
 }
+
+// MergeTimeline joins created and completed counts into one bucket per period
+func MergeTimeline(created, completed []storage.PeriodCount) []*task_pb.TaskCountBucket {
+	buckets := map[string]*task_pb.TaskCountBucket{}
+	bucket := func(period string) *task_pb.TaskCountBucket {
+		if _, ok := buckets[period]; !ok {
+			buckets[period] = &task_pb.TaskCountBucket{Period: period}
+		}
+		return buckets[period]
+	}
+	for _, c := range created {
+		bucket(c.Period).Created = c.Count
+	}
+	for _, c := range completed {
+		bucket(c.Period).Completed = c.Count
+	}
+
+	res := make([]*task_pb.TaskCountBucket, 0, len(buckets))
+	for _, b := range buckets {
+		res = append(res, b)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Period < res[j].Period })
+	return res
+}
+
+// CompletionStreaks returns the current and the longest run of consecutive
+// days with a completion. days must be sorted YYYY-MM-DD dates. The current
+// streak may end today or yesterday (relative to to), the longest streak only
+// counts days inside [from, to].
+func CompletionStreaks(days []string, from, to time.Time) (current, longest int32) {
+	fromDay, toDay := from.Format(time.DateOnly), to.Format(time.DateOnly)
+
+	var run, windowRun int32
+	var prev time.Time
+	for _, d := range days {
+		day, err := time.Parse(time.DateOnly, d)
+		if err != nil {
+			continue
+		}
+		if !prev.IsZero() && day.Sub(prev) == 24*time.Hour {
+			run++
+		} else {
+			run = 1
+		}
+		if d >= fromDay && d <= toDay {
+			if windowRun > 0 && day.Sub(prev) == 24*time.Hour {
+				windowRun++
+			} else {
+				windowRun = 1
+			}
+			if windowRun > longest {
+				longest = windowRun
+			}
+		}
+		prev = day
+	}
+
+	today, _ := time.Parse(time.DateOnly, toDay)
+	if !prev.IsZero() && (prev.Equal(today) || prev.Equal(today.Add(-24*time.Hour))) {
+		current = run
+	}
+	return current, longest
+}
+
+// BuildBoardColumns groups tasks sorted by rank into one column per board
+// status. Tasks with any other status get a column after the known ones.
+func BuildBoardColumns(tasks []*storage.Task) []*task_pb.BoardColumn {
+	columns := make([]*task_pb.BoardColumn, 0, len(storage.BoardStatuses))
+	byStatus := map[string]*task_pb.BoardColumn{}
+	for _, st := range storage.BoardStatuses {
+		column := &task_pb.BoardColumn{Status: st, Tasks: []*task_pb.Task{}}
+		byStatus[st] = column
+		columns = append(columns, column)
+	}
+	for _, t := range tasks {
+		column, ok := byStatus[t.Status]
+		if !ok {
+			column = &task_pb.BoardColumn{Status: t.Status}
+			byStatus[t.Status] = column
+			columns = append(columns, column)
+		}
+		column.Tasks = append(column.Tasks, TransformTask(t))
+	}
+	return columns
+}
+
+func TransformTemplate(template *storage.TaskTemplate) *task_pb.TaskTemplate {
+	return &task_pb.TaskTemplate{
+		Id:                     template.ID,
+		Name:                   template.Name,
+		Title:                  template.Title,
+		Description:            template.Description,
+		Checklist:              template.Checklist,
+		Tags:                   template.Tags,
+		DueOffsetMinutes:       template.DueOffsetMinutes,
+		ReminderOffsetsMinutes: template.ReminderOffsetsMinutes,
+		EstimateMinutes:        template.EstimateMinutes,
+		CreatedAt:              timestamppb.New(template.CreatedAt.In(time.Local)),
+		UpdatedAt:              timestamppb.New(template.UpdatedAt.In(time.Local)),
+	}
+}
+
+func TransformCustomField(field *storage.CustomField) *task_pb.CustomField {
+	return &task_pb.CustomField{
+		Id:        field.ID,
+		Name:      field.Name,
+		Type:      field.Type,
+		Options:   field.Options,
+		CreatedAt: timestamppb.New(field.CreatedAt.In(time.Local)),
+		UpdatedAt: timestamppb.New(field.UpdatedAt.In(time.Local)),
+	}
+}
+
+// TransformCustomFieldValues converts stored values to their proto kind by
+// Go type. Values of unexpected types are skipped.
+func TransformCustomFieldValues(values storage.CustomFieldValues) map[string]*task_pb.CustomFieldValue {
+	if len(values) == 0 {
+		return nil
+	}
+	res := make(map[string]*task_pb.CustomFieldValue, len(values))
+	for id, value := range values {
+		switch value := value.(type) {
+		case string:
+			res[id] = &task_pb.CustomFieldValue{Kind: &task_pb.CustomFieldValue_StringValue{StringValue: value}}
+		case float64:
+			res[id] = &task_pb.CustomFieldValue{Kind: &task_pb.CustomFieldValue_NumberValue{NumberValue: value}}
+		case bool:
+			res[id] = &task_pb.CustomFieldValue{Kind: &task_pb.CustomFieldValue_BoolValue{BoolValue: value}}
+		case []string:
+			res[id] = &task_pb.CustomFieldValue{Kind: &task_pb.CustomFieldValue_ListValue{ListValue: &task_pb.StringList{Values: value}}}
+		}
+	}
+	return res
+}
+
+func TransformView(view *storage.SavedView) *task_pb.SavedView {
+	filters := make([]*task_pb.CustomFieldFilter, len(view.Query.CustomFields))
+	for i, filter := range view.Query.CustomFields {
+		filters[i] = &task_pb.CustomFieldFilter{
+			FieldId: filter.FieldID,
+			Op:      filter.Op,
+			Value:   &task_pb.CustomFieldValue{Kind: &task_pb.CustomFieldValue_StringValue{StringValue: filter.Value}},
+		}
+	}
+	return &task_pb.SavedView{
+		Id:   view.ID,
+		Name: view.Name,
+		Query: &task_pb.TaskQuery{
+			Statuses:           view.Query.Statuses,
+			Tags:               view.Query.Tags,
+			Due:                view.Query.Due,
+			CustomFieldFilters: filters,
+			SortBy:             view.Query.SortBy,
+			SortFieldId:        view.Query.SortFieldID,
+			SortDesc:           view.Query.SortDesc,
+		},
+		GroupBy:    view.GroupBy,
+		CreatedAt:  timestamppb.New(view.CreatedAt.In(time.Local)),
+		UpdatedAt:  timestamppb.New(view.UpdatedAt.In(time.Local)),
+		Visibility: view.Visibility,
+		UserId:     view.UserID,
+	}
+}
+
+func TransformRetentionPolicy(policy *storage.RetentionPolicy) *task_pb.RetentionPolicy {
+	return &task_pb.RetentionPolicy{
+		ArchiveAfterDays: policy.ArchiveAfterDays,
+		PurgeAfterDays:   policy.PurgeAfterDays,
+	}
+}
+
+func TransformTaskWatcher(watcher *storage.TaskWatcher) *task_pb.TaskWatcher {
+	return &task_pb.TaskWatcher{
+		UserId:    watcher.UserID,
+		Email:     watcher.Email,
+		CreatedAt: timestamppb.New(watcher.CreatedAt),
+	}
+}
+
+// DueWindows are the relative due date windows tasks can be listed by
+var DueWindows = []string{"overdue", "today", "tomorrow", "this_week", "next_7_days", "none"}
+
+// applyDueWindow sets the due date bounds of a relative window. Days and
+// weeks are those of now's location, weeks start on Monday.
+func applyDueWindow(filter *storage.TaskFilter, window string, now time.Time) error {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	between := func(from, before time.Time) {
+		filter.DueFrom, filter.DueBefore = &from, &before
+	}
+
+	switch window {
+	case "overdue":
+		filter.DueBefore = &now
+	case "today":
+		between(today, today.AddDate(0, 0, 1))
+	case "tomorrow":
+		between(today.AddDate(0, 0, 1), today.AddDate(0, 0, 2))
+	case "this_week":
+		monday := today.AddDate(0, 0, -(int(today.Weekday())+6)%7)
+		between(monday, monday.AddDate(0, 0, 7))
+	case "next_7_days":
+		between(now, now.AddDate(0, 0, 7))
+	case "none":
+		filter.WithoutDueDate = true
+	default:
+		return status.Errorf(codes.InvalidArgument, "due must be one of %s", strings.Join(DueWindows, ", "))
+	}
+	return nil
+}
+
+// GroupTasks groups tasks by "status", "tag" or a custom field ID. Groups
+// follow the order of their first task, tasks without a value come last in
+// the group with an empty key.
+func GroupTasks(tasks []*task_pb.Task, groupBy string) []*task_pb.TaskGroup {
+	var groups []*task_pb.TaskGroup
+	byKey := map[string]*task_pb.TaskGroup{}
+	add := func(key, taskID string) {
+		group, ok := byKey[key]
+		if !ok {
+			group = &task_pb.TaskGroup{Key: key}
+			byKey[key] = group
+			if key != "" {
+				groups = append(groups, group)
+			}
+		}
+		group.TaskIds = append(group.TaskIds, taskID)
+	}
+
+	for _, t := range tasks {
+		var keys []string
+		switch groupBy {
+		case "status":
+			keys = []string{t.GetStatus()}
+		case "tag":
+			keys = t.GetTags()
+		default:
+			keys = customFieldKeys(t.GetCustomFields()[groupBy])
+		}
+		if len(keys) == 0 {
+			add("", t.GetId())
+		}
+		for _, key := range keys {
+			add(key, t.GetId())
+		}
+	}
+
+	if group, ok := byKey[""]; ok {
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// customFieldKeys formats a custom field value as group keys
+func customFieldKeys(value *task_pb.CustomFieldValue) []string {
+	switch kind := value.GetKind().(type) {
+	case *task_pb.CustomFieldValue_StringValue:
+		return []string{kind.StringValue}
+	case *task_pb.CustomFieldValue_NumberValue:
+		return []string{strconv.FormatFloat(kind.NumberValue, 'f', -1, 64)}
+	case *task_pb.CustomFieldValue_BoolValue:
+		return []string{strconv.FormatBool(kind.BoolValue)}
+	case *task_pb.CustomFieldValue_ListValue:
+		return kind.ListValue.GetValues()
+	}
+	return nil
+}
+
+// PriorityLevel returns the stored level of a priority name, none when it is
+// empty
+func PriorityLevel(name string) (int32, error) {
+	if name == "" {
+		return storage.TaskPriorityNone, nil
+	}
+	index := slices.Index(storage.TaskPriorities, name)
+	if index < 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "Priority must be one of %s", strings.Join(storage.TaskPriorities, ", "))
+	}
+	return int32(index), nil
+}
+
+func PriorityName(level int32) string {
+	if level < 0 || int(level) >= len(storage.TaskPriorities) {
+		return storage.TaskPriorities[storage.TaskPriorityNone]
+	}
+	return storage.TaskPriorities[level]
+}
+
+// NormalizeTags trims tags and drops empty and duplicate ones, keeping order
+func NormalizeTags(tags []string) []string {
+	res := make([]string, 0, len(tags))
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		res = append(res, tag)
+	}
+	return res
+}
+
+var templateVariable = regexp.MustCompile(`{{\s*([A-Za-z0-9_]+)\s*}}`)
+
+// RenderTemplateText substitutes {{name}} placeholders with vars. Names
+// without a value are left in place and returned as missing.
+func RenderTemplateText(text string, vars map[string]string) (string, []string) {
+	var missing []string
+	rendered := templateVariable.ReplaceAllStringFunc(text, func(match string) string {
+		name := templateVariable.FindStringSubmatch(match)[1]
+		value, ok := vars[name]
+		if !ok {
+			missing = append(missing, name)
+			return match
+		}
+		return value
+	})
+	return rendered, missing
+}
+
+// RenderChecklist appends checklist items to a description as a markdown
+// task list
+func RenderChecklist(description string, items []string) string {
+	if len(items) == 0 {
+		return description
+	}
+	var b strings.Builder
+	b.WriteString(description)
+	if description != "" {
+		b.WriteString("\n\n")
+	}
+	for i, item := range items {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString("- [ ] ")
+		b.WriteString(item)
+	}
+	return b.String()
+}
@@ -51,3 +51,337 @@ func TestTransformTask(t *testing.T) {
 		})
 	}
 }
+
+func TestTransformCustomFieldValues(t *testing.T) {
+	got := TransformCustomFieldValues(storage.CustomFieldValues{
+		"text":   "ACME",
+		"number": 3.5,
+		"check":  true,
+		"multi":  []string{"ui", "api"},
+		"broken": map[string]interface{}{},
+	})
+
+	if len(got) != 4 {
+		t.Fatalf("TransformCustomFieldValues() returned %d values, want 4", len(got))
+	}
+	if got["text"].GetStringValue() != "ACME" {
+		t.Errorf("text = %v, want ACME", got["text"])
+	}
+	if got["number"].GetNumberValue() != 3.5 {
+		t.Errorf("number = %v, want 3.5", got["number"])
+	}
+	if !got["check"].GetBoolValue() {
+		t.Errorf("check = %v, want true", got["check"])
+	}
+	if !reflect.DeepEqual(got["multi"].GetListValue().GetValues(), []string{"ui", "api"}) {
+		t.Errorf("multi = %v, want [ui api]", got["multi"])
+	}
+	if TransformCustomFieldValues(nil) != nil {
+		t.Error("TransformCustomFieldValues(nil) should be nil")
+	}
+}
+
+func TestBuildTimeReport(t *testing.T) {
+	kolkata, _ := time.LoadLocation("Asia/Kolkata")
+	at := func(s string) time.Time {
+		ts, _ := time.Parse(time.RFC3339, s)
+		return ts
+	}
+	end1 := at("2024-07-28T19:30:00Z")
+	end2 := at("2024-07-29T09:30:00Z")
+	end3 := at("2024-07-29T11:00:00Z")
+	end4 := at("2024-07-29T11:15:00Z")
+	entries := []*storage.TimeEntry{
+		// 19:00 UTC is already the next day in Kolkata
+		{TaskID: "a", StartedAt: at("2024-07-28T19:00:00Z"), EndedAt: &end1},
+		{TaskID: "a", StartedAt: at("2024-07-29T09:00:00Z"), EndedAt: &end2},
+		{TaskID: "b", StartedAt: at("2024-07-29T10:00:00Z"), EndedAt: &end3},
+		// Deleted tasks count towards their day and task only
+		{TaskID: "c", StartedAt: at("2024-07-29T11:00:00Z"), EndedAt: &end4},
+	}
+	tasks := map[string]*storage.Task{
+		"a": {ID: "a", Title: "Task A", Tags: []string{"billable", "acme"}, CustomFields: storage.CustomFieldValues{"project": "Website"}},
+		"b": {ID: "b", Title: "Task B"},
+	}
+
+	from, to := at("2024-07-28T00:00:00Z"), at("2024-07-30T00:00:00Z")
+	got := BuildTimeReport(entries, tasks, "project", kolkata, from, to, time.Now())
+
+	if got.TotalSeconds != 8100 {
+		t.Errorf("TotalSeconds = %d, want 8100", got.TotalSeconds)
+	}
+	if len(got.ByDay) != 1 || got.ByDay[0].Key != "2024-07-29" || got.ByDay[0].DurationSeconds != 8100 {
+		t.Errorf("ByDay = %v, want a single 2024-07-29 bucket", got.ByDay)
+	}
+	if len(got.ByTask) != 3 || got.ByTask[0].Key != "a" || got.ByTask[0].Label != "Task A" || got.ByTask[0].DurationSeconds != 3600 {
+		t.Errorf("ByTask = %v, want task a first with 3600s", got.ByTask)
+	}
+	if got.ByTask[2].Key != "c" || got.ByTask[2].Label != "" {
+		t.Errorf("ByTask = %v, want the deleted task c last without a title", got.ByTask)
+	}
+
+	wantTags := []*task_pb.TimeReportBucket{
+		{Key: "", Label: "", DurationSeconds: 3600},
+		{Key: "acme", Label: "acme", DurationSeconds: 3600},
+		{Key: "billable", Label: "billable", DurationSeconds: 3600},
+	}
+	if !reflect.DeepEqual(got.ByTag, wantTags) {
+		t.Errorf("ByTag = %v, want %v", got.ByTag, wantTags)
+	}
+	wantProjects := []*task_pb.TimeReportBucket{
+		{Key: "", Label: "", DurationSeconds: 3600},
+		{Key: "Website", Label: "Website", DurationSeconds: 3600},
+	}
+	if !reflect.DeepEqual(got.ByProject, wantProjects) {
+		t.Errorf("ByProject = %v, want %v", got.ByProject, wantProjects)
+	}
+
+	if got := BuildTimeReport(entries, tasks, "", kolkata, from, to, time.Now()); len(got.ByProject) != 0 {
+		t.Errorf("ByProject = %v without a project field, want none", got.ByProject)
+	}
+}
+
+func TestBuildTimeReport_Overnight(t *testing.T) {
+	kolkata, _ := time.LoadLocation("Asia/Kolkata")
+	at := func(s string) time.Time {
+		ts, _ := time.Parse(time.RFC3339, s)
+		return ts
+	}
+	end1 := at("2024-07-29T20:30:00Z")
+	end2 := at("2024-07-28T19:00:00Z")
+	entries := []*storage.TimeEntry{
+		// 23:00 to 02:00 in Kolkata
+		{TaskID: "a", StartedAt: at("2024-07-29T17:30:00Z"), EndedAt: &end1},
+		// Started before the report, only the last hour is in it
+		{TaskID: "b", StartedAt: at("2024-07-28T16:00:00Z"), EndedAt: &end2},
+		// Still running when the report ends
+		{TaskID: "c", StartedAt: at("2024-07-30T18:00:00Z")},
+	}
+	from, to := at("2024-07-28T18:00:00Z"), at("2024-07-30T18:30:00Z")
+
+	got := BuildTimeReport(entries, nil, "", kolkata, from, to, at("2024-07-31T00:00:00Z"))
+
+	wantDays := []*task_pb.TimeReportBucket{
+		{Key: "2024-07-28", Label: "2024-07-28", DurationSeconds: 1800},
+		{Key: "2024-07-29", Label: "2024-07-29", DurationSeconds: 1800 + 3600},
+		{Key: "2024-07-30", Label: "2024-07-30", DurationSeconds: 2*3600 + 1800},
+	}
+	if !reflect.DeepEqual(got.ByDay, wantDays) {
+		t.Errorf("ByDay = %v, want %v", got.ByDay, wantDays)
+	}
+	wantTasks := []*task_pb.TimeReportBucket{
+		{Key: "a", DurationSeconds: 3 * 3600},
+		{Key: "b", DurationSeconds: 3600},
+		{Key: "c", DurationSeconds: 1800},
+	}
+	if !reflect.DeepEqual(got.ByTask, wantTasks) {
+		t.Errorf("ByTask = %v, want %v", got.ByTask, wantTasks)
+	}
+	if got.TotalSeconds != 4*3600+1800 {
+		t.Errorf("TotalSeconds = %d, want %d", got.TotalSeconds, 4*3600+1800)
+	}
+}
+
+func TestCompletionStreaks(t *testing.T) {
+	from := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 7, 10, 15, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		days        []string
+		wantCurrent int32
+		wantLongest int32
+	}{
+		{name: "No completions"},
+		{
+			name:        "Streak ending today",
+			days:        []string{"2024-07-02", "2024-07-08", "2024-07-09", "2024-07-10"},
+			wantCurrent: 3,
+			wantLongest: 3,
+		},
+		{
+			name:        "Streak ending yesterday",
+			days:        []string{"2024-07-08", "2024-07-09"},
+			wantCurrent: 2,
+			wantLongest: 2,
+		},
+		{
+			name:        "Broken streak",
+			days:        []string{"2024-07-02", "2024-07-03", "2024-07-04", "2024-07-07"},
+			wantCurrent: 0,
+			wantLongest: 3,
+		},
+		{
+			name:        "Current streak started before the window",
+			days:        []string{"2024-06-29", "2024-06-30", "2024-07-01", "2024-07-02"},
+			wantCurrent: 0,
+			wantLongest: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current, longest := CompletionStreaks(tt.days, from, to)
+			if current != tt.wantCurrent || longest != tt.wantLongest {
+				t.Errorf("CompletionStreaks() = (%d, %d), want (%d, %d)", current, longest, tt.wantCurrent, tt.wantLongest)
+			}
+		})
+	}
+}
+
+func TestRenderTemplateText(t *testing.T) {
+	vars := map[string]string{"client": "Acme", "date": "2024-07-30"}
+	tests := []struct {
+		name        string
+		text        string
+		want        string
+		wantMissing []string
+	}{
+		{name: "No variables", text: "Plain title", want: "Plain title"},
+		{name: "Substituted", text: "Onboard {{client}} on {{ date }}", want: "Onboard Acme on 2024-07-30"},
+		{name: "Missing", text: "Call {{owner}} about {{client}}", want: "Call {{owner}} about Acme", wantMissing: []string{"owner"}},
+		{name: "Not a variable", text: "Braces {{ two words }}", want: "Braces {{ two words }}"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, missing := RenderTemplateText(tt.text, vars)
+			if got != tt.want {
+				t.Errorf("RenderTemplateText() = %q, want %q", got, tt.want)
+			}
+			if !reflect.DeepEqual(missing, tt.wantMissing) {
+				t.Errorf("RenderTemplateText() missing = %v, want %v", missing, tt.wantMissing)
+			}
+		})
+	}
+}
+
+func TestRenderChecklist(t *testing.T) {
+	if got := RenderChecklist("Notes", nil); got != "Notes" {
+		t.Errorf("RenderChecklist() = %q, want %q", got, "Notes")
+	}
+	if got, want := RenderChecklist("", []string{"One", "Two"}), "- [ ] One\n- [ ] Two"; got != want {
+		t.Errorf("RenderChecklist() = %q, want %q", got, want)
+	}
+	if got, want := RenderChecklist("Notes", []string{"One"}), "Notes\n\n- [ ] One"; got != want {
+		t.Errorf("RenderChecklist() = %q, want %q", got, want)
+	}
+}
+
+func TestNormalizeTags(t *testing.T) {
+	got := NormalizeTags([]string{" work ", "", "home", "work"})
+	if want := []string{"work", "home"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("NormalizeTags() = %v, want %v", got, want)
+	}
+}
+
+func TestApplyDueWindow(t *testing.T) {
+	// A Wednesday
+	now := time.Date(2024, 7, 31, 15, 30, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2024, 7, d, 0, 0, 0, 0, time.UTC) }
+	aug := func(d int) time.Time { return time.Date(2024, 8, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		window     string
+		from       time.Time
+		before     time.Time
+	}{
+		{window: "today", from: day(31), before: aug(1)},
+		{window: "tomorrow", from: aug(1), before: aug(2)},
+		{window: "this_week", from: day(29), before: aug(5)},
+		{window: "next_7_days", from: now, before: now.AddDate(0, 0, 7)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.window, func(t *testing.T) {
+			var filter storage.TaskFilter
+			if err := applyDueWindow(&filter, tt.window, now); err != nil {
+				t.Fatalf("applyDueWindow() error = %v", err)
+			}
+			if filter.DueFrom == nil || !filter.DueFrom.Equal(tt.from) {
+				t.Errorf("applyDueWindow() DueFrom = %v, want %v", filter.DueFrom, tt.from)
+			}
+			if filter.DueBefore == nil || !filter.DueBefore.Equal(tt.before) {
+				t.Errorf("applyDueWindow() DueBefore = %v, want %v", filter.DueBefore, tt.before)
+			}
+		})
+	}
+
+	var filter storage.TaskFilter
+	if err := applyDueWindow(&filter, "overdue", now); err != nil || filter.DueFrom != nil || !filter.DueBefore.Equal(now) {
+		t.Errorf("applyDueWindow(overdue) = %+v, %v", filter, err)
+	}
+	filter = storage.TaskFilter{}
+	if err := applyDueWindow(&filter, "none", now); err != nil || !filter.WithoutDueDate {
+		t.Errorf("applyDueWindow(none) = %+v, %v", filter, err)
+	}
+	if err := applyDueWindow(&filter, "someday", now); err == nil {
+		t.Error("applyDueWindow(someday) error = nil, want an error")
+	}
+}
+
+func TestGroupTasks(t *testing.T) {
+	tasks := []*task_pb.Task{
+		{Id: "1", Status: "completed", CustomFields: map[string]*task_pb.CustomFieldValue{
+			"labels": {Kind: &task_pb.CustomFieldValue_ListValue{ListValue: &task_pb.StringList{Values: []string{"ui", "api"}}}},
+		}},
+		{Id: "2", Status: "created"},
+		{Id: "3", Status: "completed", CustomFields: map[string]*task_pb.CustomFieldValue{
+			"labels": {Kind: &task_pb.CustomFieldValue_ListValue{ListValue: &task_pb.StringList{Values: []string{"api"}}}},
+		}},
+	}
+
+	got := GroupTasks(tasks, "status")
+	want := []*task_pb.TaskGroup{
+		{Key: "completed", TaskIds: []string{"1", "3"}},
+		{Key: "created", TaskIds: []string{"2"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupTasks(status) = %v, want %v", got, want)
+	}
+
+	got = GroupTasks(tasks, "labels")
+	want = []*task_pb.TaskGroup{
+		{Key: "ui", TaskIds: []string{"1"}},
+		{Key: "api", TaskIds: []string{"1", "3"}},
+		{Key: "", TaskIds: []string{"2"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GroupTasks(labels) = %v, want %v", got, want)
+	}
+}
+
+func TestRankNextTasks(t *testing.T) {
+	now := time.Date(2024, 7, 30, 12, 0, 0, 0, time.UTC)
+	created := now.AddDate(0, -1, 0)
+	tasks := []*storage.Task{
+		{ID: "someday", Status: storage.TaskStatusCreated, DueDate: time.Unix(0, 0), CreatedAt: created},
+		{ID: "urgent", Status: storage.TaskStatusCreated, Priority: storage.TaskPriorityUrgent, DueDate: time.Unix(0, 0), CreatedAt: created},
+		{ID: "overdue", Status: storage.TaskStatusInProgress, DueDate: now.AddDate(0, 0, -3), CreatedAt: created},
+		{ID: "long-overdue", Status: storage.TaskStatusCreated, Priority: storage.TaskPriorityLow, DueDate: now.AddDate(0, 0, -30), CreatedAt: created},
+		{ID: "tomorrow", Status: storage.TaskStatusCreated, DueDate: now.Add(20 * time.Hour), CreatedAt: created},
+		{ID: "friday", Status: storage.TaskStatusCreated, DueDate: now.AddDate(0, 0, 3), CreatedAt: created},
+		{ID: "older-friday", Status: storage.TaskStatusCreated, DueDate: now.AddDate(0, 0, 3), CreatedAt: created.Add(-time.Hour)},
+	}
+
+	type ranked struct {
+		id      string
+		score   int32
+		reasons []string
+	}
+	want := []ranked{
+		{"long-overdue", 80, []string{"low priority", "overdue"}},
+		{"overdue", 61, []string{"overdue", "in progress"}},
+		{"urgent", 40, []string{"urgent priority"}},
+		{"tomorrow", 30, []string{"due within a day"}},
+		{"older-friday", 20, []string{"due within 3 days"}},
+		{"friday", 20, []string{"due within 3 days"}},
+		{"someday", 0, nil},
+	}
+
+	got := rankNextTasks(tasks, now)
+	if len(got) != len(want) {
+		t.Fatalf("rankNextTasks() returned %d tasks, want %d", len(got), len(want))
+	}
+	for i, next := range got {
+		if next.Task.Id != want[i].id || next.Score != want[i].score || !reflect.DeepEqual(next.Reasons, want[i].reasons) {
+			t.Errorf("rankNextTasks()[%d] = %s %d %v, want %s %d %v", i, next.Task.Id, next.Score, next.Reasons, want[i].id, want[i].score, want[i].reasons)
+		}
+	}
+}
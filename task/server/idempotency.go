@@ -0,0 +1,159 @@
+package server
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/sejamuchhal/taskhub/task/storage"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// DefaultIdempotencyTTL is how long a response is kept for retries when
+// no other TTL is configured
+const DefaultIdempotencyTTL = 24 * time.Hour
+
+const maxIdempotencyKeyLength = 255
+
+// idempotentMethods are the RPCs that create tasks or change their state.
+// Retrying them with the same idempotency key returns the first response.
+var idempotentMethods = map[string]bool{
+	"/task.TaskService/CreateTask":          true,
+	"/task.TaskService/InstantiateTemplate": true,
+	"/task.TaskService/CloneTask":           true,
+	"/task.TaskService/UpdateTask":          true,
+	"/task.TaskService/MoveTask":            true,
+	"/task.TaskService/UnarchiveTask":       true,
+	"/task.TaskService/StartTimer":          true,
+	"/task.TaskService/StopTimer":           true,
+	"/task.TaskService/CreateTimeEntry":     true,
+}
+
+// UnaryIdempotency is a server interceptor that replays the stored response
+// when an idempotent RPC is retried with the same idempotency_key metadata.
+// Requests are compared by the idempotency_fingerprint metadata the gateway
+// derives from the HTTP request, or by their encoding when it is missing.
+// Failed requests are not stored, so they can be retried with the same key.
+func (s *Server) UnaryIdempotency(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	keys := md.Get("idempotency_key")
+	if !idempotentMethods[info.FullMethod] || len(keys) == 0 || keys[0] == "" {
+		return handler(ctx, req)
+	}
+	key := keys[0]
+	if len(key) > maxIdempotencyKeyLength {
+		return nil, status.Errorf(codes.InvalidArgument, "Idempotency key must be at most %d characters", maxIdempotencyKeyLength)
+	}
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	logger := s.Logger.WithFields(logrus.Fields{
+		"method":          info.FullMethod,
+		"idempotency_key": key,
+	})
+
+	hash, err := requestHash(info.FullMethod, md.Get("idempotency_fingerprint"), req)
+	if err != nil {
+		logger.WithError(err).Error("Failed to hash request")
+		return nil, status.Errorf(codes.Internal, "Failed to hash request: %v", err)
+	}
+
+	ttl := s.IdempotencyTTL
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+	err = s.Storage.CreateIdempotencyRecord(&storage.IdempotencyRecord{
+		UserID:      userID,
+		Key:         key,
+		RequestHash: hash,
+		ExpiresAt:   time.Now().Add(ttl),
+	})
+	if errors.Is(err, storage.ErrIdempotencyKeyExists) {
+		return s.replayIdempotentResponse(logger, userID, key, hash)
+	}
+	if err != nil {
+		logger.WithError(err).Error("Failed to store idempotency key")
+		return nil, status.Errorf(codes.Internal, "Failed to store idempotency key: %v", err)
+	}
+
+	resp, err := handler(ctx, req)
+	if err != nil {
+		if err := s.Storage.DeleteIdempotencyRecord(userID, key); err != nil {
+			logger.WithError(err).Error("Failed to release idempotency key")
+		}
+		return nil, err
+	}
+
+	message, ok := resp.(proto.Message)
+	if !ok {
+		return resp, nil
+	}
+	stored, err := anypb.New(message)
+	if err == nil {
+		var data []byte
+		if data, err = proto.Marshal(stored); err == nil {
+			err = s.Storage.SaveIdempotencyResponse(userID, key, data)
+		}
+	}
+	if err != nil {
+		// The request succeeded, a retry will be reported as in progress
+		logger.WithError(err).Error("Failed to store idempotent response")
+	}
+	return resp, nil
+}
+
+func (s *Server) replayIdempotentResponse(logger *logrus.Entry, userID, key, hash string) (interface{}, error) {
+	record, err := s.Storage.GetIdempotencyRecord(userID, key)
+	if err != nil {
+		logger.WithError(err).Error("Failed to load idempotency key")
+		return nil, status.Errorf(codes.Internal, "Failed to load idempotency key: %v", err)
+	}
+	if record.RequestHash != hash {
+		logger.Warn("Idempotency key reused with a different request")
+		return nil, status.Error(codes.Aborted, "This idempotency key was already used for a different request")
+	}
+	if len(record.Response) == 0 {
+		return nil, status.Error(codes.FailedPrecondition, "A request with this idempotency key is still in progress")
+	}
+
+	var stored anypb.Any
+	if err := proto.Unmarshal(record.Response, &stored); err != nil {
+		logger.WithError(err).Error("Failed to decode idempotent response")
+		return nil, status.Errorf(codes.Internal, "Failed to decode idempotent response: %v", err)
+	}
+	resp, err := stored.UnmarshalNew()
+	if err != nil {
+		logger.WithError(err).Error("Failed to decode idempotent response")
+		return nil, status.Errorf(codes.Internal, "Failed to decode idempotent response: %v", err)
+	}
+
+	logger.Info("Replaying idempotent response")
+	return resp, nil
+}
+
+// requestHash identifies a request by its method and fingerprint, or by its
+// deterministic encoding without a fingerprint
+func requestHash(method string, fingerprint []string, req interface{}) (string, error) {
+	h := sha256.New()
+	h.Write([]byte(method + "\n"))
+	if len(fingerprint) > 0 {
+		h.Write([]byte(fingerprint[0]))
+	} else if message, ok := req.(proto.Message); ok {
+		data, err := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+		if err != nil {
+			return "", err
+		}
+		h.Write(data)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
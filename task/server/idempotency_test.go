@@ -0,0 +1,112 @@
+package server_test
+
+import (
+	"context"
+
+	task_pb "github.com/sejamuchhal/taskhub/task/pb/task"
+	"github.com/sejamuchhal/taskhub/task/storage"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+var createTaskInfo = &grpc.UnaryServerInfo{FullMethod: "/task.TaskService/CreateTask"}
+
+func idempotentContext(key, fingerprint string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.New(map[string]string{
+		"user_id":                 "1234",
+		"idempotency_key":         key,
+		"idempotency_fingerprint": fingerprint,
+	}))
+}
+
+func (s *ServerTestSuite) TestUnaryIdempotency_Replay() {
+	calls := 0
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls++
+		return &task_pb.CreateTaskResponse{Id: "task-1"}, nil
+	}
+	req := &task_pb.CreateTaskRequest{Task: &task_pb.Task{Title: "Task"}}
+
+	var record *storage.IdempotencyRecord
+	var response []byte
+	s.MockStorage.EXPECT().CreateIdempotencyRecord(gomock.Any()).DoAndReturn(func(r *storage.IdempotencyRecord) error {
+		record = r
+		return nil
+	})
+	s.MockStorage.EXPECT().SaveIdempotencyResponse("1234", "key-1", gomock.Any()).DoAndReturn(func(userID, key string, data []byte) error {
+		response = data
+		return nil
+	})
+
+	resp, err := s.Server.UnaryIdempotency(idempotentContext("key-1", "POST /tasks"), req, createTaskInfo, handler)
+	s.NoError(err)
+	s.Equal("task-1", resp.(*task_pb.CreateTaskResponse).Id)
+	s.Equal("1234", record.UserID)
+	s.Equal("key-1", record.Key)
+
+	// The retry gets the stored response without running the handler again
+	s.MockStorage.EXPECT().CreateIdempotencyRecord(gomock.Any()).Return(storage.ErrIdempotencyKeyExists)
+	s.MockStorage.EXPECT().GetIdempotencyRecord("1234", "key-1").Return(&storage.IdempotencyRecord{
+		UserID:      "1234",
+		Key:         "key-1",
+		RequestHash: record.RequestHash,
+		Response:    response,
+	}, nil)
+
+	resp, err = s.Server.UnaryIdempotency(idempotentContext("key-1", "POST /tasks"), req, createTaskInfo, handler)
+	s.NoError(err)
+	s.True(proto.Equal(&task_pb.CreateTaskResponse{Id: "task-1"}, resp.(proto.Message)))
+	s.Equal(1, calls)
+}
+
+func (s *ServerTestSuite) TestUnaryIdempotency_DifferentRequest() {
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		s.Fail("handler must not run")
+		return nil, nil
+	}
+
+	s.MockStorage.EXPECT().CreateIdempotencyRecord(gomock.Any()).Return(storage.ErrIdempotencyKeyExists)
+	s.MockStorage.EXPECT().GetIdempotencyRecord("1234", "key-1").Return(&storage.IdempotencyRecord{
+		UserID:      "1234",
+		Key:         "key-1",
+		RequestHash: "another request",
+		Response:    []byte("response"),
+	}, nil)
+
+	resp, err := s.Server.UnaryIdempotency(idempotentContext("key-1", "POST /tasks"), &task_pb.CreateTaskRequest{}, createTaskInfo, handler)
+	s.Nil(resp)
+	s.Equal(codes.Aborted, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestUnaryIdempotency_FailedRequestReleasesKey() {
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return nil, status.Error(codes.InvalidArgument, "Title is required")
+	}
+
+	s.MockStorage.EXPECT().CreateIdempotencyRecord(gomock.Any()).Return(nil)
+	s.MockStorage.EXPECT().DeleteIdempotencyRecord("1234", "key-1").Return(nil)
+
+	resp, err := s.Server.UnaryIdempotency(idempotentContext("key-1", ""), &task_pb.CreateTaskRequest{}, createTaskInfo, handler)
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestUnaryIdempotency_Skipped() {
+	calls := 0
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		calls++
+		return &task_pb.ListTasksResponse{}, nil
+	}
+
+	// Reads ignore the key, and requests without one are not tracked
+	listInfo := &grpc.UnaryServerInfo{FullMethod: "/task.TaskService/ListTasks"}
+	_, err := s.Server.UnaryIdempotency(idempotentContext("key-1", ""), &task_pb.ListTasksRequest{}, listInfo, handler)
+	s.NoError(err)
+	_, err = s.Server.UnaryIdempotency(createTestContext(), &task_pb.CreateTaskRequest{}, createTaskInfo, handler)
+	s.NoError(err)
+	s.Equal(2, calls)
+}
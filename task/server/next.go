@@ -0,0 +1,160 @@
+package server
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	task_pb "github.com/sejamuchhal/taskhub/task/pb/task"
+	"github.com/sejamuchhal/taskhub/task/storage"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// The next-up score ranks open tasks by what needs attention first. It is
+// the sum of:
+//
+//   - priority: 10 points per level, from 0 for none to 40 for urgent
+//   - overdue: 50 points, plus 2 per full day overdue up to 20 more
+//   - due soon: 30 points when due within a day, 20 within 3 days and 10
+//     within a week
+//   - in progress: 5 points, so that started work gets finished
+//
+// All-day tasks are due at the end of their day. Ties go to the earlier due
+// date, then to the older task.
+const (
+	nextPriorityPoints     = 10
+	nextOverduePoints      = 50
+	nextOverduePointsByDay = 2
+	nextOverdueMaxExtra    = 20
+	nextInProgressPoints   = 5
+)
+
+// nextDueSoon are the due date windows of the score, nearest first
+var nextDueSoon = []struct {
+	within time.Duration
+	points int32
+	reason string
+}{
+	{24 * time.Hour, 30, "due within a day"},
+	{3 * 24 * time.Hour, 20, "due within 3 days"},
+	{7 * 24 * time.Hour, 10, "due within a week"},
+}
+
+// ListNextTasks returns the caller's open tasks with the highest next-up
+// score first
+func (s *Server) ListNextTasks(ctx context.Context, req *task_pb.ListNextTasksRequest) (*task_pb.ListNextTasksResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "ListNextTasks",
+	})
+	logger.Info("Received ListNextTasks request")
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int(req.GetLimit())
+	if limit < 0 {
+		return nil, status.Error(codes.InvalidArgument, "Limit must not be negative")
+	}
+	if limit == 0 {
+		limit = 10
+	}
+
+	tasks, _, err := s.Storage.ListTasksWithCount(storage.TaskFilter{
+		UserID:   userID,
+		Statuses: []string{storage.TaskStatusCreated, storage.TaskStatusInProgress},
+	}, -1, 0)
+	if err != nil {
+		errMsg := "Failed to list tasks from the database"
+		logger.WithError(err).Error(errMsg)
+		return nil, status.Errorf(codes.Internal, "%s: %v", errMsg, err)
+	}
+
+	next := rankNextTasks(tasks, time.Now())
+	if len(next) > limit {
+		next = next[:limit]
+	}
+	return &task_pb.ListNextTasksResponse{Tasks: next}, nil
+}
+
+// rankNextTasks scores tasks as of now and orders them highest first
+func rankNextTasks(tasks []*storage.Task, now time.Time) []*task_pb.NextTask {
+	type scored struct {
+		task    *storage.Task
+		score   int32
+		reasons []string
+	}
+	ranked := make([]scored, len(tasks))
+	for i, task := range tasks {
+		score, reasons := nextScore(task, now)
+		ranked[i] = scored{task: task, score: score, reasons: reasons}
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		if a.score != b.score {
+			return a.score > b.score
+		}
+		aDue, bDue := hasDueDate(a.task), hasDueDate(b.task)
+		if aDue != bDue {
+			return aDue
+		}
+		if aDue && !a.task.DueDate.Equal(b.task.DueDate) {
+			return a.task.DueDate.Before(b.task.DueDate)
+		}
+		return a.task.CreatedAt.Before(b.task.CreatedAt)
+	})
+
+	res := make([]*task_pb.NextTask, len(ranked))
+	for i, r := range ranked {
+		res[i] = &task_pb.NextTask{
+			Task:    TransformTask(r.task),
+			Score:   r.score,
+			Reasons: r.reasons,
+		}
+	}
+	return res
+}
+
+func nextScore(task *storage.Task, now time.Time) (int32, []string) {
+	var score int32
+	var reasons []string
+
+	if task.Priority > storage.TaskPriorityNone {
+		score += task.Priority * nextPriorityPoints
+		reasons = append(reasons, PriorityName(task.Priority)+" priority")
+	}
+
+	if hasDueDate(task) {
+		untilDue := task.DueDate.Sub(now)
+		if untilDue < 0 {
+			days := int32(-untilDue / (24 * time.Hour))
+			score += nextOverduePoints + min(days*nextOverduePointsByDay, nextOverdueMaxExtra)
+			reasons = append(reasons, "overdue")
+		} else {
+			for _, window := range nextDueSoon {
+				if untilDue <= window.within {
+					score += window.points
+					reasons = append(reasons, window.reason)
+					break
+				}
+			}
+		}
+	}
+
+	if task.Status == storage.TaskStatusInProgress {
+		score += nextInProgressPoints
+		reasons = append(reasons, "in progress")
+	}
+	return score, reasons
+}
+
+// hasDueDate reports whether a task has a due date, tasks without one store
+// the Unix epoch
+func hasDueDate(task *storage.Task) bool {
+	return task.DueDate.After(time.Unix(0, 0))
+}
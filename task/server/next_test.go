@@ -0,0 +1,40 @@
+package server_test
+
+import (
+	"time"
+
+	task_pb "github.com/sejamuchhal/taskhub/task/pb/task"
+	"github.com/sejamuchhal/taskhub/task/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *ServerTestSuite) TestListNextTasks() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().ListTasksWithCount(storage.TaskFilter{
+		UserID:   "1234",
+		Statuses: []string{storage.TaskStatusCreated, storage.TaskStatusInProgress},
+	}, -1, 0).Return([]*storage.Task{
+		{ID: "task-1", Status: storage.TaskStatusCreated, DueDate: time.Unix(0, 0)},
+		{ID: "task-2", Status: storage.TaskStatusCreated, Priority: storage.TaskPriorityHigh, DueDate: time.Unix(0, 0)},
+		{ID: "task-3", Status: storage.TaskStatusCreated, DueDate: time.Now().Add(-time.Hour)},
+	}, int64(3), nil)
+
+	resp, err := s.Server.ListNextTasks(ctx, &task_pb.ListNextTasksRequest{Limit: 2})
+	s.NoError(err)
+	s.Require().Len(resp.Tasks, 2)
+	s.Equal("task-3", resp.Tasks[0].Task.Id)
+	s.Equal([]string{"overdue"}, resp.Tasks[0].Reasons)
+	s.Equal("task-2", resp.Tasks[1].Task.Id)
+	s.Equal("high", resp.Tasks[1].Task.Priority)
+	s.Equal(int32(30), resp.Tasks[1].Score)
+}
+
+func (s *ServerTestSuite) TestListNextTasks_NegativeLimit() {
+	ctx := createTestContext()
+
+	resp, err := s.Server.ListNextTasks(ctx, &task_pb.ListNextTasksRequest{Limit: -1})
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+}
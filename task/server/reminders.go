@@ -0,0 +1,76 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/sejamuchhal/taskhub/task/storage"
+	"gorm.io/gorm"
+)
+
+// reminderBatchSize is how many due reminders are loaded at a time
+const reminderBatchSize = 100
+
+// dueDateReminders returns reminders offsetsMinutes before the due date of
+// task. Reminders that would already be due at now are left out, and tasks
+// without a due date get none.
+func dueDateReminders(task *storage.Task, offsetsMinutes []int64, now time.Time) []*storage.TaskReminder {
+	if !hasDueDate(task) {
+		return nil
+	}
+	var reminders []*storage.TaskReminder
+	for _, offset := range offsetsMinutes {
+		remindAt := task.DueDate.Add(-time.Duration(offset) * time.Minute)
+		if remindAt.After(now) {
+			reminders = append(reminders, &storage.TaskReminder{OffsetMinutes: offset, RemindAt: remindAt})
+		}
+	}
+	return reminders
+}
+
+// RunReminders sends due task reminders every interval until ctx is done
+func (s *Server) RunReminders(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.SendDueReminders(time.Now()); err != nil {
+			s.Logger.WithError(err).Error("Failed to send task reminders")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SendDueReminders reminds owners of their tasks once for every reminder due
+// at now. Reminders of completed, archived or deleted tasks are dropped
+// without a message.
+func (s *Server) SendDueReminders(now time.Time) error {
+	logger := s.Logger.WithField("method", "SendDueReminders")
+
+	for {
+		reminders, err := s.Storage.ListDueTaskReminders(now, reminderBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, reminder := range reminders {
+			task, err := s.Storage.GetTaskByID(reminder.TaskID)
+			if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+				return err
+			}
+			if err == nil && task.Status != storage.TaskStatusCompleted && task.ArchivedAt == nil && task.UserEmail != "" {
+				s.publishTaskEvent(logger.WithField("task_id", task.ID), "reminder", task.Title, []string{task.UserEmail})
+			}
+			if err := s.Storage.MarkTaskReminderSent(reminder.ID, now); err != nil {
+				return err
+			}
+		}
+		if len(reminders) < reminderBatchSize {
+			return nil
+		}
+	}
+}
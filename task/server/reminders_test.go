@@ -0,0 +1,32 @@
+package server_test
+
+import (
+	"time"
+
+	"github.com/sejamuchhal/taskhub/task/storage"
+	"gorm.io/gorm"
+)
+
+func (s *ServerTestSuite) TestSendDueReminders() {
+	now := time.Date(2024, 7, 30, 10, 0, 0, 0, time.UTC)
+	archivedAt := now.Add(-time.Hour)
+
+	s.MockStorage.EXPECT().ListDueTaskReminders(now, 100).Return([]*storage.TaskReminder{
+		{ID: "reminder-1", TaskID: "task-1"},
+		{ID: "reminder-2", TaskID: "task-2"},
+		{ID: "reminder-3", TaskID: "task-3"},
+		{ID: "reminder-4", TaskID: "task-4"},
+	}, nil)
+	s.MockStorage.EXPECT().GetTaskByID("task-1").Return(&storage.Task{ID: "task-1", Title: "Pay rent", Status: storage.TaskStatusCreated, UserEmail: "user@example.com"}, nil)
+	s.expectTaskEvent("reminder", "Pay rent", "user@example.com")
+	s.MockStorage.EXPECT().MarkTaskReminderSent("reminder-1", now).Return(nil)
+	// Done, archived and deleted tasks need no reminding
+	s.MockStorage.EXPECT().GetTaskByID("task-2").Return(&storage.Task{ID: "task-2", Status: storage.TaskStatusCompleted, UserEmail: "user@example.com"}, nil)
+	s.MockStorage.EXPECT().MarkTaskReminderSent("reminder-2", now).Return(nil)
+	s.MockStorage.EXPECT().GetTaskByID("task-3").Return(&storage.Task{ID: "task-3", ArchivedAt: &archivedAt, UserEmail: "user@example.com"}, nil)
+	s.MockStorage.EXPECT().MarkTaskReminderSent("reminder-3", now).Return(nil)
+	s.MockStorage.EXPECT().GetTaskByID("task-4").Return(nil, gorm.ErrRecordNotFound)
+	s.MockStorage.EXPECT().MarkTaskReminderSent("reminder-4", now).Return(nil)
+
+	s.NoError(s.Server.SendDueReminders(now))
+}
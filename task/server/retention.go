@@ -0,0 +1,207 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	task_pb "github.com/sejamuchhal/taskhub/task/pb/task"
+	"github.com/sejamuchhal/taskhub/task/storage"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// maxRetentionDays caps retention periods at about a century
+const maxRetentionDays = 36500
+
+// UnarchiveTask brings an archived task back into the default listings. The
+// task is kept for a full retention period again before it is re-archived.
+func (s *Server) UnarchiveTask(ctx context.Context, req *task_pb.UnarchiveTaskRequest) (*task_pb.UnarchiveTaskResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "UnarchiveTask",
+	})
+	logger.Info("Received UnarchiveTask request")
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := s.getOwnedTask(logger, req.GetId(), userID)
+	if err != nil {
+		return nil, err
+	}
+	if task.ArchivedAt == nil {
+		return nil, status.Error(codes.FailedPrecondition, "Task is not archived")
+	}
+
+	if err := s.Storage.SetTaskArchived(task.ID, nil); err != nil {
+		errMsg := "Failed to unarchive task"
+		logger.WithError(err).Error(errMsg)
+		return nil, status.Errorf(codes.Internal, "%s: %v", errMsg, err)
+	}
+	task.ArchivedAt = nil
+	task.UpdatedAt = time.Now()
+
+	logger.Info("Task unarchived")
+	return &task_pb.UnarchiveTaskResponse{Task: TransformTask(task)}, nil
+}
+
+// GetRetentionPolicy returns the user's retention policy, or the global one
+// when they have none
+func (s *Server) GetRetentionPolicy(ctx context.Context, req *task_pb.GetRetentionPolicyRequest) (*task_pb.GetRetentionPolicyResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "GetRetentionPolicy",
+	})
+	logger.Info("Received GetRetentionPolicy request")
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := s.Storage.GetRetentionPolicy(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return &task_pb.GetRetentionPolicyResponse{Policy: s.defaultRetentionPolicy()}, nil
+	}
+	if err != nil {
+		errMsg := "Failed to retrieve retention policy from the database"
+		logger.WithError(err).Error(errMsg)
+		return nil, status.Errorf(codes.Internal, "%s: %v", errMsg, err)
+	}
+
+	return &task_pb.GetRetentionPolicyResponse{Policy: TransformRetentionPolicy(policy)}, nil
+}
+
+// UpdateRetentionPolicy sets the user's own retention policy
+func (s *Server) UpdateRetentionPolicy(ctx context.Context, req *task_pb.UpdateRetentionPolicyRequest) (*task_pb.UpdateRetentionPolicyResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "UpdateRetentionPolicy",
+	})
+	logger.Info("Received UpdateRetentionPolicy request")
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	policy := &storage.RetentionPolicy{
+		UserID:           userID,
+		ArchiveAfterDays: req.GetPolicy().GetArchiveAfterDays(),
+		PurgeAfterDays:   req.GetPolicy().GetPurgeAfterDays(),
+	}
+	for _, days := range []int32{policy.ArchiveAfterDays, policy.PurgeAfterDays} {
+		if days < 0 || days > maxRetentionDays {
+			return nil, status.Errorf(codes.InvalidArgument, "Retention periods must be between 0 and %d days", maxRetentionDays)
+		}
+	}
+
+	if err := s.Storage.SaveRetentionPolicy(policy); err != nil {
+		errMsg := "Could not save retention policy"
+		logger.WithError(err).Error(errMsg)
+		return nil, status.Errorf(codes.Internal, "%s: %v", errMsg, err)
+	}
+
+	logger.Info("Retention policy updated")
+	return &task_pb.UpdateRetentionPolicyResponse{Policy: TransformRetentionPolicy(policy)}, nil
+}
+
+// DeleteRetentionPolicy reverts the user to the global retention policy
+func (s *Server) DeleteRetentionPolicy(ctx context.Context, req *task_pb.DeleteRetentionPolicyRequest) (*task_pb.DeleteRetentionPolicyResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "DeleteRetentionPolicy",
+	})
+	logger.Info("Received DeleteRetentionPolicy request")
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.Storage.DeleteRetentionPolicy(userID); err != nil {
+		errMsg := "Could not delete retention policy"
+		logger.WithError(err).Error(errMsg)
+		return nil, status.Errorf(codes.Internal, "%s: %v", errMsg, err)
+	}
+
+	logger.Info("Retention policy deleted")
+	return &task_pb.DeleteRetentionPolicyResponse{Policy: s.defaultRetentionPolicy()}, nil
+}
+
+func (s *Server) defaultRetentionPolicy() *task_pb.RetentionPolicy {
+	policy := TransformRetentionPolicy(&s.Retention)
+	policy.IsDefault = true
+	return policy
+}
+
+// RunRetention applies the retention policies and drops expired idempotency
+// keys every interval until ctx is done
+func (s *Server) RunRetention(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.ApplyRetention(time.Now()); err != nil {
+			s.Logger.WithError(err).Error("Failed to apply retention policies")
+		}
+		if _, err := s.Storage.DeleteExpiredIdempotencyRecords(time.Now()); err != nil {
+			s.Logger.WithError(err).Error("Failed to delete expired idempotency keys")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ApplyRetention archives and purges tasks once, following each user's
+// policy and the global policy for everyone else
+func (s *Server) ApplyRetention(now time.Time) error {
+	policies, err := s.Storage.ListRetentionPolicies()
+	if err != nil {
+		return err
+	}
+
+	var customUserIDs []string
+	for _, policy := range policies {
+		customUserIDs = append(customUserIDs, policy.UserID)
+		if err := s.applyRetentionPolicy(policy, storage.RetentionScope{UserID: policy.UserID}, now); err != nil {
+			return err
+		}
+	}
+	return s.applyRetentionPolicy(&s.Retention, storage.RetentionScope{ExcludeUserIDs: customUserIDs}, now)
+}
+
+func (s *Server) applyRetentionPolicy(policy *storage.RetentionPolicy, scope storage.RetentionScope, now time.Time) error {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"method":  "ApplyRetention",
+		"user_id": scope.UserID,
+	})
+
+	if policy.ArchiveAfterDays > 0 {
+		archived, err := s.Storage.ArchiveCompletedTasks(scope, now.AddDate(0, 0, -int(policy.ArchiveAfterDays)), now)
+		if err != nil {
+			return err
+		}
+		if archived > 0 {
+			logger.WithField("count", archived).Info("Archived completed tasks")
+		}
+	}
+	if policy.PurgeAfterDays > 0 {
+		purged, err := s.Storage.PurgeArchivedTasks(scope, now.AddDate(0, 0, -int(policy.PurgeAfterDays)))
+		if err != nil {
+			return err
+		}
+		if purged > 0 {
+			logger.WithField("count", purged).Info("Purged archived tasks")
+		}
+	}
+	return nil
+}
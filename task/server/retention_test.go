@@ -0,0 +1,74 @@
+package server_test
+
+import (
+	"time"
+
+	task_pb "github.com/sejamuchhal/taskhub/task/pb/task"
+	"github.com/sejamuchhal/taskhub/task/storage"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+func (s *ServerTestSuite) TestUnarchiveTask_Success() {
+	ctx := createTestContext()
+	archivedAt := time.Date(2024, 7, 30, 10, 0, 0, 0, time.UTC)
+
+	s.MockStorage.EXPECT().GetTaskByID("task-1").Return(&storage.Task{ID: "task-1", UserID: "1234", ArchivedAt: &archivedAt}, nil)
+	s.MockStorage.EXPECT().SetTaskArchived("task-1", nil).Return(nil)
+
+	resp, err := s.Server.UnarchiveTask(ctx, &task_pb.UnarchiveTaskRequest{Id: "task-1"})
+	s.NoError(err)
+	s.Equal("task-1", resp.Task.Id)
+	s.Nil(resp.Task.ArchivedAt)
+}
+
+func (s *ServerTestSuite) TestUnarchiveTask_NotArchived() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().GetTaskByID("task-1").Return(&storage.Task{ID: "task-1", UserID: "1234"}, nil)
+
+	resp, err := s.Server.UnarchiveTask(ctx, &task_pb.UnarchiveTaskRequest{Id: "task-1"})
+	s.Nil(resp)
+	s.Equal(codes.FailedPrecondition, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestGetRetentionPolicy_Default() {
+	ctx := createTestContext()
+	s.Server.Retention = storage.RetentionPolicy{ArchiveAfterDays: 30}
+	defer func() { s.Server.Retention = storage.RetentionPolicy{} }()
+
+	s.MockStorage.EXPECT().GetRetentionPolicy("1234").Return(&storage.RetentionPolicy{}, gorm.ErrRecordNotFound)
+
+	resp, err := s.Server.GetRetentionPolicy(ctx, &task_pb.GetRetentionPolicyRequest{})
+	s.NoError(err)
+	s.Equal(int32(30), resp.Policy.ArchiveAfterDays)
+	s.True(resp.Policy.IsDefault)
+}
+
+func (s *ServerTestSuite) TestUpdateRetentionPolicy_Invalid() {
+	ctx := createTestContext()
+
+	resp, err := s.Server.UpdateRetentionPolicy(ctx, &task_pb.UpdateRetentionPolicyRequest{
+		Policy: &task_pb.RetentionPolicy{ArchiveAfterDays: -1},
+	})
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestApplyRetention() {
+	now := time.Date(2024, 7, 30, 10, 0, 0, 0, time.UTC)
+	s.Server.Retention = storage.RetentionPolicy{ArchiveAfterDays: 30, PurgeAfterDays: 365}
+	defer func() { s.Server.Retention = storage.RetentionPolicy{} }()
+
+	s.MockStorage.EXPECT().ListRetentionPolicies().Return([]*storage.RetentionPolicy{
+		{UserID: "keep-all"},
+		{UserID: "weekly", ArchiveAfterDays: 7},
+	}, nil)
+	s.MockStorage.EXPECT().ArchiveCompletedTasks(storage.RetentionScope{UserID: "weekly"}, now.AddDate(0, 0, -7), now).Return(int64(2), nil)
+	global := storage.RetentionScope{ExcludeUserIDs: []string{"keep-all", "weekly"}}
+	s.MockStorage.EXPECT().ArchiveCompletedTasks(global, now.AddDate(0, 0, -30), now).Return(int64(5), nil)
+	s.MockStorage.EXPECT().PurgeArchivedTasks(global, now.AddDate(0, 0, -365)).Return(int64(1), nil)
+
+	s.NoError(s.Server.ApplyRetention(now))
+}
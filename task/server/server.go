@@ -1,6 +1,8 @@
 package server
 
 import (
+	"time"
+
 	amqp "github.com/rabbitmq/amqp091-go"
 	"github.com/sejamuchhal/taskhub/task/common"
 	"github.com/sejamuchhal/taskhub/task/events"
@@ -15,6 +17,10 @@ type Server struct {
 	Publisher events.RabbitMQBrokerInterface
 	Storage   storage.StorageInterface
 	Logger    *logrus.Entry
+	// Retention is the global retention policy
+	Retention storage.RetentionPolicy
+	// IdempotencyTTL is how long responses are kept for retries
+	IdempotencyTTL time.Duration
 }
 
 func NewServer(cfg *common.Config) (*Server, error) {
@@ -32,10 +38,21 @@ func NewServer(cfg *common.Config) (*Server, error) {
 		QueueName:  cfg.RMQQueue,
 	}
 
+	store, err := storage.New(cfg.DatabaseURL)
+	if err != nil {
+		logger.WithError(err).Error("Failed to open storage")
+		return nil, err
+	}
+
 	server := &Server{
 		Publisher: rmq,
-		Storage:   storage.New(),
+		Storage:   store,
 		Logger:    logger,
+		Retention: storage.RetentionPolicy{
+			ArchiveAfterDays: cfg.ArchiveAfterDays,
+			PurgeAfterDays:   cfg.PurgeAfterDays,
+		},
+		IdempotencyTTL: cfg.IdempotencyTTL,
 	}
 	return server, nil
 }
@@ -0,0 +1,88 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	task_pb "github.com/sejamuchhal/taskhub/task/pb/task"
+	"github.com/sejamuchhal/taskhub/task/storage"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const defaultStatsWindow = 30 * 24 * time.Hour
+
+// GetTaskStats returns productivity aggregates of the calling user. The
+// window defaults to the last 30 days, bucketed per day in UTC.
+func (s *Server) GetTaskStats(ctx context.Context, req *task_pb.GetTaskStatsRequest) (*task_pb.GetTaskStatsResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "GetTaskStats",
+	})
+	logger.Info("Received GetTaskStats request")
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	query := storage.TaskStatsQuery{
+		UserID:   userID,
+		To:       now,
+		Timezone: "UTC",
+		Interval: "day",
+		Now:      now,
+	}
+	if req.GetTo() != nil {
+		query.To = req.GetTo().AsTime()
+	}
+	query.From = query.To.Add(-defaultStatsWindow)
+	if req.GetFrom() != nil {
+		query.From = req.GetFrom().AsTime()
+	}
+	if !query.From.Before(query.To) {
+		return nil, status.Error(codes.InvalidArgument, "from must be before to")
+	}
+
+	switch req.GetInterval() {
+	case "", "day":
+	case "week":
+		query.Interval = "week"
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "Invalid interval %q, use day or week", req.GetInterval())
+	}
+
+	loc := time.UTC
+	if req.GetTimezone() != "" {
+		loc, err = time.LoadLocation(req.GetTimezone())
+		if err != nil {
+			logger.WithError(err).Error("Invalid timezone")
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid timezone: %s", req.GetTimezone())
+		}
+		query.Timezone = loc.String()
+	}
+
+	stats, err := s.Storage.GetTaskStats(query)
+	if err != nil {
+		errMsg := "Failed to compute task statistics"
+		logger.WithError(err).Error(errMsg)
+		return nil, status.Errorf(codes.Internal, "%s: %v", errMsg, err)
+	}
+
+	current, longest := CompletionStreaks(stats.CompletionDays, query.From.In(loc), query.To.In(loc))
+	res := &task_pb.GetTaskStatsResponse{
+		Timeline:                 MergeTimeline(stats.CreatedPerPeriod, stats.CompletedPerPeriod),
+		OverdueCount:             stats.OverdueCount,
+		AverageCompletionSeconds: int64(stats.AverageCompletionSeconds),
+		CurrentStreakDays:        current,
+		LongestStreakDays:        longest,
+	}
+	for _, c := range stats.ByStatus {
+		res.ByStatus = append(res.ByStatus, &task_pb.StatusCount{Status: c.Status, Count: c.Count})
+	}
+
+	logger.Info("GetTaskStats request processed successfully")
+	return res, nil
+}
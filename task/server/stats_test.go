@@ -0,0 +1,83 @@
+package server_test
+
+import (
+	"time"
+
+	task_pb "github.com/sejamuchhal/taskhub/task/pb/task"
+	"github.com/sejamuchhal/taskhub/task/storage"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *ServerTestSuite) TestGetTaskStats_Success() {
+	ctx := createTestContext()
+	from := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 7, 4, 0, 0, 0, 0, time.UTC)
+
+	s.MockStorage.EXPECT().GetTaskStats(gomock.Any()).DoAndReturn(func(query storage.TaskStatsQuery) (*storage.TaskStats, error) {
+		s.Equal("1234", query.UserID)
+		s.Equal("week", query.Interval)
+		s.Equal("Asia/Kolkata", query.Timezone)
+		return &storage.TaskStats{
+			ByStatus:                 []storage.StatusCount{{Status: "completed", Count: 2}, {Status: "pending", Count: 1}},
+			CreatedPerPeriod:         []storage.PeriodCount{{Period: "2024-07-01", Count: 3}},
+			CompletedPerPeriod:       []storage.PeriodCount{{Period: "2024-07-01", Count: 2}},
+			OverdueCount:             1,
+			AverageCompletionSeconds: 90.5,
+			CompletionDays:           []string{"2024-07-02", "2024-07-03"},
+		}, nil
+	})
+
+	resp, err := s.Server.GetTaskStats(ctx, &task_pb.GetTaskStatsRequest{
+		From:     timestamppb.New(from),
+		To:       timestamppb.New(to),
+		Timezone: "Asia/Kolkata",
+		Interval: "week",
+	})
+	s.NoError(err)
+	s.Len(resp.ByStatus, 2)
+	s.Equal([]*task_pb.TaskCountBucket{{Period: "2024-07-01", Created: 3, Completed: 2}}, resp.Timeline)
+	s.Equal(int64(1), resp.OverdueCount)
+	s.Equal(int64(90), resp.AverageCompletionSeconds)
+	s.Equal(int32(2), resp.CurrentStreakDays)
+	s.Equal(int32(2), resp.LongestStreakDays)
+}
+
+func (s *ServerTestSuite) TestGetTaskStats_InvalidInterval() {
+	ctx := createTestContext()
+
+	resp, err := s.Server.GetTaskStats(ctx, &task_pb.GetTaskStatsRequest{Interval: "month"})
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestGetTaskStats_InvalidTimezone() {
+	ctx := createTestContext()
+
+	resp, err := s.Server.GetTaskStats(ctx, &task_pb.GetTaskStatsRequest{Timezone: "Mars/Olympus"})
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestUpdateTask_SetsCompletedAt() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().GetTaskByID("task-1").Return(&storage.Task{ID: "task-1", UserID: "1234", Status: "pending"}, nil)
+	s.MockStorage.EXPECT().UpdateTask(gomock.Any()).DoAndReturn(func(task *storage.Task) error {
+		s.Equal("Write report", task.Title)
+		s.Equal("completed", task.Status)
+		s.NotNil(task.CompletedAt)
+		return nil
+	})
+	s.MockStorage.EXPECT().ListTaskWatchers("task-1").Return(nil, nil)
+
+	_, err := s.Server.UpdateTask(ctx, &task_pb.UpdateTaskRequest{Task: &task_pb.Task{
+		Id:      "task-1",
+		Title:   "Write report",
+		Status:  "completed",
+		DueDate: timestamppb.New(time.Now()),
+	}})
+	s.NoError(err)
+}
@@ -0,0 +1,330 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	task_pb "github.com/sejamuchhal/taskhub/task/pb/task"
+	"github.com/sejamuchhal/taskhub/task/storage"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// CreateTemplate saves a named template, either from scratch or from one of
+// the user's tasks
+func (s *Server) CreateTemplate(ctx context.Context, req *task_pb.CreateTemplateRequest) (*task_pb.CreateTemplateResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "CreateTemplate",
+	})
+	logger.Info("Received CreateTemplate request")
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	template := templateFromProto(req.GetTemplate())
+	template.ID = ""
+	template.UserID = userID
+
+	if req.GetTaskId() != "" {
+		task, err := s.getOwnedTask(logger, req.GetTaskId(), userID)
+		if err != nil {
+			return nil, err
+		}
+		template.Title = task.Title
+		template.Description = task.Description
+		template.Tags = task.Tags
+		template.EstimateMinutes = task.EstimateMinutes
+		template.DueOffsetMinutes = nil
+		if task.DueDate.After(time.Unix(0, 0)) {
+			offset := int64(task.DueDate.Sub(task.CreatedAt).Round(time.Minute) / time.Minute)
+			template.DueOffsetMinutes = &offset
+		}
+	}
+
+	if err := validateTemplate(template); err != nil {
+		return nil, err
+	}
+
+	err = s.Storage.CreateTemplate(template)
+	if err != nil {
+		if errors.Is(err, storage.ErrDuplicateTemplateName) {
+			logger.WithError(err).Warn("Duplicate template name")
+			return nil, status.Error(codes.AlreadyExists, "A template with this name already exists")
+		}
+		logger.WithError(err).Error("Could not insert template into the database")
+		return nil, status.Errorf(codes.Internal, "Could not insert template into the database: %v", err)
+	}
+
+	logger.WithField("template_id", template.ID).Info("Template created")
+	return &task_pb.CreateTemplateResponse{Template: TransformTemplate(template)}, nil
+}
+
+func (s *Server) GetTemplate(ctx context.Context, req *task_pb.GetTemplateRequest) (*task_pb.GetTemplateResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "GetTemplate",
+	})
+	logger.Info("Received GetTemplate request")
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := s.getOwnedTemplate(logger, req.GetId(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &task_pb.GetTemplateResponse{Template: TransformTemplate(template)}, nil
+}
+
+func (s *Server) ListTemplates(ctx context.Context, req *task_pb.ListTemplatesRequest) (*task_pb.ListTemplatesResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "ListTemplates",
+	})
+	logger.Info("Received ListTemplates request")
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := s.Storage.ListTemplates(userID)
+	if err != nil {
+		errMsg := "Failed to retrieve templates from the database"
+		logger.WithError(err).Error(errMsg)
+		return nil, status.Errorf(codes.Internal, "%s: %v", errMsg, err)
+	}
+
+	res := make([]*task_pb.TaskTemplate, len(templates))
+	for i, template := range templates {
+		res[i] = TransformTemplate(template)
+	}
+	return &task_pb.ListTemplatesResponse{Templates: res}, nil
+}
+
+func (s *Server) UpdateTemplate(ctx context.Context, req *task_pb.UpdateTemplateRequest) (*task_pb.UpdateTemplateResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "UpdateTemplate",
+	})
+	logger.Info("Received UpdateTemplate request")
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.getOwnedTemplate(logger, req.GetTemplate().GetId(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	template := templateFromProto(req.GetTemplate())
+	template.UserID = userID
+	template.CreatedAt = existing.CreatedAt
+	if err := validateTemplate(template); err != nil {
+		return nil, err
+	}
+
+	err = s.Storage.UpdateTemplate(template)
+	if err != nil {
+		if errors.Is(err, storage.ErrDuplicateTemplateName) {
+			logger.WithError(err).Warn("Duplicate template name")
+			return nil, status.Error(codes.AlreadyExists, "A template with this name already exists")
+		}
+		logger.WithError(err).Error("Could not update template in the database")
+		return nil, status.Errorf(codes.Internal, "Could not update template in the database: %v", err)
+	}
+
+	logger.Info("Template updated")
+	return &task_pb.UpdateTemplateResponse{Template: TransformTemplate(template)}, nil
+}
+
+func (s *Server) DeleteTemplate(ctx context.Context, req *task_pb.DeleteTemplateRequest) (*task_pb.DeleteTemplateResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "DeleteTemplate",
+	})
+	logger.Info("Received DeleteTemplate request")
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getOwnedTemplate(logger, req.GetId(), userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.Storage.DeleteTemplate(req.GetId()); err != nil {
+		logger.WithError(err).Error("Could not delete template from the database")
+		return nil, status.Errorf(codes.Internal, "Could not delete template from the database: %v", err)
+	}
+
+	logger.Info("Template deleted")
+	return &task_pb.DeleteTemplateResponse{}, nil
+}
+
+// InstantiateTemplate creates a task from a template. Checklist items are
+// added to the description as a markdown task list and the due date is
+// the template's offset from now.
+func (s *Server) InstantiateTemplate(ctx context.Context, req *task_pb.InstantiateTemplateRequest) (*task_pb.InstantiateTemplateResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "InstantiateTemplate",
+	})
+	logger.Info("Received InstantiateTemplate request")
+
+	email, err := ExtractUserEmail(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := s.getOwnedTemplate(logger, req.GetId(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := time.UTC
+	if req.GetTimezone() != "" {
+		loc, err = time.LoadLocation(req.GetTimezone())
+		if err != nil {
+			logger.WithError(err).Error("Invalid timezone")
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid timezone: %s", req.GetTimezone())
+		}
+	}
+
+	now := time.Now()
+	vars := map[string]string{"date": now.In(loc).Format(time.DateOnly)}
+	for name, value := range req.GetVariables() {
+		vars[name] = value
+	}
+
+	missing := map[string]bool{}
+	render := func(text string) string {
+		rendered, names := RenderTemplateText(text, vars)
+		for _, name := range names {
+			missing[name] = true
+		}
+		return rendered
+	}
+
+	title := render(template.Title)
+	description := render(template.Description)
+	checklist := make([]string, len(template.Checklist))
+	for i, item := range template.Checklist {
+		checklist[i] = render(item)
+	}
+	tags := make([]string, len(template.Tags))
+	for i, tag := range template.Tags {
+		tags[i] = render(tag)
+	}
+
+	if len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, status.Errorf(codes.InvalidArgument, "Missing template variables: %s", strings.Join(names, ", "))
+	}
+
+	dueDate := time.Unix(0, 0)
+	if template.DueOffsetMinutes != nil {
+		dueDate = now.Add(time.Duration(*template.DueOffsetMinutes) * time.Minute)
+	}
+
+	task := &storage.Task{
+		UserID:          userID,
+		Title:           title,
+		Description:     RenderChecklist(description, checklist),
+		DueDate:         dueDate,
+		EstimateMinutes: template.EstimateMinutes,
+		Tags:            NormalizeTags(tags),
+		UserEmail:       email,
+	}
+	reminders := dueDateReminders(task, template.ReminderOffsetsMinutes, now)
+	if err := s.Storage.CreateTaskWithReminders(task, reminders); err != nil {
+		logger.WithError(err).Error("Could not insert Task into the database")
+		return nil, status.Errorf(codes.Internal, "Could not insert Task into the database: %v", err)
+	}
+
+	logger.WithFields(logrus.Fields{
+		"task_id":     task.ID,
+		"template_id": template.ID,
+	}).Info("Task created from template")
+
+	return &task_pb.InstantiateTemplateResponse{Task: TransformTask(task)}, nil
+}
+
+func (s *Server) getOwnedTemplate(logger *logrus.Entry, templateID, userID string) (*storage.TaskTemplate, error) {
+	if templateID == "" {
+		return nil, status.Error(codes.InvalidArgument, "Template ID is required")
+	}
+
+	template, err := s.Storage.GetTemplateByID(templateID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.WithError(err).Error("Template not found")
+			return nil, status.Errorf(codes.NotFound, "Template not found: %v", err)
+		}
+		logger.WithError(err).Error("Failed to retrieve template from the database")
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve template from the database: %v", err)
+	}
+	if template.UserID != userID {
+		logger.Warn("Template belongs to another user")
+		return nil, status.Error(codes.NotFound, "Template not found")
+	}
+	return template, nil
+}
+
+func templateFromProto(template *task_pb.TaskTemplate) *storage.TaskTemplate {
+	return &storage.TaskTemplate{
+		ID:                     template.GetId(),
+		Name:                   strings.TrimSpace(template.GetName()),
+		Title:                  template.GetTitle(),
+		Description:            template.GetDescription(),
+		Checklist:              template.GetChecklist(),
+		Tags:                   NormalizeTags(template.GetTags()),
+		DueOffsetMinutes:       template.DueOffsetMinutes,
+		ReminderOffsetsMinutes: template.GetReminderOffsetsMinutes(),
+		EstimateMinutes:        template.GetEstimateMinutes(),
+	}
+}
+
+func validateTemplate(template *storage.TaskTemplate) error {
+	if template.Name == "" {
+		return status.Error(codes.InvalidArgument, "Template name is required")
+	}
+	if template.Title == "" {
+		return status.Error(codes.InvalidArgument, "Title is required")
+	}
+	if template.EstimateMinutes < 0 {
+		return status.Error(codes.InvalidArgument, "Estimate must not be negative")
+	}
+	if len(template.ReminderOffsetsMinutes) > 0 && template.DueOffsetMinutes == nil {
+		return status.Error(codes.InvalidArgument, "Reminders need a due offset")
+	}
+	for _, offset := range template.ReminderOffsetsMinutes {
+		if offset < 0 {
+			return status.Error(codes.InvalidArgument, "Reminder offsets must not be negative")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,151 @@
+package server_test
+
+import (
+	"errors"
+	"time"
+
+	task_pb "github.com/sejamuchhal/taskhub/task/pb/task"
+	"github.com/sejamuchhal/taskhub/task/storage"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *ServerTestSuite) TestCreateTemplate_FromTask() {
+	ctx := createTestContext()
+	createdAt := time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC)
+
+	s.MockStorage.EXPECT().GetTaskByID("task-1").Return(&storage.Task{
+		ID:          "task-1",
+		UserID:      "1234",
+		Title:       "Release {{version}}",
+		Description: "Ship it",
+		Tags:        []string{"release"},
+		DueDate:     createdAt.Add(48 * time.Hour),
+		CreatedAt:   createdAt,
+	}, nil)
+	s.MockStorage.EXPECT().CreateTemplate(gomock.Any()).DoAndReturn(func(template *storage.TaskTemplate) error {
+		s.Equal("1234", template.UserID)
+		s.Equal("Release checklist", template.Name)
+		s.Equal("Release {{version}}", template.Title)
+		s.Equal([]string{"release"}, template.Tags)
+		s.Equal(int64(48*60), *template.DueOffsetMinutes)
+		s.Equal([]string{"Tag the build"}, template.Checklist)
+		return nil
+	})
+
+	resp, err := s.Server.CreateTemplate(ctx, &task_pb.CreateTemplateRequest{
+		TaskId: "task-1",
+		Template: &task_pb.TaskTemplate{
+			Name:      "Release checklist",
+			Checklist: []string{"Tag the build"},
+		},
+	})
+	s.NoError(err)
+	s.Equal("Release checklist", resp.Template.Name)
+}
+
+func (s *ServerTestSuite) TestCreateTemplate_DuplicateName() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().CreateTemplate(gomock.Any()).Return(storage.ErrDuplicateTemplateName)
+
+	resp, err := s.Server.CreateTemplate(ctx, &task_pb.CreateTemplateRequest{
+		Template: &task_pb.TaskTemplate{Name: "Onboarding", Title: "Onboard {{name}}"},
+	})
+	s.Nil(resp)
+	s.Equal(codes.AlreadyExists, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestCreateTemplate_RemindersWithoutDueOffset() {
+	ctx := createTestContext()
+
+	resp, err := s.Server.CreateTemplate(ctx, &task_pb.CreateTemplateRequest{
+		Template: &task_pb.TaskTemplate{Name: "Onboarding", Title: "Onboard", ReminderOffsetsMinutes: []int64{60}},
+	})
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestInstantiateTemplate_Success() {
+	ctx := createTestContext()
+	offset := int64(24 * 60)
+
+	s.MockStorage.EXPECT().GetTemplateByID("template-1").Return(&storage.TaskTemplate{
+		ID:               "template-1",
+		UserID:           "1234",
+		Name:             "Onboarding",
+		Title:            "Onboard {{client}}",
+		Description:      "Kick-off on {{date}}",
+		Checklist:        []string{"Create {{client}} workspace", "Send welcome mail"},
+		Tags:             []string{"onboarding", "{{client}}"},
+		DueOffsetMinutes: &offset,
+		// The reminder two days ahead would already be due
+		ReminderOffsetsMinutes: []int64{60, 2 * 24 * 60},
+	}, nil)
+	s.MockStorage.EXPECT().CreateTaskWithReminders(gomock.Any(), gomock.Any()).DoAndReturn(func(task *storage.Task, reminders []*storage.TaskReminder) error {
+		s.Equal("1234", task.UserID)
+		s.Equal("Onboard Acme", task.Title)
+		s.Equal("Kick-off on "+time.Now().UTC().Format(time.DateOnly)+"\n\n- [ ] Create Acme workspace\n- [ ] Send welcome mail", task.Description)
+		s.Equal([]string{"onboarding", "Acme"}, task.Tags)
+		s.WithinDuration(time.Now().Add(24*time.Hour), task.DueDate, time.Minute)
+		s.Equal("user@example.com", task.UserEmail)
+		s.Require().Len(reminders, 1)
+		s.Equal(int64(60), reminders[0].OffsetMinutes)
+		s.Equal(task.DueDate.Add(-time.Hour), reminders[0].RemindAt)
+		task.ID = "task-1"
+		return nil
+	})
+
+	resp, err := s.Server.InstantiateTemplate(ctx, &task_pb.InstantiateTemplateRequest{
+		Id:        "template-1",
+		Variables: map[string]string{"client": "Acme"},
+	})
+	s.NoError(err)
+	s.Equal("task-1", resp.Task.Id)
+}
+
+func (s *ServerTestSuite) TestInstantiateTemplate_StorageError() {
+	ctx := createTestContext()
+	offset := int64(24 * 60)
+
+	s.MockStorage.EXPECT().GetTemplateByID("template-1").Return(&storage.TaskTemplate{
+		ID:                     "template-1",
+		UserID:                 "1234",
+		Title:                  "Onboard",
+		DueOffsetMinutes:       &offset,
+		ReminderOffsetsMinutes: []int64{60},
+	}, nil)
+	// The task and its reminders go in together, or not at all
+	s.MockStorage.EXPECT().CreateTaskWithReminders(gomock.Any(), gomock.Len(1)).Return(errors.New("connection reset"))
+
+	resp, err := s.Server.InstantiateTemplate(ctx, &task_pb.InstantiateTemplateRequest{Id: "template-1"})
+	s.Nil(resp)
+	s.Equal(codes.Internal, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestInstantiateTemplate_MissingVariables() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().GetTemplateByID("template-1").Return(&storage.TaskTemplate{
+		ID:          "template-1",
+		UserID:      "1234",
+		Title:       "Onboard {{client}}",
+		Description: "Contact {{ owner }}",
+	}, nil)
+
+	resp, err := s.Server.InstantiateTemplate(ctx, &task_pb.InstantiateTemplateRequest{Id: "template-1"})
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+	s.Contains(err.Error(), "client, owner")
+}
+
+func (s *ServerTestSuite) TestGetTemplate_OtherUser() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().GetTemplateByID("template-1").Return(&storage.TaskTemplate{ID: "template-1", UserID: "5678"}, nil)
+
+	resp, err := s.Server.GetTemplate(ctx, &task_pb.GetTemplateRequest{Id: "template-1"})
+	s.Nil(resp)
+	s.Equal(codes.NotFound, status.Code(err))
+}
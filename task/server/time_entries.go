@@ -0,0 +1,389 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	task_pb "github.com/sejamuchhal/taskhub/task/pb/task"
+	"github.com/sejamuchhal/taskhub/task/storage"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// StartTimer starts tracking time against a task. A user can only have one
+// running timer at a time.
+func (s *Server) StartTimer(ctx context.Context, req *task_pb.StartTimerRequest) (*task_pb.StartTimerResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "StartTimer",
+	})
+	logger.Info("Received StartTimer request")
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getOwnedTask(logger, req.GetTaskId(), userID); err != nil {
+		return nil, err
+	}
+
+	entry := &storage.TimeEntry{
+		TaskID:    req.GetTaskId(),
+		UserID:    userID,
+		StartedAt: time.Now(),
+		Note:      req.GetNote(),
+	}
+	err = s.Storage.StartTimer(entry)
+	if err != nil {
+		if errors.Is(err, storage.ErrTimerAlreadyRunning) {
+			logger.WithError(err).Warn("Timer already running")
+			return nil, status.Error(codes.FailedPrecondition, "A timer is already running, stop it first")
+		}
+		logger.WithError(err).Error("Could not start timer")
+		return nil, status.Errorf(codes.Internal, "Could not start timer: %v", err)
+	}
+
+	logger.WithField("time_entry_id", entry.ID).Info("Timer started")
+	return &task_pb.StartTimerResponse{Entry: TransformTimeEntry(entry)}, nil
+}
+
+// StopTimer stops the running timer of the calling user
+func (s *Server) StopTimer(ctx context.Context, req *task_pb.StopTimerRequest) (*task_pb.StopTimerResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "StopTimer",
+	})
+	logger.Info("Received StopTimer request")
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := s.Storage.StopTimer(userID, time.Now())
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.WithError(err).Warn("No running timer")
+			return nil, status.Error(codes.FailedPrecondition, "No timer is running")
+		}
+		logger.WithError(err).Error("Could not stop timer")
+		return nil, status.Errorf(codes.Internal, "Could not stop timer: %v", err)
+	}
+
+	logger.WithField("time_entry_id", entry.ID).Info("Timer stopped")
+	return &task_pb.StopTimerResponse{Entry: TransformTimeEntry(entry)}, nil
+}
+
+// CreateTimeEntry records a manually entered, already finished time entry
+func (s *Server) CreateTimeEntry(ctx context.Context, req *task_pb.CreateTimeEntryRequest) (*task_pb.CreateTimeEntryResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "CreateTimeEntry",
+	})
+	logger.Info("Received CreateTimeEntry request")
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &storage.TimeEntry{
+		TaskID: req.GetEntry().GetTaskId(),
+		UserID: userID,
+		Note:   req.GetEntry().GetNote(),
+	}
+	if err := setTimeEntrySpan(entry, req.GetEntry()); err != nil {
+		logger.WithError(err).Error("Invalid time entry")
+		return nil, err
+	}
+
+	if _, err := s.getOwnedTask(logger, entry.TaskID, userID); err != nil {
+		return nil, err
+	}
+
+	err = s.Storage.CreateTimeEntry(entry)
+	if err != nil {
+		logger.WithError(err).Error("Could not insert time entry into the database")
+		return nil, status.Errorf(codes.Internal, "Could not insert time entry into the database: %v", err)
+	}
+
+	logger.WithField("time_entry_id", entry.ID).Info("Time entry created")
+	return &task_pb.CreateTimeEntryResponse{Entry: TransformTimeEntry(entry)}, nil
+}
+
+func (s *Server) ListTimeEntries(ctx context.Context, req *task_pb.ListTimeEntriesRequest) (*task_pb.ListTimeEntriesResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "ListTimeEntries",
+	})
+	logger.Info("Received ListTimeEntries request")
+
+	if req.Limit == 0 {
+		req.Limit = 10
+	}
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := storage.TimeEntryFilter{
+		UserID: userID,
+		TaskID: req.GetTaskId(),
+	}
+	if req.GetFrom() != nil {
+		filter.From = req.GetFrom().AsTime()
+	}
+	if req.GetTo() != nil {
+		filter.To = req.GetTo().AsTime()
+	}
+
+	entries, count, err := s.Storage.ListTimeEntriesWithCount(filter, int(req.Limit), int(req.Offset))
+	if err != nil {
+		errMsg := "Failed to list time entries from the database"
+		logger.WithError(err).Error(errMsg)
+		return nil, status.Errorf(codes.Internal, "%s: %v", errMsg, err)
+	}
+
+	pbEntries := make([]*task_pb.TimeEntry, len(entries))
+	for i, entry := range entries {
+		pbEntries[i] = TransformTimeEntry(entry)
+	}
+
+	return &task_pb.ListTimeEntriesResponse{
+		Entries:    pbEntries,
+		TotalCount: count,
+	}, nil
+}
+
+// UpdateTimeEntry edits the span or note of an entry. Every edit is kept as a
+// revision.
+func (s *Server) UpdateTimeEntry(ctx context.Context, req *task_pb.UpdateTimeEntryRequest) (*task_pb.UpdateTimeEntryResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "UpdateTimeEntry",
+	})
+	logger.Info("Received UpdateTimeEntry request")
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := s.getOwnedTimeEntry(logger, req.GetEntry().GetId(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.EndedAt == nil && req.GetEntry().GetEndedAt() == nil {
+		// Keep a running timer running while its note or start is edited
+		if req.GetEntry().GetStartedAt() != nil {
+			entry.StartedAt = req.GetEntry().GetStartedAt().AsTime()
+		}
+	} else if err := setTimeEntrySpan(entry, req.GetEntry()); err != nil {
+		logger.WithError(err).Error("Invalid time entry")
+		return nil, err
+	}
+	entry.Note = req.GetEntry().GetNote()
+
+	err = s.Storage.UpdateTimeEntry(entry)
+	if err != nil {
+		logger.WithError(err).Error("Could not update time entry in the database")
+		return nil, status.Errorf(codes.Internal, "Could not update time entry in the database: %v", err)
+	}
+
+	logger.WithField("time_entry_id", entry.ID).Info("Time entry updated")
+	return &task_pb.UpdateTimeEntryResponse{Entry: TransformTimeEntry(entry)}, nil
+}
+
+func (s *Server) DeleteTimeEntry(ctx context.Context, req *task_pb.DeleteTimeEntryRequest) (*task_pb.DeleteTimeEntryResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "DeleteTimeEntry",
+	})
+	logger.Info("Received DeleteTimeEntry request")
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getOwnedTimeEntry(logger, req.GetId(), userID); err != nil {
+		return nil, err
+	}
+
+	err = s.Storage.DeleteTimeEntry(req.GetId())
+	if err != nil {
+		logger.WithError(err).Error("Could not delete time entry from the database")
+		return nil, status.Errorf(codes.Internal, "Could not delete time entry from the database: %v", err)
+	}
+
+	logger.WithField("time_entry_id", req.GetId()).Info("Time entry deleted")
+	return &task_pb.DeleteTimeEntryResponse{}, nil
+}
+
+// ListTimeEntryRevisions returns the audit trail of a time entry
+func (s *Server) ListTimeEntryRevisions(ctx context.Context, req *task_pb.ListTimeEntryRevisionsRequest) (*task_pb.ListTimeEntryRevisionsResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "ListTimeEntryRevisions",
+	})
+	logger.Info("Received ListTimeEntryRevisions request")
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := s.Storage.ListTimeEntryRevisions(req.GetId())
+	if err != nil {
+		errMsg := "Failed to list time entry revisions from the database"
+		logger.WithError(err).Error(errMsg)
+		return nil, status.Errorf(codes.Internal, "%s: %v", errMsg, err)
+	}
+	// Revisions outlive deleted entries, so ownership is checked on them
+	if len(revisions) == 0 || revisions[0].UserID != userID {
+		logger.Warn("Time entry not found")
+		return nil, status.Error(codes.NotFound, "Time entry not found")
+	}
+
+	pbRevisions := make([]*task_pb.TimeEntryRevision, len(revisions))
+	for i, revision := range revisions {
+		pbRevisions[i] = TransformTimeEntryRevision(revision)
+	}
+	return &task_pb.ListTimeEntryRevisionsResponse{Revisions: pbRevisions}, nil
+}
+
+// GetTimeReport aggregates the tracked time of the calling user per day, per
+// task, per tag and optionally per project over [from, to)
+func (s *Server) GetTimeReport(ctx context.Context, req *task_pb.GetTimeReportRequest) (*task_pb.GetTimeReportResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "GetTimeReport",
+	})
+	logger.Info("Received GetTimeReport request")
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetFrom() == nil || req.GetTo() == nil {
+		return nil, status.Error(codes.InvalidArgument, "from and to are required")
+	}
+	from, to := req.GetFrom().AsTime(), req.GetTo().AsTime()
+	if !from.Before(to) {
+		return nil, status.Error(codes.InvalidArgument, "from must be before to")
+	}
+	if req.GetProjectFieldId() != "" {
+		field, err := s.getOwnedCustomField(logger, req.GetProjectFieldId(), userID)
+		if err != nil {
+			return nil, err
+		}
+		if field.Type != storage.CustomFieldTypeText && field.Type != storage.CustomFieldTypeSingleSelect {
+			return nil, status.Error(codes.InvalidArgument, "The project field must be a text or single select field")
+		}
+	}
+
+	loc := time.UTC
+	if req.GetTimezone() != "" {
+		loc, err = time.LoadLocation(req.GetTimezone())
+		if err != nil {
+			logger.WithError(err).Error("Invalid timezone")
+			return nil, status.Errorf(codes.InvalidArgument, "Invalid timezone: %s", req.GetTimezone())
+		}
+	}
+
+	entries, _, err := s.Storage.ListTimeEntriesWithCount(storage.TimeEntryFilter{
+		UserID:      userID,
+		From:        from,
+		To:          to,
+		Overlapping: true,
+	}, -1, 0)
+	if err != nil {
+		errMsg := "Failed to list time entries from the database"
+		logger.WithError(err).Error(errMsg)
+		return nil, status.Errorf(codes.Internal, "%s: %v", errMsg, err)
+	}
+
+	var taskIDs []string
+	seen := map[string]bool{}
+	for _, entry := range entries {
+		if !seen[entry.TaskID] {
+			seen[entry.TaskID] = true
+			taskIDs = append(taskIDs, entry.TaskID)
+		}
+	}
+	// Entries of deleted tasks are still billable, they are just missing here
+	found, err := s.Storage.GetTasksByIDs(taskIDs)
+	if err != nil {
+		errMsg := "Failed to retrieve tasks from the database"
+		logger.WithError(err).Error(errMsg)
+		return nil, status.Errorf(codes.Internal, "%s: %v", errMsg, err)
+	}
+	tasks := make(map[string]*storage.Task, len(found))
+	for _, task := range found {
+		tasks[task.ID] = task
+	}
+
+	return BuildTimeReport(entries, tasks, req.GetProjectFieldId(), loc, from, to, time.Now()), nil
+}
+
+func (s *Server) getOwnedTask(logger *logrus.Entry, taskID, userID string) (*storage.Task, error) {
+	if taskID == "" {
+		return nil, status.Error(codes.InvalidArgument, "Task ID is required")
+	}
+
+	task, err := s.Storage.GetTaskByID(taskID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.WithError(err).Error("Task not found")
+			return nil, status.Errorf(codes.NotFound, "Task not found: %v", err)
+		}
+		logger.WithError(err).Error("Failed to retrieve Task from the database")
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve Task from the database: %v", err)
+	}
+	if task.UserID != userID {
+		logger.Warn("Task belongs to another user")
+		return nil, status.Error(codes.NotFound, "Task not found")
+	}
+	return task, nil
+}
+
+func (s *Server) getOwnedTimeEntry(logger *logrus.Entry, entryID, userID string) (*storage.TimeEntry, error) {
+	if entryID == "" {
+		return nil, status.Error(codes.InvalidArgument, "Time entry ID is required")
+	}
+
+	entry, err := s.Storage.GetTimeEntryByID(entryID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.WithError(err).Error("Time entry not found")
+			return nil, status.Errorf(codes.NotFound, "Time entry not found: %v", err)
+		}
+		logger.WithError(err).Error("Failed to retrieve time entry from the database")
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve time entry from the database: %v", err)
+	}
+	if entry.UserID != userID {
+		logger.Warn("Time entry belongs to another user")
+		return nil, status.Error(codes.NotFound, "Time entry not found")
+	}
+	return entry, nil
+}
+
+func setTimeEntrySpan(entry *storage.TimeEntry, req *task_pb.TimeEntry) error {
+	if req.GetStartedAt() == nil || req.GetEndedAt() == nil {
+		return status.Error(codes.InvalidArgument, "started_at and ended_at are required")
+	}
+	startedAt, endedAt := req.GetStartedAt().AsTime(), req.GetEndedAt().AsTime()
+	if !startedAt.Before(endedAt) {
+		return status.Error(codes.InvalidArgument, "started_at must be before ended_at")
+	}
+	entry.StartedAt = startedAt
+	entry.EndedAt = &endedAt
+	return nil
+}
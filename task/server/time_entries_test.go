@@ -0,0 +1,130 @@
+package server_test
+
+import (
+	"time"
+
+	task_pb "github.com/sejamuchhal/taskhub/task/pb/task"
+	"github.com/sejamuchhal/taskhub/task/storage"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+func (s *ServerTestSuite) TestStartTimer_Success() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().GetTaskByID("task-1").Return(&storage.Task{ID: "task-1", UserID: "1234"}, nil)
+	s.MockStorage.EXPECT().StartTimer(gomock.Any()).Return(nil)
+
+	resp, err := s.Server.StartTimer(ctx, &task_pb.StartTimerRequest{TaskId: "task-1"})
+	s.NoError(err)
+	s.Equal("task-1", resp.Entry.TaskId)
+	s.True(resp.Entry.Running)
+}
+
+func (s *ServerTestSuite) TestStartTimer_AlreadyRunning() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().GetTaskByID("task-1").Return(&storage.Task{ID: "task-1", UserID: "1234"}, nil)
+	s.MockStorage.EXPECT().StartTimer(gomock.Any()).Return(storage.ErrTimerAlreadyRunning)
+
+	resp, err := s.Server.StartTimer(ctx, &task_pb.StartTimerRequest{TaskId: "task-1"})
+	s.Nil(resp)
+	s.Equal(codes.FailedPrecondition, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestStartTimer_OtherUsersTask() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().GetTaskByID("task-1").Return(&storage.Task{ID: "task-1", UserID: "5678"}, nil)
+
+	resp, err := s.Server.StartTimer(ctx, &task_pb.StartTimerRequest{TaskId: "task-1"})
+	s.Nil(resp)
+	s.Equal(codes.NotFound, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestStopTimer_NoRunningTimer() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().StopTimer("1234", gomock.Any()).Return(nil, gorm.ErrRecordNotFound)
+
+	resp, err := s.Server.StopTimer(ctx, &task_pb.StopTimerRequest{})
+	s.Nil(resp)
+	s.Equal(codes.FailedPrecondition, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestCreateTimeEntry_InvalidSpan() {
+	ctx := createTestContext()
+	now := time.Now()
+
+	resp, err := s.Server.CreateTimeEntry(ctx, &task_pb.CreateTimeEntryRequest{
+		Entry: &task_pb.TimeEntry{
+			TaskId:    "task-1",
+			StartedAt: timestamppb.New(now),
+			EndedAt:   timestamppb.New(now.Add(-time.Hour)),
+		},
+	})
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestUpdateTimeEntry_OtherUsersEntry() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().GetTimeEntryByID("entry-1").Return(&storage.TimeEntry{ID: "entry-1", UserID: "5678"}, nil)
+
+	resp, err := s.Server.UpdateTimeEntry(ctx, &task_pb.UpdateTimeEntryRequest{
+		Entry: &task_pb.TimeEntry{Id: "entry-1", Note: "billable"},
+	})
+	s.Nil(resp)
+	s.Equal(codes.NotFound, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestGetTimeReport_LoadsTasksOnce() {
+	ctx := createTestContext()
+	from := time.Date(2024, 7, 29, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+	end := from.Add(time.Hour)
+
+	s.MockStorage.EXPECT().GetCustomFieldByID("project").Return(&storage.CustomField{ID: "project", UserID: "1234", Type: storage.CustomFieldTypeSingleSelect}, nil)
+	s.MockStorage.EXPECT().ListTimeEntriesWithCount(storage.TimeEntryFilter{UserID: "1234", From: from, To: to, Overlapping: true}, -1, 0).Return([]*storage.TimeEntry{
+		{TaskID: "task-1", StartedAt: from, EndedAt: &end},
+		{TaskID: "task-2", StartedAt: from, EndedAt: &end},
+		{TaskID: "task-1", StartedAt: from, EndedAt: &end},
+	}, int64(3), nil)
+	s.MockStorage.EXPECT().GetTasksByIDs([]string{"task-1", "task-2"}).Return([]*storage.Task{
+		{ID: "task-1", Title: "Design", Tags: []string{"acme"}, CustomFields: storage.CustomFieldValues{"project": "Website"}},
+	}, nil)
+
+	resp, err := s.Server.GetTimeReport(ctx, &task_pb.GetTimeReportRequest{
+		From:           timestamppb.New(from),
+		To:             timestamppb.New(to),
+		ProjectFieldId: "project",
+	})
+	s.Require().NoError(err)
+	s.Equal(int64(3*3600), resp.TotalSeconds)
+	s.Require().Len(resp.ByTask, 2)
+	s.Equal("Design", resp.ByTask[0].Label)
+	s.Require().Len(resp.ByTag, 1)
+	s.Equal("acme", resp.ByTag[0].Key)
+	s.Equal(int64(2*3600), resp.ByTag[0].DurationSeconds)
+	s.Require().Len(resp.ByProject, 1)
+	s.Equal("Website", resp.ByProject[0].Key)
+}
+
+func (s *ServerTestSuite) TestGetTimeReport_InvalidProjectField() {
+	ctx := createTestContext()
+	from := time.Date(2024, 7, 29, 0, 0, 0, 0, time.UTC)
+
+	s.MockStorage.EXPECT().GetCustomFieldByID("points").Return(&storage.CustomField{ID: "points", UserID: "1234", Type: storage.CustomFieldTypeNumber}, nil)
+
+	resp, err := s.Server.GetTimeReport(ctx, &task_pb.GetTimeReportRequest{
+		From:           timestamppb.New(from),
+		To:             timestamppb.New(from.AddDate(0, 0, 1)),
+		ProjectFieldId: "points",
+	})
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,331 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	task_pb "github.com/sejamuchhal/taskhub/task/pb/task"
+	"github.com/sejamuchhal/taskhub/task/storage"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// CreateView saves a named task query
+func (s *Server) CreateView(ctx context.Context, req *task_pb.CreateViewRequest) (*task_pb.CreateViewResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "CreateView",
+	})
+	logger.Info("Received CreateView request")
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	view := viewFromProto(req.GetView())
+	view.ID = ""
+	view.UserID = userID
+	if err := s.validateView(logger, view); err != nil {
+		return nil, err
+	}
+
+	err = s.Storage.CreateSavedView(view)
+	if err != nil {
+		if errors.Is(err, storage.ErrDuplicateViewName) {
+			logger.WithError(err).Warn("Duplicate view name")
+			return nil, status.Error(codes.AlreadyExists, "A view with this name already exists")
+		}
+		logger.WithError(err).Error("Could not insert view into the database")
+		return nil, status.Errorf(codes.Internal, "Could not insert view into the database: %v", err)
+	}
+
+	logger.WithField("view_id", view.ID).Info("View created")
+	return &task_pb.CreateViewResponse{View: TransformView(view)}, nil
+}
+
+func (s *Server) GetView(ctx context.Context, req *task_pb.GetViewRequest) (*task_pb.GetViewResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "GetView",
+	})
+	logger.Info("Received GetView request")
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	view, err := s.getVisibleView(logger, req.GetId(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &task_pb.GetViewResponse{View: TransformView(view)}, nil
+}
+
+func (s *Server) ListViews(ctx context.Context, req *task_pb.ListViewsRequest) (*task_pb.ListViewsResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "ListViews",
+	})
+	logger.Info("Received ListViews request")
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	views, err := s.Storage.ListSavedViews(userID)
+	if err != nil {
+		errMsg := "Failed to retrieve views from the database"
+		logger.WithError(err).Error(errMsg)
+		return nil, status.Errorf(codes.Internal, "%s: %v", errMsg, err)
+	}
+
+	res := make([]*task_pb.SavedView, len(views))
+	for i, view := range views {
+		res[i] = TransformView(view)
+	}
+	return &task_pb.ListViewsResponse{Views: res}, nil
+}
+
+func (s *Server) UpdateView(ctx context.Context, req *task_pb.UpdateViewRequest) (*task_pb.UpdateViewResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "UpdateView",
+	})
+	logger.Info("Received UpdateView request")
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.getOwnedView(logger, req.GetView().GetId(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	view := viewFromProto(req.GetView())
+	view.UserID = userID
+	view.CreatedAt = existing.CreatedAt
+	if err := s.validateView(logger, view); err != nil {
+		return nil, err
+	}
+
+	err = s.Storage.UpdateSavedView(view)
+	if err != nil {
+		if errors.Is(err, storage.ErrDuplicateViewName) {
+			logger.WithError(err).Warn("Duplicate view name")
+			return nil, status.Error(codes.AlreadyExists, "A view with this name already exists")
+		}
+		logger.WithError(err).Error("Could not update view in the database")
+		return nil, status.Errorf(codes.Internal, "Could not update view in the database: %v", err)
+	}
+
+	logger.Info("View updated")
+	return &task_pb.UpdateViewResponse{View: TransformView(view)}, nil
+}
+
+func (s *Server) DeleteView(ctx context.Context, req *task_pb.DeleteViewRequest) (*task_pb.DeleteViewResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "DeleteView",
+	})
+	logger.Info("Received DeleteView request")
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getOwnedView(logger, req.GetId(), userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.Storage.DeleteSavedView(req.GetId()); err != nil {
+		logger.WithError(err).Error("Could not delete view from the database")
+		return nil, status.Errorf(codes.Internal, "Could not delete view from the database: %v", err)
+	}
+
+	logger.Info("View deleted")
+	return &task_pb.DeleteViewResponse{}, nil
+}
+
+// ListViewTasks lists the tasks of the caller matching a saved view, grouped
+// if the view has a grouping. Groups only cover the requested page.
+func (s *Server) ListViewTasks(ctx context.Context, req *task_pb.ListViewTasksRequest) (*task_pb.ListViewTasksResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "ListViewTasks",
+	})
+	logger.Info("Received ListViewTasks request")
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	view, err := s.getVisibleView(logger, req.GetId(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	listReq := viewListTasksRequest(view)
+	listReq.Limit = req.GetLimit()
+	listReq.Offset = req.GetOffset()
+	listReq.Timezone = req.GetTimezone()
+
+	tasks, err := s.listTasks(logger, userID, listReq)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &task_pb.ListViewTasksResponse{
+		View:       TransformView(view),
+		Tasks:      tasks.GetTasks(),
+		TotalCount: tasks.GetTotalCount(),
+	}
+	if view.GroupBy != "" {
+		res.Groups = GroupTasks(res.Tasks, view.GroupBy)
+	}
+	return res, nil
+}
+
+// getVisibleView returns a view of the user or one shared with the workspace
+func (s *Server) getVisibleView(logger *logrus.Entry, viewID, userID string) (*storage.SavedView, error) {
+	if viewID == "" {
+		return nil, status.Error(codes.InvalidArgument, "View ID is required")
+	}
+
+	view, err := s.Storage.GetSavedViewByID(viewID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.WithError(err).Error("View not found")
+			return nil, status.Errorf(codes.NotFound, "View not found: %v", err)
+		}
+		logger.WithError(err).Error("Failed to retrieve view from the database")
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve view from the database: %v", err)
+	}
+	if view.UserID != userID && view.Visibility != storage.ViewVisibilityWorkspace {
+		logger.Warn("View belongs to another user")
+		return nil, status.Error(codes.NotFound, "View not found")
+	}
+	return view, nil
+}
+
+// getOwnedView returns a view the user may change
+func (s *Server) getOwnedView(logger *logrus.Entry, viewID, userID string) (*storage.SavedView, error) {
+	view, err := s.getVisibleView(logger, viewID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if view.UserID != userID {
+		logger.Warn("Shared view belongs to another user")
+		return nil, status.Error(codes.PermissionDenied, "Only the owner can change a shared view")
+	}
+	return view, nil
+}
+
+// validateView checks the query of a view by building its task filter, the
+// same way it is built when the view is listed
+func (s *Server) validateView(logger *logrus.Entry, view *storage.SavedView) error {
+	if view.Name == "" {
+		return status.Error(codes.InvalidArgument, "View name is required")
+	}
+
+	switch view.Visibility {
+	case "":
+		view.Visibility = storage.ViewVisibilityPrivate
+	case storage.ViewVisibilityPrivate:
+	case storage.ViewVisibilityWorkspace:
+		// Custom fields belong to their owner, other users could not list the view
+		usesCustomFields := len(view.Query.CustomFields) > 0 || view.Query.SortFieldID != "" ||
+			(view.GroupBy != "" && view.GroupBy != "status" && view.GroupBy != "tag")
+		if usesCustomFields {
+			return status.Error(codes.InvalidArgument, "Views shared with the workspace cannot use custom fields")
+		}
+	default:
+		return status.Errorf(codes.InvalidArgument, "visibility must be %s or %s", storage.ViewVisibilityPrivate, storage.ViewVisibilityWorkspace)
+	}
+
+	if _, err := s.taskFilter(logger, view.UserID, viewListTasksRequest(view), time.Now()); err != nil {
+		return err
+	}
+
+	switch view.GroupBy {
+	case "", "status", "tag":
+		return nil
+	}
+	fields, err := s.getCustomFields(logger, view.UserID)
+	if err != nil {
+		return err
+	}
+	if _, ok := fields[view.GroupBy]; !ok {
+		return status.Errorf(codes.InvalidArgument, "group_by must be status, tag or a custom field ID")
+	}
+	return nil
+}
+
+func viewFromProto(view *task_pb.SavedView) *storage.SavedView {
+	query := view.GetQuery()
+	var filters []storage.TaskQueryFieldFilter
+	for _, filter := range query.GetCustomFieldFilters() {
+		filters = append(filters, storage.TaskQueryFieldFilter{
+			FieldID: filter.GetFieldId(),
+			Op:      filter.GetOp(),
+			Value:   customFieldFilterText(filter.GetValue()),
+		})
+	}
+
+	var tags []string
+	if len(query.GetTags()) > 0 {
+		tags = NormalizeTags(query.GetTags())
+	}
+	return &storage.SavedView{
+		ID:   view.GetId(),
+		Name: strings.TrimSpace(view.GetName()),
+		Query: storage.TaskQuery{
+			Statuses:     query.GetStatuses(),
+			Tags:         tags,
+			Due:          query.GetDue(),
+			CustomFields: filters,
+			SortBy:       query.GetSortBy(),
+			SortFieldID:  query.GetSortFieldId(),
+			SortDesc:     query.GetSortDesc(),
+		},
+		GroupBy:    view.GetGroupBy(),
+		Visibility: view.GetVisibility(),
+	}
+}
+
+// customFieldFilterText stores a filter value as text. It is parsed back
+// into the type of the field whenever the view is listed.
+func customFieldFilterText(value *task_pb.CustomFieldValue) string {
+	switch kind := value.GetKind().(type) {
+	case *task_pb.CustomFieldValue_NumberValue:
+		return strconv.FormatFloat(kind.NumberValue, 'f', -1, 64)
+	case *task_pb.CustomFieldValue_BoolValue:
+		return strconv.FormatBool(kind.BoolValue)
+	}
+	return value.GetStringValue()
+}
+
+// viewListTasksRequest turns the query of a view into a ListTasks request
+func viewListTasksRequest(view *storage.SavedView) *task_pb.ListTasksRequest {
+	return &task_pb.ListTasksRequest{
+		Statuses:           view.Query.Statuses,
+		Tags:               view.Query.Tags,
+		Due:                view.Query.Due,
+		CustomFieldFilters: TransformView(view).GetQuery().GetCustomFieldFilters(),
+		SortBy:             view.Query.SortBy,
+		SortFieldId:        view.Query.SortFieldID,
+		SortDesc:           view.Query.SortDesc,
+	}
+}
@@ -0,0 +1,196 @@
+package server_test
+
+import (
+	task_pb "github.com/sejamuchhal/taskhub/task/pb/task"
+	"github.com/sejamuchhal/taskhub/task/storage"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *ServerTestSuite) TestCreateView_Success() {
+	ctx := createTestContext()
+
+	// Once for the filters and once for the grouping
+	s.MockStorage.EXPECT().ListCustomFields("1234").Return(testCustomFields(), nil).Times(2)
+	s.MockStorage.EXPECT().CreateSavedView(gomock.Any()).DoAndReturn(func(view *storage.SavedView) error {
+		s.Equal("1234", view.UserID)
+		s.Equal("Work this week", view.Name)
+		s.Equal(storage.TaskQuery{
+			Statuses:     []string{storage.TaskStatusCreated, storage.TaskStatusInProgress},
+			Tags:         []string{"work"},
+			Due:          "this_week",
+			CustomFields: []storage.TaskQueryFieldFilter{{FieldID: "points", Op: "gte", Value: "3"}},
+			SortBy:       storage.TaskSortDueDate,
+		}, view.Query)
+		s.Equal("client", view.GroupBy)
+		s.Equal(storage.ViewVisibilityPrivate, view.Visibility)
+		view.ID = "view-1"
+		return nil
+	})
+
+	resp, err := s.Server.CreateView(ctx, &task_pb.CreateViewRequest{View: &task_pb.SavedView{
+		Name: " Work this week ",
+		Query: &task_pb.TaskQuery{
+			Statuses:           []string{storage.TaskStatusCreated, storage.TaskStatusInProgress},
+			Tags:               []string{"work", " work"},
+			Due:                "this_week",
+			CustomFieldFilters: []*task_pb.CustomFieldFilter{{FieldId: "points", Op: "gte", Value: numberValue(3)}},
+			SortBy:             storage.TaskSortDueDate,
+		},
+		GroupBy: "client",
+	}})
+	s.NoError(err)
+	s.Equal("view-1", resp.View.Id)
+	s.Equal("3", resp.View.Query.CustomFieldFilters[0].Value.GetStringValue())
+}
+
+func (s *ServerTestSuite) TestCreateView_InvalidQuery() {
+	tests := map[string]struct {
+		view *task_pb.SavedView
+		// Whether custom fields are needed to validate the view
+		loadsFields bool
+	}{
+		"missing name":   {view: &task_pb.SavedView{Query: &task_pb.TaskQuery{}}},
+		"unknown status": {view: &task_pb.SavedView{Name: "View", Query: &task_pb.TaskQuery{Statuses: []string{"archived"}}}},
+		"unknown due":    {view: &task_pb.SavedView{Name: "View", Query: &task_pb.TaskQuery{Due: "someday"}}},
+		"unknown sort":   {view: &task_pb.SavedView{Name: "View", Query: &task_pb.TaskQuery{SortBy: "assignee"}}},
+		"unknown field": {
+			view:        &task_pb.SavedView{Name: "View", Query: &task_pb.TaskQuery{CustomFieldFilters: []*task_pb.CustomFieldFilter{{FieldId: "missing", Value: stringValue("1")}}}},
+			loadsFields: true,
+		},
+		"unknown group": {view: &task_pb.SavedView{Name: "View", GroupBy: "missing"}, loadsFields: true},
+		"unknown visibility": {view: &task_pb.SavedView{Name: "View", Visibility: "public"}},
+		"shared custom field": {view: &task_pb.SavedView{
+			Name:       "View",
+			Query:      &task_pb.TaskQuery{SortFieldId: "points"},
+			Visibility: storage.ViewVisibilityWorkspace,
+		}},
+	}
+	for name, tt := range tests {
+		s.Run(name, func() {
+			ctx := createTestContext()
+			if tt.loadsFields {
+				s.MockStorage.EXPECT().ListCustomFields("1234").Return(testCustomFields(), nil)
+			}
+
+			resp, err := s.Server.CreateView(ctx, &task_pb.CreateViewRequest{View: tt.view})
+			s.Nil(resp)
+			s.Equal(codes.InvalidArgument, status.Code(err))
+		})
+	}
+}
+
+func (s *ServerTestSuite) TestUpdateView_DuplicateName() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().GetSavedViewByID("view-1").Return(&storage.SavedView{ID: "view-1", UserID: "1234", Name: "Mine"}, nil)
+	s.MockStorage.EXPECT().UpdateSavedView(gomock.Any()).Return(storage.ErrDuplicateViewName)
+
+	resp, err := s.Server.UpdateView(ctx, &task_pb.UpdateViewRequest{View: &task_pb.SavedView{Id: "view-1", Name: "Taken"}})
+	s.Nil(resp)
+	s.Equal(codes.AlreadyExists, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestGetView_OtherUser() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().GetSavedViewByID("view-1").Return(&storage.SavedView{ID: "view-1", UserID: "other"}, nil)
+
+	resp, err := s.Server.GetView(ctx, &task_pb.GetViewRequest{Id: "view-1"})
+	s.Nil(resp)
+	s.Equal(codes.NotFound, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestGetView_Shared() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().GetSavedViewByID("view-1").Return(&storage.SavedView{
+		ID:         "view-1",
+		UserID:     "other",
+		Name:       "Team backlog",
+		Visibility: storage.ViewVisibilityWorkspace,
+	}, nil)
+
+	resp, err := s.Server.GetView(ctx, &task_pb.GetViewRequest{Id: "view-1"})
+	s.NoError(err)
+	s.Equal("Team backlog", resp.View.Name)
+	s.Equal(storage.ViewVisibilityWorkspace, resp.View.Visibility)
+	s.Equal("other", resp.View.UserId)
+}
+
+func (s *ServerTestSuite) TestUpdateView_SharedByOther() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().GetSavedViewByID("view-1").Return(&storage.SavedView{
+		ID:         "view-1",
+		UserID:     "other",
+		Name:       "Team backlog",
+		Visibility: storage.ViewVisibilityWorkspace,
+	}, nil)
+
+	resp, err := s.Server.UpdateView(ctx, &task_pb.UpdateViewRequest{View: &task_pb.SavedView{Id: "view-1", Name: "Mine now"}})
+	s.Nil(resp)
+	s.Equal(codes.PermissionDenied, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestListViewTasks_Shared() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().GetSavedViewByID("view-1").Return(&storage.SavedView{
+		ID:         "view-1",
+		UserID:     "other",
+		Name:       "Team backlog",
+		Query:      storage.TaskQuery{Tags: []string{"backlog"}},
+		Visibility: storage.ViewVisibilityWorkspace,
+	}, nil)
+	// The view lists the tasks of the caller, not those of its owner
+	s.MockStorage.EXPECT().ListTasksWithCount(storage.TaskFilter{UserID: "1234", Tags: []string{"backlog"}}, 20, 0).
+		Return([]*storage.Task{{ID: "task-1", Title: "a", Tags: []string{"backlog"}}}, int64(1), nil)
+
+	resp, err := s.Server.ListViewTasks(ctx, &task_pb.ListViewTasksRequest{Id: "view-1", Limit: 20})
+	s.NoError(err)
+	s.Equal(int64(1), resp.TotalCount)
+	s.Equal("task-1", resp.Tasks[0].Id)
+}
+
+func (s *ServerTestSuite) TestListViewTasks_Success() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().GetSavedViewByID("view-1").Return(&storage.SavedView{
+		ID:     "view-1",
+		UserID: "1234",
+		Name:   "Open work",
+		Query: storage.TaskQuery{
+			Statuses:     []string{storage.TaskStatusCreated},
+			Tags:         []string{"work"},
+			CustomFields: []storage.TaskQueryFieldFilter{{FieldID: "billable", Op: "eq", Value: "true"}},
+			SortBy:       storage.TaskSortTitle,
+		},
+		GroupBy: "tag",
+	}, nil)
+	s.MockStorage.EXPECT().ListCustomFields("1234").Return(testCustomFields(), nil)
+	s.MockStorage.EXPECT().ListTasksWithCount(storage.TaskFilter{
+		UserID:   "1234",
+		Statuses: []string{storage.TaskStatusCreated},
+		Tags:     []string{"work"},
+		CustomFields: []storage.CustomFieldFilter{
+			{FieldID: "billable", FieldType: storage.CustomFieldTypeCheckbox, Op: "eq", Value: true},
+		},
+		Sort: &storage.TaskSort{Column: storage.TaskSortTitle},
+	}, 20, 0).Return([]*storage.Task{
+		{ID: "task-1", Title: "a", Tags: []string{"work", "urgent"}},
+		{ID: "task-2", Title: "b", Tags: []string{"work"}},
+	}, int64(2), nil)
+
+	resp, err := s.Server.ListViewTasks(ctx, &task_pb.ListViewTasksRequest{Id: "view-1", Limit: 20})
+	s.NoError(err)
+	s.Equal(int64(2), resp.TotalCount)
+	s.Len(resp.Tasks, 2)
+	s.Equal("Open work", resp.View.Name)
+	s.Require().Len(resp.Groups, 2)
+	s.Equal("work", resp.Groups[0].Key)
+	s.Equal([]string{"task-1", "task-2"}, resp.Groups[0].TaskIds)
+	s.Equal("urgent", resp.Groups[1].Key)
+	s.Equal([]string{"task-1"}, resp.Groups[1].TaskIds)
+}
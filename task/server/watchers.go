@@ -0,0 +1,217 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+
+	event_pb "github.com/sejamuchhal/taskhub/task/pb/event"
+	task_pb "github.com/sejamuchhal/taskhub/task/pb/task"
+	"github.com/sejamuchhal/taskhub/task/storage"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+// WatchTask makes the caller follow a task they do not own, once its owner
+// has invited them. With a user ID the owner invites that user instead.
+func (s *Server) WatchTask(ctx context.Context, req *task_pb.WatchTaskRequest) (*task_pb.WatchTaskResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "WatchTask",
+	})
+	logger.Info("Received WatchTask request")
+
+	email, err := ExtractUserEmail(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.GetUserId() != "" && req.GetUserId() != userID {
+		if _, err := s.getOwnedTask(logger, req.GetTaskId(), userID); err != nil {
+			return nil, err
+		}
+		if err := s.Storage.InviteTaskWatcher(req.GetTaskId(), req.GetUserId()); err != nil {
+			errMsg := "Could not save task watcher"
+			logger.WithError(err).Error(errMsg)
+			return nil, status.Errorf(codes.Internal, "%s: %v", errMsg, err)
+		}
+		logger.Info("Task watcher invited")
+		return &task_pb.WatchTaskResponse{}, nil
+	}
+
+	task, err := s.getTask(logger, req.GetTaskId())
+	if err != nil {
+		return nil, err
+	}
+	if task.UserID == userID {
+		return nil, status.Error(codes.InvalidArgument, "Owners cannot watch their own tasks")
+	}
+
+	watchers, err := s.Storage.ListTaskWatchers(task.ID)
+	if err != nil {
+		errMsg := "Failed to retrieve task watchers from the database"
+		logger.WithError(err).Error(errMsg)
+		return nil, status.Errorf(codes.Internal, "%s: %v", errMsg, err)
+	}
+	invited := slices.ContainsFunc(watchers, func(watcher *storage.TaskWatcher) bool {
+		return watcher.UserID == userID
+	})
+	if !invited {
+		logger.Warn("Watching a task without an invite")
+		return nil, status.Error(codes.PermissionDenied, "Only users the owner invited can watch this task")
+	}
+
+	err = s.Storage.AddTaskWatcher(&storage.TaskWatcher{
+		TaskID: task.ID,
+		UserID: userID,
+		Email:  email,
+	})
+	if err != nil {
+		errMsg := "Could not save task watcher"
+		logger.WithError(err).Error(errMsg)
+		return nil, status.Errorf(codes.Internal, "%s: %v", errMsg, err)
+	}
+
+	logger.Info("Task watched")
+	return &task_pb.WatchTaskResponse{}, nil
+}
+
+// UnwatchTask stops the caller from following a task. Owners can also remove
+// any watcher of their task.
+func (s *Server) UnwatchTask(ctx context.Context, req *task_pb.UnwatchTaskRequest) (*task_pb.UnwatchTaskResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "UnwatchTask",
+	})
+	logger.Info("Received UnwatchTask request")
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	watcherID := userID
+	if req.GetUserId() != "" && req.GetUserId() != userID {
+		// Only the owner can remove someone else
+		if _, err := s.getOwnedTask(logger, req.GetTaskId(), userID); err != nil {
+			return nil, err
+		}
+		watcherID = req.GetUserId()
+	} else if req.GetTaskId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "Task ID is required")
+	}
+
+	if err := s.Storage.RemoveTaskWatcher(req.GetTaskId(), watcherID); err != nil {
+		errMsg := "Could not remove task watcher"
+		logger.WithError(err).Error(errMsg)
+		return nil, status.Errorf(codes.Internal, "%s: %v", errMsg, err)
+	}
+
+	logger.Info("Task unwatched")
+	return &task_pb.UnwatchTaskResponse{}, nil
+}
+
+// ListTaskWatchers lists who follows one of the caller's tasks
+func (s *Server) ListTaskWatchers(ctx context.Context, req *task_pb.ListTaskWatchersRequest) (*task_pb.ListTaskWatchersResponse, error) {
+	logger := s.Logger.WithFields(logrus.Fields{
+		"req":    req,
+		"method": "ListTaskWatchers",
+	})
+	logger.Info("Received ListTaskWatchers request")
+
+	userID, err := ExtractUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := s.getOwnedTask(logger, req.GetTaskId(), userID)
+	if err != nil {
+		return nil, err
+	}
+
+	watchers, err := s.Storage.ListTaskWatchers(task.ID)
+	if err != nil {
+		errMsg := "Failed to retrieve task watchers from the database"
+		logger.WithError(err).Error(errMsg)
+		return nil, status.Errorf(codes.Internal, "%s: %v", errMsg, err)
+	}
+
+	var pbWatchers []*task_pb.TaskWatcher
+	for _, watcher := range watchers {
+		pbWatchers = append(pbWatchers, TransformTaskWatcher(watcher))
+	}
+	return &task_pb.ListTaskWatchersResponse{Watchers: pbWatchers}, nil
+}
+
+func (s *Server) getTask(logger *logrus.Entry, taskID string) (*storage.Task, error) {
+	if taskID == "" {
+		return nil, status.Error(codes.InvalidArgument, "Task ID is required")
+	}
+
+	task, err := s.Storage.GetTaskByID(taskID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.WithError(err).Error("Task not found")
+			return nil, status.Errorf(codes.NotFound, "Task not found: %v", err)
+		}
+		logger.WithError(err).Error("Failed to retrieve Task from the database")
+		return nil, status.Errorf(codes.Internal, "Failed to retrieve Task from the database: %v", err)
+	}
+	return task, nil
+}
+
+// taskRecipients returns who is told about a change to a task: its owner and
+// watchers, without the user who made the change
+func (s *Server) taskRecipients(logger *logrus.Entry, task *storage.Task, actorID string) []string {
+	var recipients []string
+	// Tasks created before owners' emails were kept have none
+	if task.UserID != actorID && task.UserEmail != "" {
+		recipients = append(recipients, task.UserEmail)
+	}
+
+	watchers, err := s.Storage.ListTaskWatchers(task.ID)
+	if err != nil {
+		logger.WithError(err).Error("Failed to retrieve task watchers from the database")
+		return recipients
+	}
+	for _, watcher := range watchers {
+		// Invited users have no email until they start watching
+		if watcher.UserID != actorID && watcher.Email != "" {
+			recipients = append(recipients, watcher.Email)
+		}
+	}
+	return recipients
+}
+
+// publishTaskEvent notifies the notification service about a task change.
+// Nothing is sent without recipients. Failures are logged only, the change
+// itself already went through.
+func (s *Server) publishTaskEvent(logger *logrus.Entry, eventStatus, title string, recipients []string) {
+	if len(recipients) == 0 {
+		return
+	}
+
+	eventJSON, err := json.Marshal(&event_pb.TaskUpdateEvent{
+		Status:     eventStatus,
+		Title:      title,
+		Recipients: recipients,
+	})
+	if err != nil {
+		logger.WithError(err).Error("Failed to marshal task update event to JSON")
+		return
+	}
+
+	if err := s.Publisher.Publish(eventJSON); err != nil {
+		logger.WithError(err).Error("failed to send task update event")
+		return
+	}
+	logger.Info("Task update event sent to queue")
+}
@@ -0,0 +1,204 @@
+package server_test
+
+import (
+	"encoding/json"
+	"time"
+
+	event_pb "github.com/sejamuchhal/taskhub/task/pb/event"
+	task_pb "github.com/sejamuchhal/taskhub/task/pb/task"
+	"github.com/sejamuchhal/taskhub/task/storage"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
+)
+
+// expectTaskEvent expects a task event to be published to recipients
+func (s *ServerTestSuite) expectTaskEvent(eventStatus, title string, recipients ...string) {
+	s.MockRabbitMQ.EXPECT().Publish(gomock.Any()).DoAndReturn(func(body []byte) error {
+		var event event_pb.TaskUpdateEvent
+		s.Require().NoError(json.Unmarshal(body, &event))
+		s.Equal(eventStatus, event.Status)
+		s.Equal(title, event.Title)
+		s.Equal(recipients, event.Recipients)
+		return nil
+	})
+}
+
+func (s *ServerTestSuite) TestWatchTask_Success() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().GetTaskByID("task-1").Return(&storage.Task{ID: "task-1", UserID: "5678"}, nil)
+	s.MockStorage.EXPECT().ListTaskWatchers("task-1").Return([]*storage.TaskWatcher{
+		{TaskID: "task-1", UserID: "1234"},
+	}, nil)
+	s.MockStorage.EXPECT().AddTaskWatcher(&storage.TaskWatcher{
+		TaskID: "task-1",
+		UserID: "1234",
+		Email:  "user@example.com",
+	}).Return(nil)
+
+	resp, err := s.Server.WatchTask(ctx, &task_pb.WatchTaskRequest{TaskId: "task-1"})
+	s.NoError(err)
+	s.NotNil(resp)
+}
+
+func (s *ServerTestSuite) TestWatchTask_NotInvited() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().GetTaskByID("task-1").Return(&storage.Task{ID: "task-1", UserID: "5678"}, nil)
+	s.MockStorage.EXPECT().ListTaskWatchers("task-1").Return([]*storage.TaskWatcher{
+		{TaskID: "task-1", UserID: "9999", Email: "watcher@example.com"},
+	}, nil)
+
+	resp, err := s.Server.WatchTask(ctx, &task_pb.WatchTaskRequest{TaskId: "task-1"})
+	s.Nil(resp)
+	s.Equal(codes.PermissionDenied, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestWatchTask_OwnerInvites() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().GetTaskByID("task-1").Return(&storage.Task{ID: "task-1", UserID: "1234"}, nil)
+	s.MockStorage.EXPECT().InviteTaskWatcher("task-1", "5678").Return(nil)
+
+	resp, err := s.Server.WatchTask(ctx, &task_pb.WatchTaskRequest{TaskId: "task-1", UserId: "5678"})
+	s.NoError(err)
+	s.NotNil(resp)
+}
+
+func (s *ServerTestSuite) TestWatchTask_InviteToOtherTask() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().GetTaskByID("task-1").Return(&storage.Task{ID: "task-1", UserID: "5678"}, nil)
+
+	resp, err := s.Server.WatchTask(ctx, &task_pb.WatchTaskRequest{TaskId: "task-1", UserId: "9999"})
+	s.Nil(resp)
+	s.Equal(codes.NotFound, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestWatchTask_OwnTask() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().GetTaskByID("task-1").Return(&storage.Task{ID: "task-1", UserID: "1234"}, nil)
+
+	resp, err := s.Server.WatchTask(ctx, &task_pb.WatchTaskRequest{TaskId: "task-1"})
+	s.Nil(resp)
+	s.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestWatchTask_NotFound() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().GetTaskByID("task-1").Return(&storage.Task{}, gorm.ErrRecordNotFound)
+
+	resp, err := s.Server.WatchTask(ctx, &task_pb.WatchTaskRequest{TaskId: "task-1"})
+	s.Nil(resp)
+	s.Equal(codes.NotFound, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestUnwatchTask_Self() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().RemoveTaskWatcher("task-1", "1234").Return(nil)
+
+	_, err := s.Server.UnwatchTask(ctx, &task_pb.UnwatchTaskRequest{TaskId: "task-1"})
+	s.NoError(err)
+}
+
+func (s *ServerTestSuite) TestUnwatchTask_OwnerRemovesWatcher() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().GetTaskByID("task-1").Return(&storage.Task{ID: "task-1", UserID: "1234"}, nil)
+	s.MockStorage.EXPECT().RemoveTaskWatcher("task-1", "5678").Return(nil)
+
+	_, err := s.Server.UnwatchTask(ctx, &task_pb.UnwatchTaskRequest{TaskId: "task-1", UserId: "5678"})
+	s.NoError(err)
+}
+
+func (s *ServerTestSuite) TestUnwatchTask_OtherWatcher() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().GetTaskByID("task-1").Return(&storage.Task{ID: "task-1", UserID: "5678"}, nil)
+
+	resp, err := s.Server.UnwatchTask(ctx, &task_pb.UnwatchTaskRequest{TaskId: "task-1", UserId: "9999"})
+	s.Nil(resp)
+	s.Equal(codes.NotFound, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestListTaskWatchers() {
+	ctx := createTestContext()
+	createdAt := time.Date(2024, 7, 30, 10, 0, 0, 0, time.UTC)
+
+	s.MockStorage.EXPECT().GetTaskByID("task-1").Return(&storage.Task{ID: "task-1", UserID: "1234"}, nil)
+	s.MockStorage.EXPECT().ListTaskWatchers("task-1").Return([]*storage.TaskWatcher{
+		{TaskID: "task-1", UserID: "5678", Email: "watcher@example.com", CreatedAt: createdAt},
+	}, nil)
+
+	resp, err := s.Server.ListTaskWatchers(ctx, &task_pb.ListTaskWatchersRequest{TaskId: "task-1"})
+	s.NoError(err)
+	s.Require().Len(resp.Watchers, 1)
+	s.Equal("5678", resp.Watchers[0].UserId)
+	s.Equal("watcher@example.com", resp.Watchers[0].Email)
+	s.Equal(createdAt, resp.Watchers[0].CreatedAt.AsTime())
+}
+
+func (s *ServerTestSuite) TestListTaskWatchers_NotOwner() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().GetTaskByID("task-1").Return(&storage.Task{ID: "task-1", UserID: "5678"}, nil)
+
+	resp, err := s.Server.ListTaskWatchers(ctx, &task_pb.ListTaskWatchersRequest{TaskId: "task-1"})
+	s.Nil(resp)
+	s.Equal(codes.NotFound, status.Code(err))
+}
+
+func (s *ServerTestSuite) TestUpdateTask_NotifiesOwnerAndWatchers() {
+	// The caller, 1234, is a watcher of someone else's task
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().GetTaskByID("task-1").Return(&storage.Task{ID: "task-1", UserID: "5678", UserEmail: "owner@example.com"}, nil)
+	s.MockStorage.EXPECT().UpdateTask(gomock.Any()).DoAndReturn(func(task *storage.Task) error {
+		// Only the owner's own changes update their email
+		s.Equal("owner@example.com", task.UserEmail)
+		return nil
+	})
+	s.MockStorage.EXPECT().ListTaskWatchers("task-1").Return([]*storage.TaskWatcher{
+		{TaskID: "task-1", UserID: "1234", Email: "user@example.com"},
+		{TaskID: "task-1", UserID: "9999", Email: "watcher@example.com"},
+		// Invited but not watching yet
+		{TaskID: "task-1", UserID: "4321"},
+	}, nil)
+	s.expectTaskEvent("updated", "Write report", "owner@example.com", "watcher@example.com")
+
+	_, err := s.Server.UpdateTask(ctx, &task_pb.UpdateTaskRequest{Task: &task_pb.Task{
+		Id:      "task-1",
+		Title:   "Write report",
+		DueDate: timestamppb.New(time.Now()),
+	}})
+	s.NoError(err)
+}
+
+func (s *ServerTestSuite) TestDeleteTask_NotifiesWatchers() {
+	ctx := createTestContext()
+
+	s.MockStorage.EXPECT().GetTaskByID("task-1").Return(&storage.Task{ID: "task-1", UserID: "1234", Title: "Write report"}, nil)
+	// Watchers are looked up before they are deleted with the task
+	gomock.InOrder(
+		s.MockStorage.EXPECT().ListTaskWatchers("task-1").Return([]*storage.TaskWatcher{
+			{TaskID: "task-1", UserID: "5678", Email: "watcher@example.com"},
+		}, nil),
+		s.MockStorage.EXPECT().DeleteTask("task-1").Return(nil),
+	)
+	s.MockRabbitMQ.EXPECT().Publish(gomock.Any()).DoAndReturn(func(body []byte) error {
+		var event event_pb.TaskUpdateEvent
+		s.Require().NoError(json.Unmarshal(body, &event))
+		s.Equal("deleted", event.Status)
+		s.Equal([]string{"watcher@example.com"}, event.Recipients)
+		return nil
+	})
+
+	_, err := s.Server.DeleteTask(ctx, &task_pb.DeleteTaskRequest{Id: "task-1"})
+	s.NoError(err)
+}
@@ -0,0 +1,11 @@
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/sejamuchhal/taskhub/task/storage/storagetest"
+)
+
+func TestConformance(t *testing.T) {
+	storagetest.RunBackends(t)
+}
@@ -0,0 +1,1220 @@
+package storage
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MemoryStorage keeps everything in maps. It is meant for local development
+// and tests, nothing survives a restart. Lookups of missing rows return
+// gorm.ErrRecordNotFound like the database backed Storage.
+type MemoryStorage struct {
+	mu          sync.Mutex
+	tasks       map[string]*Task
+	timeEntries map[string]*TimeEntry
+	revisions   []*TimeEntryRevision
+	templates   map[string]*TaskTemplate
+	fields      map[string]*CustomField
+	views       map[string]*SavedView
+	policies    map[string]*RetentionPolicy
+	idempotency map[[2]string]*IdempotencyRecord
+	watchers    map[[2]string]*TaskWatcher
+	reminders   map[string]*TaskReminder
+}
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		tasks:       map[string]*Task{},
+		timeEntries: map[string]*TimeEntry{},
+		templates:   map[string]*TaskTemplate{},
+		fields:      map[string]*CustomField{},
+		views:       map[string]*SavedView{},
+		policies:    map[string]*RetentionPolicy{},
+		idempotency: map[[2]string]*IdempotencyRecord{},
+		watchers:    map[[2]string]*TaskWatcher{},
+		reminders:   map[string]*TaskReminder{},
+	}
+}
+
+func (m *MemoryStorage) CreateTask(task *Task) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.createTask(task)
+}
+
+func (m *MemoryStorage) CreateTaskWithReminders(task *Task, reminders []*TaskReminder) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, reminder := range reminders {
+		if _, ok := m.reminders[reminder.ID]; ok {
+			return gorm.ErrDuplicatedKey
+		}
+	}
+	if err := m.createTask(task); err != nil {
+		return err
+	}
+	for _, reminder := range reminders {
+		reminder.TaskID = task.ID
+		reminder.BeforeSave(nil)
+		if reminder.CreatedAt.IsZero() {
+			reminder.CreatedAt = time.Now()
+		}
+		copied := *reminder
+		m.reminders[reminder.ID] = &copied
+	}
+	return nil
+}
+
+// createTask inserts a task. The caller holds the lock.
+func (m *MemoryStorage) createTask(task *Task) error {
+	task.BeforeSave(nil)
+	if _, ok := m.tasks[task.ID]; ok {
+		return gorm.ErrDuplicatedKey
+	}
+	if task.Position == "" {
+		// New tasks go to the bottom of their column
+		last := ""
+		if column := m.column(task.UserID, task.Status, ""); len(column) > 0 {
+			last = column[len(column)-1].Position
+		}
+		position, err := RankBetween(last, "")
+		if err != nil {
+			return err
+		}
+		task.Position = position
+	}
+	now := time.Now()
+	if task.CreatedAt.IsZero() {
+		task.CreatedAt = now
+	}
+	if task.UpdatedAt.IsZero() {
+		task.UpdatedAt = now
+	}
+	m.tasks[task.ID] = copyTask(task)
+	return nil
+}
+
+func (m *MemoryStorage) GetTaskByID(id string) (*Task, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	task, ok := m.tasks[id]
+	if !ok {
+		return &Task{}, gorm.ErrRecordNotFound
+	}
+	return copyTask(task), nil
+}
+
+func (m *MemoryStorage) GetTasksByIDs(ids []string) ([]*Task, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tasks := make([]*Task, 0, len(ids))
+	for _, id := range ids {
+		if task, ok := m.tasks[id]; ok {
+			tasks = append(tasks, copyTask(task))
+		}
+	}
+	return tasks, nil
+}
+
+func (m *MemoryStorage) ListTasksWithCount(filter TaskFilter, limit, offset int) ([]*Task, int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tasks := make([]*Task, 0)
+	for _, task := range m.tasks {
+		if matchesTaskFilter(task, filter) {
+			tasks = append(tasks, copyTask(task))
+		}
+	}
+	if filter.Sort != nil && filter.SortField == nil && !slices.Contains(TaskSortColumns, filter.Sort.Column) {
+		return tasks, 0, fmt.Errorf("unsupported sort column %q", filter.Sort.Column)
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		if cmp := compareTasks(tasks[i], tasks[j], filter); cmp != 0 {
+			return cmp < 0
+		}
+		return tasks[i].CreatedAt.After(tasks[j].CreatedAt)
+	})
+	return paginate(tasks, limit, offset), int64(len(tasks)), nil
+}
+
+// compareTasks orders two tasks by the sort of a filter, 0 leaves the order
+// to the newest first tie-breaker
+func compareTasks(a, b *Task, filter TaskFilter) int {
+	if sortField := filter.SortField; sortField != nil {
+		aValue, aOK := a.CustomFields[sortField.FieldID]
+		bValue, bOK := b.CustomFields[sortField.FieldID]
+		if aOK != bOK {
+			// Tasks without a value come last
+			if aOK {
+				return -1
+			}
+			return 1
+		}
+		return directed(compareCustomFieldValues(aValue, bValue), sortField.Desc)
+	}
+
+	if filter.Sort == nil {
+		return 0
+	}
+	switch filter.Sort.Column {
+	case TaskSortUpdatedAt:
+		return directed(a.UpdatedAt.Compare(b.UpdatedAt), filter.Sort.Desc)
+	case TaskSortTitle:
+		return directed(strings.Compare(a.Title, b.Title), filter.Sort.Desc)
+	case TaskSortPriority:
+		return directed(int(a.Priority-b.Priority), filter.Sort.Desc)
+	case TaskSortDueDate:
+		aDue, bDue := a.DueDate.After(noDueDate), b.DueDate.After(noDueDate)
+		if aDue != bDue {
+			// Tasks without a due date come last
+			if aDue {
+				return -1
+			}
+			return 1
+		}
+		return directed(a.DueDate.Compare(b.DueDate), filter.Sort.Desc)
+	default:
+		return directed(a.CreatedAt.Compare(b.CreatedAt), filter.Sort.Desc)
+	}
+}
+
+func directed(cmp int, desc bool) int {
+	if desc {
+		return -cmp
+	}
+	return cmp
+}
+
+func matchesTaskFilter(task *Task, filter TaskFilter) bool {
+	if filter.UserID != "" && task.UserID != filter.UserID {
+		return false
+	}
+	if filter.Archived != (task.ArchivedAt != nil) {
+		return false
+	}
+	if len(filter.Statuses) > 0 && !slices.Contains(filter.Statuses, task.Status) {
+		return false
+	}
+	for _, tag := range filter.Tags {
+		if !slices.Contains(task.Tags, tag) {
+			return false
+		}
+	}
+	hasDueDate := task.DueDate.After(noDueDate)
+	if (filter.DueFrom != nil || filter.DueBefore != nil) && !hasDueDate {
+		return false
+	}
+	if filter.DueFrom != nil && task.DueDate.Before(*filter.DueFrom) {
+		return false
+	}
+	if filter.DueBefore != nil && !task.DueDate.Before(*filter.DueBefore) {
+		return false
+	}
+	if filter.WithoutDueDate && hasDueDate {
+		return false
+	}
+	for _, fieldFilter := range filter.CustomFields {
+		value, ok := task.CustomFields[fieldFilter.FieldID]
+		if !ok {
+			return false
+		}
+		if options, ok := value.([]string); ok {
+			if fieldFilter.Op != "eq" || !containsString(options, fieldFilter.Value) {
+				return false
+			}
+			continue
+		}
+		cmp := compareCustomFieldValues(value, fieldFilter.Value)
+		matches := map[string]bool{
+			"eq":  cmp == 0,
+			"lt":  cmp < 0,
+			"lte": cmp <= 0,
+			"gt":  cmp > 0,
+			"gte": cmp >= 0,
+		}
+		if !matches[fieldFilter.Op] {
+			return false
+		}
+	}
+	return true
+}
+
+// compareCustomFieldValues orders two values of the same custom field
+func compareCustomFieldValues(a, b interface{}) int {
+	switch a := a.(type) {
+	case string:
+		b, _ := b.(string)
+		return strings.Compare(a, b)
+	case float64:
+		b, _ := b.(float64)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+	case bool:
+		b, _ := b.(bool)
+		switch {
+		case !a && b:
+			return -1
+		case a && !b:
+			return 1
+		}
+	}
+	return 0
+}
+
+func containsString(values []string, value interface{}) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *MemoryStorage) DeleteTask(taskID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.tasks, taskID)
+	m.deleteTaskWatchers(taskID)
+	m.deleteTaskReminders(taskID)
+	return nil
+}
+
+func (m *MemoryStorage) UpdateTask(task *Task) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.tasks[task.ID]
+	if !ok {
+		return nil
+	}
+	updated := copyTask(task)
+	updated.UserID = stored.UserID
+	updated.CreatedAt = stored.CreatedAt
+	updated.UpdatedAt = time.Now()
+	m.tasks[task.ID] = updated
+
+	if !task.DueDate.After(noDueDate) {
+		m.deleteTaskReminders(task.ID)
+		return nil
+	}
+	for _, reminder := range m.reminders {
+		if reminder.TaskID == task.ID {
+			reminder.reschedule(task.DueDate)
+		}
+	}
+	return nil
+}
+
+func (m *MemoryStorage) MoveTask(move TaskMove) (*Task, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stored, ok := m.tasks[move.TaskID]
+	if !ok || stored.UserID != move.UserID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	rank, err := m.moveRank(move)
+	if err == ErrInvalidRankRange || (err == nil && len(rank) > maxRankLength) {
+		column := m.column(move.UserID, move.Status, move.TaskID)
+		for i, rank := range EvenRanks(len(column)) {
+			column[i].Position = rank
+		}
+		rank, err = m.moveRank(move)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if stored.Status != move.Status {
+		// Dragging a task in or out of the completed column (un)completes it
+		stored.CompletedAt = nil
+		if move.Status == TaskStatusCompleted {
+			completedAt := time.Now()
+			stored.CompletedAt = &completedAt
+		}
+	}
+	stored.Status = move.Status
+	stored.Position = rank
+	stored.UpdatedAt = time.Now()
+	return copyTask(stored), nil
+}
+
+// moveRank mirrors the database version of moveRank on the in-memory column
+func (m *MemoryStorage) moveRank(move TaskMove) (string, error) {
+	column := m.column(move.UserID, move.Status, move.TaskID)
+	neighbour := func(id string) (string, error) {
+		if id == "" {
+			return "", nil
+		}
+		if id == move.TaskID {
+			return "", ErrInvalidMove
+		}
+		for _, task := range column {
+			if task.ID == id {
+				return task.Position, nil
+			}
+		}
+		return "", ErrInvalidMove
+	}
+
+	lower, err := neighbour(move.AfterID)
+	if err != nil {
+		return "", err
+	}
+	upper, err := neighbour(move.BeforeID)
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case move.AfterID == "" && move.BeforeID == "":
+		if len(column) > 0 {
+			lower = column[len(column)-1].Position
+		}
+	case move.AfterID == "":
+		for _, task := range column {
+			if task.Position < upper {
+				lower = task.Position
+			}
+		}
+	case move.BeforeID == "":
+		for i := len(column) - 1; i >= 0; i-- {
+			if column[i].Position > lower {
+				upper = column[i].Position
+			}
+		}
+	}
+	return RankBetween(lower, upper)
+}
+
+// column returns the stored tasks of a board column in rank order, leaving
+// out excludeID
+func (m *MemoryStorage) column(userID, status, excludeID string) []*Task {
+	var column []*Task
+	for _, task := range m.tasks {
+		if task.UserID == userID && task.Status == status && task.ID != excludeID {
+			column = append(column, task)
+		}
+	}
+	sortBoard(column)
+	return column
+}
+
+func (m *MemoryStorage) ListBoardTasks(userID string) ([]*Task, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var tasks []*Task
+	for _, task := range m.tasks {
+		if task.UserID == userID && task.ArchivedAt == nil {
+			tasks = append(tasks, copyTask(task))
+		}
+	}
+	sortBoard(tasks)
+	return tasks, nil
+}
+
+func sortBoard(tasks []*Task) {
+	sort.Slice(tasks, func(i, j int) bool {
+		a, b := tasks[i], tasks[j]
+		if a.Status != b.Status {
+			return a.Status < b.Status
+		}
+		if a.Position != b.Position {
+			return a.Position < b.Position
+		}
+		return a.CreatedAt.Before(b.CreatedAt)
+	})
+}
+
+func (m *MemoryStorage) StartTimer(entry *TimeEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.runningEntry(entry.UserID) != nil {
+		return ErrTimerAlreadyRunning
+	}
+	entry.EndedAt = nil
+	m.insertTimeEntry(entry)
+	m.addRevision(entry, TimeEntryActionStarted)
+	return nil
+}
+
+func (m *MemoryStorage) StopTimer(userID string, endedAt time.Time) (*TimeEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry := m.runningEntry(userID)
+	if entry == nil {
+		return &TimeEntry{}, gorm.ErrRecordNotFound
+	}
+	entry.EndedAt = &endedAt
+	entry.UpdatedAt = time.Now()
+	m.addRevision(entry, TimeEntryActionStopped)
+	return copyTimeEntry(entry), nil
+}
+
+func (m *MemoryStorage) runningEntry(userID string) *TimeEntry {
+	for _, entry := range m.timeEntries {
+		if entry.UserID == userID && entry.EndedAt == nil && !entry.DeletedAt.Valid {
+			return entry
+		}
+	}
+	return nil
+}
+
+func (m *MemoryStorage) CreateTimeEntry(entry *TimeEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.insertTimeEntry(entry)
+	m.addRevision(entry, TimeEntryActionCreated)
+	return nil
+}
+
+func (m *MemoryStorage) insertTimeEntry(entry *TimeEntry) {
+	entry.BeforeSave(nil)
+	now := time.Now()
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = now
+	}
+	if entry.UpdatedAt.IsZero() {
+		entry.UpdatedAt = now
+	}
+	m.timeEntries[entry.ID] = copyTimeEntry(entry)
+}
+
+func (m *MemoryStorage) GetTimeEntryByID(id string) (*TimeEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.timeEntries[id]
+	if !ok || entry.DeletedAt.Valid {
+		return &TimeEntry{}, gorm.ErrRecordNotFound
+	}
+	return copyTimeEntry(entry), nil
+}
+
+func (m *MemoryStorage) ListTimeEntriesWithCount(filter TimeEntryFilter, limit, offset int) ([]*TimeEntry, int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entries := make([]*TimeEntry, 0)
+	for _, entry := range m.timeEntries {
+		switch {
+		case entry.DeletedAt.Valid,
+			filter.UserID != "" && entry.UserID != filter.UserID,
+			filter.TaskID != "" && entry.TaskID != filter.TaskID,
+			!filter.From.IsZero() && !filter.Overlapping && entry.StartedAt.Before(filter.From),
+			!filter.From.IsZero() && filter.Overlapping && entry.EndedAt != nil && !entry.EndedAt.After(filter.From),
+			!filter.To.IsZero() && !entry.StartedAt.Before(filter.To):
+			continue
+		}
+		entries = append(entries, copyTimeEntry(entry))
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].StartedAt.After(entries[j].StartedAt)
+	})
+	return paginate(entries, limit, offset), int64(len(entries)), nil
+}
+
+func (m *MemoryStorage) UpdateTimeEntry(entry *TimeEntry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if stored, ok := m.timeEntries[entry.ID]; ok && !stored.DeletedAt.Valid {
+		stored.StartedAt = entry.StartedAt
+		stored.EndedAt = copyTime(entry.EndedAt)
+		stored.Note = entry.Note
+		stored.UpdatedAt = time.Now()
+	}
+	m.addRevision(entry, TimeEntryActionUpdated)
+	return nil
+}
+
+func (m *MemoryStorage) DeleteTimeEntry(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry, ok := m.timeEntries[id]
+	if !ok || entry.DeletedAt.Valid {
+		return gorm.ErrRecordNotFound
+	}
+	entry.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	m.addRevision(entry, TimeEntryActionDeleted)
+	return nil
+}
+
+func (m *MemoryStorage) ListTimeEntryRevisions(entryID string) ([]*TimeEntryRevision, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	revisions := make([]*TimeEntryRevision, 0)
+	for _, revision := range m.revisions {
+		if revision.TimeEntryID == entryID {
+			copied := *revision
+			copied.EndedAt = copyTime(revision.EndedAt)
+			revisions = append(revisions, &copied)
+		}
+	}
+	return revisions, nil
+}
+
+func (m *MemoryStorage) addRevision(entry *TimeEntry, action string) {
+	revision := &TimeEntryRevision{
+		TimeEntryID: entry.ID,
+		UserID:      entry.UserID,
+		Action:      action,
+		StartedAt:   entry.StartedAt,
+		EndedAt:     copyTime(entry.EndedAt),
+		Note:        entry.Note,
+		CreatedAt:   time.Now(),
+	}
+	revision.BeforeSave(nil)
+	m.revisions = append(m.revisions, revision)
+}
+
+func (m *MemoryStorage) GetTaskStats(query TaskStatsQuery) (*TaskStats, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tasks := make([]*Task, 0, len(m.tasks))
+	for _, task := range m.tasks {
+		tasks = append(tasks, task)
+	}
+	return computeTaskStats(tasks, query)
+}
+
+func (m *MemoryStorage) CreateTemplate(template *TaskTemplate) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkTemplateName(template); err != nil {
+		return err
+	}
+	template.BeforeSave(nil)
+	now := time.Now()
+	if template.CreatedAt.IsZero() {
+		template.CreatedAt = now
+	}
+	if template.UpdatedAt.IsZero() {
+		template.UpdatedAt = now
+	}
+	m.templates[template.ID] = copyTemplate(template)
+	return nil
+}
+
+func (m *MemoryStorage) GetTemplateByID(id string) (*TaskTemplate, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	template, ok := m.templates[id]
+	if !ok {
+		return &TaskTemplate{}, gorm.ErrRecordNotFound
+	}
+	return copyTemplate(template), nil
+}
+
+func (m *MemoryStorage) ListTemplates(userID string) ([]*TaskTemplate, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var templates []*TaskTemplate
+	for _, template := range m.templates {
+		if template.UserID == userID {
+			templates = append(templates, copyTemplate(template))
+		}
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+	return templates, nil
+}
+
+func (m *MemoryStorage) UpdateTemplate(template *TaskTemplate) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkTemplateName(template); err != nil {
+		return err
+	}
+	stored, ok := m.templates[template.ID]
+	if !ok {
+		return nil
+	}
+	updated := copyTemplate(template)
+	updated.UserID = stored.UserID
+	updated.CreatedAt = stored.CreatedAt
+	updated.UpdatedAt = time.Now()
+	m.templates[template.ID] = updated
+	return nil
+}
+
+func (m *MemoryStorage) DeleteTemplate(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.templates, id)
+	return nil
+}
+
+func (m *MemoryStorage) checkTemplateName(template *TaskTemplate) error {
+	for _, other := range m.templates {
+		if other.UserID == template.UserID && other.Name == template.Name && other.ID != template.ID {
+			return ErrDuplicateTemplateName
+		}
+	}
+	return nil
+}
+
+func (m *MemoryStorage) CreateSavedView(view *SavedView) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkViewName(view); err != nil {
+		return err
+	}
+	view.BeforeSave(nil)
+	if view.Visibility == "" {
+		view.Visibility = ViewVisibilityPrivate
+	}
+	now := time.Now()
+	if view.CreatedAt.IsZero() {
+		view.CreatedAt = now
+	}
+	if view.UpdatedAt.IsZero() {
+		view.UpdatedAt = now
+	}
+	m.views[view.ID] = copyView(view)
+	return nil
+}
+
+func (m *MemoryStorage) GetSavedViewByID(id string) (*SavedView, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	view, ok := m.views[id]
+	if !ok {
+		return &SavedView{}, gorm.ErrRecordNotFound
+	}
+	return copyView(view), nil
+}
+
+func (m *MemoryStorage) ListSavedViews(userID string) ([]*SavedView, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var views []*SavedView
+	for _, view := range m.views {
+		if view.UserID == userID || view.Visibility == ViewVisibilityWorkspace {
+			views = append(views, copyView(view))
+		}
+	}
+	sort.Slice(views, func(i, j int) bool {
+		if views[i].Name != views[j].Name {
+			return views[i].Name < views[j].Name
+		}
+		return views[i].ID < views[j].ID
+	})
+	return views, nil
+}
+
+func (m *MemoryStorage) UpdateSavedView(view *SavedView) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkViewName(view); err != nil {
+		return err
+	}
+	stored, ok := m.views[view.ID]
+	if !ok {
+		return nil
+	}
+	updated := copyView(stored)
+	updated.Name = view.Name
+	updated.Query = copyView(view).Query
+	updated.GroupBy = view.GroupBy
+	updated.Visibility = view.Visibility
+	updated.UpdatedAt = time.Now()
+	m.views[view.ID] = updated
+	return nil
+}
+
+func (m *MemoryStorage) DeleteSavedView(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.views, id)
+	return nil
+}
+
+func (m *MemoryStorage) checkViewName(view *SavedView) error {
+	for _, other := range m.views {
+		if other.UserID == view.UserID && other.Name == view.Name && other.ID != view.ID {
+			return ErrDuplicateViewName
+		}
+	}
+	return nil
+}
+
+func (m *MemoryStorage) SetTaskArchived(taskID string, archivedAt *time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if task, ok := m.tasks[taskID]; ok {
+		task.ArchivedAt = copyTime(archivedAt)
+		task.UpdatedAt = time.Now()
+		if archivedAt == nil {
+			task.UnarchivedAt = copyTime(&task.UpdatedAt)
+		}
+	}
+	return nil
+}
+
+func (m *MemoryStorage) ArchiveCompletedTasks(scope RetentionScope, completedBefore, archivedAt time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var archived int64
+	for _, task := range m.tasks {
+		if inRetentionScope(task, scope) && task.Status == TaskStatusCompleted &&
+			task.ArchivedAt == nil && task.CompletedAt != nil && task.CompletedAt.Before(completedBefore) &&
+			(task.UnarchivedAt == nil || task.UnarchivedAt.Before(completedBefore)) {
+			task.ArchivedAt = copyTime(&archivedAt)
+			archived++
+		}
+	}
+	return archived, nil
+}
+
+func (m *MemoryStorage) PurgeArchivedTasks(scope RetentionScope, archivedBefore time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var purged int64
+	for id, task := range m.tasks {
+		if inRetentionScope(task, scope) && task.ArchivedAt != nil && task.ArchivedAt.Before(archivedBefore) {
+			delete(m.tasks, id)
+			m.deleteTaskWatchers(id)
+			m.deleteTaskReminders(id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+func inRetentionScope(task *Task, scope RetentionScope) bool {
+	if scope.UserID != "" && task.UserID != scope.UserID {
+		return false
+	}
+	return !slices.Contains(scope.ExcludeUserIDs, task.UserID)
+}
+
+func (m *MemoryStorage) GetRetentionPolicy(userID string) (*RetentionPolicy, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	policy, ok := m.policies[userID]
+	if !ok {
+		return &RetentionPolicy{}, gorm.ErrRecordNotFound
+	}
+	copied := *policy
+	return &copied, nil
+}
+
+func (m *MemoryStorage) ListRetentionPolicies() ([]*RetentionPolicy, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	policies := make([]*RetentionPolicy, 0, len(m.policies))
+	for _, policy := range m.policies {
+		copied := *policy
+		policies = append(policies, &copied)
+	}
+	sort.Slice(policies, func(i, j int) bool {
+		return policies[i].UserID < policies[j].UserID
+	})
+	return policies, nil
+}
+
+func (m *MemoryStorage) SaveRetentionPolicy(policy *RetentionPolicy) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	policy.UpdatedAt = time.Now()
+	copied := *policy
+	m.policies[policy.UserID] = &copied
+	return nil
+}
+
+func (m *MemoryStorage) DeleteRetentionPolicy(userID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.policies, userID)
+	return nil
+}
+
+func (m *MemoryStorage) CreateIdempotencyRecord(record *IdempotencyRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id := [2]string{record.UserID, record.Key}
+	if existing, ok := m.idempotency[id]; ok && existing.ExpiresAt.After(time.Now()) {
+		return ErrIdempotencyKeyExists
+	}
+	if record.CreatedAt.IsZero() {
+		record.CreatedAt = time.Now()
+	}
+	m.idempotency[id] = copyIdempotencyRecord(record)
+	return nil
+}
+
+func (m *MemoryStorage) GetIdempotencyRecord(userID, key string) (*IdempotencyRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	record, ok := m.idempotency[[2]string{userID, key}]
+	if !ok {
+		return &IdempotencyRecord{}, gorm.ErrRecordNotFound
+	}
+	return copyIdempotencyRecord(record), nil
+}
+
+func (m *MemoryStorage) SaveIdempotencyResponse(userID, key string, response []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if record, ok := m.idempotency[[2]string{userID, key}]; ok {
+		record.Response = append([]byte(nil), response...)
+	}
+	return nil
+}
+
+func (m *MemoryStorage) DeleteIdempotencyRecord(userID, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.idempotency, [2]string{userID, key})
+	return nil
+}
+
+func (m *MemoryStorage) DeleteExpiredIdempotencyRecords(now time.Time) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var deleted int64
+	for id, record := range m.idempotency {
+		if !record.ExpiresAt.After(now) {
+			delete(m.idempotency, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (m *MemoryStorage) CreateCustomField(field *CustomField) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkCustomFieldName(field); err != nil {
+		return err
+	}
+	field.BeforeSave(nil)
+	now := time.Now()
+	if field.CreatedAt.IsZero() {
+		field.CreatedAt = now
+	}
+	if field.UpdatedAt.IsZero() {
+		field.UpdatedAt = now
+	}
+	m.fields[field.ID] = copyCustomField(field)
+	return nil
+}
+
+func (m *MemoryStorage) GetCustomFieldByID(id string) (*CustomField, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	field, ok := m.fields[id]
+	if !ok {
+		return &CustomField{}, gorm.ErrRecordNotFound
+	}
+	return copyCustomField(field), nil
+}
+
+func (m *MemoryStorage) ListCustomFields(userID string) ([]*CustomField, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var fields []*CustomField
+	for _, field := range m.fields {
+		if field.UserID == userID {
+			fields = append(fields, copyCustomField(field))
+		}
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Name < fields[j].Name
+	})
+	return fields, nil
+}
+
+func (m *MemoryStorage) UpdateCustomField(field *CustomField) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err := m.checkCustomFieldName(field); err != nil {
+		return err
+	}
+	if stored, ok := m.fields[field.ID]; ok {
+		stored.Name = field.Name
+		stored.Options = append([]string(nil), field.Options...)
+		stored.UpdatedAt = time.Now()
+	}
+	return nil
+}
+
+func (m *MemoryStorage) DeleteCustomField(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	field, ok := m.fields[id]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	for _, task := range m.tasks {
+		if task.UserID == field.UserID {
+			delete(task.CustomFields, id)
+		}
+	}
+	delete(m.fields, id)
+	return nil
+}
+
+func (m *MemoryStorage) checkCustomFieldName(field *CustomField) error {
+	for _, other := range m.fields {
+		if other.UserID == field.UserID && other.Name == field.Name && other.ID != field.ID {
+			return ErrDuplicateCustomFieldName
+		}
+	}
+	return nil
+}
+
+// paginate applies limit and offset like the SQL clauses, a negative limit
+// returns everything after offset
+func paginate[T any](items []T, limit, offset int) []T {
+	if offset >= len(items) {
+		return items[:0]
+	}
+	items = items[offset:]
+	if limit >= 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
+
+func copyTask(task *Task) *Task {
+	copied := *task
+	copied.CompletedAt = copyTime(task.CompletedAt)
+	copied.ArchivedAt = copyTime(task.ArchivedAt)
+	copied.UnarchivedAt = copyTime(task.UnarchivedAt)
+	copied.Tags = append([]string(nil), task.Tags...)
+	copied.CustomFields = make(CustomFieldValues, len(task.CustomFields))
+	for id, value := range task.CustomFields {
+		if options, ok := value.([]string); ok {
+			value = append([]string(nil), options...)
+		}
+		copied.CustomFields[id] = value
+	}
+	return &copied
+}
+
+func copyCustomField(field *CustomField) *CustomField {
+	copied := *field
+	copied.Options = append([]string(nil), field.Options...)
+	return &copied
+}
+
+func copyTimeEntry(entry *TimeEntry) *TimeEntry {
+	copied := *entry
+	copied.EndedAt = copyTime(entry.EndedAt)
+	return &copied
+}
+
+func copyTemplate(template *TaskTemplate) *TaskTemplate {
+	copied := *template
+	copied.Checklist = append([]string(nil), template.Checklist...)
+	copied.Tags = append([]string(nil), template.Tags...)
+	copied.ReminderOffsetsMinutes = append([]int64(nil), template.ReminderOffsetsMinutes...)
+	if template.DueOffsetMinutes != nil {
+		offset := *template.DueOffsetMinutes
+		copied.DueOffsetMinutes = &offset
+	}
+	return &copied
+}
+
+func copyView(view *SavedView) *SavedView {
+	copied := *view
+	copied.Query.Statuses = append([]string(nil), view.Query.Statuses...)
+	copied.Query.Tags = append([]string(nil), view.Query.Tags...)
+	copied.Query.CustomFields = append([]TaskQueryFieldFilter(nil), view.Query.CustomFields...)
+	return &copied
+}
+
+func copyIdempotencyRecord(record *IdempotencyRecord) *IdempotencyRecord {
+	copied := *record
+	copied.Response = append([]byte(nil), record.Response...)
+	return &copied
+}
+
+func copyTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	copied := *t
+	return &copied
+}
+
+func (m *MemoryStorage) AddTaskWatcher(watcher *TaskWatcher) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id := [2]string{watcher.TaskID, watcher.UserID}
+	if existing, ok := m.watchers[id]; ok {
+		existing.Email = watcher.Email
+		watcher.CreatedAt = existing.CreatedAt
+		return nil
+	}
+	if watcher.CreatedAt.IsZero() {
+		watcher.CreatedAt = time.Now()
+	}
+	copied := *watcher
+	m.watchers[id] = &copied
+	return nil
+}
+
+func (m *MemoryStorage) InviteTaskWatcher(taskID, userID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	id := [2]string{taskID, userID}
+	if _, ok := m.watchers[id]; !ok {
+		m.watchers[id] = &TaskWatcher{TaskID: taskID, UserID: userID, CreatedAt: time.Now()}
+	}
+	return nil
+}
+
+func (m *MemoryStorage) RemoveTaskWatcher(taskID, userID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.watchers, [2]string{taskID, userID})
+	return nil
+}
+
+func (m *MemoryStorage) ListTaskWatchers(taskID string) ([]*TaskWatcher, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var watchers []*TaskWatcher
+	for _, watcher := range m.watchers {
+		if watcher.TaskID == taskID {
+			copied := *watcher
+			watchers = append(watchers, &copied)
+		}
+	}
+	sort.Slice(watchers, func(i, j int) bool {
+		if !watchers[i].CreatedAt.Equal(watchers[j].CreatedAt) {
+			return watchers[i].CreatedAt.Before(watchers[j].CreatedAt)
+		}
+		return watchers[i].UserID < watchers[j].UserID
+	})
+	return watchers, nil
+}
+
+// deleteTaskWatchers drops the watchers of a deleted task. The caller holds
+// the lock.
+func (m *MemoryStorage) deleteTaskWatchers(taskID string) {
+	for id := range m.watchers {
+		if id[0] == taskID {
+			delete(m.watchers, id)
+		}
+	}
+}
+
+func (m *MemoryStorage) ListTaskReminders(taskID string) ([]*TaskReminder, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.sortedReminders(func(reminder *TaskReminder) bool {
+		return reminder.TaskID == taskID
+	}, -1), nil
+}
+
+func (m *MemoryStorage) ListDueTaskReminders(now time.Time, limit int) ([]*TaskReminder, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.sortedReminders(func(reminder *TaskReminder) bool {
+		return reminder.SentAt == nil && !reminder.RemindAt.After(now)
+	}, limit), nil
+}
+
+func (m *MemoryStorage) MarkTaskReminderSent(id string, sentAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if reminder, ok := m.reminders[id]; ok {
+		reminder.SentAt = &sentAt
+	}
+	return nil
+}
+
+// sortedReminders returns copies of up to limit reminders that match, the
+// earliest first. A negative limit returns all. The caller holds the lock.
+func (m *MemoryStorage) sortedReminders(match func(*TaskReminder) bool, limit int) []*TaskReminder {
+	var reminders []*TaskReminder
+	for _, reminder := range m.reminders {
+		if match(reminder) {
+			copied := *reminder
+			reminders = append(reminders, &copied)
+		}
+	}
+	sort.Slice(reminders, func(i, j int) bool {
+		if !reminders[i].RemindAt.Equal(reminders[j].RemindAt) {
+			return reminders[i].RemindAt.Before(reminders[j].RemindAt)
+		}
+		return reminders[i].ID < reminders[j].ID
+	})
+	if limit >= 0 && len(reminders) > limit {
+		reminders = reminders[:limit]
+	}
+	return reminders
+}
+
+// deleteTaskReminders drops the reminders of a deleted task. The caller holds
+// the lock.
+func (m *MemoryStorage) deleteTaskReminders(taskID string) {
+	for id, reminder := range m.reminders {
+		if reminder.TaskID == taskID {
+			delete(m.reminders, id)
+		}
+	}
+}
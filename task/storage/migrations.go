@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/go-gormigrate/gormigrate/v2"
@@ -8,15 +9,17 @@ import (
 	"gorm.io/gorm"
 )
 
-func MigrateDB(db *gorm.DB) *gorm.DB {
+// MigrateDB brings the schema up to date. The migrations run on Postgres as
+// well as SQLite.
+func MigrateDB(db *gorm.DB) error {
 	logger := common.Logger
 	logger = logger.WithField("method", "MigrateDB")
 	dbObj, err := db.DB()
 	if err != nil {
-		logger.WithError(err).Error("Failed to get db")
+		return fmt.Errorf("failed to get db: %w", err)
 	}
 	if err := dbObj.Ping(); err != nil {
-		logger.WithError(err).Fatalln("Could not ping DB")
+		return fmt.Errorf("could not ping db: %w", err)
 	}
 	logger.Info("running migrations")
 	options := &gormigrate.Options{
@@ -26,11 +29,11 @@ func MigrateDB(db *gorm.DB) *gorm.DB {
 
 	migration := gormigrate.New(db, options, migrations)
 	if err := migration.Migrate(); err != nil {
-		logger.WithError(err).Fatalln("failed to migrate database")
+		return fmt.Errorf("failed to migrate database: %w", err)
 	}
 
 	logger.Info("migrations completed")
-	return db
+	return nil
 }
 
 var migrations = []*gormigrate.Migration{
@@ -57,4 +60,412 @@ var migrations = []*gormigrate.Migration{
 			return tx.Migrator().DropTable("tasks")
 		},
 	},
+	{
+		ID: "202610191000",
+		Migrate: func(tx *gorm.DB) error {
+			type Task struct {
+				EstimateMinutes int32 `gorm:"not null;default:0"`
+			}
+			if err := tx.Migrator().AddColumn(&Task{}, "EstimateMinutes"); err != nil {
+				return err
+			}
+
+			type TimeEntry struct {
+				ID        string    `gorm:"size:255;not null;primary_key"`
+				TaskID    string    `gorm:"size:255;index:idx_time_entry_task_id;not null"`
+				UserID    string    `gorm:"size:255;index:idx_time_entry_user_id;not null"`
+				StartedAt time.Time `gorm:"not null"`
+				EndedAt   *time.Time
+				Note      string         `gorm:"type:text"`
+				CreatedAt time.Time      `gorm:"default:CURRENT_TIMESTAMP"`
+				UpdatedAt time.Time      `gorm:"default:CURRENT_TIMESTAMP"`
+				DeletedAt gorm.DeletedAt `gorm:"index"`
+			}
+			if err := tx.Migrator().CreateTable(&TimeEntry{}); err != nil {
+				return err
+			}
+
+			// Only one running timer per user
+			if err := tx.Exec("CREATE UNIQUE INDEX idx_time_entry_running ON time_entries (user_id) WHERE ended_at IS NULL AND deleted_at IS NULL").Error; err != nil {
+				return err
+			}
+
+			type TimeEntryRevision struct {
+				ID          string `gorm:"size:255;not null;primary_key"`
+				TimeEntryID string `gorm:"size:255;index:idx_time_entry_revision_entry_id;not null"`
+				UserID      string `gorm:"size:255;not null"`
+				Action      string `gorm:"size:50;not null"`
+				StartedAt   time.Time
+				EndedAt     *time.Time
+				Note        string    `gorm:"type:text"`
+				CreatedAt   time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+			}
+			return tx.Migrator().CreateTable(&TimeEntryRevision{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropTable("time_entry_revisions", "time_entries"); err != nil {
+				return err
+			}
+			type Task struct {
+				EstimateMinutes int32
+			}
+			return tx.Migrator().DropColumn(&Task{}, "EstimateMinutes")
+		},
+	},
+	{
+		ID: "202610191100",
+		Migrate: func(tx *gorm.DB) error {
+			type Task struct {
+				CompletedAt *time.Time
+			}
+			if err := tx.Migrator().AddColumn(&Task{}, "CompletedAt"); err != nil {
+				return err
+			}
+			// Best guess for tasks completed before completion time was tracked
+			return tx.Exec("UPDATE tasks SET completed_at = updated_at WHERE status = ?", "completed").Error
+		},
+		Rollback: func(tx *gorm.DB) error {
+			type Task struct {
+				CompletedAt *time.Time
+			}
+			return tx.Migrator().DropColumn(&Task{}, "CompletedAt")
+		},
+	}, {
+		ID: "202610191200",
+		Migrate: func(tx *gorm.DB) error {
+			type Task struct {
+				ID       string
+				UserID   string `gorm:"index:idx_task_board,priority:1"`
+				Status   string `gorm:"index:idx_task_board,priority:2"`
+				Position string `gorm:"size:255;index:idx_task_board,priority:3;not null;default:''"`
+			}
+			if err := tx.Migrator().AddColumn(&Task{}, "Position"); err != nil {
+				return err
+			}
+			if err := tx.Migrator().CreateIndex(&Task{}, "idx_task_board"); err != nil {
+				return err
+			}
+
+			// Existing tasks keep their creation order within each column
+			var tasks []Task
+			if err := tx.Order("user_id, status, created_at").Find(&tasks).Error; err != nil {
+				return err
+			}
+			for start := 0; start < len(tasks); {
+				end := start
+				for end < len(tasks) && tasks[end].UserID == tasks[start].UserID && tasks[end].Status == tasks[start].Status {
+					end++
+				}
+				for i, rank := range EvenRanks(end - start) {
+					if err := tx.Model(&Task{}).Where("id = ?", tasks[start+i].ID).UpdateColumn("position", rank).Error; err != nil {
+						return err
+					}
+				}
+				start = end
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			type Task struct {
+				Position string
+			}
+			if err := tx.Migrator().DropIndex(&Task{}, "idx_task_board"); err != nil {
+				return err
+			}
+			return tx.Migrator().DropColumn(&Task{}, "Position")
+		},
+	}, {
+		ID: "202610191300",
+		Migrate: func(tx *gorm.DB) error {
+			type Task struct {
+				Tags []string `gorm:"serializer:json;type:text"`
+			}
+			if err := tx.Migrator().AddColumn(&Task{}, "Tags"); err != nil {
+				return err
+			}
+
+			type TaskTemplate struct {
+				ID                     string   `gorm:"size:255;not null;primary_key"`
+				UserID                 string   `gorm:"size:255;uniqueIndex:idx_task_template_user_name;not null"`
+				Name                   string   `gorm:"size:255;uniqueIndex:idx_task_template_user_name;not null"`
+				Title                  string   `gorm:"size:255;not null"`
+				Description            string   `gorm:"type:text"`
+				Checklist              []string `gorm:"serializer:json;type:text"`
+				Tags                   []string `gorm:"serializer:json;type:text"`
+				DueOffsetMinutes       *int64
+				ReminderOffsetsMinutes []int64   `gorm:"serializer:json;type:text"`
+				EstimateMinutes        int32     `gorm:"not null;default:0"`
+				CreatedAt              time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+				UpdatedAt              time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+			}
+			return tx.Migrator().CreateTable(&TaskTemplate{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropTable("task_templates"); err != nil {
+				return err
+			}
+			type Task struct {
+				Tags []string
+			}
+			return tx.Migrator().DropColumn(&Task{}, "Tags")
+		},
+	}, {
+		ID: "202610191400",
+		Migrate: func(tx *gorm.DB) error {
+			type Task struct {
+				DueAllDay bool `gorm:"not null;default:false"`
+			}
+			return tx.Migrator().AddColumn(&Task{}, "DueAllDay")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			type Task struct {
+				DueAllDay bool
+			}
+			return tx.Migrator().DropColumn(&Task{}, "DueAllDay")
+		},
+	}, {
+		ID: "202610191500",
+		Migrate: func(tx *gorm.DB) error {
+			type Task struct {
+				CustomFields CustomFieldValues `gorm:"not null;default:'{}'"`
+			}
+			if err := tx.Migrator().AddColumn(&Task{}, "CustomFields"); err != nil {
+				return err
+			}
+			if tx.Dialector.Name() == "postgres" {
+				err := tx.Exec("CREATE INDEX idx_task_custom_fields ON tasks USING gin (custom_fields)").Error
+				if err != nil {
+					return err
+				}
+			}
+
+			type CustomField struct {
+				ID        string    `gorm:"size:255;not null;primary_key"`
+				UserID    string    `gorm:"size:255;uniqueIndex:idx_custom_field_user_name;not null"`
+				Name      string    `gorm:"size:255;uniqueIndex:idx_custom_field_user_name;not null"`
+				Type      string    `gorm:"size:50;not null"`
+				Options   []string  `gorm:"serializer:json;type:text"`
+				CreatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+				UpdatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+			}
+			return tx.Migrator().CreateTable(&CustomField{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropTable("custom_fields"); err != nil {
+				return err
+			}
+			type Task struct {
+				CustomFields CustomFieldValues
+			}
+			return tx.Migrator().DropColumn(&Task{}, "CustomFields")
+		},
+	},
+	{
+		ID: "202610191600",
+		Migrate: func(tx *gorm.DB) error {
+			type SavedView struct {
+				ID        string    `gorm:"size:255;not null;primary_key"`
+				UserID    string    `gorm:"size:255;uniqueIndex:idx_saved_view_user_name;not null"`
+				Name      string    `gorm:"size:255;uniqueIndex:idx_saved_view_user_name;not null"`
+				Query     TaskQuery `gorm:"serializer:json;type:text"`
+				GroupBy   string    `gorm:"size:255;not null;default:''"`
+				CreatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+				UpdatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+			}
+			return tx.Migrator().CreateTable(&SavedView{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable("saved_views")
+		},
+	},
+	{
+		ID: "202610191700",
+		Migrate: func(tx *gorm.DB) error {
+			type Task struct {
+				ArchivedAt *time.Time `gorm:"index:idx_task_archived_at"`
+			}
+			if err := tx.Migrator().AddColumn(&Task{}, "ArchivedAt"); err != nil {
+				return err
+			}
+			if err := tx.Migrator().CreateIndex(&Task{}, "idx_task_archived_at"); err != nil {
+				return err
+			}
+
+			type RetentionPolicy struct {
+				UserID           string    `gorm:"size:255;not null;primary_key"`
+				ArchiveAfterDays int32     `gorm:"not null;default:0"`
+				PurgeAfterDays   int32     `gorm:"not null;default:0"`
+				UpdatedAt        time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+			}
+			return tx.Migrator().CreateTable(&RetentionPolicy{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropTable("retention_policies"); err != nil {
+				return err
+			}
+			type Task struct {
+				ArchivedAt *time.Time `gorm:"index:idx_task_archived_at"`
+			}
+			if err := tx.Migrator().DropIndex(&Task{}, "idx_task_archived_at"); err != nil {
+				return err
+			}
+			return tx.Migrator().DropColumn(&Task{}, "ArchivedAt")
+		},
+	},
+	{
+		ID: "202610191800",
+		Migrate: func(tx *gorm.DB) error {
+			type IdempotencyRecord struct {
+				UserID      string `gorm:"size:255;not null;primary_key"`
+				Key         string `gorm:"column:idempotency_key;size:255;not null;primary_key"`
+				RequestHash string `gorm:"size:64;not null"`
+				Response    []byte
+				CreatedAt   time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+				ExpiresAt   time.Time `gorm:"not null;index:idx_idempotency_record_expires_at"`
+			}
+			return tx.Migrator().CreateTable(&IdempotencyRecord{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable("idempotency_records")
+		},
+	},
+	{
+		ID: "202610191900",
+		Migrate: func(tx *gorm.DB) error {
+			type Task struct {
+				UserEmail string `gorm:"size:255;not null;default:''"`
+			}
+			if err := tx.Migrator().AddColumn(&Task{}, "UserEmail"); err != nil {
+				return err
+			}
+
+			type TaskWatcher struct {
+				TaskID    string    `gorm:"size:255;not null;primary_key"`
+				UserID    string    `gorm:"size:255;not null;primary_key;index:idx_task_watcher_user_id"`
+				Email     string    `gorm:"size:255;not null"`
+				CreatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+			}
+			return tx.Migrator().CreateTable(&TaskWatcher{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			if err := tx.Migrator().DropTable("task_watchers"); err != nil {
+				return err
+			}
+			type Task struct {
+				UserEmail string `gorm:"size:255;not null;default:''"`
+			}
+			return tx.Migrator().DropColumn(&Task{}, "UserEmail")
+		},
+	},
+	{
+		ID: "202610192000",
+		Migrate: func(tx *gorm.DB) error {
+			type Task struct {
+				Priority int32 `gorm:"not null;default:0;index:idx_task_priority"`
+			}
+			if err := tx.Migrator().AddColumn(&Task{}, "Priority"); err != nil {
+				return err
+			}
+			return tx.Migrator().CreateIndex(&Task{}, "idx_task_priority")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			type Task struct {
+				Priority int32 `gorm:"not null;default:0;index:idx_task_priority"`
+			}
+			if err := tx.Migrator().DropIndex(&Task{}, "idx_task_priority"); err != nil {
+				return err
+			}
+			return tx.Migrator().DropColumn(&Task{}, "Priority")
+		},
+	},
+	{
+		ID: "202610201000",
+		Migrate: func(tx *gorm.DB) error {
+			type TaskReminder struct {
+				ID        string    `gorm:"size:255;not null;primary_key"`
+				TaskID    string    `gorm:"size:255;index:idx_task_reminder_task_id;not null"`
+				RemindAt  time.Time `gorm:"index:idx_task_reminder_remind_at;not null"`
+				SentAt    *time.Time
+				CreatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+			}
+			return tx.Migrator().CreateTable(&TaskReminder{})
+		},
+		Rollback: func(tx *gorm.DB) error {
+			return tx.Migrator().DropTable("task_reminders")
+		},
+	},
+	{
+		ID: "202610211000",
+		Migrate: func(tx *gorm.DB) error {
+			type TaskReminder struct {
+				ID            string
+				TaskID        string
+				OffsetMinutes int64 `gorm:"not null;default:0"`
+				RemindAt      time.Time
+			}
+			if err := tx.Migrator().AddColumn(&TaskReminder{}, "OffsetMinutes"); err != nil {
+				return err
+			}
+
+			// Existing reminders keep their time before the current due date
+			var reminders []struct {
+				ID       string
+				RemindAt time.Time
+				DueDate  time.Time
+			}
+			err := tx.Table("task_reminders").
+				Select("task_reminders.id, task_reminders.remind_at, tasks.due_date").
+				Joins("JOIN tasks ON tasks.id = task_reminders.task_id").
+				Scan(&reminders).Error
+			if err != nil {
+				return err
+			}
+			for _, reminder := range reminders {
+				offset := int64(reminder.DueDate.Sub(reminder.RemindAt) / time.Minute)
+				if err := tx.Model(&TaskReminder{}).Where("id = ?", reminder.ID).UpdateColumn("offset_minutes", offset).Error; err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+		Rollback: func(tx *gorm.DB) error {
+			type TaskReminder struct {
+				OffsetMinutes int64
+			}
+			return tx.Migrator().DropColumn(&TaskReminder{}, "OffsetMinutes")
+		},
+	},
+	{
+		ID: "202610221000",
+		Migrate: func(tx *gorm.DB) error {
+			type Task struct {
+				UnarchivedAt *time.Time
+			}
+			return tx.Migrator().AddColumn(&Task{}, "UnarchivedAt")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			type Task struct {
+				UnarchivedAt *time.Time
+			}
+			return tx.Migrator().DropColumn(&Task{}, "UnarchivedAt")
+		},
+	},
+	{
+		ID: "202610231000",
+		Migrate: func(tx *gorm.DB) error {
+			type SavedView struct {
+				Visibility string `gorm:"size:50;not null;default:'private';index:idx_saved_view_visibility"`
+			}
+			if err := tx.Migrator().AddColumn(&SavedView{}, "Visibility"); err != nil {
+				return err
+			}
+			return tx.Migrator().CreateIndex(&SavedView{}, "idx_saved_view_visibility")
+		},
+		Rollback: func(tx *gorm.DB) error {
+			type SavedView struct {
+				Visibility string
+			}
+			return tx.Migrator().DropColumn(&SavedView{}, "Visibility")
+		},
+	},
 }
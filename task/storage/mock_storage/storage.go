@@ -11,6 +11,7 @@ package mock_storage
 
 import (
 	reflect "reflect"
+	time "time"
 
 	storage "github.com/sejamuchhal/taskhub/task/storage"
 	gomock "go.uber.org/mock/gomock"
@@ -39,6 +40,77 @@ func (m *MockStorageInterface) EXPECT() *MockStorageInterfaceMockRecorder {
 	return m.recorder
 }
 
+// AddTaskWatcher mocks base method.
+func (m *MockStorageInterface) AddTaskWatcher(arg0 *storage.TaskWatcher) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTaskWatcher", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddTaskWatcher indicates an expected call of AddTaskWatcher.
+func (mr *MockStorageInterfaceMockRecorder) AddTaskWatcher(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTaskWatcher", reflect.TypeOf((*MockStorageInterface)(nil).AddTaskWatcher), arg0)
+}
+
+// ArchiveCompletedTasks mocks base method.
+func (m *MockStorageInterface) ArchiveCompletedTasks(arg0 storage.RetentionScope, arg1, arg2 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ArchiveCompletedTasks", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ArchiveCompletedTasks indicates an expected call of ArchiveCompletedTasks.
+func (mr *MockStorageInterfaceMockRecorder) ArchiveCompletedTasks(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ArchiveCompletedTasks", reflect.TypeOf((*MockStorageInterface)(nil).ArchiveCompletedTasks), arg0, arg1, arg2)
+}
+
+// CreateCustomField mocks base method.
+func (m *MockStorageInterface) CreateCustomField(arg0 *storage.CustomField) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCustomField", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateCustomField indicates an expected call of CreateCustomField.
+func (mr *MockStorageInterfaceMockRecorder) CreateCustomField(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCustomField", reflect.TypeOf((*MockStorageInterface)(nil).CreateCustomField), arg0)
+}
+
+// CreateIdempotencyRecord mocks base method.
+func (m *MockStorageInterface) CreateIdempotencyRecord(arg0 *storage.IdempotencyRecord) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIdempotencyRecord", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateIdempotencyRecord indicates an expected call of CreateIdempotencyRecord.
+func (mr *MockStorageInterfaceMockRecorder) CreateIdempotencyRecord(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIdempotencyRecord", reflect.TypeOf((*MockStorageInterface)(nil).CreateIdempotencyRecord), arg0)
+}
+
+// CreateSavedView mocks base method.
+func (m *MockStorageInterface) CreateSavedView(arg0 *storage.SavedView) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSavedView", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSavedView indicates an expected call of CreateSavedView.
+func (mr *MockStorageInterfaceMockRecorder) CreateSavedView(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSavedView", reflect.TypeOf((*MockStorageInterface)(nil).CreateSavedView), arg0)
+}
+
 // CreateTask mocks base method.
 func (m *MockStorageInterface) CreateTask(arg0 *storage.Task) error {
 	m.ctrl.T.Helper()
@@ -53,6 +125,119 @@ func (mr *MockStorageInterfaceMockRecorder) CreateTask(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTask", reflect.TypeOf((*MockStorageInterface)(nil).CreateTask), arg0)
 }
 
+// CreateTaskWithReminders mocks base method.
+func (m *MockStorageInterface) CreateTaskWithReminders(arg0 *storage.Task, arg1 []*storage.TaskReminder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTaskWithReminders", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTaskWithReminders indicates an expected call of CreateTaskWithReminders.
+func (mr *MockStorageInterfaceMockRecorder) CreateTaskWithReminders(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTaskWithReminders", reflect.TypeOf((*MockStorageInterface)(nil).CreateTaskWithReminders), arg0, arg1)
+}
+
+// CreateTemplate mocks base method.
+func (m *MockStorageInterface) CreateTemplate(arg0 *storage.TaskTemplate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTemplate", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTemplate indicates an expected call of CreateTemplate.
+func (mr *MockStorageInterfaceMockRecorder) CreateTemplate(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTemplate", reflect.TypeOf((*MockStorageInterface)(nil).CreateTemplate), arg0)
+}
+
+// CreateTimeEntry mocks base method.
+func (m *MockStorageInterface) CreateTimeEntry(arg0 *storage.TimeEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTimeEntry", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateTimeEntry indicates an expected call of CreateTimeEntry.
+func (mr *MockStorageInterfaceMockRecorder) CreateTimeEntry(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTimeEntry", reflect.TypeOf((*MockStorageInterface)(nil).CreateTimeEntry), arg0)
+}
+
+// DeleteCustomField mocks base method.
+func (m *MockStorageInterface) DeleteCustomField(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCustomField", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCustomField indicates an expected call of DeleteCustomField.
+func (mr *MockStorageInterfaceMockRecorder) DeleteCustomField(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCustomField", reflect.TypeOf((*MockStorageInterface)(nil).DeleteCustomField), arg0)
+}
+
+// DeleteExpiredIdempotencyRecords mocks base method.
+func (m *MockStorageInterface) DeleteExpiredIdempotencyRecords(arg0 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteExpiredIdempotencyRecords", arg0)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteExpiredIdempotencyRecords indicates an expected call of DeleteExpiredIdempotencyRecords.
+func (mr *MockStorageInterfaceMockRecorder) DeleteExpiredIdempotencyRecords(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteExpiredIdempotencyRecords", reflect.TypeOf((*MockStorageInterface)(nil).DeleteExpiredIdempotencyRecords), arg0)
+}
+
+// DeleteIdempotencyRecord mocks base method.
+func (m *MockStorageInterface) DeleteIdempotencyRecord(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIdempotencyRecord", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIdempotencyRecord indicates an expected call of DeleteIdempotencyRecord.
+func (mr *MockStorageInterfaceMockRecorder) DeleteIdempotencyRecord(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIdempotencyRecord", reflect.TypeOf((*MockStorageInterface)(nil).DeleteIdempotencyRecord), arg0, arg1)
+}
+
+// DeleteRetentionPolicy mocks base method.
+func (m *MockStorageInterface) DeleteRetentionPolicy(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteRetentionPolicy", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteRetentionPolicy indicates an expected call of DeleteRetentionPolicy.
+func (mr *MockStorageInterfaceMockRecorder) DeleteRetentionPolicy(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteRetentionPolicy", reflect.TypeOf((*MockStorageInterface)(nil).DeleteRetentionPolicy), arg0)
+}
+
+// DeleteSavedView mocks base method.
+func (m *MockStorageInterface) DeleteSavedView(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSavedView", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSavedView indicates an expected call of DeleteSavedView.
+func (mr *MockStorageInterfaceMockRecorder) DeleteSavedView(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSavedView", reflect.TypeOf((*MockStorageInterface)(nil).DeleteSavedView), arg0)
+}
+
 // DeleteTask mocks base method.
 func (m *MockStorageInterface) DeleteTask(arg0 string) error {
 	m.ctrl.T.Helper()
@@ -67,6 +252,94 @@ func (mr *MockStorageInterfaceMockRecorder) DeleteTask(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTask", reflect.TypeOf((*MockStorageInterface)(nil).DeleteTask), arg0)
 }
 
+// DeleteTemplate mocks base method.
+func (m *MockStorageInterface) DeleteTemplate(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemplate", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTemplate indicates an expected call of DeleteTemplate.
+func (mr *MockStorageInterfaceMockRecorder) DeleteTemplate(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplate", reflect.TypeOf((*MockStorageInterface)(nil).DeleteTemplate), arg0)
+}
+
+// DeleteTimeEntry mocks base method.
+func (m *MockStorageInterface) DeleteTimeEntry(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTimeEntry", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTimeEntry indicates an expected call of DeleteTimeEntry.
+func (mr *MockStorageInterfaceMockRecorder) DeleteTimeEntry(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTimeEntry", reflect.TypeOf((*MockStorageInterface)(nil).DeleteTimeEntry), arg0)
+}
+
+// GetCustomFieldByID mocks base method.
+func (m *MockStorageInterface) GetCustomFieldByID(arg0 string) (*storage.CustomField, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetCustomFieldByID", arg0)
+	ret0, _ := ret[0].(*storage.CustomField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetCustomFieldByID indicates an expected call of GetCustomFieldByID.
+func (mr *MockStorageInterfaceMockRecorder) GetCustomFieldByID(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetCustomFieldByID", reflect.TypeOf((*MockStorageInterface)(nil).GetCustomFieldByID), arg0)
+}
+
+// GetIdempotencyRecord mocks base method.
+func (m *MockStorageInterface) GetIdempotencyRecord(arg0, arg1 string) (*storage.IdempotencyRecord, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIdempotencyRecord", arg0, arg1)
+	ret0, _ := ret[0].(*storage.IdempotencyRecord)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIdempotencyRecord indicates an expected call of GetIdempotencyRecord.
+func (mr *MockStorageInterfaceMockRecorder) GetIdempotencyRecord(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIdempotencyRecord", reflect.TypeOf((*MockStorageInterface)(nil).GetIdempotencyRecord), arg0, arg1)
+}
+
+// GetRetentionPolicy mocks base method.
+func (m *MockStorageInterface) GetRetentionPolicy(arg0 string) (*storage.RetentionPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRetentionPolicy", arg0)
+	ret0, _ := ret[0].(*storage.RetentionPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRetentionPolicy indicates an expected call of GetRetentionPolicy.
+func (mr *MockStorageInterfaceMockRecorder) GetRetentionPolicy(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRetentionPolicy", reflect.TypeOf((*MockStorageInterface)(nil).GetRetentionPolicy), arg0)
+}
+
+// GetSavedViewByID mocks base method.
+func (m *MockStorageInterface) GetSavedViewByID(arg0 string) (*storage.SavedView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSavedViewByID", arg0)
+	ret0, _ := ret[0].(*storage.SavedView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSavedViewByID indicates an expected call of GetSavedViewByID.
+func (mr *MockStorageInterfaceMockRecorder) GetSavedViewByID(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSavedViewByID", reflect.TypeOf((*MockStorageInterface)(nil).GetSavedViewByID), arg0)
+}
+
 // GetTaskByID mocks base method.
 func (m *MockStorageInterface) GetTaskByID(arg0 string) (*storage.Task, error) {
 	m.ctrl.T.Helper()
@@ -82,8 +355,187 @@ func (mr *MockStorageInterfaceMockRecorder) GetTaskByID(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskByID", reflect.TypeOf((*MockStorageInterface)(nil).GetTaskByID), arg0)
 }
 
+// GetTaskStats mocks base method.
+func (m *MockStorageInterface) GetTaskStats(arg0 storage.TaskStatsQuery) (*storage.TaskStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTaskStats", arg0)
+	ret0, _ := ret[0].(*storage.TaskStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTaskStats indicates an expected call of GetTaskStats.
+func (mr *MockStorageInterfaceMockRecorder) GetTaskStats(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTaskStats", reflect.TypeOf((*MockStorageInterface)(nil).GetTaskStats), arg0)
+}
+
+// GetTasksByIDs mocks base method.
+func (m *MockStorageInterface) GetTasksByIDs(arg0 []string) ([]*storage.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTasksByIDs", arg0)
+	ret0, _ := ret[0].([]*storage.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTasksByIDs indicates an expected call of GetTasksByIDs.
+func (mr *MockStorageInterfaceMockRecorder) GetTasksByIDs(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTasksByIDs", reflect.TypeOf((*MockStorageInterface)(nil).GetTasksByIDs), arg0)
+}
+
+// GetTemplateByID mocks base method.
+func (m *MockStorageInterface) GetTemplateByID(arg0 string) (*storage.TaskTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTemplateByID", arg0)
+	ret0, _ := ret[0].(*storage.TaskTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTemplateByID indicates an expected call of GetTemplateByID.
+func (mr *MockStorageInterfaceMockRecorder) GetTemplateByID(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTemplateByID", reflect.TypeOf((*MockStorageInterface)(nil).GetTemplateByID), arg0)
+}
+
+// GetTimeEntryByID mocks base method.
+func (m *MockStorageInterface) GetTimeEntryByID(arg0 string) (*storage.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTimeEntryByID", arg0)
+	ret0, _ := ret[0].(*storage.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTimeEntryByID indicates an expected call of GetTimeEntryByID.
+func (mr *MockStorageInterfaceMockRecorder) GetTimeEntryByID(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTimeEntryByID", reflect.TypeOf((*MockStorageInterface)(nil).GetTimeEntryByID), arg0)
+}
+
+// InviteTaskWatcher mocks base method.
+func (m *MockStorageInterface) InviteTaskWatcher(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InviteTaskWatcher", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InviteTaskWatcher indicates an expected call of InviteTaskWatcher.
+func (mr *MockStorageInterfaceMockRecorder) InviteTaskWatcher(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InviteTaskWatcher", reflect.TypeOf((*MockStorageInterface)(nil).InviteTaskWatcher), arg0, arg1)
+}
+
+// ListBoardTasks mocks base method.
+func (m *MockStorageInterface) ListBoardTasks(arg0 string) ([]*storage.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBoardTasks", arg0)
+	ret0, _ := ret[0].([]*storage.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBoardTasks indicates an expected call of ListBoardTasks.
+func (mr *MockStorageInterfaceMockRecorder) ListBoardTasks(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBoardTasks", reflect.TypeOf((*MockStorageInterface)(nil).ListBoardTasks), arg0)
+}
+
+// ListCustomFields mocks base method.
+func (m *MockStorageInterface) ListCustomFields(arg0 string) ([]*storage.CustomField, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCustomFields", arg0)
+	ret0, _ := ret[0].([]*storage.CustomField)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCustomFields indicates an expected call of ListCustomFields.
+func (mr *MockStorageInterfaceMockRecorder) ListCustomFields(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCustomFields", reflect.TypeOf((*MockStorageInterface)(nil).ListCustomFields), arg0)
+}
+
+// ListDueTaskReminders mocks base method.
+func (m *MockStorageInterface) ListDueTaskReminders(arg0 time.Time, arg1 int) ([]*storage.TaskReminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDueTaskReminders", arg0, arg1)
+	ret0, _ := ret[0].([]*storage.TaskReminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDueTaskReminders indicates an expected call of ListDueTaskReminders.
+func (mr *MockStorageInterfaceMockRecorder) ListDueTaskReminders(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDueTaskReminders", reflect.TypeOf((*MockStorageInterface)(nil).ListDueTaskReminders), arg0, arg1)
+}
+
+// ListRetentionPolicies mocks base method.
+func (m *MockStorageInterface) ListRetentionPolicies() ([]*storage.RetentionPolicy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListRetentionPolicies")
+	ret0, _ := ret[0].([]*storage.RetentionPolicy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListRetentionPolicies indicates an expected call of ListRetentionPolicies.
+func (mr *MockStorageInterfaceMockRecorder) ListRetentionPolicies() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListRetentionPolicies", reflect.TypeOf((*MockStorageInterface)(nil).ListRetentionPolicies))
+}
+
+// ListSavedViews mocks base method.
+func (m *MockStorageInterface) ListSavedViews(arg0 string) ([]*storage.SavedView, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSavedViews", arg0)
+	ret0, _ := ret[0].([]*storage.SavedView)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSavedViews indicates an expected call of ListSavedViews.
+func (mr *MockStorageInterfaceMockRecorder) ListSavedViews(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSavedViews", reflect.TypeOf((*MockStorageInterface)(nil).ListSavedViews), arg0)
+}
+
+// ListTaskReminders mocks base method.
+func (m *MockStorageInterface) ListTaskReminders(arg0 string) ([]*storage.TaskReminder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskReminders", arg0)
+	ret0, _ := ret[0].([]*storage.TaskReminder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskReminders indicates an expected call of ListTaskReminders.
+func (mr *MockStorageInterfaceMockRecorder) ListTaskReminders(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskReminders", reflect.TypeOf((*MockStorageInterface)(nil).ListTaskReminders), arg0)
+}
+
+// ListTaskWatchers mocks base method.
+func (m *MockStorageInterface) ListTaskWatchers(arg0 string) ([]*storage.TaskWatcher, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskWatchers", arg0)
+	ret0, _ := ret[0].([]*storage.TaskWatcher)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskWatchers indicates an expected call of ListTaskWatchers.
+func (mr *MockStorageInterfaceMockRecorder) ListTaskWatchers(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskWatchers", reflect.TypeOf((*MockStorageInterface)(nil).ListTaskWatchers), arg0)
+}
+
 // ListTasksWithCount mocks base method.
-func (m *MockStorageInterface) ListTasksWithCount(arg0 string, arg1, arg2 int) ([]*storage.Task, int64, error) {
+func (m *MockStorageInterface) ListTasksWithCount(arg0 storage.TaskFilter, arg1, arg2 int) ([]*storage.Task, int64, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ListTasksWithCount", arg0, arg1, arg2)
 	ret0, _ := ret[0].([]*storage.Task)
@@ -98,6 +550,209 @@ func (mr *MockStorageInterfaceMockRecorder) ListTasksWithCount(arg0, arg1, arg2
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasksWithCount", reflect.TypeOf((*MockStorageInterface)(nil).ListTasksWithCount), arg0, arg1, arg2)
 }
 
+// ListTemplates mocks base method.
+func (m *MockStorageInterface) ListTemplates(arg0 string) ([]*storage.TaskTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTemplates", arg0)
+	ret0, _ := ret[0].([]*storage.TaskTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTemplates indicates an expected call of ListTemplates.
+func (mr *MockStorageInterfaceMockRecorder) ListTemplates(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTemplates", reflect.TypeOf((*MockStorageInterface)(nil).ListTemplates), arg0)
+}
+
+// ListTimeEntriesWithCount mocks base method.
+func (m *MockStorageInterface) ListTimeEntriesWithCount(arg0 storage.TimeEntryFilter, arg1, arg2 int) ([]*storage.TimeEntry, int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTimeEntriesWithCount", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*storage.TimeEntry)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ListTimeEntriesWithCount indicates an expected call of ListTimeEntriesWithCount.
+func (mr *MockStorageInterfaceMockRecorder) ListTimeEntriesWithCount(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTimeEntriesWithCount", reflect.TypeOf((*MockStorageInterface)(nil).ListTimeEntriesWithCount), arg0, arg1, arg2)
+}
+
+// ListTimeEntryRevisions mocks base method.
+func (m *MockStorageInterface) ListTimeEntryRevisions(arg0 string) ([]*storage.TimeEntryRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTimeEntryRevisions", arg0)
+	ret0, _ := ret[0].([]*storage.TimeEntryRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTimeEntryRevisions indicates an expected call of ListTimeEntryRevisions.
+func (mr *MockStorageInterfaceMockRecorder) ListTimeEntryRevisions(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTimeEntryRevisions", reflect.TypeOf((*MockStorageInterface)(nil).ListTimeEntryRevisions), arg0)
+}
+
+// MarkTaskReminderSent mocks base method.
+func (m *MockStorageInterface) MarkTaskReminderSent(arg0 string, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkTaskReminderSent", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkTaskReminderSent indicates an expected call of MarkTaskReminderSent.
+func (mr *MockStorageInterfaceMockRecorder) MarkTaskReminderSent(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkTaskReminderSent", reflect.TypeOf((*MockStorageInterface)(nil).MarkTaskReminderSent), arg0, arg1)
+}
+
+// MoveTask mocks base method.
+func (m *MockStorageInterface) MoveTask(arg0 storage.TaskMove) (*storage.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveTask", arg0)
+	ret0, _ := ret[0].(*storage.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveTask indicates an expected call of MoveTask.
+func (mr *MockStorageInterfaceMockRecorder) MoveTask(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTask", reflect.TypeOf((*MockStorageInterface)(nil).MoveTask), arg0)
+}
+
+// PurgeArchivedTasks mocks base method.
+func (m *MockStorageInterface) PurgeArchivedTasks(arg0 storage.RetentionScope, arg1 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeArchivedTasks", arg0, arg1)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeArchivedTasks indicates an expected call of PurgeArchivedTasks.
+func (mr *MockStorageInterfaceMockRecorder) PurgeArchivedTasks(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeArchivedTasks", reflect.TypeOf((*MockStorageInterface)(nil).PurgeArchivedTasks), arg0, arg1)
+}
+
+// RemoveTaskWatcher mocks base method.
+func (m *MockStorageInterface) RemoveTaskWatcher(arg0, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveTaskWatcher", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveTaskWatcher indicates an expected call of RemoveTaskWatcher.
+func (mr *MockStorageInterfaceMockRecorder) RemoveTaskWatcher(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveTaskWatcher", reflect.TypeOf((*MockStorageInterface)(nil).RemoveTaskWatcher), arg0, arg1)
+}
+
+// SaveIdempotencyResponse mocks base method.
+func (m *MockStorageInterface) SaveIdempotencyResponse(arg0, arg1 string, arg2 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveIdempotencyResponse", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveIdempotencyResponse indicates an expected call of SaveIdempotencyResponse.
+func (mr *MockStorageInterfaceMockRecorder) SaveIdempotencyResponse(arg0, arg1, arg2 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveIdempotencyResponse", reflect.TypeOf((*MockStorageInterface)(nil).SaveIdempotencyResponse), arg0, arg1, arg2)
+}
+
+// SaveRetentionPolicy mocks base method.
+func (m *MockStorageInterface) SaveRetentionPolicy(arg0 *storage.RetentionPolicy) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRetentionPolicy", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveRetentionPolicy indicates an expected call of SaveRetentionPolicy.
+func (mr *MockStorageInterfaceMockRecorder) SaveRetentionPolicy(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRetentionPolicy", reflect.TypeOf((*MockStorageInterface)(nil).SaveRetentionPolicy), arg0)
+}
+
+// SetTaskArchived mocks base method.
+func (m *MockStorageInterface) SetTaskArchived(arg0 string, arg1 *time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTaskArchived", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTaskArchived indicates an expected call of SetTaskArchived.
+func (mr *MockStorageInterfaceMockRecorder) SetTaskArchived(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTaskArchived", reflect.TypeOf((*MockStorageInterface)(nil).SetTaskArchived), arg0, arg1)
+}
+
+// StartTimer mocks base method.
+func (m *MockStorageInterface) StartTimer(arg0 *storage.TimeEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartTimer", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartTimer indicates an expected call of StartTimer.
+func (mr *MockStorageInterfaceMockRecorder) StartTimer(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartTimer", reflect.TypeOf((*MockStorageInterface)(nil).StartTimer), arg0)
+}
+
+// StopTimer mocks base method.
+func (m *MockStorageInterface) StopTimer(arg0 string, arg1 time.Time) (*storage.TimeEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StopTimer", arg0, arg1)
+	ret0, _ := ret[0].(*storage.TimeEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StopTimer indicates an expected call of StopTimer.
+func (mr *MockStorageInterfaceMockRecorder) StopTimer(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopTimer", reflect.TypeOf((*MockStorageInterface)(nil).StopTimer), arg0, arg1)
+}
+
+// UpdateCustomField mocks base method.
+func (m *MockStorageInterface) UpdateCustomField(arg0 *storage.CustomField) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCustomField", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCustomField indicates an expected call of UpdateCustomField.
+func (mr *MockStorageInterfaceMockRecorder) UpdateCustomField(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCustomField", reflect.TypeOf((*MockStorageInterface)(nil).UpdateCustomField), arg0)
+}
+
+// UpdateSavedView mocks base method.
+func (m *MockStorageInterface) UpdateSavedView(arg0 *storage.SavedView) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSavedView", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSavedView indicates an expected call of UpdateSavedView.
+func (mr *MockStorageInterfaceMockRecorder) UpdateSavedView(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSavedView", reflect.TypeOf((*MockStorageInterface)(nil).UpdateSavedView), arg0)
+}
+
 // UpdateTask mocks base method.
 func (m *MockStorageInterface) UpdateTask(arg0 *storage.Task) error {
 	m.ctrl.T.Helper()
@@ -111,3 +766,31 @@ func (mr *MockStorageInterfaceMockRecorder) UpdateTask(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTask", reflect.TypeOf((*MockStorageInterface)(nil).UpdateTask), arg0)
 }
+
+// UpdateTemplate mocks base method.
+func (m *MockStorageInterface) UpdateTemplate(arg0 *storage.TaskTemplate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTemplate", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTemplate indicates an expected call of UpdateTemplate.
+func (mr *MockStorageInterfaceMockRecorder) UpdateTemplate(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTemplate", reflect.TypeOf((*MockStorageInterface)(nil).UpdateTemplate), arg0)
+}
+
+// UpdateTimeEntry mocks base method.
+func (m *MockStorageInterface) UpdateTimeEntry(arg0 *storage.TimeEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTimeEntry", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTimeEntry indicates an expected call of UpdateTimeEntry.
+func (mr *MockStorageInterfaceMockRecorder) UpdateTimeEntry(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTimeEntry", reflect.TypeOf((*MockStorageInterface)(nil).UpdateTimeEntry), arg0)
+}
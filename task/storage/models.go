@@ -1,10 +1,15 @@
 package storage
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
 )
 
 const (
@@ -13,15 +18,117 @@ const (
 	TaskStatusCompleted  = "completed"
 )
 
+// TaskPriorities are the priority names, lowest first. A task stores the index
+// of its priority so that listings can be sorted by it.
+var TaskPriorities = []string{"none", "low", "medium", "high", "urgent"}
+
+const (
+	TaskPriorityNone int32 = iota
+	TaskPriorityLow
+	TaskPriorityMedium
+	TaskPriorityHigh
+	TaskPriorityUrgent
+)
+
+const (
+	TimeEntryActionCreated = "created"
+	TimeEntryActionStarted = "started"
+	TimeEntryActionStopped = "stopped"
+	TimeEntryActionUpdated = "updated"
+	TimeEntryActionDeleted = "deleted"
+)
+
+const (
+	CustomFieldTypeText         = "text"
+	CustomFieldTypeNumber       = "number"
+	CustomFieldTypeDate         = "date"
+	CustomFieldTypeSingleSelect = "single_select"
+	CustomFieldTypeMultiSelect  = "multi_select"
+	CustomFieldTypeCheckbox     = "checkbox"
+)
+
+// CustomFieldTypes are the supported custom field types
+var CustomFieldTypes = []string{
+	CustomFieldTypeText,
+	CustomFieldTypeNumber,
+	CustomFieldTypeDate,
+	CustomFieldTypeSingleSelect,
+	CustomFieldTypeMultiSelect,
+	CustomFieldTypeCheckbox,
+}
+
+const (
+	TaskSortCreatedAt = "created_at"
+	TaskSortUpdatedAt = "updated_at"
+	TaskSortDueDate   = "due_date"
+	TaskSortTitle     = "title"
+	TaskSortPriority  = "priority"
+)
+
+// TaskSortColumns are the task columns listings can be ordered by
+var TaskSortColumns = []string{TaskSortCreatedAt, TaskSortUpdatedAt, TaskSortDueDate, TaskSortTitle, TaskSortPriority}
+
+const (
+	// ViewVisibilityPrivate views are only listed for their owner
+	ViewVisibilityPrivate = "private"
+	// ViewVisibilityWorkspace views are listed for every user
+	ViewVisibilityWorkspace = "workspace"
+)
+
+// CustomFieldDateLayout is how date custom fields are stored
+const CustomFieldDateLayout = "2006-01-02"
+
+// BoardStatuses are the board columns, in display order
+var BoardStatuses = []string{TaskStatusCreated, TaskStatusInProgress, TaskStatusCompleted}
+
+// ErrTimerAlreadyRunning is returned when a user tries to start a second timer
+var ErrTimerAlreadyRunning = errors.New("a timer is already running for this user")
+
+// ErrDuplicateTemplateName is returned when a user already has a template
+// with the same name
+var ErrDuplicateTemplateName = errors.New("a template with this name already exists")
+
+// ErrIdempotencyKeyExists is returned when an unexpired idempotency key is
+// claimed again
+var ErrIdempotencyKeyExists = errors.New("this idempotency key is already in use")
+
+// ErrDuplicateCustomFieldName is returned when a user already has a custom
+// field with the same name
+var ErrDuplicateCustomFieldName = errors.New("a custom field with this name already exists")
+
+// ErrDuplicateViewName is returned when a user already has a saved view with
+// the same name
+var ErrDuplicateViewName = errors.New("a view with this name already exists")
+
+// ErrInvalidMove is returned when the neighbours of a move are not adjacent
+// tasks of the target column
+var ErrInvalidMove = errors.New("neighbouring tasks must belong to the target column")
+
 type Task struct {
-	ID          string    `sql:"size:255;not null";gorm:"primary_key"`
-	Title       string    `gorm:"size:255;not null" json:"title"`
-	Description string    `gorm:"type:text" json:"description"`
-	Status      string    `gorm:"size:50;not null;default:'pending'" json:"status"`
-	DueDate     time.Time `json:"due_date"`
-	CreatedAt   time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
-	UpdatedAt   time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
-	UserID      string    `gorm:"type:string;index:idx_task_user_id;not null"`
+	ID              string            `sql:"size:255;not null";gorm:"primary_key"`
+	Title           string            `gorm:"size:255;not null" json:"title"`
+	Description     string            `gorm:"type:text" json:"description"`
+	Status          string            `gorm:"size:50;not null;default:'pending'" json:"status"`
+	DueDate         time.Time         `json:"due_date"`
+	DueAllDay       bool              `gorm:"not null;default:false" json:"due_all_day"`
+	CreatedAt       time.Time         `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt       time.Time         `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+	UserID          string            `gorm:"type:string;index:idx_task_user_id;not null"`
+	EstimateMinutes int32             `gorm:"not null;default:0" json:"estimate_minutes"`
+	CompletedAt     *time.Time        `json:"completed_at"`
+	Position        string            `gorm:"size:255;not null;default:''" json:"position"`
+	Tags            []string          `gorm:"serializer:json;type:text" json:"tags"`
+	CustomFields    CustomFieldValues `gorm:"not null;default:'{}'" json:"custom_fields"`
+	// ArchivedAt is set while a task is archived
+	ArchivedAt *time.Time `gorm:"index:idx_task_archived_at" json:"archived_at"`
+	// UnarchivedAt is when the task was last brought back from the archive,
+	// its retention period starts over from there
+	UnarchivedAt *time.Time `json:"unarchived_at"`
+	// UserEmail is where the owner is notified about changes made by others.
+	// It is empty for tasks the owner has not touched since it was added.
+	UserEmail string `gorm:"size:255;not null;default:''" json:"user_email"`
+	// Priority is an index into TaskPriorities
+	Priority int32 `gorm:"not null;default:0;index:idx_task_priority" json:"priority"`
 }
 
 func (task *Task) BeforeSave(tx *gorm.DB) (err error) {
@@ -34,3 +141,370 @@ func (task *Task) BeforeSave(tx *gorm.DB) (err error) {
 
 	return nil
 }
+
+// TimeEntry is a span of time tracked against a task. A nil EndedAt marks the
+// user's running timer.
+type TimeEntry struct {
+	ID        string         `gorm:"size:255;not null;primary_key" json:"id"`
+	TaskID    string         `gorm:"size:255;index:idx_time_entry_task_id;not null" json:"task_id"`
+	UserID    string         `gorm:"size:255;index:idx_time_entry_user_id;not null" json:"user_id"`
+	StartedAt time.Time      `gorm:"not null" json:"started_at"`
+	EndedAt   *time.Time     `json:"ended_at"`
+	Note      string         `gorm:"type:text" json:"note"`
+	CreatedAt time.Time      `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt time.Time      `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"deleted_at"`
+}
+
+func (entry *TimeEntry) BeforeSave(tx *gorm.DB) (err error) {
+	if entry.ID == "" {
+		entry.ID = uuid.NewString()
+	}
+	return nil
+}
+
+// Duration returns the tracked time, counting a running timer up to now
+func (entry *TimeEntry) Duration(now time.Time) time.Duration {
+	if entry.EndedAt == nil {
+		return now.Sub(entry.StartedAt)
+	}
+	return entry.EndedAt.Sub(entry.StartedAt)
+}
+
+// TimeEntryRevision is an append-only snapshot of a time entry taken after
+// every change, so billed time can be audited.
+type TimeEntryRevision struct {
+	ID          string     `gorm:"size:255;not null;primary_key" json:"id"`
+	TimeEntryID string     `gorm:"size:255;index:idx_time_entry_revision_entry_id;not null" json:"time_entry_id"`
+	UserID      string     `gorm:"size:255;not null" json:"user_id"`
+	Action      string     `gorm:"size:50;not null" json:"action"`
+	StartedAt   time.Time  `json:"started_at"`
+	EndedAt     *time.Time `json:"ended_at"`
+	Note        string     `gorm:"type:text" json:"note"`
+	CreatedAt   time.Time  `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+func (revision *TimeEntryRevision) BeforeSave(tx *gorm.DB) (err error) {
+	if revision.ID == "" {
+		revision.ID = uuid.NewString()
+	}
+	return nil
+}
+
+// TimeEntryFilter narrows down time entry listings. Zero values are ignored.
+// From and To select the entries started in the window, or with Overlapping
+// the entries that ran during any part of it.
+type TimeEntryFilter struct {
+	UserID      string
+	TaskID      string
+	From        time.Time
+	To          time.Time
+	Overlapping bool
+}
+
+// TaskStatsQuery describes the window and bucketing of a statistics request
+type TaskStatsQuery struct {
+	UserID   string
+	From     time.Time
+	To       time.Time
+	Timezone string
+	// Interval is either "day" or "week"
+	Interval string
+	Now      time.Time
+}
+
+type PeriodCount struct {
+	Period string
+	Count  int64
+}
+
+type StatusCount struct {
+	Status string
+	Count  int64
+}
+
+// TaskStats holds the aggregates computed for a TaskStatsQuery
+type TaskStats struct {
+	ByStatus                 []StatusCount
+	CreatedPerPeriod         []PeriodCount
+	CompletedPerPeriod       []PeriodCount
+	OverdueCount             int64
+	AverageCompletionSeconds float64
+	// CompletionDays lists the distinct local dates (YYYY-MM-DD) with at
+	// least one completion up to the end of the window, in ascending order
+	CompletionDays []string
+}
+
+// TaskMove places a task in a board column between two neighbours. AfterID is
+// the task right above the new spot and BeforeID the one right below; either
+// may be empty at the edges of the column. With neither set the task goes to
+// the bottom of the column.
+type TaskMove struct {
+	TaskID   string
+	UserID   string
+	Status   string
+	AfterID  string
+	BeforeID string
+}
+
+// TaskReminder reminds the owner of a task OffsetMinutes before its due
+// date. RemindAt follows the due date whenever it changes. SentAt is set once
+// the reminder went out.
+type TaskReminder struct {
+	ID            string     `gorm:"size:255;not null;primary_key" json:"id"`
+	TaskID        string     `gorm:"size:255;index:idx_task_reminder_task_id;not null" json:"task_id"`
+	OffsetMinutes int64      `gorm:"not null;default:0" json:"offset_minutes"`
+	RemindAt      time.Time  `gorm:"index:idx_task_reminder_remind_at;not null" json:"remind_at"`
+	SentAt        *time.Time `json:"sent_at"`
+	CreatedAt     time.Time  `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+// reschedule moves the reminder to OffsetMinutes before dueDate and reports
+// whether that changed it. A reminder that already went out is sent again if
+// it moved past the time it was sent.
+func (reminder *TaskReminder) reschedule(dueDate time.Time) bool {
+	remindAt := dueDate.Add(-time.Duration(reminder.OffsetMinutes) * time.Minute)
+	if remindAt.Equal(reminder.RemindAt) {
+		return false
+	}
+	reminder.RemindAt = remindAt
+	if reminder.SentAt != nil && remindAt.After(*reminder.SentAt) {
+		reminder.SentAt = nil
+	}
+	return true
+}
+
+func (reminder *TaskReminder) BeforeSave(tx *gorm.DB) (err error) {
+	if reminder.ID == "" {
+		reminder.ID = uuid.NewString()
+	}
+	return nil
+}
+
+// TaskTemplate is a named, reusable task of a user. Text fields may contain
+// {{variables}} that are substituted on instantiation.
+type TaskTemplate struct {
+	ID                     string    `gorm:"size:255;not null;primary_key" json:"id"`
+	UserID                 string    `gorm:"size:255;uniqueIndex:idx_task_template_user_name;not null" json:"user_id"`
+	Name                   string    `gorm:"size:255;uniqueIndex:idx_task_template_user_name;not null" json:"name"`
+	Title                  string    `gorm:"size:255;not null" json:"title"`
+	Description            string    `gorm:"type:text" json:"description"`
+	Checklist              []string  `gorm:"serializer:json;type:text" json:"checklist"`
+	Tags                   []string  `gorm:"serializer:json;type:text" json:"tags"`
+	DueOffsetMinutes       *int64    `json:"due_offset_minutes"`
+	ReminderOffsetsMinutes []int64   `gorm:"serializer:json;type:text" json:"reminder_offsets_minutes"`
+	EstimateMinutes        int32     `gorm:"not null;default:0" json:"estimate_minutes"`
+	CreatedAt              time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt              time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+func (template *TaskTemplate) BeforeSave(tx *gorm.DB) (err error) {
+	if template.ID == "" {
+		template.ID = uuid.NewString()
+	}
+	return nil
+}
+
+// CustomField is a typed attribute a user can set on their tasks. Values are
+// stored on the task, keyed by the field ID so that renaming keeps them.
+type CustomField struct {
+	ID        string    `gorm:"size:255;not null;primary_key" json:"id"`
+	UserID    string    `gorm:"size:255;uniqueIndex:idx_custom_field_user_name;not null" json:"user_id"`
+	Name      string    `gorm:"size:255;uniqueIndex:idx_custom_field_user_name;not null" json:"name"`
+	Type      string    `gorm:"size:50;not null" json:"type"`
+	Options   []string  `gorm:"serializer:json;type:text" json:"options"`
+	CreatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+func (field *CustomField) BeforeSave(tx *gorm.DB) (err error) {
+	if field.ID == "" {
+		field.ID = uuid.NewString()
+	}
+	return nil
+}
+
+// CustomFieldValues maps custom field IDs to the values of a task. Values are
+// a string for text, date and single-select fields, a float64 for numbers, a
+// bool for checkboxes and a []string for multi-select fields.
+type CustomFieldValues map[string]interface{}
+
+// GormDBDataType stores the values as JSONB on Postgres and JSON text elsewhere
+func (CustomFieldValues) GormDBDataType(db *gorm.DB, field *schema.Field) string {
+	if db.Dialector.Name() == "postgres" {
+		return "jsonb"
+	}
+	return "text"
+}
+
+func (values CustomFieldValues) Value() (driver.Value, error) {
+	if values == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(map[string]interface{}(values))
+	return string(data), err
+}
+
+func (values *CustomFieldValues) Scan(src interface{}) error {
+	var data []byte
+	switch src := src.(type) {
+	case nil:
+		*values = nil
+		return nil
+	case string:
+		data = []byte(src)
+	case []byte:
+		data = src
+	default:
+		return fmt.Errorf("cannot scan %T into CustomFieldValues", src)
+	}
+	return json.Unmarshal(data, values)
+}
+
+// UnmarshalJSON restores multi-select values as []string
+func (values *CustomFieldValues) UnmarshalJSON(data []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	for id, value := range raw {
+		if list, ok := value.([]interface{}); ok {
+			options := make([]string, 0, len(list))
+			for _, option := range list {
+				if option, ok := option.(string); ok {
+					options = append(options, option)
+				}
+			}
+			raw[id] = options
+		}
+	}
+	*values = raw
+	return nil
+}
+
+// CustomFieldFilter matches tasks by the value of a custom field. Op is one of
+// "eq", "lt", "lte", "gt" and "gte"; "eq" on a multi-select field matches
+// tasks having the option. Value has the Go type of the field's values, a
+// single option for multi-select fields.
+type CustomFieldFilter struct {
+	FieldID   string
+	FieldType string
+	Op        string
+	Value     interface{}
+}
+
+// CustomFieldSort orders tasks by a custom field, tasks without a value last
+type CustomFieldSort struct {
+	FieldID   string
+	FieldType string
+	Desc      bool
+}
+
+// TaskSort orders tasks by one of TaskSortColumns. Tasks without a due date
+// come last when sorting by due date.
+type TaskSort struct {
+	Column string
+	Desc   bool
+}
+
+// TaskFilter narrows down and orders task listings. Zero values are ignored,
+// without a sort tasks are listed newest first.
+type TaskFilter struct {
+	UserID string
+	// Statuses lists the accepted statuses
+	Statuses []string
+	// Tags must all be set on a task
+	Tags []string
+	// DueFrom and DueBefore bound the due date, tasks without one never
+	// match a bound
+	DueFrom        *time.Time
+	DueBefore      *time.Time
+	WithoutDueDate bool
+	CustomFields   []CustomFieldFilter
+	Sort           *TaskSort
+	// SortField takes precedence over Sort
+	SortField *CustomFieldSort
+	// Archived lists archived tasks instead of the ones that are not
+	Archived bool
+}
+
+// SavedView is a named task query a user can list again later. Views shared
+// with the workspace are listed for everyone, but only their owner can change
+// them.
+type SavedView struct {
+	ID     string    `gorm:"size:255;not null;primary_key" json:"id"`
+	UserID string    `gorm:"size:255;uniqueIndex:idx_saved_view_user_name;not null" json:"user_id"`
+	Name   string    `gorm:"size:255;uniqueIndex:idx_saved_view_user_name;not null" json:"name"`
+	Query  TaskQuery `gorm:"serializer:json;type:text" json:"query"`
+	// GroupBy is "status", "tag" or a custom field ID
+	GroupBy    string    `gorm:"size:255;not null;default:''" json:"group_by"`
+	Visibility string    `gorm:"size:50;not null;default:'private';index:idx_saved_view_visibility" json:"visibility"`
+	CreatedAt  time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt  time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+func (view *SavedView) BeforeSave(tx *gorm.DB) (err error) {
+	if view.ID == "" {
+		view.ID = uuid.NewString()
+	}
+	return nil
+}
+
+// TaskQuery is the definition stored in a saved view. The due window is
+// relative, like "this_week", and custom field values are kept as text, so
+// both are resolved each time the view is listed.
+type TaskQuery struct {
+	Statuses     []string               `json:"statuses,omitempty"`
+	Tags         []string               `json:"tags,omitempty"`
+	Due          string                 `json:"due,omitempty"`
+	CustomFields []TaskQueryFieldFilter `json:"custom_fields,omitempty"`
+	SortBy       string                 `json:"sort_by,omitempty"`
+	SortFieldID  string                 `json:"sort_field_id,omitempty"`
+	SortDesc     bool                   `json:"sort_desc,omitempty"`
+}
+
+type TaskQueryFieldFilter struct {
+	FieldID string `json:"field_id"`
+	Op      string `json:"op"`
+	Value   string `json:"value"`
+}
+
+// RetentionPolicy decides when completed tasks are archived and when archived
+// tasks are purged. A user's policy replaces the global one, zero days turns
+// a step off.
+type RetentionPolicy struct {
+	UserID           string    `gorm:"size:255;not null;primary_key" json:"user_id"`
+	ArchiveAfterDays int32     `gorm:"not null;default:0" json:"archive_after_days"`
+	PurgeAfterDays   int32     `gorm:"not null;default:0" json:"purge_after_days"`
+	UpdatedAt        time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+// RetentionScope selects the users a retention step applies to
+type RetentionScope struct {
+	// UserID limits the step to one user, all users when empty
+	UserID string
+	// ExcludeUserIDs are skipped, such as users with their own policy
+	ExcludeUserIDs []string
+}
+
+// IdempotencyRecord remembers the response to a request made with an
+// idempotency key, so that retries get the same result. Response is empty
+// while the first request is still running.
+type IdempotencyRecord struct {
+	UserID      string    `gorm:"size:255;not null;primary_key" json:"user_id"`
+	Key         string    `gorm:"column:idempotency_key;size:255;not null;primary_key" json:"key"`
+	RequestHash string    `gorm:"size:64;not null" json:"request_hash"`
+	Response    []byte    `json:"response"`
+	CreatedAt   time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+	ExpiresAt   time.Time `gorm:"not null;index:idx_idempotency_record_expires_at" json:"expires_at"`
+}
+
+// TaskWatcher is a user following a task they do not own. Watchers are sent
+// the task's update events at Email. Invited users who have not started
+// watching yet have no Email.
+type TaskWatcher struct {
+	TaskID    string    `gorm:"size:255;not null;primary_key" json:"task_id"`
+	UserID    string    `gorm:"size:255;not null;primary_key;index:idx_task_watcher_user_id" json:"user_id"`
+	Email     string    `gorm:"size:255;not null" json:"email"`
+	CreatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP" json:"created_at"`
+}
@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"errors"
+	"strings"
+)
+
+// Ranks are base-36 fractions written without the leading "0.", so that
+// plain string comparison orders them. They never end in '0', which keeps
+// room for a rank between any two of them.
+const rankDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// maxRankLength is the rank length past which a column gets rebalanced
+const maxRankLength = 24
+
+// ErrInvalidRankRange is returned when no rank fits between two ranks
+var ErrInvalidRankRange = errors.New("lower rank must sort before upper rank")
+
+// RankBetween returns a rank that sorts strictly between lower and upper. An
+// empty lower means the start of the column, an empty upper its end.
+func RankBetween(lower, upper string) (string, error) {
+	if upper != "" && lower >= upper {
+		return "", ErrInvalidRankRange
+	}
+	return rankMidpoint(lower, upper), nil
+}
+
+func rankMidpoint(lower, upper string) string {
+	if upper != "" {
+		// Keep the common prefix, lower is padded with zeros
+		n := 0
+		for n < len(upper) && rankDigitAt(lower, n) == upper[n] {
+			n++
+		}
+		if n > 0 {
+			rest := ""
+			if n < len(lower) {
+				rest = lower[n:]
+			}
+			return upper[:n] + rankMidpoint(rest, upper[n:])
+		}
+	}
+
+	lo := 0
+	if lower != "" {
+		lo = strings.IndexByte(rankDigits, lower[0])
+	}
+	hi := len(rankDigits)
+	if upper != "" {
+		hi = strings.IndexByte(rankDigits, upper[0])
+	}
+	if hi-lo > 1 {
+		return string(rankDigits[(lo+hi)/2])
+	}
+	// Adjacent digits: a longer upper leaves room right after its first digit
+	if len(upper) > 1 {
+		return upper[:1]
+	}
+	rest := ""
+	if lower != "" {
+		rest = lower[1:]
+	}
+	return string(rankDigits[lo]) + rankMidpoint(rest, "")
+}
+
+func rankDigitAt(rank string, i int) byte {
+	if i < len(rank) {
+		return rank[i]
+	}
+	return rankDigits[0]
+}
+
+// EvenRanks returns n ascending ranks spread evenly over the rank space, all
+// of them as short as possible.
+func EvenRanks(n int) []string {
+	width, slots := 1, len(rankDigits)
+	for slots <= n {
+		width++
+		slots *= len(rankDigits)
+	}
+
+	ranks := make([]string, n)
+	step := slots / (n + 1)
+	for i := range ranks {
+		v := (i + 1) * step
+		digits := make([]byte, width)
+		for j := width - 1; j >= 0; j-- {
+			digits[j] = rankDigits[v%len(rankDigits)]
+			v /= len(rankDigits)
+		}
+		ranks[i] = strings.TrimRight(string(digits), rankDigits[:1])
+	}
+	return ranks
+}
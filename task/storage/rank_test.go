@@ -0,0 +1,85 @@
+package storage
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestRankBetween(t *testing.T) {
+	tests := []struct {
+		name  string
+		lower string
+		upper string
+		want  string
+	}{
+		{name: "Empty column", want: "i"},
+		{name: "After last", lower: "i", want: "r"},
+		{name: "Before first", upper: "i", want: "9"},
+		{name: "Between", lower: "a", upper: "c", want: "b"},
+		{name: "Adjacent digits", lower: "a", upper: "b", want: "ai"},
+		{name: "Common prefix", lower: "a1", upper: "a3", want: "a2"},
+		{name: "Upper is longer", lower: "a", upper: "b5", want: "b"},
+		{name: "Lower is a prefix of upper", lower: "a", upper: "a1", want: "a0i"},
+		{name: "Before the smallest digit", upper: "01", want: "00i"},
+		{name: "After the largest digit", lower: "z", want: "zi"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RankBetween(tt.lower, tt.upper)
+			if err != nil {
+				t.Fatalf("RankBetween() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("RankBetween() = %q, want %q", got, tt.want)
+			}
+			if got <= tt.lower || (tt.upper != "" && got >= tt.upper) {
+				t.Errorf("RankBetween() = %q is not between %q and %q", got, tt.lower, tt.upper)
+			}
+		})
+	}
+
+	if _, err := RankBetween("b", "a"); err != ErrInvalidRankRange {
+		t.Errorf("RankBetween() error = %v, want %v", err, ErrInvalidRankRange)
+	}
+	if _, err := RankBetween("a", "a"); err != ErrInvalidRankRange {
+		t.Errorf("RankBetween() error = %v, want %v", err, ErrInvalidRankRange)
+	}
+}
+
+func TestRankBetween_RepeatedInserts(t *testing.T) {
+	// Always inserting right after the same rank keeps growing it
+	lower, upper := "a", "b"
+	for i := 0; i < 200; i++ {
+		rank, err := RankBetween(lower, upper)
+		if err != nil {
+			t.Fatalf("RankBetween() error = %v", err)
+		}
+		if rank <= lower || rank >= upper {
+			t.Fatalf("RankBetween(%q, %q) = %q is out of range", lower, upper, rank)
+		}
+		upper = rank
+	}
+	if len(upper) <= maxRankLength {
+		t.Errorf("expected ranks to outgrow maxRankLength, got %q", upper)
+	}
+}
+
+func TestEvenRanks(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 35, 36, 1000} {
+		ranks := EvenRanks(n)
+		if len(ranks) != n {
+			t.Fatalf("EvenRanks(%d) returned %d ranks", n, len(ranks))
+		}
+		if !sort.StringsAreSorted(ranks) {
+			t.Errorf("EvenRanks(%d) is not sorted", n)
+		}
+		for i, rank := range ranks {
+			if rank == "" || rank[len(rank)-1] == '0' {
+				t.Errorf("EvenRanks(%d)[%d] = %q has a trailing zero", n, i, rank)
+			}
+			if i > 0 && ranks[i-1] == rank {
+				t.Errorf("EvenRanks(%d) has duplicate rank %q", n, rank)
+			}
+		}
+	}
+}
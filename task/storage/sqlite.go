@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+)
+
+// sqliteConfig returns the dialector and gorm config for the SQLite database
+// at path, which may be ":memory:". SQLite stores times as text, so they are
+// always written in UTC with a fixed layout to keep comparisons and ordering
+// correct.
+func sqliteConfig(path string) (gorm.Dialector, *gorm.Config) {
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	dsn := path + separator + "_time_format=sqlite&_pragma=busy_timeout(5000)"
+	config := &gorm.Config{
+		NowFunc: func() time.Time {
+			return time.Now().UTC()
+		},
+	}
+	return sqlite.Open(dsn), config
+}
+
+// setupSQLite configures a freshly opened SQLite connection pool
+func setupSQLite(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	// SQLite has a single writer, and every connection to :memory: would
+	// open its own empty database
+	sqlDB.SetMaxOpenConns(1)
+
+	if err := db.Callback().Create().Before("gorm:create").Register("taskhub:utc_times", utcTimes); err != nil {
+		return err
+	}
+	return db.Callback().Update().Before("gorm:update").Register("taskhub:utc_times", utcTimes)
+}
+
+// utcTimes converts the times about to be written to UTC
+func utcTimes(db *gorm.DB) {
+	if values, ok := db.Statement.Dest.(map[string]interface{}); ok {
+		for column, value := range values {
+			values[column] = toUTC(value)
+		}
+		return
+	}
+	if db.Statement.Schema == nil {
+		return
+	}
+
+	dest := reflect.Indirect(reflect.ValueOf(db.Statement.Dest))
+	switch dest.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < dest.Len(); i++ {
+			structTimesToUTC(db, reflect.Indirect(dest.Index(i)))
+		}
+	case reflect.Struct:
+		structTimesToUTC(db, dest)
+	}
+}
+
+func structTimesToUTC(db *gorm.DB, value reflect.Value) {
+	if !value.CanAddr() || value.Type() != db.Statement.Schema.ModelType {
+		return
+	}
+	ctx := db.Statement.Context
+	for _, field := range db.Statement.Schema.Fields {
+		fieldValue, isZero := field.ValueOf(ctx, value)
+		if isZero {
+			continue
+		}
+		switch fieldValue.(type) {
+		case time.Time, *time.Time:
+			if err := field.Set(ctx, value, toUTC(fieldValue)); err != nil {
+				db.AddError(err)
+			}
+		}
+	}
+}
+
+func toUTC(value interface{}) interface{} {
+	switch t := value.(type) {
+	case time.Time:
+		return t.UTC()
+	case *time.Time:
+		if t != nil {
+			utc := t.UTC()
+			return &utc
+		}
+	}
+	return value
+}
@@ -0,0 +1,169 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// sqliteQuarterLayout is the layout of the quarter hours of sqliteQuarterHour
+const sqliteQuarterLayout = "2006-01-02 15:04"
+
+// sqliteQuarterHour returns the SQLite expression truncating a time column to
+// the quarter hour, in UTC
+func sqliteQuarterHour(column string) string {
+	return fmt.Sprintf(`strftime('%%Y-%%m-%%d %%H:', %[1]s) || printf('%%02d', CAST(strftime('%%M', %[1]s) AS INTEGER) / 15 * 15)`, column)
+}
+
+// sqliteTaskStats fills in the aggregates of stats that depend on the time
+// zone. SQLite only knows UTC, so the tasks are counted per quarter hour in
+// SQL and the counts are added up per local period here. Every UTC offset in
+// use is a whole number of quarter hours, so no quarter hour straddles two
+// local days.
+func (s *Storage) sqliteTaskStats(query TaskStatsQuery, stats *TaskStats) error {
+	loc, err := time.LoadLocation(query.Timezone)
+	if err != nil {
+		return err
+	}
+	// Times are stored as UTC text, so they only compare right to UTC
+	from, to := query.From.UTC(), query.To.UTC()
+
+	for column, dest := range map[string]*[]PeriodCount{
+		"created_at":   &stats.CreatedPerPeriod,
+		"completed_at": &stats.CompletedPerPeriod,
+	} {
+		var counts []struct {
+			Quarter string
+			Count   int64
+		}
+		err = s.db.Raw(fmt.Sprintf(`SELECT %[2]s AS quarter, COUNT(*) AS count
+			FROM tasks WHERE user_id = ? AND %[1]s >= ? AND %[1]s < ?
+			GROUP BY quarter`, column, sqliteQuarterHour(column)),
+			query.UserID, from, to).
+			Scan(&counts).Error
+		if err != nil {
+			return err
+		}
+
+		periods := map[string]int64{}
+		for _, count := range counts {
+			quarter, err := time.Parse(sqliteQuarterLayout, count.Quarter)
+			if err != nil {
+				return err
+			}
+			periods[localPeriod(quarter, loc, query.Interval)] += count.Count
+		}
+		for _, period := range sortedKeys(periods) {
+			*dest = append(*dest, PeriodCount{Period: period, Count: periods[period]})
+		}
+	}
+
+	err = s.db.Raw(`SELECT COALESCE(AVG((julianday(completed_at) - julianday(created_at)) * 86400), 0) FROM tasks
+		WHERE user_id = ? AND completed_at >= ? AND completed_at < ?`,
+		query.UserID, from, to).
+		Scan(&stats.AverageCompletionSeconds).Error
+	if err != nil {
+		return err
+	}
+
+	var quarters []string
+	err = s.db.Raw(fmt.Sprintf(`SELECT DISTINCT %s AS quarter FROM tasks
+		WHERE user_id = ? AND completed_at IS NOT NULL AND completed_at < ?`, sqliteQuarterHour("completed_at")),
+		query.UserID, to).
+		Scan(&quarters).Error
+	if err != nil {
+		return err
+	}
+	days := map[string]bool{}
+	for _, value := range quarters {
+		quarter, err := time.Parse(sqliteQuarterLayout, value)
+		if err != nil {
+			return err
+		}
+		days[localPeriod(quarter, loc, "day")] = true
+	}
+	stats.CompletionDays = sortedKeys(days)
+	return nil
+}
+
+// computeTaskStats aggregates a user's tasks in Go for MemoryStorage, with
+// the same semantics as Storage.GetTaskStats
+func computeTaskStats(tasks []*Task, query TaskStatsQuery) (*TaskStats, error) {
+	loc, err := time.LoadLocation(query.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &TaskStats{}
+	byStatus := map[string]int64{}
+	created := map[string]int64{}
+	completed := map[string]int64{}
+	completionDays := map[string]bool{}
+	var completionSeconds float64
+	var completionCount int64
+
+	inWindow := func(t time.Time) bool {
+		return !t.Before(query.From) && t.Before(query.To)
+	}
+
+	for _, task := range tasks {
+		if task.UserID != query.UserID {
+			continue
+		}
+		byStatus[task.Status]++
+		if inWindow(task.CreatedAt) {
+			created[localPeriod(task.CreatedAt, loc, query.Interval)]++
+		}
+		if task.Status != TaskStatusCompleted && task.DueDate.After(noDueDate) && task.DueDate.Before(query.Now) {
+			stats.OverdueCount++
+		}
+		if task.CompletedAt == nil {
+			continue
+		}
+		if inWindow(*task.CompletedAt) {
+			completed[localPeriod(*task.CompletedAt, loc, query.Interval)]++
+			completionSeconds += task.CompletedAt.Sub(task.CreatedAt).Seconds()
+			completionCount++
+		}
+		if task.CompletedAt.Before(query.To) {
+			completionDays[task.CompletedAt.In(loc).Format("2006-01-02")] = true
+		}
+	}
+
+	for _, status := range sortedKeys(byStatus) {
+		stats.ByStatus = append(stats.ByStatus, StatusCount{Status: status, Count: byStatus[status]})
+	}
+	for _, period := range sortedKeys(created) {
+		stats.CreatedPerPeriod = append(stats.CreatedPerPeriod, PeriodCount{Period: period, Count: created[period]})
+	}
+	for _, period := range sortedKeys(completed) {
+		stats.CompletedPerPeriod = append(stats.CompletedPerPeriod, PeriodCount{Period: period, Count: completed[period]})
+	}
+	for _, day := range sortedKeys(completionDays) {
+		stats.CompletionDays = append(stats.CompletionDays, day)
+	}
+	if completionCount > 0 {
+		stats.AverageCompletionSeconds = completionSeconds / float64(completionCount)
+	}
+	return stats, nil
+}
+
+// localPeriod returns the first day (YYYY-MM-DD) of the day or ISO week that
+// t falls in, in loc
+func localPeriod(t time.Time, loc *time.Location, interval string) string {
+	t = t.In(loc)
+	if interval == "week" {
+		// Weeks start on Monday, like date_trunc('week', ...)
+		t = t.AddDate(0, 0, -((int(t.Weekday()) + 6) % 7))
+	}
+	return t.Format("2006-01-02")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNew_Backends(t *testing.T) {
+	store, err := New("memory://")
+	if err != nil {
+		t.Fatalf("New(memory) error = %v", err)
+	}
+	if _, ok := store.(*MemoryStorage); !ok {
+		t.Errorf("New(memory) = %T, want *MemoryStorage", store)
+	}
+
+	store, err = New("sqlite://:memory:")
+	if err != nil {
+		t.Fatalf("New(sqlite) error = %v", err)
+	}
+	if _, ok := store.(*Storage); !ok {
+		t.Errorf("New(sqlite) = %T, want *Storage", store)
+	}
+
+	if _, err := New("mysql://localhost/taskhub"); err == nil {
+		t.Error("New(mysql) error = nil, want unsupported scheme")
+	}
+}
+
+func TestSQLite_TimesAreStoredInUTC(t *testing.T) {
+	store, err := New("sqlite://:memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	kolkata := time.FixedZone("IST", 5*60*60+30*60)
+	start := time.Date(2024, 7, 30, 9, 0, 0, 0, kolkata)
+
+	// 09:00 IST is 03:30 UTC, which must sort before 04:00 UTC
+	for _, startedAt := range []time.Time{start, time.Date(2024, 7, 30, 4, 0, 0, 0, time.UTC)} {
+		endedAt := startedAt.Add(10 * time.Minute)
+		if err := store.CreateTimeEntry(&TimeEntry{TaskID: "task-1", UserID: "user-1", StartedAt: startedAt, EndedAt: &endedAt}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, count, err := store.ListTimeEntriesWithCount(TimeEntryFilter{
+		UserID: "user-1",
+		From:   start,
+		To:     start.Add(time.Hour),
+	}, -1, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 2 || len(entries) != 2 {
+		t.Fatalf("got %d entries (count %d), want 2", len(entries), count)
+	}
+	if !entries[1].StartedAt.Equal(start) {
+		t.Errorf("oldest entry started at %v, want %v", entries[1].StartedAt, start)
+	}
+}
@@ -0,0 +1,1183 @@
+// Package storagetest is a conformance suite for implementations of
+// storage.StorageInterface. Every backend must pass it, so that handlers
+// behave the same whichever one is configured.
+//
+// Each test works on data of a fresh user, so the suite can also run against
+// a shared database that already holds rows.
+package storagetest
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sejamuchhal/taskhub/task/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// Factory returns the implementation under test. It is called once per test.
+type Factory func(t *testing.T) storage.StorageInterface
+
+// Run runs the conformance suite against the implementation returned by
+// newStorage
+func Run(t *testing.T, newStorage Factory) {
+	tests := map[string]func(t *testing.T, s storage.StorageInterface){
+		"Tasks":              testTasks,
+		"ListTasks":          testListTasks,
+		"UpdateTask":         testUpdateTask,
+		"MoveTask":           testMoveTask,
+		"MoveTaskRebalance":  testMoveTaskRebalance,
+		"Timers":             testTimers,
+		"TimeEntries":        testTimeEntries,
+		"ListTimeEntries":    testListTimeEntries,
+		"TimeEntryRevisions": testTimeEntryRevisions,
+		"TaskStats":          testTaskStats,
+		"Templates":          testTemplates,
+		"CustomFields":       testCustomFields,
+		"FilterCustomFields": testFilterCustomFields,
+		"FilterTasks":        testFilterTasks,
+		"SortTasks":          testSortTasks,
+		"SavedViews":         testSavedViews,
+		"Archiving":          testArchiving,
+		"RetentionPolicies":  testRetentionPolicies,
+		"IdempotencyRecords": testIdempotencyRecords,
+		"TaskWatchers":       testTaskWatchers,
+		"TaskReminders":      testTaskReminders,
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			test(t, newStorage(t))
+		})
+	}
+}
+
+// RunBackends opens every backend storage.New supports and runs the suite on
+// each. Postgres needs a database, so it only runs when TEST_DATABASE_URL
+// points at one.
+func RunBackends(t *testing.T) {
+	backends := map[string]string{
+		"memory": "memory://",
+		"sqlite": "sqlite://:memory:",
+	}
+	if databaseURL := os.Getenv("TEST_DATABASE_URL"); databaseURL != "" {
+		backends["postgres"] = databaseURL
+	}
+
+	for name, databaseURL := range backends {
+		t.Run(name, func(t *testing.T) {
+			Run(t, func(t *testing.T) storage.StorageInterface {
+				store, err := storage.New(databaseURL)
+				require.NoError(t, err, "New(%q)", databaseURL)
+				return store
+			})
+		})
+	}
+}
+
+// base is a fixed instant all fixtures are relative to. It has no sub-second
+// part, as databases differ in the precision they store.
+var base = time.Date(2024, 7, 30, 10, 0, 0, 0, time.UTC)
+
+func newUserID() string {
+	return uuid.NewString()
+}
+
+func createTask(t *testing.T, s storage.StorageInterface, task *storage.Task) *storage.Task {
+	t.Helper()
+	require.NoError(t, s.CreateTask(task))
+	require.NotEmpty(t, task.ID)
+	return task
+}
+
+func testTasks(t *testing.T, s storage.StorageInterface) {
+	userID := newUserID()
+	task := createTask(t, s, &storage.Task{
+		Title:           "Write report",
+		Description:     "Quarterly numbers",
+		DueDate:         base.Add(24 * time.Hour),
+		DueAllDay:       true,
+		UserID:          userID,
+		EstimateMinutes: 30,
+		Tags:            []string{"work", "urgent"},
+	})
+	assert.Equal(t, storage.TaskStatusCreated, task.Status)
+	assert.NotEmpty(t, task.Position)
+
+	got, err := s.GetTaskByID(task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Write report", got.Title)
+	assert.Equal(t, "Quarterly numbers", got.Description)
+	assert.Equal(t, storage.TaskStatusCreated, got.Status)
+	assert.True(t, got.DueDate.Equal(base.Add(24*time.Hour)))
+	assert.True(t, got.DueAllDay)
+	assert.Equal(t, userID, got.UserID)
+	assert.Equal(t, int32(30), got.EstimateMinutes)
+	assert.Equal(t, []string{"work", "urgent"}, got.Tags)
+	assert.Equal(t, task.Position, got.Position)
+	assert.Nil(t, got.CompletedAt)
+	assert.False(t, got.CreatedAt.IsZero())
+
+	// Later tasks go to the bottom of their column
+	second := createTask(t, s, &storage.Task{Title: "Second", UserID: userID})
+	assert.Greater(t, second.Position, task.Position)
+
+	byIDs, err := s.GetTasksByIDs([]string{second.ID, uuid.NewString(), task.ID})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{task.ID, second.ID}, taskIDs(byIDs))
+	byIDs, err = s.GetTasksByIDs(nil)
+	require.NoError(t, err)
+	assert.Empty(t, byIDs)
+
+	require.NoError(t, s.DeleteTask(task.ID))
+	_, err = s.GetTaskByID(task.ID)
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+
+	_, err = s.GetTaskByID(uuid.NewString())
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+}
+
+func testListTasks(t *testing.T, s storage.StorageInterface) {
+	userID := newUserID()
+	var ids []string
+	for i := 0; i < 5; i++ {
+		task := createTask(t, s, &storage.Task{
+			Title:     "Task",
+			UserID:    userID,
+			CreatedAt: base.Add(time.Duration(i) * time.Minute),
+		})
+		ids = append(ids, task.ID)
+	}
+	otherTask := createTask(t, s, &storage.Task{Title: "Other", UserID: newUserID(), CreatedAt: base})
+
+	// Newest first, the count ignores limit and offset
+	tasks, count, err := s.ListTasksWithCount(storage.TaskFilter{UserID: userID}, 2, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), count)
+	assert.Equal(t, []string{ids[4], ids[3]}, taskIDs(tasks))
+
+	tasks, count, err = s.ListTasksWithCount(storage.TaskFilter{UserID: userID}, 2, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), count)
+	assert.Equal(t, []string{ids[2], ids[1]}, taskIDs(tasks))
+
+	tasks, count, err = s.ListTasksWithCount(storage.TaskFilter{UserID: userID}, 2, 4)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), count)
+	assert.Equal(t, []string{ids[0]}, taskIDs(tasks))
+
+	tasks, count, err = s.ListTasksWithCount(storage.TaskFilter{UserID: userID}, 2, 10)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), count)
+	assert.Empty(t, tasks)
+
+	tasks, count, err = s.ListTasksWithCount(storage.TaskFilter{UserID: newUserID()}, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count)
+	assert.Empty(t, tasks)
+
+	// An empty user ID lists the tasks of every user
+	tasks, count, err = s.ListTasksWithCount(storage.TaskFilter{}, -1, 0)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, count, int64(6))
+	assert.Len(t, tasks, int(count))
+	assert.Subset(t, taskIDs(tasks), append(ids, otherTask.ID))
+}
+
+func testUpdateTask(t *testing.T, s storage.StorageInterface) {
+	userID := newUserID()
+	task := createTask(t, s, &storage.Task{
+		Title:     "Draft",
+		DueDate:   base,
+		DueAllDay: true,
+		UserID:    userID,
+		Tags:      []string{"home"},
+	})
+
+	completedAt := base.Add(time.Hour)
+	task.Title = "Final"
+	task.Status = storage.TaskStatusCompleted
+	task.DueAllDay = false
+	task.Tags = nil
+	task.CompletedAt = &completedAt
+	require.NoError(t, s.UpdateTask(task))
+
+	got, err := s.GetTaskByID(task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Final", got.Title)
+	assert.Equal(t, storage.TaskStatusCompleted, got.Status)
+	assert.False(t, got.DueAllDay, "zero values must be written")
+	assert.Empty(t, got.Tags)
+	require.NotNil(t, got.CompletedAt)
+	assert.True(t, got.CompletedAt.Equal(completedAt))
+	assert.Equal(t, userID, got.UserID)
+}
+
+func testMoveTask(t *testing.T, s storage.StorageInterface) {
+	userID := newUserID()
+	a := createTask(t, s, &storage.Task{Title: "A", UserID: userID, CreatedAt: base})
+	b := createTask(t, s, &storage.Task{Title: "B", UserID: userID, CreatedAt: base.Add(time.Minute)})
+	c := createTask(t, s, &storage.Task{Title: "C", UserID: userID, CreatedAt: base.Add(2 * time.Minute)})
+
+	// Move C between A and B
+	moved, err := s.MoveTask(storage.TaskMove{TaskID: c.ID, UserID: userID, Status: storage.TaskStatusCreated, AfterID: a.ID, BeforeID: b.ID})
+	require.NoError(t, err)
+	assert.Equal(t, storage.TaskStatusCreated, moved.Status)
+	assert.Equal(t, []string{a.ID, c.ID, b.ID}, boardColumn(t, s, userID, storage.TaskStatusCreated))
+
+	// To the top with only a lower neighbour
+	_, err = s.MoveTask(storage.TaskMove{TaskID: b.ID, UserID: userID, Status: storage.TaskStatusCreated, BeforeID: a.ID})
+	require.NoError(t, err)
+	assert.Equal(t, []string{b.ID, a.ID, c.ID}, boardColumn(t, s, userID, storage.TaskStatusCreated))
+
+	// Into the completed column, which completes the task
+	moved, err = s.MoveTask(storage.TaskMove{TaskID: a.ID, UserID: userID, Status: storage.TaskStatusCompleted})
+	require.NoError(t, err)
+	assert.Equal(t, storage.TaskStatusCompleted, moved.Status)
+	assert.NotNil(t, moved.CompletedAt)
+	got, err := s.GetTaskByID(a.ID)
+	require.NoError(t, err)
+	assert.Equal(t, storage.TaskStatusCompleted, got.Status)
+	assert.NotNil(t, got.CompletedAt)
+	assert.Equal(t, []string{a.ID}, boardColumn(t, s, userID, storage.TaskStatusCompleted))
+
+	// And out of it again
+	moved, err = s.MoveTask(storage.TaskMove{TaskID: a.ID, UserID: userID, Status: storage.TaskStatusInProgress})
+	require.NoError(t, err)
+	assert.Nil(t, moved.CompletedAt)
+	got, err = s.GetTaskByID(a.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got.CompletedAt)
+
+	// Neighbours must be in the target column
+	_, err = s.MoveTask(storage.TaskMove{TaskID: b.ID, UserID: userID, Status: storage.TaskStatusInProgress, AfterID: c.ID})
+	assert.Equal(t, storage.ErrInvalidMove, err)
+	_, err = s.MoveTask(storage.TaskMove{TaskID: b.ID, UserID: userID, Status: storage.TaskStatusCreated, AfterID: b.ID})
+	assert.Equal(t, storage.ErrInvalidMove, err)
+
+	// Other users' tasks are not found
+	_, err = s.MoveTask(storage.TaskMove{TaskID: b.ID, UserID: newUserID(), Status: storage.TaskStatusCreated})
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+}
+
+func testMoveTaskRebalance(t *testing.T, s storage.StorageInterface) {
+	userID := newUserID()
+	first := createTask(t, s, &storage.Task{Title: "First", UserID: userID, CreatedAt: base, UpdatedAt: base})
+	top := first
+	want := []string{top.ID}
+
+	// Moving task after task to the top grows the ranks until the column
+	// has to be rebalanced
+	for i := 1; i <= 150; i++ {
+		task := createTask(t, s, &storage.Task{Title: "Task", UserID: userID, CreatedAt: base.Add(time.Duration(i) * time.Second)})
+		moved, err := s.MoveTask(storage.TaskMove{TaskID: task.ID, UserID: userID, Status: storage.TaskStatusCreated, BeforeID: top.ID})
+		require.NoError(t, err)
+		require.LessOrEqual(t, len(moved.Position), 24)
+		top = task
+		want = append([]string{task.ID}, want...)
+	}
+	assert.Equal(t, want, boardColumn(t, s, userID, storage.TaskStatusCreated))
+
+	// Rebalancing does not count as a change to the other tasks
+	got, err := s.GetTaskByID(first.ID)
+	require.NoError(t, err)
+	assert.True(t, base.Equal(got.UpdatedAt), "got %v", got.UpdatedAt)
+}
+
+func testTimers(t *testing.T, s storage.StorageInterface) {
+	userID := newUserID()
+	entry := &storage.TimeEntry{TaskID: uuid.NewString(), UserID: userID, StartedAt: base}
+	require.NoError(t, s.StartTimer(entry))
+	require.NotEmpty(t, entry.ID)
+
+	err := s.StartTimer(&storage.TimeEntry{TaskID: uuid.NewString(), UserID: userID, StartedAt: base.Add(time.Minute)})
+	assert.Equal(t, storage.ErrTimerAlreadyRunning, err)
+
+	// Other users have their own timer
+	require.NoError(t, s.StartTimer(&storage.TimeEntry{TaskID: uuid.NewString(), UserID: newUserID(), StartedAt: base}))
+
+	stopped, err := s.StopTimer(userID, base.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, entry.ID, stopped.ID)
+	require.NotNil(t, stopped.EndedAt)
+	assert.True(t, stopped.EndedAt.Equal(base.Add(time.Hour)))
+
+	got, err := s.GetTimeEntryByID(entry.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got.EndedAt)
+	assert.True(t, got.EndedAt.Equal(base.Add(time.Hour)))
+
+	_, err = s.StopTimer(userID, base.Add(2*time.Hour))
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+
+	// Stopping frees the slot for a new timer
+	require.NoError(t, s.StartTimer(&storage.TimeEntry{TaskID: uuid.NewString(), UserID: userID, StartedAt: base.Add(2 * time.Hour)}))
+}
+
+func testTimeEntries(t *testing.T, s storage.StorageInterface) {
+	userID := newUserID()
+	endedAt := base.Add(30 * time.Minute)
+	entry := &storage.TimeEntry{TaskID: uuid.NewString(), UserID: userID, StartedAt: base, EndedAt: &endedAt, Note: "review"}
+	require.NoError(t, s.CreateTimeEntry(entry))
+
+	got, err := s.GetTimeEntryByID(entry.ID)
+	require.NoError(t, err)
+	assert.Equal(t, entry.TaskID, got.TaskID)
+	assert.Equal(t, userID, got.UserID)
+	assert.True(t, got.StartedAt.Equal(base))
+	require.NotNil(t, got.EndedAt)
+	assert.True(t, got.EndedAt.Equal(endedAt))
+	assert.Equal(t, "review", got.Note)
+
+	newEnd := base.Add(45 * time.Minute)
+	got.StartedAt = base.Add(5 * time.Minute)
+	got.EndedAt = &newEnd
+	got.Note = ""
+	require.NoError(t, s.UpdateTimeEntry(got))
+
+	got, err = s.GetTimeEntryByID(entry.ID)
+	require.NoError(t, err)
+	assert.True(t, got.StartedAt.Equal(base.Add(5*time.Minute)))
+	require.NotNil(t, got.EndedAt)
+	assert.True(t, got.EndedAt.Equal(newEnd))
+	assert.Equal(t, "", got.Note)
+
+	require.NoError(t, s.DeleteTimeEntry(entry.ID))
+	_, err = s.GetTimeEntryByID(entry.ID)
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+
+	err = s.DeleteTimeEntry(entry.ID)
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+
+	entries, count, err := s.ListTimeEntriesWithCount(storage.TimeEntryFilter{UserID: userID}, -1, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), count, "deleted entries are not listed")
+	assert.Empty(t, entries)
+}
+
+func testListTimeEntries(t *testing.T, s storage.StorageInterface) {
+	userID := newUserID()
+	taskID := uuid.NewString()
+	var ids []string
+	for i := 0; i < 4; i++ {
+		startedAt := base.Add(time.Duration(i) * time.Hour)
+		endedAt := startedAt.Add(10 * time.Minute)
+		entryTaskID := taskID
+		if i == 3 {
+			entryTaskID = uuid.NewString()
+		}
+		entry := &storage.TimeEntry{TaskID: entryTaskID, UserID: userID, StartedAt: startedAt, EndedAt: &endedAt}
+		require.NoError(t, s.CreateTimeEntry(entry))
+		ids = append(ids, entry.ID)
+	}
+	otherEnd := base.Add(time.Minute)
+	require.NoError(t, s.CreateTimeEntry(&storage.TimeEntry{TaskID: taskID, UserID: newUserID(), StartedAt: base, EndedAt: &otherEnd}))
+
+	// Most recent first, a negative limit returns everything
+	entries, count, err := s.ListTimeEntriesWithCount(storage.TimeEntryFilter{UserID: userID}, -1, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), count)
+	assert.Equal(t, []string{ids[3], ids[2], ids[1], ids[0]}, entryIDs(entries))
+
+	entries, count, err = s.ListTimeEntriesWithCount(storage.TimeEntryFilter{UserID: userID}, 2, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), count)
+	assert.Equal(t, []string{ids[2], ids[1]}, entryIDs(entries))
+
+	entries, count, err = s.ListTimeEntriesWithCount(storage.TimeEntryFilter{UserID: userID, TaskID: taskID}, -1, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	assert.Equal(t, []string{ids[2], ids[1], ids[0]}, entryIDs(entries))
+
+	// From is inclusive and To exclusive, in any time zone
+	kolkata := time.FixedZone("IST", 5*60*60+30*60)
+	entries, count, err = s.ListTimeEntriesWithCount(storage.TimeEntryFilter{
+		UserID: userID,
+		From:   base.Add(time.Hour).In(kolkata),
+		To:     base.Add(3 * time.Hour).In(kolkata),
+	}, -1, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	assert.Equal(t, []string{ids[2], ids[1]}, entryIDs(entries))
+
+	// Overlapping also takes entries still running at From
+	entries, count, err = s.ListTimeEntriesWithCount(storage.TimeEntryFilter{
+		UserID:      userID,
+		From:        base.Add(time.Hour + 5*time.Minute),
+		To:          base.Add(3 * time.Hour),
+		Overlapping: true,
+	}, -1, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	assert.Equal(t, []string{ids[2], ids[1]}, entryIDs(entries))
+	entries, _, err = s.ListTimeEntriesWithCount(storage.TimeEntryFilter{
+		UserID:      userID,
+		From:        base.Add(time.Hour + 10*time.Minute),
+		To:          base.Add(3 * time.Hour),
+		Overlapping: true,
+	}, -1, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []string{ids[2]}, entryIDs(entries))
+}
+
+func testTimeEntryRevisions(t *testing.T, s storage.StorageInterface) {
+	userID := newUserID()
+	entry := &storage.TimeEntry{TaskID: uuid.NewString(), UserID: userID, StartedAt: base}
+	require.NoError(t, s.StartTimer(entry))
+	_, err := s.StopTimer(userID, base.Add(time.Hour))
+	require.NoError(t, err)
+
+	got, err := s.GetTimeEntryByID(entry.ID)
+	require.NoError(t, err)
+	got.Note = "billable"
+	require.NoError(t, s.UpdateTimeEntry(got))
+	require.NoError(t, s.DeleteTimeEntry(entry.ID))
+
+	revisions, err := s.ListTimeEntryRevisions(entry.ID)
+	require.NoError(t, err)
+	var actions []string
+	for _, revision := range revisions {
+		assert.Equal(t, entry.ID, revision.TimeEntryID)
+		assert.Equal(t, userID, revision.UserID)
+		actions = append(actions, revision.Action)
+	}
+	assert.Equal(t, []string{
+		storage.TimeEntryActionStarted,
+		storage.TimeEntryActionStopped,
+		storage.TimeEntryActionUpdated,
+		storage.TimeEntryActionDeleted,
+	}, actions)
+	require.Len(t, revisions, 4)
+	assert.Nil(t, revisions[0].EndedAt)
+	assert.Equal(t, "billable", revisions[2].Note)
+
+	revisions, err = s.ListTimeEntryRevisions(uuid.NewString())
+	require.NoError(t, err)
+	assert.Empty(t, revisions)
+}
+
+func testTaskStats(t *testing.T, s storage.StorageInterface) {
+	userID := newUserID()
+	// Monday 2024-07-29 in UTC, but still Sunday in Los Angeles
+	monday := time.Date(2024, 7, 29, 5, 0, 0, 0, time.UTC)
+	completedAt := base.Add(2 * time.Hour)
+	createTask(t, s, &storage.Task{Title: "Done", UserID: userID, Status: storage.TaskStatusCompleted, CreatedAt: monday, CompletedAt: &completedAt})
+	createTask(t, s, &storage.Task{Title: "Overdue", UserID: userID, CreatedAt: base, DueDate: base.Add(time.Hour)})
+	createTask(t, s, &storage.Task{Title: "No due date", UserID: userID, Status: storage.TaskStatusInProgress, CreatedAt: base, DueDate: time.Unix(0, 0)})
+	createTask(t, s, &storage.Task{Title: "Other user", UserID: newUserID(), CreatedAt: base, DueDate: base})
+
+	query := storage.TaskStatsQuery{
+		UserID:   userID,
+		From:     monday.Add(-time.Hour),
+		To:       base.Add(24 * time.Hour),
+		Timezone: "UTC",
+		Interval: "day",
+		Now:      base.Add(3 * time.Hour),
+	}
+	stats, err := s.GetTaskStats(query)
+	require.NoError(t, err)
+	assert.Equal(t, []storage.StatusCount{
+		{Status: storage.TaskStatusCompleted, Count: 1},
+		{Status: storage.TaskStatusCreated, Count: 1},
+		{Status: storage.TaskStatusInProgress, Count: 1},
+	}, stats.ByStatus)
+	assert.Equal(t, []storage.PeriodCount{
+		{Period: "2024-07-29", Count: 1},
+		{Period: "2024-07-30", Count: 2},
+	}, stats.CreatedPerPeriod)
+	assert.Equal(t, []storage.PeriodCount{{Period: "2024-07-30", Count: 1}}, stats.CompletedPerPeriod)
+	assert.Equal(t, int64(1), stats.OverdueCount)
+	assert.InDelta(t, completedAt.Sub(monday).Seconds(), stats.AverageCompletionSeconds, 1)
+	assert.Equal(t, []string{"2024-07-30"}, stats.CompletionDays)
+
+	// Weeks start on Monday, days are local to the time zone
+	query.Interval = "week"
+	query.Timezone = "America/Los_Angeles"
+	stats, err = s.GetTaskStats(query)
+	require.NoError(t, err)
+	assert.Equal(t, []storage.PeriodCount{
+		{Period: "2024-07-22", Count: 1},
+		{Period: "2024-07-29", Count: 2},
+	}, stats.CreatedPerPeriod)
+	assert.Equal(t, []string{"2024-07-30"}, stats.CompletionDays)
+
+	// Days split at local midnight in zones half an hour off UTC too
+	userID = newUserID()
+	createTask(t, s, &storage.Task{Title: "Before midnight", UserID: userID, CreatedAt: time.Date(2024, 7, 30, 18, 20, 0, 0, time.UTC)})
+	createTask(t, s, &storage.Task{Title: "After midnight", UserID: userID, CreatedAt: time.Date(2024, 7, 30, 18, 40, 0, 0, time.UTC)})
+	stats, err = s.GetTaskStats(storage.TaskStatsQuery{
+		UserID:   userID,
+		From:     base,
+		To:       base.Add(24 * time.Hour),
+		Timezone: "Asia/Kolkata",
+		Interval: "day",
+		Now:      base,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []storage.PeriodCount{
+		{Period: "2024-07-30", Count: 1},
+		{Period: "2024-07-31", Count: 1},
+	}, stats.CreatedPerPeriod)
+}
+
+func testTemplates(t *testing.T, s storage.StorageInterface) {
+	userID := newUserID()
+	dueOffset := int64(60)
+	weekly := &storage.TaskTemplate{
+		UserID:                 userID,
+		Name:                   "weekly",
+		Title:                  "Weekly review",
+		Checklist:              []string{"inbox", "calendar"},
+		Tags:                   []string{"review"},
+		DueOffsetMinutes:       &dueOffset,
+		ReminderOffsetsMinutes: []int64{15},
+		EstimateMinutes:        20,
+	}
+	require.NoError(t, s.CreateTemplate(weekly))
+	require.NotEmpty(t, weekly.ID)
+	require.NoError(t, s.CreateTemplate(&storage.TaskTemplate{UserID: userID, Name: "daily", Title: "Daily standup"}))
+
+	// Names are unique per user
+	err := s.CreateTemplate(&storage.TaskTemplate{UserID: userID, Name: "weekly", Title: "Copy"})
+	assert.Equal(t, storage.ErrDuplicateTemplateName, err)
+	require.NoError(t, s.CreateTemplate(&storage.TaskTemplate{UserID: newUserID(), Name: "weekly", Title: "Someone else's"}))
+
+	got, err := s.GetTemplateByID(weekly.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Weekly review", got.Title)
+	assert.Equal(t, []string{"inbox", "calendar"}, got.Checklist)
+	assert.Equal(t, []string{"review"}, got.Tags)
+	require.NotNil(t, got.DueOffsetMinutes)
+	assert.Equal(t, int64(60), *got.DueOffsetMinutes)
+	assert.Equal(t, []int64{15}, got.ReminderOffsetsMinutes)
+	assert.Equal(t, int32(20), got.EstimateMinutes)
+
+	templates, err := s.ListTemplates(userID)
+	require.NoError(t, err)
+	require.Len(t, templates, 2)
+	assert.Equal(t, "daily", templates[0].Name)
+	assert.Equal(t, "weekly", templates[1].Name)
+
+	// Renaming onto an existing name fails, clearing fields sticks
+	got.Name = "daily"
+	assert.Equal(t, storage.ErrDuplicateTemplateName, s.UpdateTemplate(got))
+	got.Name = "weekly review"
+	got.Checklist = nil
+	got.DueOffsetMinutes = nil
+	got.EstimateMinutes = 0
+	require.NoError(t, s.UpdateTemplate(got))
+
+	got, err = s.GetTemplateByID(weekly.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "weekly review", got.Name)
+	assert.Empty(t, got.Checklist)
+	assert.Nil(t, got.DueOffsetMinutes)
+	assert.Equal(t, int32(0), got.EstimateMinutes)
+
+	require.NoError(t, s.DeleteTemplate(weekly.ID))
+	_, err = s.GetTemplateByID(weekly.ID)
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+	templates, err = s.ListTemplates(userID)
+	require.NoError(t, err)
+	assert.Len(t, templates, 1)
+}
+
+func testCustomFields(t *testing.T, s storage.StorageInterface) {
+	userID := newUserID()
+	points := &storage.CustomField{UserID: userID, Name: "Points", Type: storage.CustomFieldTypeNumber}
+	require.NoError(t, s.CreateCustomField(points))
+	require.NotEmpty(t, points.ID)
+	labels := &storage.CustomField{UserID: userID, Name: "Labels", Type: storage.CustomFieldTypeMultiSelect, Options: []string{"ui", "api"}}
+	require.NoError(t, s.CreateCustomField(labels))
+
+	// Names are unique per user
+	err := s.CreateCustomField(&storage.CustomField{UserID: userID, Name: "Points", Type: storage.CustomFieldTypeText})
+	assert.Equal(t, storage.ErrDuplicateCustomFieldName, err)
+	require.NoError(t, s.CreateCustomField(&storage.CustomField{UserID: newUserID(), Name: "Points", Type: storage.CustomFieldTypeText}))
+
+	fields, err := s.ListCustomFields(userID)
+	require.NoError(t, err)
+	require.Len(t, fields, 2)
+	assert.Equal(t, "Labels", fields[0].Name)
+	assert.Equal(t, []string{"ui", "api"}, fields[0].Options)
+	assert.Equal(t, "Points", fields[1].Name)
+
+	labels.Name = "Points"
+	assert.Equal(t, storage.ErrDuplicateCustomFieldName, s.UpdateCustomField(labels))
+	labels.Name = "Areas"
+	labels.Options = []string{"ui", "api", "docs"}
+	require.NoError(t, s.UpdateCustomField(labels))
+	got, err := s.GetCustomFieldByID(labels.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Areas", got.Name)
+	assert.Equal(t, storage.CustomFieldTypeMultiSelect, got.Type)
+	assert.Equal(t, []string{"ui", "api", "docs"}, got.Options)
+
+	// Values of every type round trip
+	task := createTask(t, s, &storage.Task{Title: "Task", UserID: userID, CustomFields: storage.CustomFieldValues{
+		points.ID: 3.5,
+		labels.ID: []string{"ui", "docs"},
+		"text":    "ACME",
+		"date":    "2024-08-01",
+		"check":   true,
+	}})
+	other := createTask(t, s, &storage.Task{Title: "Other", UserID: userID, CustomFields: storage.CustomFieldValues{points.ID: 1.0}})
+	plain := createTask(t, s, &storage.Task{Title: "No values", UserID: userID})
+
+	gotTask, err := s.GetTaskByID(task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, storage.CustomFieldValues{
+		points.ID: 3.5,
+		labels.ID: []string{"ui", "docs"},
+		"text":    "ACME",
+		"date":    "2024-08-01",
+		"check":   true,
+	}, gotTask.CustomFields)
+	gotTask, err = s.GetTaskByID(plain.ID)
+	require.NoError(t, err)
+	assert.Empty(t, gotTask.CustomFields)
+
+	// Deleting a field removes its values
+	require.NoError(t, s.DeleteCustomField(points.ID))
+	_, err = s.GetCustomFieldByID(points.ID)
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+	gotTask, err = s.GetTaskByID(task.ID)
+	require.NoError(t, err)
+	assert.NotContains(t, gotTask.CustomFields, points.ID)
+	assert.Contains(t, gotTask.CustomFields, labels.ID)
+	gotTask, err = s.GetTaskByID(other.ID)
+	require.NoError(t, err)
+	assert.Empty(t, gotTask.CustomFields)
+
+	err = s.DeleteCustomField(points.ID)
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+}
+
+func testFilterCustomFields(t *testing.T, s storage.StorageInterface) {
+	userID := newUserID()
+	const points, client, due, done, labels = "points", "client", "due", "done", "labels"
+	small := createTask(t, s, &storage.Task{Title: "Small", UserID: userID, CreatedAt: base, CustomFields: storage.CustomFieldValues{
+		points: 1.0, client: "acme", due: "2024-08-01", done: true, labels: []string{"ui"},
+	}})
+	large := createTask(t, s, &storage.Task{Title: "Large", UserID: userID, CreatedAt: base.Add(time.Minute), CustomFields: storage.CustomFieldValues{
+		points: 8.0, client: "globex", due: "2024-08-15", done: false, labels: []string{"ui", "api"},
+	}})
+	medium := createTask(t, s, &storage.Task{Title: "Medium", UserID: userID, CreatedAt: base.Add(2 * time.Minute), CustomFields: storage.CustomFieldValues{
+		points: 3.0, client: "acme", labels: []string{"api"},
+	}})
+	empty := createTask(t, s, &storage.Task{Title: "Empty", UserID: userID, CreatedAt: base.Add(3 * time.Minute)})
+	createTask(t, s, &storage.Task{Title: "Other user", UserID: newUserID(), CustomFields: storage.CustomFieldValues{points: 1.0}})
+
+	list := func(filter storage.TaskFilter) []string {
+		t.Helper()
+		filter.UserID = userID
+		tasks, count, err := s.ListTasksWithCount(filter, -1, 0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(tasks)), count)
+		return taskIDs(tasks)
+	}
+	where := func(field, fieldType, op string, value interface{}) storage.TaskFilter {
+		return storage.TaskFilter{CustomFields: []storage.CustomFieldFilter{{FieldID: field, FieldType: fieldType, Op: op, Value: value}}}
+	}
+
+	assert.Equal(t, []string{medium.ID, small.ID}, list(where(points, storage.CustomFieldTypeNumber, "lte", 3.0)))
+	assert.Equal(t, []string{large.ID}, list(where(points, storage.CustomFieldTypeNumber, "gt", 3.0)))
+	assert.Equal(t, []string{medium.ID, small.ID}, list(where(client, storage.CustomFieldTypeSingleSelect, "eq", "acme")))
+	assert.Equal(t, []string{large.ID}, list(where(due, storage.CustomFieldTypeDate, "gte", "2024-08-02")))
+	assert.Equal(t, []string{small.ID}, list(where(done, storage.CustomFieldTypeCheckbox, "eq", true)))
+	assert.Equal(t, []string{large.ID}, list(where(done, storage.CustomFieldTypeCheckbox, "eq", false)))
+	assert.Equal(t, []string{medium.ID, large.ID}, list(where(labels, storage.CustomFieldTypeMultiSelect, "eq", "api")))
+	assert.Empty(t, list(where(labels, storage.CustomFieldTypeMultiSelect, "eq", "docs")))
+
+	// Filters combine
+	filter := where(client, storage.CustomFieldTypeText, "eq", "acme")
+	filter.CustomFields = append(filter.CustomFields, storage.CustomFieldFilter{FieldID: points, FieldType: storage.CustomFieldTypeNumber, Op: "lt", Value: 2.0})
+	assert.Equal(t, []string{small.ID}, list(filter))
+
+	// Sorting puts tasks without a value last in both directions
+	sorted := list(storage.TaskFilter{SortField: &storage.CustomFieldSort{FieldID: points, FieldType: storage.CustomFieldTypeNumber}})
+	assert.Equal(t, []string{small.ID, medium.ID, large.ID, empty.ID}, sorted)
+	sorted = list(storage.TaskFilter{SortField: &storage.CustomFieldSort{FieldID: points, FieldType: storage.CustomFieldTypeNumber, Desc: true}})
+	assert.Equal(t, []string{large.ID, medium.ID, small.ID, empty.ID}, sorted)
+	sorted = list(storage.TaskFilter{SortField: &storage.CustomFieldSort{FieldID: due, FieldType: storage.CustomFieldTypeDate}})
+	assert.Equal(t, []string{small.ID, large.ID, empty.ID, medium.ID}, sorted)
+
+	// Pagination applies after sorting
+	tasks, count, err := s.ListTasksWithCount(storage.TaskFilter{
+		UserID:    userID,
+		SortField: &storage.CustomFieldSort{FieldID: points, FieldType: storage.CustomFieldTypeNumber},
+	}, 2, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), count)
+	assert.Equal(t, []string{medium.ID, large.ID}, taskIDs(tasks))
+}
+
+func testFilterTasks(t *testing.T, s storage.StorageInterface) {
+	userID := newUserID()
+	report := createTask(t, s, &storage.Task{Title: "Report", UserID: userID, CreatedAt: base,
+		Tags: []string{"work", "urgent"}, DueDate: base.Add(24 * time.Hour)})
+	review := createTask(t, s, &storage.Task{Title: "Review", UserID: userID, CreatedAt: base.Add(time.Minute),
+		Tags: []string{"work"}, Status: storage.TaskStatusInProgress, DueDate: base.Add(72 * time.Hour)})
+	groceries := createTask(t, s, &storage.Task{Title: "Groceries", UserID: userID, CreatedAt: base.Add(2 * time.Minute),
+		Tags: []string{"home"}, Status: storage.TaskStatusCompleted, DueDate: base.Add(-24 * time.Hour)})
+	someday := createTask(t, s, &storage.Task{Title: "Someday", UserID: userID, CreatedAt: base.Add(3 * time.Minute),
+		DueDate: time.Unix(0, 0)})
+	createTask(t, s, &storage.Task{Title: "Other user", UserID: newUserID(), Tags: []string{"work"}})
+
+	list := func(filter storage.TaskFilter) []string {
+		t.Helper()
+		filter.UserID = userID
+		tasks, count, err := s.ListTasksWithCount(filter, -1, 0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(tasks)), count)
+		return taskIDs(tasks)
+	}
+	at := func(offset time.Duration) *time.Time {
+		t := base.Add(offset)
+		return &t
+	}
+
+	assert.Equal(t, []string{someday.ID, review.ID, report.ID},
+		list(storage.TaskFilter{Statuses: []string{storage.TaskStatusCreated, storage.TaskStatusInProgress}}))
+	assert.Equal(t, []string{review.ID, report.ID}, list(storage.TaskFilter{Tags: []string{"work"}}))
+	assert.Equal(t, []string{report.ID}, list(storage.TaskFilter{Tags: []string{"work", "urgent"}}))
+	assert.Empty(t, list(storage.TaskFilter{Tags: []string{"work", "home"}}))
+
+	// Due bounds include the start and exclude the end, tasks without a due
+	// date never match them
+	assert.Equal(t, []string{review.ID, report.ID}, list(storage.TaskFilter{DueFrom: at(24 * time.Hour)}))
+	assert.Equal(t, []string{groceries.ID, report.ID}, list(storage.TaskFilter{DueBefore: at(72 * time.Hour)}))
+	assert.Equal(t, []string{report.ID}, list(storage.TaskFilter{DueFrom: at(0), DueBefore: at(48 * time.Hour)}))
+	assert.Equal(t, []string{someday.ID}, list(storage.TaskFilter{WithoutDueDate: true}))
+
+	// Filters combine
+	assert.Equal(t, []string{review.ID}, list(storage.TaskFilter{
+		Statuses: []string{storage.TaskStatusInProgress},
+		Tags:     []string{"work"},
+		DueFrom:  at(0),
+	}))
+}
+
+func testSortTasks(t *testing.T, s storage.StorageInterface) {
+	userID := newUserID()
+	b := createTask(t, s, &storage.Task{Title: "b", UserID: userID, CreatedAt: base, DueDate: base.Add(48 * time.Hour), Priority: storage.TaskPriorityUrgent})
+	c := createTask(t, s, &storage.Task{Title: "c", UserID: userID, CreatedAt: base.Add(time.Minute), DueDate: time.Unix(0, 0)})
+	a := createTask(t, s, &storage.Task{Title: "a", UserID: userID, CreatedAt: base.Add(2 * time.Minute), DueDate: base.Add(24 * time.Hour), Priority: storage.TaskPriorityLow})
+
+	list := func(sort *storage.TaskSort) []string {
+		t.Helper()
+		tasks, _, err := s.ListTasksWithCount(storage.TaskFilter{UserID: userID, Sort: sort}, -1, 0)
+		require.NoError(t, err)
+		return taskIDs(tasks)
+	}
+
+	assert.Equal(t, []string{a.ID, c.ID, b.ID}, list(nil))
+	assert.Equal(t, []string{b.ID, c.ID, a.ID}, list(&storage.TaskSort{Column: storage.TaskSortCreatedAt}))
+	assert.Equal(t, []string{a.ID, b.ID, c.ID}, list(&storage.TaskSort{Column: storage.TaskSortTitle}))
+	assert.Equal(t, []string{c.ID, b.ID, a.ID}, list(&storage.TaskSort{Column: storage.TaskSortTitle, Desc: true}))
+
+	// Tasks without a due date come last in both directions
+	assert.Equal(t, []string{a.ID, b.ID, c.ID}, list(&storage.TaskSort{Column: storage.TaskSortDueDate}))
+	assert.Equal(t, []string{b.ID, a.ID, c.ID}, list(&storage.TaskSort{Column: storage.TaskSortDueDate, Desc: true}))
+
+	assert.Equal(t, []string{c.ID, a.ID, b.ID}, list(&storage.TaskSort{Column: storage.TaskSortPriority}))
+	assert.Equal(t, []string{b.ID, a.ID, c.ID}, list(&storage.TaskSort{Column: storage.TaskSortPriority, Desc: true}))
+
+	_, _, err := s.ListTasksWithCount(storage.TaskFilter{UserID: userID, Sort: &storage.TaskSort{Column: "id; DROP TABLE tasks"}}, -1, 0)
+	assert.Error(t, err)
+}
+
+func testSavedViews(t *testing.T, s storage.StorageInterface) {
+	userID := newUserID()
+	week := &storage.SavedView{
+		UserID: userID,
+		Name:   "Due this week",
+		Query: storage.TaskQuery{
+			Statuses:     []string{storage.TaskStatusCreated, storage.TaskStatusInProgress},
+			Tags:         []string{"work"},
+			Due:          "this_week",
+			CustomFields: []storage.TaskQueryFieldFilter{{FieldID: "points", Op: "gte", Value: "3"}},
+			SortBy:       storage.TaskSortDueDate,
+		},
+		GroupBy: "status",
+	}
+	require.NoError(t, s.CreateSavedView(week))
+	require.NotEmpty(t, week.ID)
+	require.NoError(t, s.CreateSavedView(&storage.SavedView{UserID: userID, Name: "All"}))
+
+	// Names are unique per user
+	err := s.CreateSavedView(&storage.SavedView{UserID: userID, Name: "All"})
+	assert.Equal(t, storage.ErrDuplicateViewName, err)
+	otherUserID := newUserID()
+	require.NoError(t, s.CreateSavedView(&storage.SavedView{UserID: otherUserID, Name: "All"}))
+	shared := &storage.SavedView{UserID: otherUserID, Name: "Team backlog", Visibility: storage.ViewVisibilityWorkspace}
+	require.NoError(t, s.CreateSavedView(shared))
+
+	got, err := s.GetSavedViewByID(week.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Due this week", got.Name)
+	assert.Equal(t, week.Query, got.Query)
+	assert.Equal(t, "status", got.GroupBy)
+	assert.Equal(t, storage.ViewVisibilityPrivate, got.Visibility)
+
+	// Views shared with the workspace are listed for everyone
+	views, err := s.ListSavedViews(userID)
+	require.NoError(t, err)
+	require.Len(t, views, 3)
+	assert.Equal(t, "All", views[0].Name)
+	assert.Equal(t, "Due this week", views[1].Name)
+	assert.Equal(t, shared.ID, views[2].ID)
+
+	got.Name = "All"
+	assert.Equal(t, storage.ErrDuplicateViewName, s.UpdateSavedView(got))
+	got.Name = "Work this week"
+	got.Query = storage.TaskQuery{Tags: []string{"work"}}
+	got.GroupBy = ""
+	got.Visibility = storage.ViewVisibilityWorkspace
+	require.NoError(t, s.UpdateSavedView(got))
+
+	got, err = s.GetSavedViewByID(week.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Work this week", got.Name)
+	assert.Equal(t, storage.TaskQuery{Tags: []string{"work"}}, got.Query)
+	assert.Empty(t, got.GroupBy)
+	assert.Equal(t, storage.ViewVisibilityWorkspace, got.Visibility)
+
+	views, err = s.ListSavedViews(otherUserID)
+	require.NoError(t, err)
+	require.Len(t, views, 3)
+	assert.Equal(t, []string{"All", "Team backlog", "Work this week"}, []string{views[0].Name, views[1].Name, views[2].Name})
+
+	require.NoError(t, s.DeleteSavedView(week.ID))
+	_, err = s.GetSavedViewByID(week.ID)
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+}
+
+func testArchiving(t *testing.T, s storage.StorageInterface) {
+	userID, otherUserID := newUserID(), newUserID()
+	day := 24 * time.Hour
+	longAgo, yesterday := base.Add(-40*day), base.Add(-day)
+	// Edited recently, but completed long ago
+	old := createTask(t, s, &storage.Task{Title: "Old", UserID: userID, Status: storage.TaskStatusCompleted, CompletedAt: &longAgo, UpdatedAt: yesterday})
+	recent := createTask(t, s, &storage.Task{Title: "Recent", UserID: userID, Status: storage.TaskStatusCompleted, CompletedAt: &yesterday, UpdatedAt: yesterday})
+	open := createTask(t, s, &storage.Task{Title: "Open", UserID: userID, Status: storage.TaskStatusInProgress, UpdatedAt: longAgo})
+	excluded := createTask(t, s, &storage.Task{Title: "Excluded", UserID: otherUserID, Status: storage.TaskStatusCompleted, CompletedAt: &longAgo, UpdatedAt: longAgo})
+
+	// Excluded users are skipped
+	archived, err := s.ArchiveCompletedTasks(storage.RetentionScope{UserID: otherUserID, ExcludeUserIDs: []string{otherUserID}}, base.Add(-30*day), base)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), archived)
+
+	// Only tasks completed before the cutoff are archived
+	archived, err = s.ArchiveCompletedTasks(storage.RetentionScope{UserID: userID}, base.Add(-30*day), base)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), archived)
+
+	tasks, _, err := s.ListTasksWithCount(storage.TaskFilter{UserID: userID}, -1, 0)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{recent.ID, open.ID}, taskIDs(tasks))
+	tasks, count, err := s.ListTasksWithCount(storage.TaskFilter{UserID: userID, Archived: true}, -1, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	assert.Equal(t, []string{old.ID}, taskIDs(tasks))
+	require.NotNil(t, tasks[0].ArchivedAt)
+	assert.True(t, base.Equal(*tasks[0].ArchivedAt))
+
+	board, err := s.ListBoardTasks(userID)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{recent.ID, open.ID}, taskIDs(board))
+
+	got, err := s.GetTaskByID(excluded.ID)
+	require.NoError(t, err)
+	assert.Nil(t, got.ArchivedAt)
+
+	// Unarchiving restarts the retention period
+	require.NoError(t, s.SetTaskArchived(old.ID, nil))
+	archived, err = s.ArchiveCompletedTasks(storage.RetentionScope{UserID: userID}, base.Add(-30*day), base)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), archived)
+	tasks, _, err = s.ListTasksWithCount(storage.TaskFilter{UserID: userID}, -1, 0)
+	require.NoError(t, err)
+	assert.Len(t, tasks, 3)
+
+	// Only tasks archived before the cutoff are purged
+	archivedAt := base.Add(-400 * day)
+	require.NoError(t, s.SetTaskArchived(old.ID, &archivedAt))
+	require.NoError(t, s.SetTaskArchived(recent.ID, &base))
+	purged, err := s.PurgeArchivedTasks(storage.RetentionScope{UserID: userID}, base.Add(-365*day))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+	_, err = s.GetTaskByID(old.ID)
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+	_, err = s.GetTaskByID(recent.ID)
+	assert.NoError(t, err)
+}
+
+func testRetentionPolicies(t *testing.T, s storage.StorageInterface) {
+	userID := newUserID()
+	_, err := s.GetRetentionPolicy(userID)
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+
+	require.NoError(t, s.SaveRetentionPolicy(&storage.RetentionPolicy{UserID: userID, ArchiveAfterDays: 30}))
+	require.NoError(t, s.SaveRetentionPolicy(&storage.RetentionPolicy{UserID: userID, ArchiveAfterDays: 7, PurgeAfterDays: 365}))
+	got, err := s.GetRetentionPolicy(userID)
+	require.NoError(t, err)
+	assert.Equal(t, int32(7), got.ArchiveAfterDays)
+	assert.Equal(t, int32(365), got.PurgeAfterDays)
+
+	policies, err := s.ListRetentionPolicies()
+	require.NoError(t, err)
+	var userIDs []string
+	for _, policy := range policies {
+		userIDs = append(userIDs, policy.UserID)
+	}
+	assert.Contains(t, userIDs, userID)
+
+	require.NoError(t, s.DeleteRetentionPolicy(userID))
+	_, err = s.GetRetentionPolicy(userID)
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+}
+
+func testIdempotencyRecords(t *testing.T, s storage.StorageInterface) {
+	userID := newUserID()
+	now := time.Now()
+	record := &storage.IdempotencyRecord{UserID: userID, Key: "retry-1", RequestHash: "hash", ExpiresAt: now.Add(time.Hour)}
+	require.NoError(t, s.CreateIdempotencyRecord(record))
+
+	// Keys are claimed once per user
+	err := s.CreateIdempotencyRecord(&storage.IdempotencyRecord{UserID: userID, Key: "retry-1", RequestHash: "other", ExpiresAt: now.Add(time.Hour)})
+	assert.Equal(t, storage.ErrIdempotencyKeyExists, err)
+	require.NoError(t, s.CreateIdempotencyRecord(&storage.IdempotencyRecord{UserID: newUserID(), Key: "retry-1", RequestHash: "hash", ExpiresAt: now.Add(time.Hour)}))
+
+	got, err := s.GetIdempotencyRecord(userID, "retry-1")
+	require.NoError(t, err)
+	assert.Equal(t, "hash", got.RequestHash)
+	assert.Empty(t, got.Response)
+
+	require.NoError(t, s.SaveIdempotencyResponse(userID, "retry-1", []byte("response")))
+	got, err = s.GetIdempotencyRecord(userID, "retry-1")
+	require.NoError(t, err)
+	assert.Equal(t, []byte("response"), got.Response)
+
+	require.NoError(t, s.DeleteIdempotencyRecord(userID, "retry-1"))
+	_, err = s.GetIdempotencyRecord(userID, "retry-1")
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+
+	// Expired keys can be claimed again and are cleaned up
+	expired := &storage.IdempotencyRecord{UserID: userID, Key: "retry-2", RequestHash: "hash", ExpiresAt: now.Add(-time.Minute)}
+	require.NoError(t, s.CreateIdempotencyRecord(expired))
+	require.NoError(t, s.CreateIdempotencyRecord(&storage.IdempotencyRecord{UserID: userID, Key: "retry-2", RequestHash: "new", ExpiresAt: now.Add(-time.Minute)}))
+	got, err = s.GetIdempotencyRecord(userID, "retry-2")
+	require.NoError(t, err)
+	assert.Equal(t, "new", got.RequestHash)
+
+	deleted, err := s.DeleteExpiredIdempotencyRecords(now)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, deleted, int64(1))
+	_, err = s.GetIdempotencyRecord(userID, "retry-2")
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+}
+
+func testTaskWatchers(t *testing.T, s storage.StorageInterface) {
+	ownerID, watcherID, otherWatcherID := newUserID(), newUserID(), newUserID()
+	task := createTask(t, s, &storage.Task{Title: "Watched", UserID: ownerID, UserEmail: "owner@example.com"})
+	got, err := s.GetTaskByID(task.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "owner@example.com", got.UserEmail)
+
+	watchers, err := s.ListTaskWatchers(task.ID)
+	require.NoError(t, err)
+	assert.Empty(t, watchers)
+
+	require.NoError(t, s.AddTaskWatcher(&storage.TaskWatcher{TaskID: task.ID, UserID: watcherID, Email: "old@example.com", CreatedAt: base}))
+	require.NoError(t, s.AddTaskWatcher(&storage.TaskWatcher{TaskID: task.ID, UserID: otherWatcherID, Email: "other@example.com", CreatedAt: base.Add(time.Minute)}))
+	// Watching again keeps the watcher's place and updates their email
+	require.NoError(t, s.AddTaskWatcher(&storage.TaskWatcher{TaskID: task.ID, UserID: watcherID, Email: "watcher@example.com", CreatedAt: base.Add(time.Hour)}))
+
+	watchers, err = s.ListTaskWatchers(task.ID)
+	require.NoError(t, err)
+	require.Len(t, watchers, 2)
+	assert.Equal(t, watcherID, watchers[0].UserID)
+	assert.Equal(t, "watcher@example.com", watchers[0].Email)
+	assert.Equal(t, otherWatcherID, watchers[1].UserID)
+
+	require.NoError(t, s.RemoveTaskWatcher(task.ID, otherWatcherID))
+	watchers, err = s.ListTaskWatchers(task.ID)
+	require.NoError(t, err)
+	require.Len(t, watchers, 1)
+	assert.Equal(t, watcherID, watchers[0].UserID)
+
+	// Invites have no email until the user starts watching, and inviting
+	// someone who already watches changes nothing
+	invitedID := newUserID()
+	require.NoError(t, s.InviteTaskWatcher(task.ID, invitedID))
+	require.NoError(t, s.InviteTaskWatcher(task.ID, watcherID))
+	watchers, err = s.ListTaskWatchers(task.ID)
+	require.NoError(t, err)
+	require.Len(t, watchers, 2)
+	assert.Equal(t, "watcher@example.com", watchers[0].Email)
+	assert.Equal(t, invitedID, watchers[1].UserID)
+	assert.Empty(t, watchers[1].Email)
+	require.NoError(t, s.InviteTaskWatcher(task.ID, invitedID))
+	require.NoError(t, s.AddTaskWatcher(&storage.TaskWatcher{TaskID: task.ID, UserID: invitedID, Email: "invited@example.com"}))
+	watchers, err = s.ListTaskWatchers(task.ID)
+	require.NoError(t, err)
+	require.Len(t, watchers, 2)
+	assert.Equal(t, "invited@example.com", watchers[1].Email)
+
+	// Watchers go away with the task
+	require.NoError(t, s.DeleteTask(task.ID))
+	watchers, err = s.ListTaskWatchers(task.ID)
+	require.NoError(t, err)
+	assert.Empty(t, watchers)
+
+	purged := createTask(t, s, &storage.Task{Title: "Purged", UserID: ownerID})
+	require.NoError(t, s.AddTaskWatcher(&storage.TaskWatcher{TaskID: purged.ID, UserID: watcherID, Email: "watcher@example.com"}))
+	archivedAt := base.Add(-time.Hour)
+	require.NoError(t, s.SetTaskArchived(purged.ID, &archivedAt))
+	count, err := s.PurgeArchivedTasks(storage.RetentionScope{UserID: ownerID}, base)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), count)
+	watchers, err = s.ListTaskWatchers(purged.ID)
+	require.NoError(t, err)
+	assert.Empty(t, watchers)
+}
+
+func testTaskReminders(t *testing.T, s storage.StorageInterface) {
+	dueDate := base.Add(2 * time.Hour)
+	task := &storage.Task{Title: "Reminded", UserID: newUserID(), DueDate: dueDate}
+	reminders := []*storage.TaskReminder{
+		{OffsetMinutes: 60, RemindAt: base.Add(time.Hour)},
+		{OffsetMinutes: 180, RemindAt: base.Add(-time.Hour)},
+	}
+	require.NoError(t, s.CreateTaskWithReminders(task, reminders))
+	require.NotEmpty(t, task.ID)
+	other := &storage.Task{Title: "Other", UserID: newUserID(), DueDate: base}
+	require.NoError(t, s.CreateTaskWithReminders(other, []*storage.TaskReminder{{RemindAt: base}}))
+	otherReminders, err := s.ListTaskReminders(other.ID)
+	require.NoError(t, err)
+	require.Len(t, otherReminders, 1)
+	reminders = append(reminders, otherReminders[0])
+	for _, reminder := range reminders {
+		assert.NotEmpty(t, reminder.ID)
+	}
+	none := createTask(t, s, &storage.Task{Title: "Not reminded", UserID: newUserID()})
+	got, err := s.ListTaskReminders(none.ID)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	// A failed reminder leaves no task behind
+	failed := &storage.Task{Title: "Failed", UserID: newUserID(), DueDate: dueDate}
+	err = s.CreateTaskWithReminders(failed, []*storage.TaskReminder{{ID: reminders[0].ID, RemindAt: base}})
+	require.Error(t, err)
+	_, err = s.GetTaskByID(failed.ID)
+	assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "got %v", err)
+
+	got, err = s.ListTaskReminders(task.ID)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, task.ID, got[0].TaskID)
+	assert.Equal(t, int64(180), got[0].OffsetMinutes)
+	assert.True(t, base.Add(-time.Hour).Equal(got[0].RemindAt))
+	assert.True(t, base.Add(time.Hour).Equal(got[1].RemindAt))
+	assert.Nil(t, got[0].SentAt)
+
+	// Due means at or before now and not sent yet
+	due, err := s.ListDueTaskReminders(base, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 2)
+	assert.Equal(t, reminders[1].ID, due[0].ID)
+	assert.Equal(t, reminders[2].ID, due[1].ID)
+	due, err = s.ListDueTaskReminders(base, 1)
+	require.NoError(t, err)
+	assert.Len(t, due, 1)
+
+	require.NoError(t, s.MarkTaskReminderSent(reminders[1].ID, base))
+	due, err = s.ListDueTaskReminders(base, 10)
+	require.NoError(t, err)
+	require.Len(t, due, 1)
+	assert.Equal(t, reminders[2].ID, due[0].ID)
+	got, err = s.ListTaskReminders(task.ID)
+	require.NoError(t, err)
+	require.NotNil(t, got[0].SentAt)
+	assert.True(t, base.Equal(*got[0].SentAt))
+
+	// Reminders follow the due date. The one already sent goes out again
+	// once it moves past the time it was sent.
+	task, err = s.GetTaskByID(task.ID)
+	require.NoError(t, err)
+	task.DueDate = dueDate.Add(24 * time.Hour)
+	require.NoError(t, s.UpdateTask(task))
+	got, err = s.ListTaskReminders(task.ID)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.True(t, base.Add(23*time.Hour).Equal(got[0].RemindAt))
+	assert.Nil(t, got[0].SentAt)
+	assert.True(t, base.Add(25*time.Hour).Equal(got[1].RemindAt))
+
+	// Moving the due date back does not resend a reminder
+	require.NoError(t, s.MarkTaskReminderSent(got[0].ID, base.Add(23*time.Hour)))
+	task.DueDate = dueDate.Add(12 * time.Hour)
+	require.NoError(t, s.UpdateTask(task))
+	got, err = s.ListTaskReminders(task.ID)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.True(t, base.Add(11*time.Hour).Equal(got[0].RemindAt))
+	assert.NotNil(t, got[0].SentAt)
+
+	// Without a due date there is nothing to remind of
+	cleared := &storage.Task{Title: "Cleared", UserID: newUserID(), DueDate: dueDate}
+	require.NoError(t, s.CreateTaskWithReminders(cleared, []*storage.TaskReminder{{OffsetMinutes: 60, RemindAt: base.Add(time.Hour)}}))
+	cleared.DueDate = time.Unix(0, 0)
+	require.NoError(t, s.UpdateTask(cleared))
+	got, err = s.ListTaskReminders(cleared.ID)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	// Reminders go away with the task
+	require.NoError(t, s.DeleteTask(task.ID))
+	got, err = s.ListTaskReminders(task.ID)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	archivedAt := base.Add(-time.Hour)
+	require.NoError(t, s.SetTaskArchived(other.ID, &archivedAt))
+	_, err = s.PurgeArchivedTasks(storage.RetentionScope{UserID: other.UserID}, base)
+	require.NoError(t, err)
+	got, err = s.ListTaskReminders(other.ID)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func taskIDs(tasks []*storage.Task) []string {
+	ids := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
+
+func entryIDs(entries []*storage.TimeEntry) []string {
+	ids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.ID)
+	}
+	return ids
+}
+
+// boardColumn returns the task IDs of a board column in order
+func boardColumn(t *testing.T, s storage.StorageInterface, userID, status string) []string {
+	t.Helper()
+	tasks, err := s.ListBoardTasks(userID)
+	require.NoError(t, err)
+	var ids []string
+	for _, task := range tasks {
+		if task.Status == status {
+			ids = append(ids, task.ID)
+		}
+	}
+	return ids
+}